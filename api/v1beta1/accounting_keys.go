@@ -42,6 +42,16 @@ func (o *Accounting) ServiceFQDNShort() string {
 	return domainname.FqdnShort(s.Name, s.Namespace)
 }
 
+// JwksKey returns the Secret of the Slurm `jwks` file, with the keys of the
+// Controllers of the Accounting.
+func (o *Accounting) JwksKey() types.NamespacedName {
+	key := o.Key()
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-jwks", key.Name),
+		Namespace: o.Namespace,
+	}
+}
+
 func (o *Accounting) AuthStorageKey() types.NamespacedName {
 	return types.NamespacedName{
 		Name:      o.Spec.StorageConfig.PasswordKeyRef.Name,
@@ -76,6 +86,25 @@ func (o *Accounting) AuthSlurmRef() *corev1.SecretKeySelector {
 	}
 }
 
+// IsMungeAuth reports if the Slurm authentication is `auth/munge`.
+func (o *Accounting) IsMungeAuth() bool {
+	return o.Spec.Auth.IsMunge()
+}
+
+func (o *Accounting) AuthMungeKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.AuthMungeRef().Name,
+		Namespace: o.Namespace,
+	}
+}
+
+func (o *Accounting) AuthMungeRef() *corev1.SecretKeySelector {
+	if o.Spec.Auth == nil {
+		return &corev1.SecretKeySelector{}
+	}
+	return o.Spec.Auth.MungeKeyRef.DeepCopy()
+}
+
 func (o *Accounting) AuthJwtHs256Key() types.NamespacedName {
 	return types.NamespacedName{
 		Name:      o.Spec.JwtHs256KeyRef.Name,
@@ -93,6 +122,19 @@ func (o *Accounting) AuthJwtHs256Ref() *corev1.SecretKeySelector {
 	}
 }
 
+// GeneratedKeyRefs returns the key refs which the operator generates when
+// their Secret does not exist.
+func (o *Accounting) GeneratedKeyRefs() []*corev1.SecretKeySelector {
+	out := []*corev1.SecretKeySelector{}
+	if o.Spec.SlurmKeyRef.Generate && !o.IsMungeAuth() {
+		out = append(out, o.AuthSlurmRef())
+	}
+	if o.Spec.JwtHs256KeyRef.Generate {
+		out = append(out, o.AuthJwtHs256Ref())
+	}
+	return out
+}
+
 func (o *Accounting) ConfigKey() types.NamespacedName {
 	return types.NamespacedName{
 		Name:      fmt.Sprintf("%s-accounting", o.Name),
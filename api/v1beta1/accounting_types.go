@@ -18,17 +18,23 @@ var (
 )
 
 // AccountingSpec defines the desired state of Accounting
-// +kubebuilder:validation:XValidation:rule="!self.external ? has(self.slurmKeyRef) : true", message="slurmKeyRef must be set when external is false"
+// +kubebuilder:validation:XValidation:rule="!self.external && !(has(self.auth) && self.auth.type == 'auth/munge') ? has(self.slurmKeyRef) : true", message="slurmKeyRef must be set when external is false, unless auth.type is auth/munge"
 // +kubebuilder:validation:XValidation:rule="!self.external ? has(self.jwtHs256KeyRef) : true", message="jwtHs256KeyRef must be set when external is false"
 // +kubebuilder:validation:XValidation:rule="self.external ? has(self.externalConfig) : true", message="externalConfig must be set when external is true"
 type AccountingSpec struct {
+	// Auth selects the Slurm authentication, `auth/slurm` by default. It must
+	// match the Auth of the Controllers of the Accounting.
+	// +optional
+	Auth *SlurmAuth `json:"auth,omitempty"`
+
 	// Slurm `auth/slurm` key authentication.
+	// Unused when Auth selects `auth/munge`.
 	// +optional
-	SlurmKeyRef corev1.SecretKeySelector `json:"slurmKeyRef,omitzero"`
+	SlurmKeyRef GeneratedSecretKeySelector `json:"slurmKeyRef,omitzero"`
 
 	// Slurm `auth/jwt` JWT HS256 key authentication.
 	// +optional
-	JwtHs256KeyRef corev1.SecretKeySelector `json:"jwtHs256KeyRef,omitzero"`
+	JwtHs256KeyRef GeneratedSecretKeySelector `json:"jwtHs256KeyRef,omitzero"`
 
 	// external indicates if this component is external to Kubernetes or not.
 	// If true, then externalConfig is used and other fields are ignored.
@@ -105,6 +111,16 @@ type StorageConfig struct {
 
 // AccountingStatus defines the observed state of Accounting
 type AccountingStatus struct {
+	// The generation observed by the Accounting controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// GeneratedKeys are the Secrets of the key refs which were generated.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	GeneratedKeys []GeneratedKeyStatus `json:"generatedKeys,omitempty"`
+
 	// Represents the latest available observations of a Accounting's current state.
 	// +optional
 	// +patchMergeKey=type
@@ -117,6 +133,8 @@ type AccountingStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=slurmdbd
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Whether the component is ready."
+// +kubebuilder:printcolumn:name="REASON",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",priority=1,description="The reason for the Ready condition."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Accounting is the Schema for the accountings API
@@ -9,6 +9,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -54,6 +55,83 @@ type JwtSecretKeySelector struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// GeneratedSecretKeySelector selects a key of a Secret, which the operator may
+// generate when the Secret does not exist.
+type GeneratedSecretKeySelector struct {
+	// SecretKeySelector selects a key of a Secret.
+	// +structType=atomic
+	corev1.SecretKeySelector `json:",inline"`
+
+	// generate, when true, has the operator generate a random key into an
+	// immutable Secret, when the Secret does not exist.
+	// +optional
+	Generate bool `json:"generate,omitzero"`
+}
+
+// SlurmAuthType is the Slurm `AuthType` plugin.
+// +enum
+// +kubebuilder:validation:Enum=auth/slurm;auth/munge
+type SlurmAuthType string
+
+const (
+	// SlurmAuthTypeSlurm authenticates with the `auth/slurm` key.
+	SlurmAuthTypeSlurm SlurmAuthType = "auth/slurm"
+	// SlurmAuthTypeMunge authenticates with a MUNGE key, through a munged
+	// sidecar of each pod.
+	SlurmAuthTypeMunge SlurmAuthType = "auth/munge"
+)
+
+// SlurmAuth selects the Slurm authentication.
+// Ref: https://slurm.schedmd.com/authentication.html
+// +kubebuilder:validation:XValidation:rule="self.type == 'auth/munge' ? has(self.mungeKeyRef) : true", message="mungeKeyRef must be set when type is auth/munge"
+type SlurmAuth struct {
+	// Type is the Slurm `AuthType`, which also selects the `CredType`.
+	// +optional
+	// +default:="auth/slurm"
+	Type SlurmAuthType `json:"type,omitempty"`
+
+	// MungeKeyRef is a reference to the MUNGE key, for `auth/munge`.
+	// +optional
+	MungeKeyRef corev1.SecretKeySelector `json:"mungeKeyRef,omitzero"`
+
+	// The munged sidecar configuration, for `auth/munge`, which requires its image.
+	// +optional
+	Munged ContainerWrapper `json:"munged,omitzero"`
+}
+
+// AuthType returns the Slurm `AuthType`, which defaults to `auth/slurm`.
+func (o *SlurmAuth) AuthType() SlurmAuthType {
+	if o == nil || o.Type == "" {
+		return SlurmAuthTypeSlurm
+	}
+	return o.Type
+}
+
+// IsMunge reports if the Slurm authentication is `auth/munge`.
+func (o *SlurmAuth) IsMunge() bool {
+	return o.AuthType() == SlurmAuthTypeMunge
+}
+
+// GeneratedKeyStatus is the observed state of a Secret generated by the
+// operator.
+type GeneratedKeyStatus struct {
+	// Name is the name of the Secret.
+	Name string `json:"name"`
+
+	// Keys are the keys of the Secret which were generated.
+	// +optional
+	// +listType=atomic
+	Keys []string `json:"keys,omitempty"`
+
+	// GeneratedAt is when the Secret was generated.
+	// +optional
+	GeneratedAt metav1.Time `json:"generatedAt,omitzero"`
+
+	// Retained reports if the Secret is kept when its owners are deleted.
+	// +optional
+	Retained bool `json:"retained,omitzero"`
+}
+
 // PodTemplate describes a template for creating copies of a predefined pod.
 type PodTemplate struct {
 	// Standard object's metadata.
@@ -5,8 +5,12 @@ package v1beta1
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/SlinkyProject/slurm-operator/internal/utils/domainname"
@@ -19,6 +23,16 @@ func (o *Controller) ClusterName() string {
 	return fmt.Sprintf("%s_%s", o.Namespace, o.Name)
 }
 
+// IsUserGranted reports if objects of the namespace may act as the Slurm user.
+func (o *Controller) IsUserGranted(namespace, username string) bool {
+	for _, grant := range o.Spec.UserGrants {
+		if grant.Namespace == namespace && slices.Contains(grant.Usernames, username) {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *Controller) Key() types.NamespacedName {
 	return types.NamespacedName{
 		Name:      fmt.Sprintf("%s-controller", o.Name),
@@ -30,8 +44,40 @@ func (o *Controller) PrimaryName() string {
 	if o.Spec.External {
 		return o.Spec.ExternalConfig.Host
 	}
+	return o.HostName(0)
+}
+
+// Replicas returns the number of slurmctld instances, the primary and backups.
+func (o *Controller) Replicas() int32 {
+	if o.Spec.External || o.Spec.Replicas == nil || *o.Spec.Replicas < 1 {
+		return 1
+	}
+	return *o.Spec.Replicas
+}
+
+// IsHighlyAvailable reports if there are backup slurmctld instances.
+func (o *Controller) IsHighlyAvailable() bool {
+	return o.Replicas() > 1
+}
+
+// HostName returns the slurmctld hostname of the replica with the ordinal.
+func (o *Controller) HostName(ordinal int32) string {
 	key := o.Key()
-	return fmt.Sprintf("%s-0", key.Name)
+	return fmt.Sprintf("%s-%d", key.Name, ordinal)
+}
+
+// HostServiceKey returns the Service of the replica with the ordinal.
+func (o *Controller) HostServiceKey(ordinal int32) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.HostName(ordinal),
+		Namespace: o.Namespace,
+	}
+}
+
+// HostServiceFQDNShort returns the Service address of the replica with the ordinal.
+func (o *Controller) HostServiceFQDNShort(ordinal int32) string {
+	s := o.HostServiceKey(ordinal)
+	return domainname.FqdnShort(s.Name, s.Namespace)
 }
 
 func (o *Controller) PrimaryFQDN() string {
@@ -75,6 +121,25 @@ func (o *Controller) AuthSlurmRef() *corev1.SecretKeySelector {
 	}
 }
 
+// IsMungeAuth reports if the Slurm authentication is `auth/munge`.
+func (o *Controller) IsMungeAuth() bool {
+	return o.Spec.Auth.IsMunge()
+}
+
+func (o *Controller) AuthMungeKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.AuthMungeRef().Name,
+		Namespace: o.Namespace,
+	}
+}
+
+func (o *Controller) AuthMungeRef() *corev1.SecretKeySelector {
+	if o.Spec.Auth == nil {
+		return &corev1.SecretKeySelector{}
+	}
+	return o.Spec.Auth.MungeKeyRef.DeepCopy()
+}
+
 func (o *Controller) AuthJwtHs256Key() types.NamespacedName {
 	return types.NamespacedName{
 		Name:      o.Spec.JwtHs256KeyRef.Name,
@@ -92,9 +157,220 @@ func (o *Controller) AuthJwtHs256Ref() *corev1.SecretKeySelector {
 	}
 }
 
+// GeneratedKeyRefs returns the key refs which the operator generates when
+// their Secret does not exist.
+func (o *Controller) GeneratedKeyRefs() []*corev1.SecretKeySelector {
+	out := []*corev1.SecretKeySelector{}
+	if o.Spec.SlurmKeyRef.Generate && !o.IsMungeAuth() {
+		out = append(out, o.AuthSlurmRef())
+	}
+	if o.Spec.JwtHs256KeyRef.Generate {
+		out = append(out, o.AuthJwtHs256Ref())
+	}
+	return out
+}
+
+// SlurmJwksKey returns the Secret of the `slurm.jwks` files of a rotation of
+// the `auth/slurm` key.
+func (o *Controller) SlurmJwksKey() types.NamespacedName {
+	key := o.Key()
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-slurm-jwks", key.Name),
+		Namespace: o.Namespace,
+	}
+}
+
+// AuthSlurmNewRef returns the new `auth/slurm` key of the rotation, if any.
+func (o *Controller) AuthSlurmNewRef() *corev1.SecretKeySelector {
+	if o.Spec.SlurmKeyRotation == nil {
+		return nil
+	}
+	ref := o.Spec.SlurmKeyRotation.NewSlurmKeyRef
+	return &ref
+}
+
+// KeyRotationComponents are the components in the order they are rolled:
+// slurmdbd, slurmctld, slurmrestd, login, then slurmd.
+var KeyRotationComponents = []KeyRotationComponent{
+	KeyRotationComponentAccounting,
+	KeyRotationComponentController,
+	KeyRotationComponentRestApi,
+	KeyRotationComponentLoginSet,
+	KeyRotationComponentNodeSet,
+}
+
+// SlurmKeyRotationPhase returns the phase of the `auth/slurm` key rotation
+// which the component is rolled to, which is empty when the component only
+// has the current key.
+func (o *Controller) SlurmKeyRotationPhase(component KeyRotationComponent) KeyRotationPhase {
+	if o.Spec.SlurmKeyRotation == nil || o.Status.KeyRotation == nil {
+		return ""
+	}
+	for _, status := range o.Status.KeyRotation.Components {
+		if status.Name == component {
+			return status.Phase
+		}
+	}
+	return ""
+}
+
+// JwksKey returns the Secret of the Slurm `jwks` file.
+func (o *Controller) JwksKey() types.NamespacedName {
+	key := o.Key()
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-jwks", key.Name),
+		Namespace: o.Namespace,
+	}
+}
+
+// JwtSigningKeyID returns the `kid` of the signing key, which is empty for
+// the HS256 key.
+func (o *Controller) JwtSigningKeyID() string {
+	if o.Spec.JwtKeys == nil {
+		return ""
+	}
+	return o.Spec.JwtKeys.SigningKeyID
+}
+
+// JwtKeyIDs returns the `kid` of the keys published in the `jwks` file.
+func (o *Controller) JwtKeyIDs() []string {
+	if o.Spec.JwtKeys == nil {
+		return nil
+	}
+	out := make([]string, 0, len(o.Spec.JwtKeys.Keys))
+	for _, key := range o.Spec.JwtKeys.Keys {
+		out = append(out, key.KeyID)
+	}
+	return out
+}
+
+// JwtKeySecretKeys returns the Secrets of the private keys of the JWT keys.
+func (o *Controller) JwtKeySecretKeys() []types.NamespacedName {
+	if o.Spec.JwtKeys == nil {
+		return nil
+	}
+	out := make([]types.NamespacedName, 0, len(o.Spec.JwtKeys.Keys))
+	for _, key := range o.Spec.JwtKeys.Keys {
+		out = append(out, types.NamespacedName{
+			Name:      key.PrivateKeyRef.Name,
+			Namespace: o.Namespace,
+		})
+	}
+	return out
+}
+
 func (o *Controller) ConfigKey() types.NamespacedName {
 	return types.NamespacedName{
 		Name:      fmt.Sprintf("%s-config", o.Name),
 		Namespace: o.Namespace,
 	}
 }
+
+// ReservedSlurmConfigKeys are the `slurm.conf` parameters owned by the operator,
+// which cannot be set in the SlurmConfig.
+var ReservedSlurmConfigKeys = []string{
+	"AuthType",
+	"ClusterName",
+	"SlurmctldHost",
+	"SlurmctldPort",
+	"SlurmdPort",
+	"StateSaveLocation",
+}
+
+// IsReservedSlurmConfigKey reports if the `slurm.conf` parameter is owned by the operator.
+func IsReservedSlurmConfigKey(key string) bool {
+	return slices.ContainsFunc(ReservedSlurmConfigKeys, func(reserved string) bool {
+		return strings.EqualFold(reserved, key)
+	})
+}
+
+// SlurmConfigKeys returns the SlurmConfig parameter names, sorted.
+func (o *Controller) SlurmConfigKeys() []string {
+	keys := make([]string, 0, len(o.Spec.SlurmConfig))
+	for key := range o.Spec.SlurmConfig {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// SlurmConfigDuplicates returns the SlurmConfig parameter names which only
+// differ by case, because `slurm.conf` parameter names are case-insensitive.
+func (o *Controller) SlurmConfigDuplicates() []string {
+	seen := make(map[string]string, len(o.Spec.SlurmConfig))
+	duplicates := []string{}
+	for _, key := range o.SlurmConfigKeys() {
+		lower := strings.ToLower(key)
+		if first, ok := seen[lower]; ok {
+			if !slices.Contains(duplicates, first) {
+				duplicates = append(duplicates, first)
+			}
+			duplicates = append(duplicates, key)
+			continue
+		}
+		seen[lower] = key
+	}
+	return duplicates
+}
+
+// SlurmConfigConflicts returns the SlurmConfig parameter names which are also
+// set in ExtraConf, which takes precedence.
+func (o *Controller) SlurmConfigConflicts() []string {
+	extraKeys := map[string]bool{}
+	for line := range strings.SplitSeq(o.Spec.ExtraConf, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, _, ok := strings.Cut(strings.Fields(line)[0], "=")
+		if !ok {
+			continue
+		}
+		extraKeys[strings.ToLower(key)] = true
+	}
+	conflicts := []string{}
+	for _, key := range o.SlurmConfigKeys() {
+		if extraKeys[strings.ToLower(key)] {
+			conflicts = append(conflicts, key)
+		}
+	}
+	return conflicts
+}
+
+// SelectsNodeSet reports if the NodeSet is in the partition, either by its name
+// or by the NodeSet selector.
+func (o *ControllerPartition) SelectsNodeSet(nodeset *NodeSet) bool {
+	if slices.Contains(o.NodeSets, nodeset.Name) {
+		return true
+	}
+	if o.NodeSetSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(o.NodeSetSelector)
+	if err != nil || selector.Empty() {
+		return false
+	}
+	return selector.Matches(labels.Set(nodeset.Labels))
+}
+
+// PartitionNames returns the names of the Controller partitions.
+func (o *Controller) PartitionNames() []string {
+	names := make([]string, 0, len(o.Spec.Partitions))
+	for _, partition := range o.Spec.Partitions {
+		names = append(names, partition.Name)
+	}
+	return names
+}
+
+// IsTopologyEnabled reports if the Slurm network topology is generated.
+func (o *Controller) IsTopologyEnabled() bool {
+	return o.Spec.Topology != nil && len(o.Spec.Topology.LabelKeys) > 0
+}
+
+// TopologyPlugin returns the Slurm TopologyPlugin, defaulting to `topology/tree`.
+func (o *ControllerTopology) TopologyPlugin() TopologyPlugin {
+	if o.Plugin == "" {
+		return TopologyPluginTree
+	}
+	return o.Plugin
+}
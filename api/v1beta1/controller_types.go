@@ -4,6 +4,10 @@
 package v1beta1
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -19,6 +23,7 @@ var (
 
 // ControllerSpec defines the desired state of Controller
 // +kubebuilder:validation:XValidation:rule="self.external ? has(self.externalConfig) : true", message="externalConfig must be set when external is true"
+// +kubebuilder:validation:XValidation:rule="has(self.auth) && self.auth.type == 'auth/munge' ? true : has(self.slurmKeyRef)", message="slurmKeyRef must be set unless auth.type is auth/munge"
 type ControllerSpec struct {
 	// The Slurm ClusterName, which uniquely identifies the Slurm Cluster to
 	// itself and accounting.
@@ -26,13 +31,39 @@ type ControllerSpec struct {
 	// +optional
 	ClusterName string `json:"clusterName,omitzero"`
 
+	// Auth selects the Slurm authentication, `auth/slurm` by default.
+	// +optional
+	Auth *SlurmAuth `json:"auth,omitempty"`
+
 	// Slurm `auth/slurm` key authentication.
-	// +required
-	SlurmKeyRef corev1.SecretKeySelector `json:"slurmKeyRef,omitzero"`
+	// Unused when Auth selects `auth/munge`.
+	// +optional
+	SlurmKeyRef GeneratedSecretKeySelector `json:"slurmKeyRef,omitzero"`
 
 	// Slurm `auth/jwt` JWT HS256 key authentication.
 	// +required
-	JwtHs256KeyRef corev1.SecretKeySelector `json:"jwtHs256KeyRef,omitzero"`
+	JwtHs256KeyRef GeneratedSecretKeySelector `json:"jwtHs256KeyRef,omitzero"`
+
+	// JwtKeys are the Slurm `auth/jwt` RS256 and ES256 keys, published in the
+	// `jwks` file. JWTs signed by any of them, or by the HS256 key, are accepted.
+	// Ref: https://slurm.schedmd.com/jwt.html
+	// +optional
+	JwtKeys *JwtKeys `json:"jwtKeys,omitempty"`
+
+	// SlurmKeyRotation rotates the `auth/slurm` key to a new key, by staging it
+	// alongside the current key in the `slurm.jwks` file, and rolling each
+	// component in turn.
+	// Ref: https://slurm.schedmd.com/authentication.html#slurm_jwks
+	// +optional
+	SlurmKeyRotation *SlurmKeyRotation `json:"slurmKeyRotation,omitempty"`
+
+	// UserGrants allow objects of namespaces to act as Slurm users of this
+	// cluster, i.e. TokenPolicies of other namespaces which issue JWTs signed
+	// by this Controller, and SlurmJobs which are submitted as the users.
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	UserGrants []UserGrant `json:"userGrants,omitempty"`
 
 	// accountingRef is a reference to the Accounting CR to which this has membership.
 	// +optional
@@ -48,6 +79,17 @@ type ControllerSpec struct {
 	// +optional
 	ExternalConfig ExternalConfig `json:"externalConfig,omitzero"`
 
+	// Replicas is the number of slurmctld instances.
+	// The first replica is the primary controller and the others are backup
+	// controllers, in ordinal order, which take over when the primary fails.
+	// More than one replica requires the save-state to be shared, through an
+	// existing `ReadWriteMany` PersistentVolumeClaim.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SlurmctldHost
+	// +optional
+	// +default:=1
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
+
 	// The slurmctld container configuration.
 	// See corev1.Container spec.
 	// Ref: https://github.com/kubernetes/api/blob/master/core/v1/types.go#L2885
@@ -68,7 +110,36 @@ type ControllerSpec struct {
 	// +optional
 	Template PodTemplate `json:"template,omitempty"`
 
+	// Partitions is a list of Slurm partitions, each spanning the selected
+	// NodeSets of this Controller. These are in addition to the partition of
+	// each NodeSet, and must not have the same name as a NodeSet partition.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Partitions []ControllerPartition `json:"partitions,omitempty"`
+
+	// Topology configures the Slurm network topology. The `topology.conf` file
+	// is generated from the labels of the Kubernetes nodes where NodeSet pods
+	// run, unless it is provided by ConfigFileRefs.
+	// Ref: https://slurm.schedmd.com/topology.html
+	// +optional
+	Topology *ControllerTopology `json:"topology,omitempty"`
+
+	// SlurmConfig is a map of `slurm.conf` parameters, merged over the
+	// operator defaults. A parameter replaces the operator default of the same
+	// name, otherwise it is added. Parameter names are case-insensitive.
+	// Certain parameters are owned by the operator and cannot be set (e.g.
+	// `SlurmctldHost`, `AuthType`, `StateSaveLocation`, `ClusterName`).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	SlurmConfig map[string]SlurmConfigValue `json:"slurmConfig,omitempty"`
+
 	// ExtraConf is appended onto the end of the `slurm.conf` file.
+	// It takes precedence over SlurmConfig and the operator defaults.
 	// Ref: https://slurm.schedmd.com/slurm.conf.html
 	// +optional
 	ExtraConf string `json:"extraConf,omitempty"`
@@ -126,8 +197,362 @@ type ControllerPersistence struct {
 	corev1.PersistentVolumeClaimSpec `json:",inline"`
 }
 
+// ControllerPartition defines a Slurm partition spanning NodeSets.
+type ControllerPartition struct {
+	// Name of the Slurm partition.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[^\s=,]+$`
+	Name string `json:"name"`
+
+	// NodeSets is the list of NodeSet names, of this Controller, in the partition.
+	// +optional
+	// +listType=set
+	NodeSets []string `json:"nodeSets,omitempty"`
+
+	// NodeSetSelector selects NodeSets, of this Controller, in the partition.
+	// NodeSets selected by either NodeSets or NodeSetSelector are in the partition.
+	// +optional
+	NodeSetSelector *metav1.LabelSelector `json:"nodeSetSelector,omitempty"`
+
+	// Config is added to the partition line.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
+	// +optional
+	Config string `json:"config,omitzero"`
+}
+
+// TopologyPlugin is the Slurm topology plugin.
+// +enum
+type TopologyPlugin string
+
+const (
+	TopologyPluginTree  TopologyPlugin = "topology/tree"
+	TopologyPluginBlock TopologyPlugin = "topology/block"
+)
+
+// ControllerTopology defines how the Slurm network topology is generated.
+type ControllerTopology struct {
+	// Plugin is the Slurm TopologyPlugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_TopologyPlugin
+	// +optional
+	// +default:="topology/tree"
+	// +kubebuilder:validation:Enum=topology/tree;topology/block
+	Plugin TopologyPlugin `json:"plugin,omitempty"`
+
+	// LabelKeys are the Kubernetes node label keys describing the topology,
+	// ordered from the top (e.g. `topology.kubernetes.io/zone`) to the nodes
+	// (e.g. a rack label).
+	// With `topology/tree`, each label key is a level of switches.
+	// With `topology/block`, each combination of label values is a block.
+	// Nodes lacking a label are grouped under the `unknown` value.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	LabelKeys []string `json:"labelKeys"`
+
+	// BlockSizes are the planning base block sizes, with `topology/block`.
+	// Ref: https://slurm.schedmd.com/topology.conf.html#OPT_BlockSizes
+	// +optional
+	// +listType=atomic
+	BlockSizes []int32 `json:"blockSizes,omitempty"`
+}
+
+// SlurmConfigValue is a `slurm.conf` parameter value, either a scalar (string,
+// number, or boolean) or a list of scalars which is rendered comma separated.
+type SlurmConfigValue []string
+
+// String returns the value as rendered in `slurm.conf`.
+func (o SlurmConfigValue) String() string {
+	return strings.Join(o, ",")
+}
+
+// MarshalJSON encodes a single value as a scalar, otherwise as a list.
+func (o SlurmConfigValue) MarshalJSON() ([]byte, error) {
+	if len(o) == 1 {
+		return json.Marshal(o[0])
+	}
+	return json.Marshal([]string(o))
+}
+
+// UnmarshalJSON decodes a scalar or a list of scalars.
+func (o *SlurmConfigValue) UnmarshalJSON(data []byte) error {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		items = []any{raw}
+	}
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		switch v := item.(type) {
+		case nil:
+			continue
+		case string, bool, float64:
+			values = append(values, fmt.Sprint(v))
+		default:
+			return fmt.Errorf("invalid slurm config value, must be a scalar or a list of scalars: %s", string(data))
+		}
+	}
+	*o = values
+	return nil
+}
+
+// SlurmConfigStatus is the observed state of the `slurm.conf` parameters.
+type SlurmConfigStatus struct {
+	// Conflicts is the list of `slurmConfig` parameters which are also set in
+	// `extraConf`, which takes precedence.
+	// +optional
+	// +listType=set
+	Conflicts []string `json:"conflicts,omitempty"`
+
+	// Preview is the rendered `slurm.conf` file, as last synced.
+	// +optional
+	Preview string `json:"preview,omitempty"`
+}
+
+// SlurmrestdStatus is the observed state of the slurmrestd endpoints, which
+// the operator sends Slurm requests to.
+type SlurmrestdStatus struct {
+	// Active is the slurmrestd server which requests are sent to first.
+	// +optional
+	Active string `json:"active,omitempty"`
+
+	// Endpoints are the slurmrestd servers of the RestApis of the Controller.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Endpoints []SlurmrestdEndpoint `json:"endpoints,omitempty"`
+}
+
+// JwtKeyAlgorithm is the signing algorithm of a JWT key.
+// +enum
+type JwtKeyAlgorithm string
+
+const (
+	JwtKeyAlgorithmRS256 JwtKeyAlgorithm = "RS256"
+	JwtKeyAlgorithmES256 JwtKeyAlgorithm = "ES256"
+)
+
+// UserGrant allows objects of a namespace to act as Slurm users.
+type UserGrant struct {
+	// Namespace which is granted the usernames.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Usernames which objects of the namespace may act as.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	Usernames []string `json:"usernames"`
+}
+
+// JwtKeys are the keys published in the Slurm `jwks` file.
+type JwtKeys struct {
+	// SigningKeyID is the `kid` of the key which signs the Tokens of the
+	// Controller, and the JWTs of the slurm-operator.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	SigningKeyID string `json:"signingKeyId"`
+
+	// Keys are the published keys. A key is rotated by adding the new key,
+	// then making it the signing key, then removing the old key once no JWT
+	// signed by it remains, as reported in the status.
+	// +required
+	// +listType=map
+	// +listMapKey=kid
+	// +kubebuilder:validation:MinItems=1
+	Keys []JwtKey `json:"keys"`
+}
+
+// JwtKey is a key published in the Slurm `jwks` file.
+type JwtKey struct {
+	// KeyID is the `kid` of the key.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	KeyID string `json:"kid"`
+
+	// Algorithm is the signing algorithm of the key.
+	// +optional
+	// +default:="RS256"
+	// +kubebuilder:validation:Enum=RS256;ES256
+	Algorithm JwtKeyAlgorithm `json:"algorithm,omitempty"`
+
+	// PrivateKeyRef is a reference to the PEM encoded private key.
+	// Only its public key is published.
+	// +required
+	PrivateKeyRef corev1.SecretKeySelector `json:"privateKeyRef"`
+}
+
+// JwtKeysStatus is the observed state of the JWT keys, and of their rotation.
+type JwtKeysStatus struct {
+	// SigningKeyID is the `kid` of the key which signs new JWTs.
+	// +optional
+	SigningKeyID string `json:"signingKeyId,omitempty"`
+
+	// PublishedKeyIDs are the `kid` of the keys in the `jwks` file.
+	// +optional
+	PublishedKeyIDs []string `json:"publishedKeyIds,omitempty"`
+
+	// PendingTokens are the Tokens of the Controller which are not yet signed
+	// by the signing key.
+	// +optional
+	PendingTokens []string `json:"pendingTokens,omitempty"`
+
+	// ClientKeyID is the `kid` of the key which signed the JWT of the
+	// slurm-operator, which is empty for the HS256 key.
+	// +optional
+	ClientKeyID string `json:"clientKeyId,omitempty"`
+
+	// RetirableKeyIDs are the `kid` of the published keys which no longer
+	// sign any JWT, and may be removed.
+	// +optional
+	RetirableKeyIDs []string `json:"retirableKeyIds,omitempty"`
+}
+
+// SlurmKeyRotation is a rotation of the `auth/slurm` key.
+type SlurmKeyRotation struct {
+	// NewSlurmKeyRef is a reference to the new `auth/slurm` key.
+	// +required
+	NewSlurmKeyRef corev1.SecretKeySelector `json:"newSlurmKeyRef"`
+
+	// Rollback rolls the components back to the current key, unless the
+	// current key is already being retired.
+	// +optional
+	Rollback bool `json:"rollback,omitzero"`
+
+	// ProgressDeadlineSeconds is how long a component may take to roll before
+	// the rotation is rolled back.
+	// +optional
+	// +default:=1800
+	// +kubebuilder:validation:Minimum=1
+	ProgressDeadlineSeconds int32 `json:"progressDeadlineSeconds,omitzero"`
+}
+
+// KeyRotationPhase is the phase of a rotation of the `auth/slurm` key.
+// +enum
+type KeyRotationPhase string
+
+const (
+	// KeyRotationPhaseStaging adds the new key to the components, which still
+	// sign with the current key.
+	KeyRotationPhaseStaging KeyRotationPhase = "Staging"
+	// KeyRotationPhaseSwitching makes the components sign with the new key,
+	// while still accepting the current key.
+	KeyRotationPhaseSwitching KeyRotationPhase = "Switching"
+	// KeyRotationPhaseRetiring removes the current key from the components.
+	KeyRotationPhaseRetiring KeyRotationPhase = "Retiring"
+	// KeyRotationPhaseComplete is when all components only have the new key.
+	KeyRotationPhaseComplete KeyRotationPhase = "Complete"
+	// KeyRotationPhaseRollingBack makes the components sign with the current
+	// key again.
+	KeyRotationPhaseRollingBack KeyRotationPhase = "RollingBack"
+	// KeyRotationPhaseRolledBack is when all components sign with the current
+	// key again.
+	KeyRotationPhaseRolledBack KeyRotationPhase = "RolledBack"
+)
+
+// KeyRotationComponent is a component rolled by a rotation of the
+// `auth/slurm` key.
+// +enum
+type KeyRotationComponent string
+
+const (
+	KeyRotationComponentAccounting KeyRotationComponent = "Accounting"
+	KeyRotationComponentController KeyRotationComponent = "Controller"
+	KeyRotationComponentRestApi    KeyRotationComponent = "RestApi"
+	KeyRotationComponentLoginSet   KeyRotationComponent = "LoginSet"
+	KeyRotationComponentNodeSet    KeyRotationComponent = "NodeSet"
+)
+
+// KeyRotationStatus is the observed state of a rotation of the `auth/slurm`
+// key.
+type KeyRotationStatus struct {
+	// Phase is the phase of the rotation.
+	// +optional
+	Phase KeyRotationPhase `json:"phase,omitempty"`
+
+	// Component is the component being rolled to the phase.
+	// +optional
+	Component KeyRotationComponent `json:"component,omitempty"`
+
+	// Components are the phases which the components were rolled to.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Components []KeyRotationComponentStatus `json:"components,omitempty"`
+
+	// Message is a human readable message about the rotation.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// KeyRotationComponentStatus is the observed state of a component in a
+// rotation of the `auth/slurm` key.
+type KeyRotationComponentStatus struct {
+	// Name of the component.
+	// +required
+	Name KeyRotationComponent `json:"name"`
+
+	// Phase is the phase which the component is rolled to.
+	// +required
+	Phase KeyRotationPhase `json:"phase"`
+
+	// StartTime is when the component started rolling to the phase.
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitzero"`
+
+	// Rolled is true once all pods of the component are ready in the phase.
+	// +optional
+	Rolled bool `json:"rolled,omitzero"`
+}
+
+// SlurmrestdEndpoint is the observed state of a slurmrestd server.
+type SlurmrestdEndpoint struct {
+	// Name of the RestApi.
+	// +required
+	Name string `json:"name"`
+
+	// Server is the URL of the slurmrestd.
+	// +required
+	Server string `json:"server"`
+
+	// Ready is true when the RestApi is ready, and the server is not backing
+	// off after failed requests.
+	// +required
+	Ready bool `json:"ready"`
+}
+
 // ControllerStatus defines the observed state of Controller
 type ControllerStatus struct {
+	// The generation observed by the Controller controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// SlurmConfig is the observed state of the `slurm.conf` parameters.
+	// +optional
+	SlurmConfig *SlurmConfigStatus `json:"slurmConfig,omitempty"`
+
+	// Slurmrestd is the observed state of the slurmrestd endpoints.
+	// +optional
+	Slurmrestd *SlurmrestdStatus `json:"slurmrestd,omitempty"`
+
+	// JwtKeys is the observed state of the JWT keys.
+	// +optional
+	JwtKeys *JwtKeysStatus `json:"jwtKeys,omitempty"`
+
+	// GeneratedKeys are the Secrets of the key refs which were generated.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	GeneratedKeys []GeneratedKeyStatus `json:"generatedKeys,omitempty"`
+
+	// KeyRotation is the observed state of the rotation of the `auth/slurm`
+	// key.
+	// +optional
+	KeyRotation *KeyRotationStatus `json:"keyRotation,omitempty"`
+
 	// Represents the latest available observations of a Controller's current state.
 	// +optional
 	// +patchMergeKey=type
@@ -140,6 +565,9 @@ type ControllerStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=slurmctld
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Whether the component is ready."
+// +kubebuilder:printcolumn:name="REASON",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",priority=1,description="The reason for the Ready condition."
+// +kubebuilder:printcolumn:name="SLURMRESTD",type="string",JSONPath=".status.slurmrestd.active",priority=1,description="The slurmrestd server which requests are sent to."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Controller is the Schema for the controllers API
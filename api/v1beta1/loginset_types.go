@@ -66,6 +66,10 @@ type LoginSetStatus struct {
 	// +optional
 	Replicas int32 `json:"replicas,omitempty"`
 
+	// The generation observed by the LoginSet controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Represents the latest available observations of a LoginSet's current state.
 	// +optional
 	// +patchMergeKey=type
@@ -83,6 +87,8 @@ type LoginSetStatus struct {
 // +kubebuilder:resource:shortName=loginsets;lss;sackd
 // +kubebuilder:subresource:scale:specpath=".spec.replicas",statuspath=".status.replicas",selectorpath=".status.selector"
 // +kubebuilder:printcolumn:name="REPLICAS",type="integer",JSONPath=".status.replicas",priority=0,description="The current number of pods."
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Whether the component is ready."
+// +kubebuilder:printcolumn:name="REASON",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",priority=1,description="The reason for the Ready condition."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // LoginSet is the Schema for the loginsets API
@@ -5,8 +5,12 @@ package v1beta1
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 )
 
 func (o *NodeSet) Key() types.NamespacedName {
@@ -16,6 +20,15 @@ func (o *NodeSet) Key() types.NamespacedName {
 	}
 }
 
+// SlurmName returns the name of the Slurm NodeSet, and of its partition, which
+// is the pod hostname prefix when set.
+func (o *NodeSet) SlurmName() string {
+	if hostname := o.Spec.Template.PodSpecWrapper.Hostname; hostname != "" {
+		return strings.Trim(hostname, "-")
+	}
+	return o.Name
+}
+
 func (o *NodeSet) HeadlessServiceKey() types.NamespacedName {
 	key := o.Key()
 	return types.NamespacedName{
@@ -23,3 +36,143 @@ func (o *NodeSet) HeadlessServiceKey() types.NamespacedName {
 		Namespace: o.Namespace,
 	}
 }
+
+func (o *NodeSet) IsAutoscalingEnabled() bool {
+	return o.Spec.Autoscaling != nil && o.Spec.Autoscaling.Enabled
+}
+
+func (o *NodeSetAutoscaling) Bounds() (minReplicas, maxReplicas int32) {
+	minReplicas = ptr.Deref(o.MinReplicas, 0)
+	maxReplicas = max(o.MaxReplicas, minReplicas)
+	return minReplicas, maxReplicas
+}
+
+func (o *NodeSetAutoscaling) ScaleUpWindow() time.Duration {
+	window := 0 * time.Second
+	if o.ScaleUpStabilizationWindow != nil {
+		window = o.ScaleUpStabilizationWindow.Duration
+	}
+	return window
+}
+
+func (o *NodeSetAutoscaling) ScaleDownWindow() time.Duration {
+	window := 300 * time.Second
+	if o.ScaleDownStabilizationWindow != nil {
+		window = o.ScaleDownStabilizationWindow.Duration
+	}
+	return window
+}
+
+func (o *NodeSetDrainPolicy) DrainTimeout() (time.Duration, bool) {
+	if o == nil || o.Timeout == nil {
+		return 0, false
+	}
+	return o.Timeout.Duration, true
+}
+
+func (o *NodeSetDrainPolicy) DrainAction() NodeSetDrainAction {
+	if o == nil || o.Action == "" {
+		return NodeSetDrainActionWait
+	}
+	return o.Action
+}
+
+func (o *NodeSetDrainPolicy) DrainGracePeriod() time.Duration {
+	if o == nil || o.GraceSignal == "" {
+		return 0
+	}
+	period := 60 * time.Second
+	if o.GracePeriod != nil {
+		period = o.GracePeriod.Duration
+	}
+	return period
+}
+
+func (o *NodeSetRemediation) MaxConcurrentRemediations() int32 {
+	if o == nil {
+		return 0
+	}
+	return max(ptr.Deref(o.MaxConcurrent, 1), 1)
+}
+
+func (o *NodeSetNodeHealthRule) HealthAction() NodeSetNodeHealthAction {
+	if o == nil || o.Action == "" {
+		return NodeSetNodeHealthActionDrain
+	}
+	return o.Action
+}
+
+func (o *NodeSetNodeConditionMatch) ConditionStatus() corev1.ConditionStatus {
+	if o == nil || o.Status == "" {
+		return corev1.ConditionTrue
+	}
+	return o.Status
+}
+
+func (o *OpportunisticNodeSetStrategy) UpdateDeadline() (time.Duration, bool) {
+	if o == nil || o.Deadline == nil {
+		return 0, false
+	}
+	return o.Deadline.Duration, true
+}
+
+func (o *RollingUpdateNodeSetStrategy) IsPaused() bool {
+	return o != nil && o.Paused
+}
+
+func (o *RollingUpdateNodeSetStrategy) PartitionOrdinal() int {
+	if o == nil {
+		return 0
+	}
+	return int(ptr.Deref(o.Partition, 0))
+}
+
+func (o *RollingUpdateNodeSetStrategy) CanaryConfig() *NodeSetCanary {
+	if o == nil {
+		return nil
+	}
+	return o.Canary
+}
+
+func (o *NodeSetCanary) SoakDuration() time.Duration {
+	period := 10 * time.Minute
+	if o != nil && o.SoakPeriod != nil {
+		period = o.SoakPeriod.Duration
+	}
+	return period
+}
+
+func (o *NodeSetDeletionCost) DeletionCostPolicy() NodeSetDeletionCostPolicy {
+	if o == nil || o.Policy == "" {
+		return NodeSetDeletionCostPolicyWorkload
+	}
+	return o.Policy
+}
+
+// IsLowPriority returns true if the Slurm job priority is at or below the
+// LowPriorityThreshold.
+func (o *NodeSetDeletionCost) IsLowPriority(priority int64) bool {
+	return o != nil && o.LowPriorityThreshold != nil && priority <= *o.LowPriorityThreshold
+}
+
+// defaultNodeConfGres are the extended resources mapped to Slurm GRES when
+// none are configured.
+var defaultNodeConfGres = []NodeSetGres{
+	{Resource: "nvidia.com/gpu", Name: "gpu"},
+	{Resource: "amd.com/gpu", Name: "gpu"},
+}
+
+// GresMappings returns the extended resources mapped to Slurm GRES.
+func (o *NodeSetNodeConf) GresMappings() []NodeSetGres {
+	if o == nil || len(o.Gres) == 0 {
+		return defaultNodeConfGres
+	}
+	out := make([]NodeSetGres, 0, len(o.Gres))
+	for _, gres := range o.Gres {
+		if gres.Name == "" {
+			gres.Name = "gpu"
+		}
+		out = append(out, gres)
+	}
+	return out
+}
@@ -5,6 +5,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -97,6 +98,332 @@ type NodeSetSpec struct {
 	// +optional
 	// +default:=false
 	TaintKubeNodes bool `json:"taintKubeNodes,omitempty"`
+
+	// Autoscaling configures the built-in Slurm-aware autoscaler, which sizes
+	// the NodeSet from the pending jobs of its partition. When enabled,
+	// `replicas` is managed by the operator and should not be set by other
+	// scalers (e.g. KEDA, HPA).
+	// +optional
+	Autoscaling *NodeSetAutoscaling `json:"autoscaling,omitempty"`
+
+	// DrainPolicy bounds how long a condemned NodeSet pod (e.g. scale-in,
+	// rolling update) may wait for its Slurm node to drain, and what is done
+	// to the remaining jobs afterwards. By default, the operator waits for the
+	// running jobs to complete, however long that takes.
+	// +optional
+	DrainPolicy *NodeSetDrainPolicy `json:"drainPolicy,omitempty"`
+
+	// Remediation recreates NodeSet pods whose Slurm node stays in an
+	// unhealthy state (e.g. `INVALID_REG` after a slurmd configuration
+	// mismatch). By default, such pods are left as they are.
+	// +optional
+	Remediation *NodeSetRemediation `json:"remediation,omitempty"`
+
+	// NodeHealth maps the conditions and taints of the Kubernetes node of
+	// each pod to the DRAIN or DOWN state of its Slurm node, so Slurm stops
+	// scheduling onto an unhealthy node before `SlurmdTimeout` expires. The
+	// state is reversed once the Kubernetes node is healthy again, if the
+	// reason of the Slurm node is still the operator's.
+	// +optional
+	NodeHealth *NodeSetNodeHealth `json:"nodeHealth,omitempty"`
+
+	// NodeConf derives the Slurm node parameters of each pod from the slurmd
+	// container resources and its Kubernetes node, instead of relying on the
+	// autodetection of slurmd, which may see the host rather than the pod.
+	// Parameters set in `extraConf` take precedence.
+	// +optional
+	NodeConf *NodeSetNodeConf `json:"nodeConf,omitempty"`
+
+	// DeletionCost has the operator maintain the deletion cost of each pod
+	// from the workload of its Slurm node, so scale-in removes the least
+	// valuable capacity first. By default, pods are removed by ordinal, unless
+	// the deletion cost annotation is set by other means.
+	// +optional
+	DeletionCost *NodeSetDeletionCost `json:"deletionCost,omitempty"`
+}
+
+// NodeSetDeletionCostPolicy is how the deletion cost of a pod is computed.
+// +enum
+type NodeSetDeletionCostPolicy string
+
+const (
+	// NodeSetDeletionCostPolicyWorkload ranks pods by the workload of their
+	// Slurm node: idle nodes first, then nodes running only preemptible or
+	// low-priority jobs, then by allocated GPUs, allocated CPUs, and the
+	// summed priority of the running jobs.
+	NodeSetDeletionCostPolicyWorkload NodeSetDeletionCostPolicy = "Workload"
+	// NodeSetDeletionCostPolicyIdle only ranks idle Slurm nodes before busy
+	// ones.
+	NodeSetDeletionCostPolicyIdle NodeSetDeletionCostPolicy = "Idle"
+)
+
+// NodeSetDeletionCost defines how the deletion cost of pods is computed.
+type NodeSetDeletionCost struct {
+	// Policy is how the deletion cost of a pod is computed.
+	// +optional
+	// +default:="Workload"
+	// +kubebuilder:validation:Enum=Workload;Idle
+	Policy NodeSetDeletionCostPolicy `json:"policy,omitempty"`
+
+	// PreemptibleQOS are the Slurm QOS whose jobs are considered preemptible.
+	// +optional
+	// +listType=set
+	PreemptibleQOS []string `json:"preemptibleQOS,omitempty"`
+
+	// LowPriorityThreshold is the Slurm job priority at or below which jobs
+	// are considered low-priority. By default, no job is low-priority.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	LowPriorityThreshold *int64 `json:"lowPriorityThreshold,omitempty"`
+}
+
+// NodeSetNodeConf defines how the Slurm node parameters are derived.
+type NodeSetNodeConf struct {
+	// FromResources sets `CPUs`, `RealMemory`, and `Gres` from the limits of
+	// the slurmd container, falling back to its requests.
+	// +optional
+	// +default:=false
+	FromResources bool `json:"fromResources,omitempty"`
+
+	// MemoryReserve is subtracted from the slurmd container memory for
+	// `RealMemory`, leaving room for slurmd and the other pod processes.
+	// +optional
+	MemoryReserve *resource.Quantity `json:"memoryReserve,omitempty"`
+
+	// Gres maps extended resources of the slurmd container to Slurm GRES.
+	// Defaults to `nvidia.com/gpu` and `amd.com/gpu` as `gpu`.
+	// +optional
+	// +listType=map
+	// +listMapKey=resource
+	Gres []NodeSetGres `json:"gres,omitempty"`
+
+	// FeatureLabels are the Kubernetes node labels (e.g.
+	// `node.kubernetes.io/instance-type`, `topology.kubernetes.io/zone`)
+	// whose values are added to the features of the Slurm node, once the pod
+	// is scheduled.
+	// +optional
+	// +listType=set
+	FeatureLabels []string `json:"featureLabels,omitempty"`
+}
+
+// NodeSetGres maps an extended resource to a Slurm GRES.
+type NodeSetGres struct {
+	// Resource is the name of the extended resource (e.g. `nvidia.com/gpu`).
+	// +required
+	Resource corev1.ResourceName `json:"resource"`
+
+	// Name is the GRES name.
+	// +optional
+	// +default:="gpu"
+	Name string `json:"name,omitempty"`
+
+	// Type is the GRES type (e.g. `h100`).
+	// +optional
+	Type string `json:"type,omitempty"`
+}
+
+// NodeSetDrainAction is the action taken on jobs still running on a Slurm node
+// after the drain timeout elapsed.
+// +enum
+type NodeSetDrainAction string
+
+const (
+	// NodeSetDrainActionWait keeps waiting for the jobs to complete, only
+	// reporting that the drain timed out.
+	NodeSetDrainActionWait NodeSetDrainAction = "Wait"
+	// NodeSetDrainActionRequeue sets the Slurm node DOWN, which requeues the
+	// jobs that allow it and terminates the rest.
+	NodeSetDrainActionRequeue NodeSetDrainAction = "Requeue"
+	// NodeSetDrainActionCancel cancels the jobs.
+	NodeSetDrainActionCancel NodeSetDrainAction = "Cancel"
+	// NodeSetDrainActionForceDelete deletes the pod without regard for the jobs.
+	NodeSetDrainActionForceDelete NodeSetDrainAction = "ForceDelete"
+)
+
+// NodeSetDrainPolicy defines the drain timeout and the action taken after it.
+type NodeSetDrainPolicy struct {
+	// Timeout is how long to wait for the Slurm node to drain, starting when
+	// the pod was condemned. If unset, the drain never times out.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Action is taken on the jobs still running after the timeout.
+	// +optional
+	// +default:="Wait"
+	// +kubebuilder:validation:Enum=Wait;Requeue;Cancel;ForceDelete
+	Action NodeSetDrainAction `json:"action,omitempty"`
+
+	// GraceSignal is sent to the jobs still running after the timeout, before
+	// taking the action (e.g. `SIGTERM`, `SIGUSR1`), so they may checkpoint.
+	// +optional
+	GraceSignal string `json:"graceSignal,omitempty"`
+
+	// GracePeriod is how long to wait after sending the GraceSignal before
+	// taking the action. Defaults to 60s.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// NodeSetRemediationState is an unhealthy Slurm node state which may be
+// remediated.
+// +enum
+type NodeSetRemediationState string
+
+const (
+	// NodeSetRemediationStateDown is the `DOWN` base state.
+	NodeSetRemediationStateDown NodeSetRemediationState = "Down"
+	// NodeSetRemediationStateFail is the `FAIL` flag state.
+	NodeSetRemediationStateFail NodeSetRemediationState = "Fail"
+	// NodeSetRemediationStateNotResponding is the `NOT_RESPONDING` flag state.
+	NodeSetRemediationStateNotResponding NodeSetRemediationState = "NotResponding"
+	// NodeSetRemediationStateInvalidReg is the `INVALID_REG` flag state.
+	NodeSetRemediationStateInvalidReg NodeSetRemediationState = "InvalidReg"
+)
+
+// NodeSetRemediation defines when NodeSet pods are recreated for the state of
+// their Slurm node.
+type NodeSetRemediation struct {
+	// Rules select the unhealthy Slurm node states, and how long a Slurm node
+	// must stay in them, before its pod is deleted and recreated.
+	// +required
+	// +listType=map
+	// +listMapKey=state
+	// +kubebuilder:validation:MinItems=1
+	Rules []NodeSetRemediationRule `json:"rules"`
+
+	// MaxConcurrent is the maximum number of NodeSet pods which may be
+	// unavailable for pods to be remediated. Pods which are not yet running
+	// and ready, such as the replacements of remediated pods, count towards
+	// it.
+	// +optional
+	// +default:=1
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+}
+
+// NodeSetRemediationRule defines an unhealthy Slurm node state to remediate.
+type NodeSetRemediationRule struct {
+	// State is the unhealthy Slurm node state.
+	// +required
+	// +kubebuilder:validation:Enum=Down;Fail;NotResponding;InvalidReg
+	State NodeSetRemediationState `json:"state"`
+
+	// MinDuration is how long the Slurm node must stay in the state before
+	// its pod is remediated.
+	// +required
+	MinDuration metav1.Duration `json:"minDuration"`
+
+	// OperatorReasonOnly only remediates the pod when the reason of the Slurm
+	// node is empty, or was set by the operator, preserving the states set by
+	// administrators.
+	// +optional
+	// +default:=false
+	OperatorReasonOnly bool `json:"operatorReasonOnly,omitempty"`
+}
+
+// NodeSetNodeHealthAction is the state which the Slurm node is set to when its
+// Kubernetes node is unhealthy.
+// +enum
+type NodeSetNodeHealthAction string
+
+const (
+	// NodeSetNodeHealthActionDrain drains the Slurm node, letting the running
+	// jobs complete.
+	NodeSetNodeHealthActionDrain NodeSetNodeHealthAction = "Drain"
+	// NodeSetNodeHealthActionDown sets the Slurm node DOWN, which requeues the
+	// jobs that allow it and terminates the rest.
+	NodeSetNodeHealthActionDown NodeSetNodeHealthAction = "Down"
+)
+
+// NodeSetNodeHealth defines how the health of Kubernetes nodes is propagated
+// into the state of Slurm nodes.
+type NodeSetNodeHealth struct {
+	// Rules match the conditions and taints of unhealthy Kubernetes nodes.
+	// The first matching rule applies.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	Rules []NodeSetNodeHealthRule `json:"rules"`
+}
+
+// NodeSetNodeHealthRule maps a condition or a taint of the Kubernetes node to
+// the state of the Slurm node.
+// +kubebuilder:validation:XValidation:rule="has(self.condition) != has(self.taint)",message="exactly one of condition or taint must be set"
+type NodeSetNodeHealthRule struct {
+	// Condition matches a condition of the Kubernetes node (e.g. `Ready` is
+	// `False`, `KernelDeadlock` is `True`).
+	// +optional
+	Condition *NodeSetNodeConditionMatch `json:"condition,omitempty"`
+
+	// Taint matches a taint of the Kubernetes node (e.g.
+	// `node.kubernetes.io/unreachable`).
+	// +optional
+	Taint *NodeSetNodeTaintMatch `json:"taint,omitempty"`
+
+	// Action is the state which the Slurm node is set to.
+	// +optional
+	// +default:="Drain"
+	// +kubebuilder:validation:Enum=Drain;Down
+	Action NodeSetNodeHealthAction `json:"action,omitempty"`
+
+	// Reason is the reason of the Slurm node. Defaults to a description of the
+	// matched condition or taint.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// NodeSetNodeConditionMatch matches a condition of the Kubernetes node.
+type NodeSetNodeConditionMatch struct {
+	// Type of the condition.
+	// +required
+	Type corev1.NodeConditionType `json:"type"`
+
+	// Status of the condition.
+	// +optional
+	// +default:="True"
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status corev1.ConditionStatus `json:"status,omitempty"`
+}
+
+// NodeSetNodeTaintMatch matches a taint of the Kubernetes node.
+type NodeSetNodeTaintMatch struct {
+	// Key of the taint.
+	// +required
+	Key string `json:"key"`
+
+	// Effect of the taint. If unset, matches all effects.
+	// +optional
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	Effect corev1.TaintEffect `json:"effect,omitempty"`
+}
+
+// NodeSetAutoscaling defines the built-in autoscaler configuration.
+type NodeSetAutoscaling struct {
+	// Enabled will have the operator manage `replicas` from Slurm job demand.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// MinReplicas is the lower bound of replicas the autoscaler may scale-in to.
+	// +optional
+	// +default:=0
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper bound of replicas the autoscaler may scale-out to.
+	// +required
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// ScaleUpStabilizationWindow is the duration for which past recommendations
+	// are considered when scaling-out. The lowest recommendation in the window
+	// is used. Defaults to 0s (scale-out immediately).
+	// +optional
+	ScaleUpStabilizationWindow *metav1.Duration `json:"scaleUpStabilizationWindow,omitempty"`
+
+	// ScaleDownStabilizationWindow is the duration for which past
+	// recommendations are considered when scaling-in. The highest
+	// recommendation in the window is used. Defaults to 300s.
+	// +optional
+	ScaleDownStabilizationWindow *metav1.Duration `json:"scaleDownStabilizationWindow,omitempty"`
 }
 
 // NodeSetPartition defines the Slurm partition configuration for the NodeSet.
@@ -124,6 +451,11 @@ type NodeSetUpdateStrategy struct {
 	// RollingUpdateNodeSetStrategyType.
 	// +optional
 	RollingUpdate *RollingUpdateNodeSetStrategy `json:"rollingUpdate,omitempty"`
+
+	// Opportunistic is used to communicate parameters when Type is
+	// OpportunisticNodeSetStrategyType.
+	// +optional
+	Opportunistic *OpportunisticNodeSetStrategy `json:"opportunistic,omitempty"`
 }
 
 // PersistentVolumeClaimRetentionPolicyType is a string enumeration of the policies that will determine
@@ -176,6 +508,11 @@ const (
 	// OnDeleteNodeSetStrategyType indicates that NodeSet pods will only be
 	// replaced when the old pod is killed for any reason.
 	OnDeleteNodeSetStrategyType NodeSetUpdateStrategyType = "OnDelete"
+
+	// OpportunisticNodeSetStrategyType indicates that NodeSet pods will replace
+	// the old pods by new ones once their Slurm node becomes idle, without
+	// draining the busy Slurm nodes of the old pods.
+	OpportunisticNodeSetStrategyType NodeSetUpdateStrategyType = "Opportunistic"
 )
 
 // RollingUpdateNodeSetStrategy is used to communicate parameters for
@@ -187,6 +524,72 @@ type RollingUpdateNodeSetStrategy struct {
 	// Defaults to 1.
 	// +optional
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// Partition indicates the ordinal at which the NodeSet should be
+	// partitioned for updates. During a rolling update, only pods with an
+	// ordinal greater than or equal to the partition are updated. Pods with an
+	// ordinal less than the partition keep their revision, unless deleted.
+	// Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Partition *int32 `json:"partition,omitempty"`
+
+	// Paused stops the rolling update from replacing any more pods. Pods
+	// already being replaced will finish doing so.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Canary will first update only a subset of the pods, and wait for their
+	// Slurm nodes to stay healthy for a soak period before updating the rest.
+	// +optional
+	Canary *NodeSetCanary `json:"canary,omitempty"`
+}
+
+// OpportunisticNodeSetStrategy is used to communicate parameters for
+// OpportunisticNodeSetStrategyType.
+type OpportunisticNodeSetStrategy struct {
+	// The maximum number of pods that can be unavailable during the update.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up. This can not be 0.
+	// Defaults to 1.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// PreferEarliestDeadline will replace the old pods whose deadline
+	// annotation is earliest first, when more old pods can be replaced than
+	// MaxUnavailable allows.
+	// +optional
+	PreferEarliestDeadline bool `json:"preferEarliestDeadline,omitempty"`
+
+	// Deadline is how long after the rollout of the update revision started
+	// (`status.updateStartTime`) that the remaining old pods are drained and
+	// replaced, like a rolling update.
+	// By default, old pods are only replaced once their Slurm node is idle.
+	// +optional
+	Deadline *metav1.Duration `json:"deadline,omitempty"`
+}
+
+// NodeSetCanary defines the canary phase of a NodeSet rolling update.
+type NodeSetCanary struct {
+	// Replicas is the number of pods updated during the canary phase.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up.
+	// Defaults to 1.
+	// +optional
+	Replicas *intstr.IntOrString `json:"replicas,omitempty"`
+
+	// SoakPeriod is how long the Slurm nodes of the canary pods must stay
+	// healthy before the remaining pods are updated. Defaults to 10m.
+	// +optional
+	SoakPeriod *metav1.Duration `json:"soakPeriod,omitempty"`
+
+	// AutoRollback will restore the NodeSet to the previous revision when the
+	// Slurm node of a canary pod becomes DOWN, FAIL, NOT_RESPONDING,
+	// INVALID_REG, or DRAIN (unless drained by the operator) during the canary
+	// phase.
+	// Otherwise, the rolling update is halted until the NodeSet is changed.
+	// +optional
+	AutoRollback bool `json:"autoRollback,omitempty"`
 }
 
 // NodeSetStatus defines the observed state of NodeSet
@@ -245,6 +648,11 @@ type NodeSetStatus struct {
 	// latest version of the NodeSet.
 	NodeSetHash string `json:"nodeSetHash"`
 
+	// UpdateStartTime is when the NodeSetHash last changed, which starts the
+	// rollout of the revision, even when it reverts to an older revision.
+	// +optional
+	UpdateStartTime *metav1.Time `json:"updateStartTime,omitempty"`
+
 	// Count of hash collisions for the NodeSet. The NodeSet controller
 	// uses this field as a collision avoidance mechanism when it needs to
 	// create the name for the newest ControllerRevision.
@@ -261,6 +669,27 @@ type NodeSetStatus struct {
 
 	// Add Selector to status for HPA support in the scale subresource.
 	Selector string `json:"selector"`
+
+	// Autoscaling is the last observed state of the built-in autoscaler.
+	// +optional
+	Autoscaling *NodeSetAutoscalingStatus `json:"autoscaling,omitempty"`
+}
+
+// NodeSetAutoscalingStatus defines the observed state of the built-in autoscaler.
+type NodeSetAutoscalingStatus struct {
+	// DesiredReplicas is the number of replicas last recommended from Slurm
+	// job demand, before stabilization.
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+
+	// PendingJobs is the number of pending Slurm jobs considered for the
+	// NodeSet partition.
+	// +optional
+	PendingJobs int32 `json:"pendingJobs,omitempty"`
+
+	// LastScaleTime is the last time the autoscaler changed `replicas`.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -35,3 +35,49 @@ func (o *RestApi) ServiceFQDNShort() string {
 	s := o.ServiceKey()
 	return domainname.FqdnShort(s.Name, s.Namespace)
 }
+
+// IsTLSEnabled reports if slurmrestd serves HTTPS.
+func (o *RestApi) IsTLSEnabled() bool {
+	return o.Spec.TLS != nil
+}
+
+// IsTLSGenerated reports if the TLS certificates are generated.
+func (o *RestApi) IsTLSGenerated() bool {
+	return o.IsTLSEnabled() && o.Spec.TLS.SecretRef == nil
+}
+
+// TLSKey returns the Secret with the server certificate and the CA.
+func (o *RestApi) TLSKey() types.NamespacedName {
+	if o.IsTLSEnabled() && o.Spec.TLS.SecretRef != nil {
+		return types.NamespacedName{
+			Name:      o.Spec.TLS.SecretRef.Name,
+			Namespace: o.Namespace,
+		}
+	}
+	key := o.Key()
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-tls", key.Name),
+		Namespace: o.Namespace,
+	}
+}
+
+// TLSClientKey returns the Secret with the client certificate. Generated
+// client certificates are kept with the server certificate.
+func (o *RestApi) TLSClientKey() types.NamespacedName {
+	if o.IsTLSEnabled() && !o.IsTLSGenerated() && o.Spec.TLS.ClientSecretRef != nil {
+		return types.NamespacedName{
+			Name:      o.Spec.TLS.ClientSecretRef.Name,
+			Namespace: o.Namespace,
+		}
+	}
+	return o.TLSKey()
+}
+
+// ConfigKey returns the ConfigMap with the slurmrestd configuration.
+func (o *RestApi) ConfigKey() types.NamespacedName {
+	key := o.Key()
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-config", key.Name),
+		Namespace: o.Namespace,
+	}
+}
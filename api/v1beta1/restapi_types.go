@@ -4,6 +4,7 @@
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -43,10 +44,42 @@ type RestApiSpec struct {
 	// Service defines a template for a Kubernetes Service object.
 	// +optional
 	Service ServiceSpec `json:"service,omitzero"`
+
+	// tls serves the slurmrestd API over HTTPS. The slurm-operator verifies
+	// the server certificate against the CA.
+	// +optional
+	TLS *RestApiTLS `json:"tls,omitempty"`
+}
+
+// RestApiTLS defines the TLS configuration of slurmrestd.
+type RestApiTLS struct {
+	// secretRef is a Secret with the server certificate (`tls.crt`), its
+	// private key (`tls.key`), and the CA which issued it (`ca.crt`), as issued
+	// by cert-manager.
+	// If unset, a self-signed CA and certificates are generated.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// clientCertificate makes the slurm-operator present a client certificate
+	// issued by the CA. slurmrestd does not verify client certificates, so it
+	// is only enforced by a proxy in front of slurmrestd which does.
+	// +optional
+	ClientCertificate bool `json:"clientCertificate,omitempty"`
+
+	// clientSecretRef is a Secret with the client certificate (`tls.crt`) and
+	// its private key (`tls.key`) which the slurm-operator presents when
+	// `clientCertificate` is enabled.
+	// Required for `clientCertificate` when `secretRef` is set.
+	// +optional
+	ClientSecretRef *corev1.LocalObjectReference `json:"clientSecretRef,omitempty"`
 }
 
 // RestApiStatus defines the observed state of Restapi
 type RestApiStatus struct {
+	// The generation observed by the RestApi controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Represents the latest available observations of a Restapi's current state.
 	// +optional
 	// +patchMergeKey=type
@@ -59,6 +92,8 @@ type RestApiStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=slurmrestd
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Whether the component is ready."
+// +kubebuilder:printcolumn:name="REASON",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",priority=1,description="The reason for the Ready condition."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Restapi is the Schema for the restapis API
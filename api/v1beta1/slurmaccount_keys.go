@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *SlurmAccount) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// SlurmName returns the name of the Slurm account.
+func (o *SlurmAccount) SlurmName() string {
+	if o.Spec.Name != "" {
+		return o.Spec.Name
+	}
+	return o.Name
+}
+
+// ParentAccount returns the parent of the Slurm account.
+func (o *SlurmAccount) ParentAccount() string {
+	if o.Spec.ParentAccount != "" {
+		return o.Spec.ParentAccount
+	}
+	return "root"
+}
+
+// IsRetained reports if the Slurm account is kept when the SlurmAccount is deleted.
+func (o *SlurmAccount) IsRetained() bool {
+	return o.Spec.DeletionPolicy == DeletionPolicyRetain
+}
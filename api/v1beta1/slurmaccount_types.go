@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SlurmAccountKind = "SlurmAccount"
+)
+
+var (
+	SlurmAccountGVK        = GroupVersion.WithKind(SlurmAccountKind)
+	SlurmAccountAPIVersion = GroupVersion.String()
+)
+
+// SlurmAccountSpec defines the desired state of SlurmAccount
+type SlurmAccountSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// The Controller must have accounting.
+	// +required
+	ControllerRef ObjectReference `json:"controllerRef,omitzero"`
+
+	// Name is the name of the Slurm account.
+	// Defaults to the name of the SlurmAccount.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9_][a-z0-9_.-]*$`
+	Name string `json:"name,omitempty"`
+
+	SlurmAccountParameters `json:",inline"`
+
+	// DeletionPolicy is what happens to the Slurm account when the SlurmAccount
+	// is deleted.
+	// +optional
+	// +default:="Delete"
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// SlurmAccountParameters are the properties of a Slurm account.
+type SlurmAccountParameters struct {
+	// Description of the account.
+	// Defaults to the account name.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Organization to which the account belongs.
+	// Defaults to the account name.
+	// +optional
+	Organization string `json:"organization,omitempty"`
+
+	// ParentAccount is the parent of the account in the account hierarchy.
+	// Defaults to `root`.
+	// +optional
+	ParentAccount string `json:"parentAccount,omitempty"`
+
+	// Limits of the account association on the cluster.
+	// +optional
+	Limits AssociationLimits `json:"limits,omitzero"`
+}
+
+// SlurmAccountStatus defines the observed state of SlurmAccount
+type SlurmAccountStatus struct {
+	// The most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitzero"`
+
+	// Observed is the Slurm account, as last read from the Slurm database.
+	// +optional
+	Observed *SlurmAccountParameters `json:"observed,omitempty"`
+
+	// LastDriftTime is when the Slurm account was last found to differ from
+	// the spec, and was corrected.
+	// +optional
+	LastDriftTime *metav1.Time `json:"lastDriftTime,omitempty"`
+
+	// Represents the latest available observations of a SlurmAccount's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=sacct
+// +kubebuilder:printcolumn:name="PARENT",type="string",JSONPath=".status.observed.parentAccount",description="The parent account."
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="If the Slurm account is in sync."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmAccount is the Schema for the slurmaccounts API
+type SlurmAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmAccountSpec   `json:"spec,omitempty"`
+	Status SlurmAccountStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmAccountList contains a list of SlurmAccount
+type SlurmAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SlurmAccount{}, &SlurmAccountList{})
+}
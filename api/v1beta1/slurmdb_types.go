@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeletionPolicy describes what happens to the Slurm database record when
+// the object is deleted.
+// +enum
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the Slurm database record.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain leaves the Slurm database record as is.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// AssociationLimits are the limits of a Slurm association.
+// Unset limits are unlimited, or inherited from the parent account.
+// TRES are given as a Slurm TRES string (e.g. `cpu=100,mem=200G,gres/gpu=8`).
+// Ref: https://slurm.schedmd.com/resource_limits.html
+type AssociationLimits struct {
+	// Fairshare is the number of shares used for fairshare calculation.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Fairshare *int32 `json:"fairshare,omitempty"`
+
+	// GrpJobs is the total number of jobs able to run at any given time, for
+	// the association and its children.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	GrpJobs *int32 `json:"grpJobs,omitempty"`
+
+	// GrpSubmitJobs is the total number of jobs able to be submitted at any
+	// given time, for the association and its children.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	GrpSubmitJobs *int32 `json:"grpSubmitJobs,omitempty"`
+
+	// GrpTRES is the total TRES able to be used at any given time, for the
+	// association and its children.
+	// +optional
+	GrpTRES string `json:"grpTRES,omitempty"`
+
+	// MaxJobs is the number of jobs able to run at any given time.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxJobs *int32 `json:"maxJobs,omitempty"`
+
+	// MaxSubmitJobs is the number of jobs able to be submitted at any given time.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxSubmitJobs *int32 `json:"maxSubmitJobs,omitempty"`
+
+	// MaxTRESPerJob is the TRES each job is able to use.
+	// +optional
+	MaxTRESPerJob string `json:"maxTRESPerJob,omitempty"`
+
+	// MaxWallDurationPerJob is the wall clock time each job is able to use.
+	// It is rounded down to minutes.
+	// +optional
+	MaxWallDurationPerJob *metav1.Duration `json:"maxWallDurationPerJob,omitempty"`
+
+	// QOS is the list of QOS able to be used.
+	// +optional
+	// +listType=set
+	QOS []string `json:"qos,omitempty"`
+
+	// DefaultQOS is the QOS used when none is requested.
+	// +optional
+	DefaultQOS string `json:"defaultQOS,omitempty"`
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *SlurmJob) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// SlurmName returns the name of the Slurm job.
+func (o *SlurmJob) SlurmName() string {
+	if o.Spec.Name != "" {
+		return o.Spec.Name
+	}
+	return o.Name
+}
+
+// WorkingDir returns the working directory of the batch script.
+func (o *SlurmJob) WorkingDir() string {
+	if o.Spec.WorkingDir != "" {
+		return o.Spec.WorkingDir
+	}
+	return "/tmp"
+}
+
+// IsSubmitted reports if the Slurm job was submitted.
+func (o *SlurmJob) IsSubmitted() bool {
+	return o.Status.JobID != 0
+}
+
+// IsFinished reports if the Slurm job completed or failed.
+func (o *SlurmJob) IsFinished() bool {
+	return meta.IsStatusConditionTrue(o.Status.Conditions, ConditionComplete) ||
+		meta.IsStatusConditionTrue(o.Status.Conditions, ConditionFailed)
+}
+
+// ExpiresAt returns when the finished SlurmJob is to be deleted, if it has a TTL.
+func (o *SlurmJob) ExpiresAt() (time.Time, bool) {
+	if o.Spec.TTLSecondsAfterFinished == nil || !o.IsFinished() || o.Status.CompletionTime == nil {
+		return time.Time{}, false
+	}
+	ttl := time.Duration(*o.Spec.TTLSecondsAfterFinished) * time.Second
+	return o.Status.CompletionTime.Add(ttl), true
+}
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SlurmJobKind = "SlurmJob"
+)
+
+var (
+	SlurmJobGVK        = GroupVersion.WithKind(SlurmJobKind)
+	SlurmJobAPIVersion = GroupVersion.String()
+)
+
+// SlurmJobSpec defines the desired state of SlurmJob.
+// The Slurm job is submitted once; the spec cannot be changed afterwards.
+type SlurmJobSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// +required
+	ControllerRef ObjectReference `json:"controllerRef,omitzero"`
+
+	// Name is the name of the Slurm job.
+	// Defaults to the name of the SlurmJob.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Script is the batch script of the job, starting with a shebang (e.g. `#!/bin/bash`).
+	// Ref: https://slurm.schedmd.com/sbatch.html
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Script string `json:"script"`
+
+	// Partition to submit the job to.
+	// Defaults to the default partition of the Slurm cluster.
+	// +optional
+	Partition string `json:"partition,omitempty"`
+
+	// Account to charge the resources used by the job to.
+	// Defaults to the default account of the user.
+	// +optional
+	Account string `json:"account,omitempty"`
+
+	// QOS of the job.
+	// Defaults to the default QOS of the association.
+	// +optional
+	QOS string `json:"qos,omitempty"`
+
+	// Resources requested by the job.
+	// +optional
+	Resources SlurmJobResources `json:"resources,omitzero"`
+
+	// WorkingDir is the working directory of the batch script.
+	// +optional
+	// +default:="/tmp"
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// Env is the environment of the batch script.
+	// Only `value` is supported.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Array submits a job array with the given indexes (e.g. `0-15%4`).
+	// Ref: https://slurm.schedmd.com/job_array.html
+	// +optional
+	Array string `json:"array,omitempty"`
+
+	// Dependencies of the job on other SlurmJobs in the namespace.
+	// The job is submitted once all of its dependencies were submitted.
+	// +optional
+	Dependencies []SlurmJobDependency `json:"dependencies,omitempty"`
+
+	// SubmitAs is the Slurm user which submits the job, and which the job runs
+	// as. The job is submitted with a JWT for the user, signed by the
+	// `jwtHs256KeyRef` of the Controller, which must grant the user to the
+	// namespace of the SlurmJob with `Controller.Spec.UserGrants`.
+	// Defaults to the user of the slurm-operator.
+	// +optional
+	SubmitAs string `json:"submitAs,omitempty"`
+
+	// TTLSecondsAfterFinished limits the lifetime of a SlurmJob that has
+	// finished. The SlurmJob is deleted the given number of seconds after it
+	// finished. If unset, the SlurmJob is not deleted.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// SlurmJobResources are the resources requested by a Slurm job.
+type SlurmJobResources struct {
+	// Nodes is the number of nodes to allocate.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Nodes *int32 `json:"nodes,omitempty"`
+
+	// Tasks is the number of tasks to launch.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Tasks *int32 `json:"tasks,omitempty"`
+
+	// CPUsPerTask is the number of CPUs for each task.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	CPUsPerTask *int32 `json:"cpusPerTask,omitempty"`
+
+	// MemoryPerNode is the memory required on each node.
+	// It is rounded up to mebibytes.
+	// +optional
+	MemoryPerNode *resource.Quantity `json:"memoryPerNode,omitempty"`
+
+	// TimeLimit is the wall clock time of the job.
+	// It is rounded up to minutes.
+	// +optional
+	TimeLimit *metav1.Duration `json:"timeLimit,omitempty"`
+
+	// TRESPerNode are the trackable resources required on each node
+	// (e.g. `gres/gpu:2`).
+	// +optional
+	TRESPerNode string `json:"tresPerNode,omitempty"`
+}
+
+// SlurmJobDependencyType is when a dependent job may start.
+// +enum
+type SlurmJobDependencyType string
+
+const (
+	// SlurmJobDependencyAfter starts after the dependency started, or was cancelled.
+	SlurmJobDependencyAfter SlurmJobDependencyType = "after"
+	// SlurmJobDependencyAfterAny starts after the dependency finished.
+	SlurmJobDependencyAfterAny SlurmJobDependencyType = "afterany"
+	// SlurmJobDependencyAfterOK starts after the dependency finished successfully.
+	SlurmJobDependencyAfterOK SlurmJobDependencyType = "afterok"
+	// SlurmJobDependencyAfterNotOK starts after the dependency failed.
+	SlurmJobDependencyAfterNotOK SlurmJobDependencyType = "afternotok"
+)
+
+// SlurmJobDependency is a dependency on another SlurmJob.
+type SlurmJobDependency struct {
+	// Type of the dependency.
+	// +optional
+	// +default:="afterok"
+	// +kubebuilder:validation:Enum=after;afterany;afterok;afternotok
+	Type SlurmJobDependencyType `json:"type,omitempty"`
+
+	// SlurmJob is the name of the SlurmJob, in the same namespace, that is depended on.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	SlurmJob string `json:"slurmJob"`
+}
+
+// SlurmJobStatus defines the observed state of SlurmJob
+type SlurmJobStatus struct {
+	// The most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitzero"`
+
+	// JobID is the Slurm job ID, or the array job ID of a job array.
+	// +optional
+	JobID int32 `json:"jobID,omitzero"`
+
+	// State is the Slurm job state (e.g. `PENDING`, `RUNNING`, `COMPLETED`).
+	// For a job array, it is the state of the array as a whole.
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// StateReason is the reason for the Slurm job state (e.g. `Resources`).
+	// +optional
+	StateReason string `json:"stateReason,omitempty"`
+
+	// ExitCode is the exit code of the batch script, once finished.
+	// For a job array, it is the highest exit code of the array tasks.
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// ArrayTasks are the number of array tasks in each phase, for a job array.
+	// +optional
+	ArrayTasks *SlurmJobArrayTasks `json:"arrayTasks,omitempty"`
+
+	// SubmitTime is when the Slurm job was submitted.
+	// +optional
+	SubmitTime *metav1.Time `json:"submitTime,omitempty"`
+
+	// StartTime is when the Slurm job started running.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the Slurm job finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Represents the latest available observations of a SlurmJob's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// SlurmJobArrayTasks are the number of array tasks in each phase.
+type SlurmJobArrayTasks struct {
+	// Pending array tasks.
+	// +optional
+	Pending int32 `json:"pending,omitzero"`
+	// Running array tasks.
+	// +optional
+	Running int32 `json:"running,omitzero"`
+	// Succeeded array tasks.
+	// +optional
+	Succeeded int32 `json:"succeeded,omitzero"`
+	// Failed array tasks.
+	// +optional
+	Failed int32 `json:"failed,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=sjob
+// +kubebuilder:printcolumn:name="JOBID",type="integer",JSONPath=".status.jobID",description="The Slurm job ID."
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state",description="The Slurm job state."
+// +kubebuilder:printcolumn:name="EXIT CODE",type="integer",JSONPath=".status.exitCode",description="The exit code of the Slurm job."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmJob is the Schema for the slurmjobs API
+type SlurmJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmJobSpec   `json:"spec,omitempty"`
+	Status SlurmJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmJobList contains a list of SlurmJob
+type SlurmJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SlurmJob{}, &SlurmJobList{})
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *SlurmMaintenance) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// ReservationName returns the name of the Slurm reservation.
+func (o *SlurmMaintenance) ReservationName() string {
+	return fmt.Sprintf("slinky_%s_%s", o.Namespace, o.Name)
+}
+
+// Users returns the Slurm users allowed to use the reservation.
+func (o *SlurmMaintenance) Users() []string {
+	if len(o.Spec.Users) == 0 {
+		return []string{"root"}
+	}
+	return o.Spec.Users
+}
+
+// EndTime returns when the maintenance window ends.
+func (o *SlurmMaintenance) EndTime() time.Time {
+	return o.Spec.StartTime.Add(o.Spec.Duration.Duration)
+}
+
+// IsActive reports if the maintenance window is in progress at the given time.
+func (o *SlurmMaintenance) IsActive(now time.Time) bool {
+	return !now.Before(o.Spec.StartTime.Time) && now.Before(o.EndTime())
+}
+
+// IsUpcoming reports if the maintenance window has not started at the given time.
+func (o *SlurmMaintenance) IsUpcoming(now time.Time) bool {
+	return now.Before(o.Spec.StartTime.Time)
+}
+
+// SelectsNodeSetPod reports if the NodeSet pod is under maintenance, either by
+// its NodeSet name or by the pod selector.
+func (o *SlurmMaintenance) SelectsNodeSetPod(nodesetName string, podLabels map[string]string) bool {
+	if slices.Contains(o.Spec.NodeSets, nodesetName) {
+		return true
+	}
+	if o.Spec.PodSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(o.Spec.PodSelector)
+	if err != nil || selector.Empty() {
+		return false
+	}
+	return selector.Matches(labels.Set(podLabels))
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SlurmMaintenanceKind = "SlurmMaintenance"
+)
+
+var (
+	SlurmMaintenanceGVK        = GroupVersion.WithKind(SlurmMaintenanceKind)
+	SlurmMaintenanceAPIVersion = GroupVersion.String()
+)
+
+// SlurmMaintenanceSpec defines the desired state of SlurmMaintenance
+type SlurmMaintenanceSpec struct {
+	// NodeSets is the list of NodeSet names, in the same namespace, whose
+	// Slurm nodes are under maintenance.
+	// +optional
+	// +listType=set
+	NodeSets []string `json:"nodeSets,omitempty"`
+
+	// PodSelector selects NodeSet pods, in the same namespace, whose Slurm nodes
+	// are under maintenance. Pods selected by either NodeSets or PodSelector are
+	// under maintenance.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// StartTime is when the maintenance window starts.
+	// Running jobs are expected to complete by then, Slurm will not schedule
+	// jobs on the nodes that would overlap with the maintenance window.
+	// +required
+	StartTime metav1.Time `json:"startTime,omitzero"`
+
+	// Duration is the length of the maintenance window.
+	// +required
+	Duration metav1.Duration `json:"duration,omitzero"`
+
+	// Users is the list of Slurm users allowed to use the reservation.
+	// Defaults to `root`.
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// RecreatePods will delete the NodeSet pods, once their Slurm node is
+	// drained during the maintenance window, so they are recreated.
+	// Only pods created before the maintenance window started are deleted.
+	// +optional
+	RecreatePods bool `json:"recreatePods,omitzero"`
+}
+
+// SlurmMaintenancePhase is a label for the state of the maintenance window.
+// +enum
+type SlurmMaintenancePhase string
+
+const (
+	// SlurmMaintenancePending means the Slurm reservation was not created yet.
+	SlurmMaintenancePending SlurmMaintenancePhase = "Pending"
+	// SlurmMaintenanceScheduled means the Slurm reservation exists and the
+	// maintenance window has not started yet.
+	SlurmMaintenanceScheduled SlurmMaintenancePhase = "Scheduled"
+	// SlurmMaintenanceInProgress means the maintenance window has started.
+	SlurmMaintenanceInProgress SlurmMaintenancePhase = "InProgress"
+	// SlurmMaintenanceCompleted means the maintenance window has ended.
+	SlurmMaintenanceCompleted SlurmMaintenancePhase = "Completed"
+)
+
+// SlurmMaintenanceStatus defines the observed state of SlurmMaintenance
+type SlurmMaintenanceStatus struct {
+	// The most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitzero"`
+
+	// Phase of the maintenance window.
+	// +optional
+	Phase SlurmMaintenancePhase `json:"phase,omitempty"`
+
+	// ReservationName is the name of the Slurm reservation.
+	// +optional
+	ReservationName string `json:"reservationName,omitempty"`
+
+	// ControllerRef is the Controller which the Slurm reservation was created
+	// on, so it can be deleted after the selected NodeSets are gone.
+	// +optional
+	ControllerRef ObjectReference `json:"controllerRef,omitzero"`
+
+	// NodeSets is the list of NodeSets with pods under maintenance.
+	// +optional
+	// +listType=set
+	NodeSets []string `json:"nodeSets,omitempty"`
+
+	// Nodes is the list of Slurm nodes in the reservation.
+	// +optional
+	// +listType=set
+	Nodes []string `json:"nodes,omitempty"`
+
+	// QuiescedNodes is the list of Slurm nodes that are drained and have no
+	// running jobs.
+	// +optional
+	// +listType=set
+	QuiescedNodes []string `json:"quiescedNodes,omitempty"`
+
+	// RunningJobs is the list of Slurm job IDs still running on the nodes.
+	// +optional
+	// +listType=set
+	RunningJobs []int32 `json:"runningJobs,omitempty"`
+
+	// Represents the latest available observations of a SlurmMaintenance's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=maint
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase",description="The phase of the maintenance window."
+// +kubebuilder:printcolumn:name="START",type="string",JSONPath=".spec.startTime",description="The start of the maintenance window."
+// +kubebuilder:printcolumn:name="DURATION",type="string",JSONPath=".spec.duration",description="The length of the maintenance window."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmMaintenance is the Schema for the slurmmaintenances API
+type SlurmMaintenance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmMaintenanceSpec   `json:"spec,omitempty"`
+	Status SlurmMaintenanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmMaintenanceList contains a list of SlurmMaintenance
+type SlurmMaintenanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmMaintenance `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SlurmMaintenance{}, &SlurmMaintenanceList{})
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *SlurmQOS) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// SlurmName returns the name of the Slurm QOS.
+func (o *SlurmQOS) SlurmName() string {
+	if o.Spec.Name != "" {
+		return o.Spec.Name
+	}
+	return o.Name
+}
+
+// IsRetained reports if the Slurm QOS is kept when the SlurmQOS is deleted.
+func (o *SlurmQOS) IsRetained() bool {
+	return o.Spec.DeletionPolicy == DeletionPolicyRetain
+}
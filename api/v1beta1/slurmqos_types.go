@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SlurmQOSKind = "SlurmQOS"
+)
+
+var (
+	SlurmQOSGVK        = GroupVersion.WithKind(SlurmQOSKind)
+	SlurmQOSAPIVersion = GroupVersion.String()
+)
+
+// SlurmQOSSpec defines the desired state of SlurmQOS
+type SlurmQOSSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// The Controller must have accounting.
+	// +required
+	ControllerRef ObjectReference `json:"controllerRef,omitzero"`
+
+	// Name is the name of the Slurm QOS.
+	// Defaults to the name of the SlurmQOS.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	SlurmQOSParameters `json:",inline"`
+
+	// DeletionPolicy is what happens to the Slurm QOS when the SlurmQOS is
+	// deleted.
+	// +optional
+	// +default:="Delete"
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// SlurmQOSParameters are the properties of a Slurm QOS.
+// Unset limits are unlimited.
+// TRES are given as a Slurm TRES string (e.g. `cpu=100,mem=200G,gres/gpu=8`).
+// Ref: https://slurm.schedmd.com/qos.html
+type SlurmQOSParameters struct {
+	// Description of the QOS.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Priority of jobs using the QOS.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Flags of the QOS (e.g. `DENY_LIMIT`, `NO_DECAY`).
+	// +optional
+	// +listType=set
+	Flags []string `json:"flags,omitempty"`
+
+	// PreemptMode is the mechanism used to preempt jobs of the QOS
+	// (e.g. `REQUEUE`, `SUSPEND`, `GANG`).
+	// +optional
+	// +listType=set
+	PreemptMode []string `json:"preemptMode,omitempty"`
+
+	// Preempt is the list of QOS whose jobs can be preempted by jobs of the QOS.
+	// +optional
+	// +listType=set
+	Preempt []string `json:"preempt,omitempty"`
+
+	// GrpJobs is the total number of jobs able to run at any given time.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	GrpJobs *int32 `json:"grpJobs,omitempty"`
+
+	// GrpSubmitJobs is the total number of jobs able to be submitted at any
+	// given time.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	GrpSubmitJobs *int32 `json:"grpSubmitJobs,omitempty"`
+
+	// GrpTRES is the total TRES able to be used at any given time.
+	// +optional
+	GrpTRES string `json:"grpTRES,omitempty"`
+
+	// MaxJobsPerUser is the number of jobs each user is able to run at any
+	// given time.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxJobsPerUser *int32 `json:"maxJobsPerUser,omitempty"`
+
+	// MaxJobsPerAccount is the number of jobs each account is able to run at
+	// any given time.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxJobsPerAccount *int32 `json:"maxJobsPerAccount,omitempty"`
+
+	// MaxSubmitJobsPerUser is the number of jobs each user is able to submit
+	// at any given time.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxSubmitJobsPerUser *int32 `json:"maxSubmitJobsPerUser,omitempty"`
+
+	// MaxTRESPerJob is the TRES each job is able to use.
+	// +optional
+	MaxTRESPerJob string `json:"maxTRESPerJob,omitempty"`
+
+	// MaxTRESPerNode is the TRES each node of a job is able to use.
+	// +optional
+	MaxTRESPerNode string `json:"maxTRESPerNode,omitempty"`
+
+	// MaxTRESPerUser is the TRES each user is able to use.
+	// +optional
+	MaxTRESPerUser string `json:"maxTRESPerUser,omitempty"`
+
+	// MaxWallDurationPerJob is the wall clock time each job is able to use.
+	// It is rounded down to minutes.
+	// +optional
+	MaxWallDurationPerJob *metav1.Duration `json:"maxWallDurationPerJob,omitempty"`
+}
+
+// SlurmQOSStatus defines the observed state of SlurmQOS
+type SlurmQOSStatus struct {
+	// The most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitzero"`
+
+	// Observed is the Slurm QOS, as last read from the Slurm database.
+	// +optional
+	Observed *SlurmQOSParameters `json:"observed,omitempty"`
+
+	// LastDriftTime is when the Slurm QOS was last found to differ from the
+	// spec, and was corrected.
+	// +optional
+	LastDriftTime *metav1.Time `json:"lastDriftTime,omitempty"`
+
+	// Represents the latest available observations of a SlurmQOS's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=slurmqoses,shortName=sqos
+// +kubebuilder:printcolumn:name="PRIORITY",type="integer",JSONPath=".status.observed.priority",description="The priority of the QOS."
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="If the Slurm QOS is in sync."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmQOS is the Schema for the slurmqoses API
+type SlurmQOS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmQOSSpec   `json:"spec,omitempty"`
+	Status SlurmQOSStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmQOSList contains a list of SlurmQOS
+type SlurmQOSList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmQOS `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SlurmQOS{}, &SlurmQOSList{})
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *SlurmUser) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// SlurmName returns the name of the Slurm user.
+func (o *SlurmUser) SlurmName() string {
+	if o.Spec.Name != "" {
+		return o.Spec.Name
+	}
+	return o.Name
+}
+
+// DefaultAccount returns the default account of the Slurm user.
+func (o *SlurmUser) DefaultAccount() string {
+	if o.Spec.DefaultAccount != "" {
+		return o.Spec.DefaultAccount
+	}
+	if len(o.Spec.Associations) > 0 {
+		return o.Spec.Associations[0].Account
+	}
+	return ""
+}
+
+// AdminLevel returns the administrative privilege of the Slurm user.
+func (o *SlurmUser) AdminLevel() SlurmAdminLevel {
+	if o.Spec.AdminLevel != "" {
+		return o.Spec.AdminLevel
+	}
+	return SlurmAdminLevelNone
+}
+
+// IsRetained reports if the Slurm user is kept when the SlurmUser is deleted.
+func (o *SlurmUser) IsRetained() bool {
+	return o.Spec.DeletionPolicy == DeletionPolicyRetain
+}
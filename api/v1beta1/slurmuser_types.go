@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SlurmUserKind = "SlurmUser"
+)
+
+var (
+	SlurmUserGVK        = GroupVersion.WithKind(SlurmUserKind)
+	SlurmUserAPIVersion = GroupVersion.String()
+)
+
+// SlurmUserSpec defines the desired state of SlurmUser
+type SlurmUserSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// The Controller must have accounting.
+	// +required
+	ControllerRef ObjectReference `json:"controllerRef,omitzero"`
+
+	// Name is the name of the Slurm user.
+	// Defaults to the name of the SlurmUser.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	SlurmUserParameters `json:",inline"`
+
+	// DeletionPolicy is what happens to the Slurm user when the SlurmUser is
+	// deleted.
+	// +optional
+	// +default:="Delete"
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// SlurmAdminLevel is the administrative privilege of a Slurm user.
+// +enum
+type SlurmAdminLevel string
+
+const (
+	SlurmAdminLevelNone          SlurmAdminLevel = "None"
+	SlurmAdminLevelOperator      SlurmAdminLevel = "Operator"
+	SlurmAdminLevelAdministrator SlurmAdminLevel = "Administrator"
+)
+
+// SlurmUserParameters are the properties of a Slurm user.
+type SlurmUserParameters struct {
+	// DefaultAccount is the account used when none is requested.
+	// Defaults to the account of the first association.
+	// +optional
+	DefaultAccount string `json:"defaultAccount,omitempty"`
+
+	// AdminLevel is the administrative privilege of the user.
+	// +optional
+	// +default:="None"
+	// +kubebuilder:validation:Enum=None;Operator;Administrator
+	AdminLevel SlurmAdminLevel `json:"adminLevel,omitempty"`
+
+	// Associations of the user with accounts on the cluster.
+	// Associations of the user that are not listed are deleted.
+	// +optional
+	// +listType=atomic
+	Associations []SlurmUserAssociation `json:"associations,omitempty"`
+}
+
+// SlurmUserAssociation is an association of a Slurm user with an account.
+type SlurmUserAssociation struct {
+	// Account is the name of the Slurm account.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Account string `json:"account"`
+
+	// Partition restricts the association to a Slurm partition.
+	// +optional
+	Partition string `json:"partition,omitempty"`
+
+	// Limits of the association.
+	// +optional
+	Limits AssociationLimits `json:"limits,omitzero"`
+}
+
+// SlurmUserStatus defines the observed state of SlurmUser
+type SlurmUserStatus struct {
+	// The most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitzero"`
+
+	// Observed is the Slurm user, as last read from the Slurm database.
+	// +optional
+	Observed *SlurmUserParameters `json:"observed,omitempty"`
+
+	// LastDriftTime is when the Slurm user was last found to differ from the
+	// spec, and was corrected.
+	// +optional
+	LastDriftTime *metav1.Time `json:"lastDriftTime,omitempty"`
+
+	// Represents the latest available observations of a SlurmUser's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=suser
+// +kubebuilder:printcolumn:name="ACCOUNT",type="string",JSONPath=".status.observed.defaultAccount",description="The default account."
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="If the Slurm user is in sync."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmUser is the Schema for the slurmusers API
+type SlurmUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmUserSpec   `json:"spec,omitempty"`
+	Status SlurmUserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmUserList contains a list of SlurmUser
+type SlurmUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SlurmUser{}, &SlurmUserList{})
+}
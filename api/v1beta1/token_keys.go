@@ -34,6 +34,12 @@ func (o *Token) Lifetime() time.Duration {
 	return lifetime
 }
 
+// HasControllerRef reports if the JWT is signed by the signing key of a
+// Controller, instead of an HS256 key.
+func (o *Token) HasControllerRef() bool {
+	return o.Spec.ControllerRef.Name != ""
+}
+
 func (o *Token) JwtHs256Key() types.NamespacedName {
 	namespace := o.Spec.JwtHs256KeyRef.Namespace
 	if namespace == "" {
@@ -77,3 +83,9 @@ func (o *Token) SecretRef() *corev1.SecretKeySelector {
 		Key: key,
 	}
 }
+
+// RefreshTime returns the time when the JWT which expires at the time is
+// refreshed, once 4/5 of its lifetime passed.
+func (o *Token) RefreshTime(expiresAt time.Time) time.Time {
+	return expiresAt.Add(-o.Lifetime() / 5)
+}
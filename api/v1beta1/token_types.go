@@ -18,11 +18,17 @@ var (
 )
 
 // TokenSpec defines the desired state of Token
+// +kubebuilder:validation:XValidation:rule="has(self.jwtHs256KeyRef) != has(self.controllerRef)", message="exactly one of jwtHs256KeyRef or controllerRef must be set"
 type TokenSpec struct {
 	// Slurm `auth/jwt` JWT HS256 key authentication.
-	// +required
+	// +optional
 	JwtHs256KeyRef JwtSecretKeySelector `json:"jwtHs256KeyRef,omitzero"`
 
+	// controllerRef is a reference to the Controller whose signing key signs
+	// the JWT. The JWT is signed again when the signing key changes.
+	// +optional
+	ControllerRef ObjectReference `json:"controllerRef,omitzero"`
+
 	// The username whom the token is created for.
 	// +required
 	Username string `json:"username,omitzero"`
@@ -40,6 +46,58 @@ type TokenSpec struct {
 	// SecretRef describes how to create the secret containing the JWT.
 	// +optional
 	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// SecretTemplate describes the metadata of the secret, and additional
+	// formats of the JWT written into it.
+	// +optional
+	SecretTemplate *TokenSecretTemplate `json:"secretTemplate,omitempty"`
+}
+
+// TokenSecretTemplate describes the secret containing the JWT.
+type TokenSecretTemplate struct {
+	// Metadata of the secret.
+	// +optional
+	Metadata Metadata `json:"metadata,omitzero"`
+
+	// Formats are additional keys of the secret, each containing the JWT in a
+	// format.
+	// +optional
+	// +listType=map
+	// +listMapKey=key
+	Formats []TokenSecretFormat `json:"formats,omitempty"`
+
+	// SlurmrestdURL is the URL of slurmrestd written into the formats.
+	// Defaults to the URL of the first Restapi of the Controller, by name, when
+	// the controllerRef is set.
+	// +optional
+	SlurmrestdURL string `json:"slurmrestdURL,omitempty"`
+}
+
+// TokenSecretFormatType is a format of the JWT.
+// +kubebuilder:validation:Enum=Env;Netrc;JSON
+type TokenSecretFormatType string
+
+const (
+	// TokenSecretFormatEnv is an env-file with `SLURM_JWT=` and, when known,
+	// `SLURMRESTD_URL=`.
+	TokenSecretFormatEnv TokenSecretFormatType = "Env"
+	// TokenSecretFormatNetrc is a `.netrc` entry for the host of slurmrestd,
+	// whose login is the username and password is the JWT.
+	TokenSecretFormatNetrc TokenSecretFormatType = "Netrc"
+	// TokenSecretFormatJSON is a JSON document with the JWT and its claims.
+	TokenSecretFormatJSON TokenSecretFormatType = "JSON"
+)
+
+// TokenSecretFormat is a key of the secret containing the JWT in a format.
+type TokenSecretFormat struct {
+	// Key of the secret.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Type of the format.
+	// +required
+	Type TokenSecretFormatType `json:"type"`
 }
 
 // TokenStatus defines the observed state of Token
@@ -47,7 +105,21 @@ type TokenStatus struct {
 	// IssuedAt indicates the time when the JWT was issued.
 	IssuedAt *metav1.Time `json:"issuedAt,omitempty"`
 
-	// Represents the latest available observations of a Restapi's current state.
+	// ExpiresAt indicates the time when the JWT expires.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// NextRefreshAt indicates the time when the JWT will be refreshed.
+	// Unset when the JWT is not refreshed.
+	// +optional
+	NextRefreshAt *metav1.Time `json:"nextRefreshAt,omitempty"`
+
+	// KeyID is the ID of the key which signed the JWT, which is empty for the
+	// HS256 key.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// Represents the latest available observations of a Token's current state.
 	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -61,6 +133,8 @@ type TokenStatus struct {
 // +kubebuilder:resource:shortName=tokens;jwt
 // +kubebuilder:printcolumn:name="USER",type="string",JSONPath=".spec.username",description="The username issued to the JWT."
 // +kubebuilder:printcolumn:name="IAT",type="date",JSONPath=".status.issuedAt",description="The JWT Issued At time."
+// +kubebuilder:printcolumn:name="EXP",type="date",JSONPath=".status.expiresAt",description="The JWT Expiration time."
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Whether the JWT is valid."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Token is the Schema for the tokens API
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ServiceAccountSelectorAll matches all ServiceAccounts of a namespace.
+	ServiceAccountSelectorAll = "*"
+)
+
+func (o *TokenPolicy) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+func (o *TokenPolicy) MaxLifetime() time.Duration {
+	lifetime := 15 * time.Minute
+	if o.Spec.MaxLifetime != nil {
+		lifetime = o.Spec.MaxLifetime.Duration
+	}
+	return lifetime
+}
+
+// MatchesServiceAccount reports if the ServiceAccount is one of the
+// ServiceAccounts of the policy.
+func (o *TokenPolicy) MatchesServiceAccount(serviceAccount types.NamespacedName) bool {
+	for _, selector := range o.Spec.ServiceAccounts {
+		namespace := selector.Namespace
+		if namespace == "" {
+			namespace = o.Namespace
+		}
+		if namespace != serviceAccount.Namespace {
+			continue
+		}
+		if selector.Name == ServiceAccountSelectorAll || selector.Name == serviceAccount.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// ControllerKey returns the key of the Controller, whose namespace defaults to
+// the namespace of the TokenPolicy.
+func (o *TokenPolicy) ControllerKey() types.NamespacedName {
+	namespace := o.Spec.ControllerRef.Namespace
+	if namespace == "" {
+		namespace = o.Namespace
+	}
+	return types.NamespacedName{
+		Name:      o.Spec.ControllerRef.Name,
+		Namespace: namespace,
+	}
+}
+
+// IsPermittedBy reports if the Controller permits the policy to issue its JWTs.
+// A Controller permits the policies of its own namespace, and of the
+// namespaces which it grants the username.
+func (o *TokenPolicy) IsPermittedBy(controller *Controller) bool {
+	return controller.Namespace == o.Namespace ||
+		controller.IsUserGranted(o.Namespace, o.Spec.Username)
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TokenPolicyKind = "TokenPolicy"
+)
+
+var (
+	TokenPolicyGVK        = GroupVersion.WithKind(TokenPolicyKind)
+	TokenPolicyAPIVersion = GroupVersion.String()
+)
+
+// TokenPolicySpec defines the desired state of TokenPolicy.
+// A ServiceAccount which matches the policy may exchange its token for a
+// short-lived Slurm JWT of the username.
+type TokenPolicySpec struct {
+	// controllerRef is a reference to the Controller whose signing key signs
+	// the JWTs. The namespace defaults to the namespace of the TokenPolicy.
+	// A Controller of another namespace must grant the username to the
+	// namespace of the TokenPolicy, with `Controller.Spec.UserGrants`.
+	// +required
+	ControllerRef ObjectReference `json:"controllerRef,omitzero"`
+
+	// ServiceAccounts which may exchange their tokens.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	ServiceAccounts []ServiceAccountSelector `json:"serviceAccounts"`
+
+	// The username whom the JWTs are issued for.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Username string `json:"username"`
+
+	// The maximum lifetime of the JWTs. A shorter lifetime may be requested.
+	// +optional
+	// +default:="15m"
+	MaxLifetime *metav1.Duration `json:"maxLifetime,omitempty"`
+}
+
+// ServiceAccountSelector selects ServiceAccounts.
+type ServiceAccountSelector struct {
+	// Namespace of the ServiceAccount.
+	// Defaults to the namespace of the TokenPolicy.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the ServiceAccount, or `*` for all ServiceAccounts of the
+	// namespace.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// TokenPolicyStatus defines the observed state of TokenPolicy
+type TokenPolicyStatus struct{}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="USER",type="string",JSONPath=".spec.username",description="The username issued to the JWTs."
+// +kubebuilder:printcolumn:name="MAX LIFETIME",type="string",JSONPath=".spec.maxLifetime",description="The maximum lifetime of the JWTs."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TokenPolicy is the Schema for the tokenpolicies API
+type TokenPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TokenPolicySpec   `json:"spec,omitempty"`
+	Status TokenPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TokenPolicyList contains a list of TokenPolicy
+type TokenPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TokenPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TokenPolicy{}, &TokenPolicyList{})
+}
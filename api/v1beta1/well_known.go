@@ -23,10 +23,48 @@ const (
 	// The implicit deletion cost for pods that don't set the annotation is 0, negative values are permitted.
 	AnnotationPodDeletionCost = NodeSetPrefix + "pod-deletion-cost"
 
+	// AnnotationPodDeletionCostManaged indicates that the AnnotationPodDeletionCost was set by the NodeSet DeletionCost
+	// policy, so it is removed when the policy is disabled.
+	// NOTE: Set by the NodeSet controller.
+	AnnotationPodDeletionCostManaged = NodeSetPrefix + "pod-deletion-cost-managed"
+
 	// AnnotationPodDeadline stores a time.RFC3339 timestamp, indicating when the Slurm node should complete its running
 	// workload by. Pods with an earlier deadline are preferred to be deleted before pods with a later deadline.
 	// NOTE: this is honored on a best-effort basis, and does not offer guarantees on pod deletion order.
 	AnnotationPodDeadline = NodeSetPrefix + "pod-deadline"
+
+	// AnnotationPodDrainStart stores a time.RFC3339 timestamp, indicating when the condemned NodeSet Pod started to
+	// drain. The NodeSet DrainPolicy timeout is measured from it.
+	// NOTE: Set by the NodeSet controller.
+	AnnotationPodDrainStart = NodeSetPrefix + "pod-drain-start"
+
+	// AnnotationSlurmJwks indicates the `slurm.jwks` file which the Pods mount during a rotation of the `auth/slurm`
+	// key.
+	// NOTE: Set by the operator.
+	AnnotationSlurmJwks = SlinkyPrefix + "slurm-jwks"
+)
+
+// Well Known Annotations for Objects of type corev1.Secret
+const (
+	// AnnotationGeneratedKeys lists the keys of a Secret which were generated by the operator.
+	// NOTE: Set by the Controller and Accounting controllers.
+	AnnotationGeneratedKeys = SlinkyPrefix + "generated-keys"
+
+	// AnnotationRetainOnDelete, when "true" on a generated Secret, keeps the Secret when its owners are deleted.
+	AnnotationRetainOnDelete = SlinkyPrefix + "retain-on-delete"
+)
+
+// Well Known Finalizers
+const (
+	// FinalizerSlurmMaintenance ensures the Slurm reservation is deleted with the SlurmMaintenance.
+	FinalizerSlurmMaintenance = SlinkyPrefix + "slurmmaintenance"
+
+	// FinalizerSlurmdb ensures the Slurm database record is handled per the DeletionPolicy of the
+	// SlurmAccount, SlurmUser, or SlurmQOS.
+	FinalizerSlurmdb = SlinkyPrefix + "slurmdb"
+
+	// FinalizerSlurmJob ensures the Slurm job is cancelled with the SlurmJob.
+	FinalizerSlurmJob = SlinkyPrefix + "slurmjob"
 )
 
 // Well Known Annotations for Objects of type corev1.Node
@@ -54,3 +92,59 @@ const (
 	// NOTE: Set by the NodeSet controller
 	LabelNodeSetPodProtect = NodeSetPrefix + "pod-protect"
 )
+
+// Well Known Conditions
+const (
+	// ConditionReady indicates the Slurm component is ready to serve.
+	ConditionReady = "Ready"
+
+	// ConditionProgressing indicates the Slurm component is rolling out changes.
+	ConditionProgressing = "Progressing"
+
+	// ConditionDegraded indicates the Slurm component failed to reconcile or is unhealthy.
+	ConditionDegraded = "Degraded"
+
+	// ConditionConfigValid indicates the referenced configuration (e.g. Secrets) could be resolved.
+	ConditionConfigValid = "ConfigValid"
+
+	// ConditionQuiesced indicates the Slurm nodes under maintenance are drained and have no running jobs.
+	ConditionQuiesced = "Quiesced"
+
+	// ConditionSubmitted indicates the Slurm job was submitted.
+	ConditionSubmitted = "Submitted"
+
+	// ConditionComplete indicates the Slurm job completed successfully.
+	ConditionComplete = "Complete"
+
+	// ConditionFailed indicates the Slurm job failed, was cancelled, or was lost.
+	ConditionFailed = "Failed"
+
+	// ConditionJwtKeysRotated indicates all JWTs of the Controller are signed by its signing key.
+	ConditionJwtKeysRotated = "JwtKeysRotated"
+
+	// ConditionExpired indicates the JWT of the Token expired.
+	ConditionExpired = "Expired"
+
+	// ConditionSigningKeyMissing indicates the key which signs the JWT of the Token could not be resolved.
+	ConditionSigningKeyMissing = "SigningKeyMissing"
+)
+
+// Well Known Condition Reasons
+const (
+	ReasonAsExpected         = "AsExpected"
+	ReasonExpired            = "Expired"
+	ReasonInvalidConfig      = "InvalidConfig"
+	ReasonInvalidToken       = "InvalidToken"
+	ReasonJobNotFound        = "JobNotFound"
+	ReasonJobsRunning        = "JobsRunning"
+	ReasonKeysRetirable      = "KeysRetirable"
+	ReasonNotStarted         = "NotStarted"
+	ReasonPingFailed         = "PingFailed"
+	ReasonPodsNotReady       = "PodsNotReady"
+	ReasonResigning          = "Resigning"
+	ReasonRollingOut         = "RollingOut"
+	ReasonSigningKeyNotFound = "SigningKeyNotFound"
+	ReasonSyncFailed         = "SyncFailed"
+	ReasonWaitingForDeps     = "WaitingForDependencies"
+	ReasonWorkloadNotFound   = "WorkloadNotFound"
+)
@@ -76,6 +76,11 @@ func (in *AccountingList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccountingSpec) DeepCopyInto(out *AccountingSpec) {
 	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(SlurmAuth)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SlurmKeyRef.DeepCopyInto(&out.SlurmKeyRef)
 	in.JwtHs256KeyRef.DeepCopyInto(&out.JwtHs256KeyRef)
 	out.ExternalConfig = in.ExternalConfig
@@ -98,6 +103,13 @@ func (in *AccountingSpec) DeepCopy() *AccountingSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccountingStatus) DeepCopyInto(out *AccountingStatus) {
 	*out = *in
+	if in.GeneratedKeys != nil {
+		in, out := &in.GeneratedKeys, &out.GeneratedKeys
+		*out = make([]GeneratedKeyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -117,6 +129,56 @@ func (in *AccountingStatus) DeepCopy() *AccountingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssociationLimits) DeepCopyInto(out *AssociationLimits) {
+	*out = *in
+	if in.Fairshare != nil {
+		in, out := &in.Fairshare, &out.Fairshare
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GrpJobs != nil {
+		in, out := &in.GrpJobs, &out.GrpJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GrpSubmitJobs != nil {
+		in, out := &in.GrpSubmitJobs, &out.GrpSubmitJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxJobs != nil {
+		in, out := &in.MaxJobs, &out.MaxJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSubmitJobs != nil {
+		in, out := &in.MaxSubmitJobs, &out.MaxSubmitJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxWallDurationPerJob != nil {
+		in, out := &in.MaxWallDurationPerJob, &out.MaxWallDurationPerJob
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.QOS != nil {
+		in, out := &in.QOS, &out.QOS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssociationLimits.
+func (in *AssociationLimits) DeepCopy() *AssociationLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(AssociationLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerWrapper) DeepCopyInto(out *ContainerWrapper) {
 	clone := in.DeepCopy()
@@ -182,6 +244,31 @@ func (in *ControllerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerPartition) DeepCopyInto(out *ControllerPartition) {
+	*out = *in
+	if in.NodeSets != nil {
+		in, out := &in.NodeSets, &out.NodeSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSetSelector != nil {
+		in, out := &in.NodeSetSelector, &out.NodeSetSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerPartition.
+func (in *ControllerPartition) DeepCopy() *ControllerPartition {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerPartition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerPersistence) DeepCopyInto(out *ControllerPersistence) {
 	*out = *in
@@ -201,14 +288,69 @@ func (in *ControllerPersistence) DeepCopy() *ControllerPersistence {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerSpec) DeepCopyInto(out *ControllerSpec) {
 	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(SlurmAuth)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SlurmKeyRef.DeepCopyInto(&out.SlurmKeyRef)
 	in.JwtHs256KeyRef.DeepCopyInto(&out.JwtHs256KeyRef)
+	if in.JwtKeys != nil {
+		in, out := &in.JwtKeys, &out.JwtKeys
+		*out = new(JwtKeys)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlurmKeyRotation != nil {
+		in, out := &in.SlurmKeyRotation, &out.SlurmKeyRotation
+		*out = new(SlurmKeyRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserGrants != nil {
+		in, out := &in.UserGrants, &out.UserGrants
+		*out = make([]UserGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.AccountingRef = in.AccountingRef
 	out.ExternalConfig = in.ExternalConfig
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
 	in.Slurmctld.DeepCopyInto(&out.Slurmctld)
 	in.Reconfigure.DeepCopyInto(&out.Reconfigure)
 	in.LogFile.DeepCopyInto(&out.LogFile)
 	in.Template.DeepCopyInto(&out.Template)
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]ControllerPartition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(ControllerTopology)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlurmConfig != nil {
+		in, out := &in.SlurmConfig, &out.SlurmConfig
+		*out = make(map[string]SlurmConfigValue, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(SlurmConfigValue, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.ConfigFileRefs != nil {
 		in, out := &in.ConfigFileRefs, &out.ConfigFileRefs
 		*out = make([]ObjectReference, len(*in))
@@ -252,6 +394,33 @@ func (in *ControllerSpec) DeepCopy() *ControllerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerStatus) DeepCopyInto(out *ControllerStatus) {
 	*out = *in
+	if in.SlurmConfig != nil {
+		in, out := &in.SlurmConfig, &out.SlurmConfig
+		*out = new(SlurmConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Slurmrestd != nil {
+		in, out := &in.Slurmrestd, &out.Slurmrestd
+		*out = new(SlurmrestdStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JwtKeys != nil {
+		in, out := &in.JwtKeys, &out.JwtKeys
+		*out = new(JwtKeysStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GeneratedKeys != nil {
+		in, out := &in.GeneratedKeys, &out.GeneratedKeys
+		*out = make([]GeneratedKeyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(KeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -271,6 +440,31 @@ func (in *ControllerStatus) DeepCopy() *ControllerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerTopology) DeepCopyInto(out *ControllerTopology) {
+	*out = *in
+	if in.LabelKeys != nil {
+		in, out := &in.LabelKeys, &out.LabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockSizes != nil {
+		in, out := &in.BlockSizes, &out.BlockSizes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerTopology.
+func (in *ControllerTopology) DeepCopy() *ControllerTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalConfig) DeepCopyInto(out *ExternalConfig) {
 	*out = *in
@@ -286,6 +480,111 @@ func (in *ExternalConfig) DeepCopy() *ExternalConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedKeyStatus) DeepCopyInto(out *GeneratedKeyStatus) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.GeneratedAt.DeepCopyInto(&out.GeneratedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedKeyStatus.
+func (in *GeneratedKeyStatus) DeepCopy() *GeneratedKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedSecretKeySelector) DeepCopyInto(out *GeneratedSecretKeySelector) {
+	*out = *in
+	in.SecretKeySelector.DeepCopyInto(&out.SecretKeySelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedSecretKeySelector.
+func (in *GeneratedSecretKeySelector) DeepCopy() *GeneratedSecretKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedSecretKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JwtKey) DeepCopyInto(out *JwtKey) {
+	*out = *in
+	in.PrivateKeyRef.DeepCopyInto(&out.PrivateKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JwtKey.
+func (in *JwtKey) DeepCopy() *JwtKey {
+	if in == nil {
+		return nil
+	}
+	out := new(JwtKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JwtKeys) DeepCopyInto(out *JwtKeys) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]JwtKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JwtKeys.
+func (in *JwtKeys) DeepCopy() *JwtKeys {
+	if in == nil {
+		return nil
+	}
+	out := new(JwtKeys)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JwtKeysStatus) DeepCopyInto(out *JwtKeysStatus) {
+	*out = *in
+	if in.PublishedKeyIDs != nil {
+		in, out := &in.PublishedKeyIDs, &out.PublishedKeyIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingTokens != nil {
+		in, out := &in.PendingTokens, &out.PendingTokens
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetirableKeyIDs != nil {
+		in, out := &in.RetirableKeyIDs, &out.RetirableKeyIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JwtKeysStatus.
+func (in *JwtKeysStatus) DeepCopy() *JwtKeysStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JwtKeysStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JwtSecretKeySelector) DeepCopyInto(out *JwtSecretKeySelector) {
 	*out = *in
@@ -302,6 +601,44 @@ func (in *JwtSecretKeySelector) DeepCopy() *JwtSecretKeySelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRotationComponentStatus) DeepCopyInto(out *KeyRotationComponentStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotationComponentStatus.
+func (in *KeyRotationComponentStatus) DeepCopy() *KeyRotationComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRotationComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRotationStatus) DeepCopyInto(out *KeyRotationStatus) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]KeyRotationComponentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotationStatus.
+func (in *KeyRotationStatus) DeepCopy() *KeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoginSet) DeepCopyInto(out *LoginSet) {
 	*out = *in
@@ -496,73 +833,360 @@ func (in *NodeSet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetList) DeepCopyInto(out *NodeSetList) {
+func (in *NodeSetAutoscaling) DeepCopyInto(out *NodeSetAutoscaling) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]NodeSet, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleUpStabilizationWindow != nil {
+		in, out := &in.ScaleUpStabilizationWindow, &out.ScaleUpStabilizationWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownStabilizationWindow != nil {
+		in, out := &in.ScaleDownStabilizationWindow, &out.ScaleDownStabilizationWindow
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetList.
-func (in *NodeSetList) DeepCopy() *NodeSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetAutoscaling.
+func (in *NodeSetAutoscaling) DeepCopy() *NodeSetAutoscaling {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetList)
+	out := new(NodeSetAutoscaling)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeSetList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetAutoscalingStatus) DeepCopyInto(out *NodeSetAutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetAutoscalingStatus.
+func (in *NodeSetAutoscalingStatus) DeepCopy() *NodeSetAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetPartition) DeepCopyInto(out *NodeSetPartition) {
+func (in *NodeSetCanary) DeepCopyInto(out *NodeSetCanary) {
 	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.SoakPeriod != nil {
+		in, out := &in.SoakPeriod, &out.SoakPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPartition.
-func (in *NodeSetPartition) DeepCopy() *NodeSetPartition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetCanary.
+func (in *NodeSetCanary) DeepCopy() *NodeSetCanary {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetPartition)
+	out := new(NodeSetCanary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetPersistentVolumeClaimRetentionPolicy) DeepCopyInto(out *NodeSetPersistentVolumeClaimRetentionPolicy) {
+func (in *NodeSetDeletionCost) DeepCopyInto(out *NodeSetDeletionCost) {
 	*out = *in
+	if in.PreemptibleQOS != nil {
+		in, out := &in.PreemptibleQOS, &out.PreemptibleQOS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LowPriorityThreshold != nil {
+		in, out := &in.LowPriorityThreshold, &out.LowPriorityThreshold
+		*out = new(int64)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPersistentVolumeClaimRetentionPolicy.
-func (in *NodeSetPersistentVolumeClaimRetentionPolicy) DeepCopy() *NodeSetPersistentVolumeClaimRetentionPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetDeletionCost.
+func (in *NodeSetDeletionCost) DeepCopy() *NodeSetDeletionCost {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetPersistentVolumeClaimRetentionPolicy)
+	out := new(NodeSetDeletionCost)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetSpec) DeepCopyInto(out *NodeSetSpec) {
+func (in *NodeSetDrainPolicy) DeepCopyInto(out *NodeSetDrainPolicy) {
 	*out = *in
-	out.ControllerRef = in.ControllerRef
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetDrainPolicy.
+func (in *NodeSetDrainPolicy) DeepCopy() *NodeSetDrainPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetDrainPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetGres) DeepCopyInto(out *NodeSetGres) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetGres.
+func (in *NodeSetGres) DeepCopy() *NodeSetGres {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetGres)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetList) DeepCopyInto(out *NodeSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetList.
+func (in *NodeSetList) DeepCopy() *NodeSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetNodeConditionMatch) DeepCopyInto(out *NodeSetNodeConditionMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetNodeConditionMatch.
+func (in *NodeSetNodeConditionMatch) DeepCopy() *NodeSetNodeConditionMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetNodeConditionMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetNodeConf) DeepCopyInto(out *NodeSetNodeConf) {
+	*out = *in
+	if in.MemoryReserve != nil {
+		in, out := &in.MemoryReserve, &out.MemoryReserve
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Gres != nil {
+		in, out := &in.Gres, &out.Gres
+		*out = make([]NodeSetGres, len(*in))
+		copy(*out, *in)
+	}
+	if in.FeatureLabels != nil {
+		in, out := &in.FeatureLabels, &out.FeatureLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetNodeConf.
+func (in *NodeSetNodeConf) DeepCopy() *NodeSetNodeConf {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetNodeConf)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetNodeHealth) DeepCopyInto(out *NodeSetNodeHealth) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]NodeSetNodeHealthRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetNodeHealth.
+func (in *NodeSetNodeHealth) DeepCopy() *NodeSetNodeHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetNodeHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetNodeHealthRule) DeepCopyInto(out *NodeSetNodeHealthRule) {
+	*out = *in
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(NodeSetNodeConditionMatch)
+		**out = **in
+	}
+	if in.Taint != nil {
+		in, out := &in.Taint, &out.Taint
+		*out = new(NodeSetNodeTaintMatch)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetNodeHealthRule.
+func (in *NodeSetNodeHealthRule) DeepCopy() *NodeSetNodeHealthRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetNodeHealthRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetNodeTaintMatch) DeepCopyInto(out *NodeSetNodeTaintMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetNodeTaintMatch.
+func (in *NodeSetNodeTaintMatch) DeepCopy() *NodeSetNodeTaintMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetNodeTaintMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetPartition) DeepCopyInto(out *NodeSetPartition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPartition.
+func (in *NodeSetPartition) DeepCopy() *NodeSetPartition {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetPartition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetPersistentVolumeClaimRetentionPolicy) DeepCopyInto(out *NodeSetPersistentVolumeClaimRetentionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPersistentVolumeClaimRetentionPolicy.
+func (in *NodeSetPersistentVolumeClaimRetentionPolicy) DeepCopy() *NodeSetPersistentVolumeClaimRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetPersistentVolumeClaimRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetRemediation) DeepCopyInto(out *NodeSetRemediation) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]NodeSetRemediationRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetRemediation.
+func (in *NodeSetRemediation) DeepCopy() *NodeSetRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetRemediationRule) DeepCopyInto(out *NodeSetRemediationRule) {
+	*out = *in
+	out.MinDuration = in.MinDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetRemediationRule.
+func (in *NodeSetRemediationRule) DeepCopy() *NodeSetRemediationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetRemediationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetSpec) DeepCopyInto(out *NodeSetSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
 		*out = new(int32)
 		**out = **in
 	}
@@ -588,6 +1212,36 @@ func (in *NodeSetSpec) DeepCopyInto(out *NodeSetSpec) {
 		*out = new(NodeSetPersistentVolumeClaimRetentionPolicy)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(NodeSetAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DrainPolicy != nil {
+		in, out := &in.DrainPolicy, &out.DrainPolicy
+		*out = new(NodeSetDrainPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(NodeSetRemediation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeHealth != nil {
+		in, out := &in.NodeHealth, &out.NodeHealth
+		*out = new(NodeSetNodeHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeConf != nil {
+		in, out := &in.NodeConf, &out.NodeConf
+		*out = new(NodeSetNodeConf)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionCost != nil {
+		in, out := &in.DeletionCost, &out.DeletionCost
+		*out = new(NodeSetDeletionCost)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSpec.
@@ -603,6 +1257,10 @@ func (in *NodeSetSpec) DeepCopy() *NodeSetSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSetStatus) DeepCopyInto(out *NodeSetStatus) {
 	*out = *in
+	if in.UpdateStartTime != nil {
+		in, out := &in.UpdateStartTime, &out.UpdateStartTime
+		*out = (*in).DeepCopy()
+	}
 	if in.CollisionCount != nil {
 		in, out := &in.CollisionCount, &out.CollisionCount
 		*out = new(int32)
@@ -615,6 +1273,11 @@ func (in *NodeSetStatus) DeepCopyInto(out *NodeSetStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(NodeSetAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetStatus.
@@ -622,71 +1285,1033 @@ func (in *NodeSetStatus) DeepCopy() *NodeSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetUpdateStrategy) DeepCopyInto(out *NodeSetUpdateStrategy) {
-	*out = *in
-	if in.RollingUpdate != nil {
-		in, out := &in.RollingUpdate, &out.RollingUpdate
-		*out = new(RollingUpdateNodeSetStrategy)
-		(*in).DeepCopyInto(*out)
+	out := new(NodeSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetUpdateStrategy) DeepCopyInto(out *NodeSetUpdateStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(RollingUpdateNodeSetStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Opportunistic != nil {
+		in, out := &in.Opportunistic, &out.Opportunistic
+		*out = new(OpportunisticNodeSetStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetUpdateStrategy.
+func (in *NodeSetUpdateStrategy) DeepCopy() *NodeSetUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpportunisticNodeSetStrategy) DeepCopyInto(out *OpportunisticNodeSetStrategy) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpportunisticNodeSetStrategy.
+func (in *OpportunisticNodeSetStrategy) DeepCopy() *OpportunisticNodeSetStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(OpportunisticNodeSetStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSpecWrapper) DeepCopyInto(out *PodSpecWrapper) {
+	clone := in.DeepCopy()
+	*out = *clone
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+	*out = *in
+	in.PodMetadata.DeepCopyInto(&out.PodMetadata)
+	in.PodSpecWrapper.DeepCopyInto(&out.PodSpecWrapper)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
+func (in *PodTemplate) DeepCopy() *PodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestApi) DeepCopyInto(out *RestApi) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApi.
+func (in *RestApi) DeepCopy() *RestApi {
+	if in == nil {
+		return nil
+	}
+	out := new(RestApi)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestApi) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestApiList) DeepCopyInto(out *RestApiList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RestApi, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiList.
+func (in *RestApiList) DeepCopy() *RestApiList {
+	if in == nil {
+		return nil
+	}
+	out := new(RestApiList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestApiList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestApiSpec) DeepCopyInto(out *RestApiSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Slurmrestd.DeepCopyInto(&out.Slurmrestd)
+	in.Template.DeepCopyInto(&out.Template)
+	in.Service.DeepCopyInto(&out.Service)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RestApiTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiSpec.
+func (in *RestApiSpec) DeepCopy() *RestApiSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestApiSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestApiStatus) DeepCopyInto(out *RestApiStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiStatus.
+func (in *RestApiStatus) DeepCopy() *RestApiStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestApiStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestApiTLS) DeepCopyInto(out *RestApiTLS) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ClientSecretRef != nil {
+		in, out := &in.ClientSecretRef, &out.ClientSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiTLS.
+func (in *RestApiTLS) DeepCopy() *RestApiTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(RestApiTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateNodeSetStrategy) DeepCopyInto(out *RollingUpdateNodeSetStrategy) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(NodeSetCanary)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateNodeSetStrategy.
+func (in *RollingUpdateNodeSetStrategy) DeepCopy() *RollingUpdateNodeSetStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateNodeSetStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountSelector) DeepCopyInto(out *ServiceAccountSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountSelector.
+func (in *ServiceAccountSelector) DeepCopy() *ServiceAccountSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMonitor) DeepCopyInto(out *ServiceMonitor) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	if in.MetricEndpoints != nil {
+		in, out := &in.MetricEndpoints, &out.MetricEndpoints
+		*out = make([]MetricEndpoint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitor.
+func (in *ServiceMonitor) DeepCopy() *ServiceMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.ServiceSpecWrapper.DeepCopyInto(&out.ServiceSpecWrapper)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
+func (in *ServiceSpec) DeepCopy() *ServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceSpecWrapper) DeepCopyInto(out *ServiceSpecWrapper) {
+	clone := in.DeepCopy()
+	*out = *clone
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmAccount) DeepCopyInto(out *SlurmAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccount.
+func (in *SlurmAccount) DeepCopy() *SlurmAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmAccountList) DeepCopyInto(out *SlurmAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccountList.
+func (in *SlurmAccountList) DeepCopy() *SlurmAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmAccountParameters) DeepCopyInto(out *SlurmAccountParameters) {
+	*out = *in
+	in.Limits.DeepCopyInto(&out.Limits)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccountParameters.
+func (in *SlurmAccountParameters) DeepCopy() *SlurmAccountParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAccountParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmAccountSpec) DeepCopyInto(out *SlurmAccountSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	in.SlurmAccountParameters.DeepCopyInto(&out.SlurmAccountParameters)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccountSpec.
+func (in *SlurmAccountSpec) DeepCopy() *SlurmAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmAccountStatus) DeepCopyInto(out *SlurmAccountStatus) {
+	*out = *in
+	if in.Observed != nil {
+		in, out := &in.Observed, &out.Observed
+		*out = new(SlurmAccountParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastDriftTime != nil {
+		in, out := &in.LastDriftTime, &out.LastDriftTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccountStatus.
+func (in *SlurmAccountStatus) DeepCopy() *SlurmAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmAuth) DeepCopyInto(out *SlurmAuth) {
+	*out = *in
+	in.MungeKeyRef.DeepCopyInto(&out.MungeKeyRef)
+	in.Munged.DeepCopyInto(&out.Munged)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAuth.
+func (in *SlurmAuth) DeepCopy() *SlurmAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmConfigStatus) DeepCopyInto(out *SlurmConfigStatus) {
+	*out = *in
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmConfigStatus.
+func (in *SlurmConfigStatus) DeepCopy() *SlurmConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in SlurmConfigValue) DeepCopyInto(out *SlurmConfigValue) {
+	{
+		in := &in
+		*out = make(SlurmConfigValue, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmConfigValue.
+func (in SlurmConfigValue) DeepCopy() SlurmConfigValue {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmConfigValue)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJob) DeepCopyInto(out *SlurmJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJob.
+func (in *SlurmJob) DeepCopy() *SlurmJob {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobArrayTasks) DeepCopyInto(out *SlurmJobArrayTasks) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobArrayTasks.
+func (in *SlurmJobArrayTasks) DeepCopy() *SlurmJobArrayTasks {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobArrayTasks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobDependency) DeepCopyInto(out *SlurmJobDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobDependency.
+func (in *SlurmJobDependency) DeepCopy() *SlurmJobDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobList) DeepCopyInto(out *SlurmJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobList.
+func (in *SlurmJobList) DeepCopy() *SlurmJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobResources) DeepCopyInto(out *SlurmJobResources) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CPUsPerTask != nil {
+		in, out := &in.CPUsPerTask, &out.CPUsPerTask
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryPerNode != nil {
+		in, out := &in.MemoryPerNode, &out.MemoryPerNode
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TimeLimit != nil {
+		in, out := &in.TimeLimit, &out.TimeLimit
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobResources.
+func (in *SlurmJobResources) DeepCopy() *SlurmJobResources {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobSpec) DeepCopyInto(out *SlurmJobSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]SlurmJobDependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobSpec.
+func (in *SlurmJobSpec) DeepCopy() *SlurmJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobStatus) DeepCopyInto(out *SlurmJobStatus) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ArrayTasks != nil {
+		in, out := &in.ArrayTasks, &out.ArrayTasks
+		*out = new(SlurmJobArrayTasks)
+		**out = **in
+	}
+	if in.SubmitTime != nil {
+		in, out := &in.SubmitTime, &out.SubmitTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobStatus.
+func (in *SlurmJobStatus) DeepCopy() *SlurmJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmKeyRotation) DeepCopyInto(out *SlurmKeyRotation) {
+	*out = *in
+	in.NewSlurmKeyRef.DeepCopyInto(&out.NewSlurmKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmKeyRotation.
+func (in *SlurmKeyRotation) DeepCopy() *SlurmKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmMaintenance) DeepCopyInto(out *SlurmMaintenance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmMaintenance.
+func (in *SlurmMaintenance) DeepCopy() *SlurmMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmMaintenance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmMaintenanceList) DeepCopyInto(out *SlurmMaintenanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmMaintenance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmMaintenanceList.
+func (in *SlurmMaintenanceList) DeepCopy() *SlurmMaintenanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmMaintenanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmMaintenanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmMaintenanceSpec) DeepCopyInto(out *SlurmMaintenanceSpec) {
+	*out = *in
+	if in.NodeSets != nil {
+		in, out := &in.NodeSets, &out.NodeSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	out.Duration = in.Duration
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmMaintenanceSpec.
+func (in *SlurmMaintenanceSpec) DeepCopy() *SlurmMaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmMaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmMaintenanceStatus) DeepCopyInto(out *SlurmMaintenanceStatus) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.NodeSets != nil {
+		in, out := &in.NodeSets, &out.NodeSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.QuiescedNodes != nil {
+		in, out := &in.QuiescedNodes, &out.QuiescedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RunningJobs != nil {
+		in, out := &in.RunningJobs, &out.RunningJobs
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmMaintenanceStatus.
+func (in *SlurmMaintenanceStatus) DeepCopy() *SlurmMaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmMaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmQOS) DeepCopyInto(out *SlurmQOS) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOS.
+func (in *SlurmQOS) DeepCopy() *SlurmQOS {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmQOS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmQOS) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmQOSList) DeepCopyInto(out *SlurmQOSList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmQOS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOSList.
+func (in *SlurmQOSList) DeepCopy() *SlurmQOSList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmQOSList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmQOSList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmQOSParameters) DeepCopyInto(out *SlurmQOSParameters) {
+	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreemptMode != nil {
+		in, out := &in.PreemptMode, &out.PreemptMode
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Preempt != nil {
+		in, out := &in.Preempt, &out.Preempt
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GrpJobs != nil {
+		in, out := &in.GrpJobs, &out.GrpJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GrpSubmitJobs != nil {
+		in, out := &in.GrpSubmitJobs, &out.GrpSubmitJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxJobsPerUser != nil {
+		in, out := &in.MaxJobsPerUser, &out.MaxJobsPerUser
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxJobsPerAccount != nil {
+		in, out := &in.MaxJobsPerAccount, &out.MaxJobsPerAccount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSubmitJobsPerUser != nil {
+		in, out := &in.MaxSubmitJobsPerUser, &out.MaxSubmitJobsPerUser
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxWallDurationPerJob != nil {
+		in, out := &in.MaxWallDurationPerJob, &out.MaxWallDurationPerJob
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetUpdateStrategy.
-func (in *NodeSetUpdateStrategy) DeepCopy() *NodeSetUpdateStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOSParameters.
+func (in *SlurmQOSParameters) DeepCopy() *SlurmQOSParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetUpdateStrategy)
+	out := new(SlurmQOSParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+func (in *SlurmQOSSpec) DeepCopyInto(out *SlurmQOSSpec) {
 	*out = *in
+	out.ControllerRef = in.ControllerRef
+	in.SlurmQOSParameters.DeepCopyInto(&out.SlurmQOSParameters)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
-func (in *ObjectReference) DeepCopy() *ObjectReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOSSpec.
+func (in *SlurmQOSSpec) DeepCopy() *SlurmQOSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectReference)
+	out := new(SlurmQOSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSpecWrapper) DeepCopyInto(out *PodSpecWrapper) {
-	clone := in.DeepCopy()
-	*out = *clone
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+func (in *SlurmQOSStatus) DeepCopyInto(out *SlurmQOSStatus) {
 	*out = *in
-	in.PodMetadata.DeepCopyInto(&out.PodMetadata)
-	in.PodSpecWrapper.DeepCopyInto(&out.PodSpecWrapper)
+	if in.Observed != nil {
+		in, out := &in.Observed, &out.Observed
+		*out = new(SlurmQOSParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastDriftTime != nil {
+		in, out := &in.LastDriftTime, &out.LastDriftTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
-func (in *PodTemplate) DeepCopy() *PodTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOSStatus.
+func (in *SlurmQOSStatus) DeepCopy() *SlurmQOSStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PodTemplate)
+	out := new(SlurmQOSStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestApi) DeepCopyInto(out *RestApi) {
+func (in *SlurmUser) DeepCopyInto(out *SlurmUser) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -694,18 +2319,18 @@ func (in *RestApi) DeepCopyInto(out *RestApi) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApi.
-func (in *RestApi) DeepCopy() *RestApi {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUser.
+func (in *SlurmUser) DeepCopy() *SlurmUser {
 	if in == nil {
 		return nil
 	}
-	out := new(RestApi)
+	out := new(SlurmUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RestApi) DeepCopyObject() runtime.Object {
+func (in *SlurmUser) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -713,31 +2338,47 @@ func (in *RestApi) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestApiList) DeepCopyInto(out *RestApiList) {
+func (in *SlurmUserAssociation) DeepCopyInto(out *SlurmUserAssociation) {
+	*out = *in
+	in.Limits.DeepCopyInto(&out.Limits)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUserAssociation.
+func (in *SlurmUserAssociation) DeepCopy() *SlurmUserAssociation {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmUserAssociation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmUserList) DeepCopyInto(out *SlurmUserList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RestApi, len(*in))
+		*out = make([]SlurmUser, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiList.
-func (in *RestApiList) DeepCopy() *RestApiList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUserList.
+func (in *SlurmUserList) DeepCopy() *SlurmUserList {
 	if in == nil {
 		return nil
 	}
-	out := new(RestApiList)
+	out := new(SlurmUserList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RestApiList) DeepCopyObject() runtime.Object {
+func (in *SlurmUserList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -745,115 +2386,110 @@ func (in *RestApiList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestApiSpec) DeepCopyInto(out *RestApiSpec) {
+func (in *SlurmUserParameters) DeepCopyInto(out *SlurmUserParameters) {
 	*out = *in
-	out.ControllerRef = in.ControllerRef
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
+	if in.Associations != nil {
+		in, out := &in.Associations, &out.Associations
+		*out = make([]SlurmUserAssociation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	in.Slurmrestd.DeepCopyInto(&out.Slurmrestd)
-	in.Template.DeepCopyInto(&out.Template)
-	in.Service.DeepCopyInto(&out.Service)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiSpec.
-func (in *RestApiSpec) DeepCopy() *RestApiSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUserParameters.
+func (in *SlurmUserParameters) DeepCopy() *SlurmUserParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(RestApiSpec)
+	out := new(SlurmUserParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestApiStatus) DeepCopyInto(out *RestApiStatus) {
+func (in *SlurmUserSpec) DeepCopyInto(out *SlurmUserSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.ControllerRef = in.ControllerRef
+	in.SlurmUserParameters.DeepCopyInto(&out.SlurmUserParameters)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiStatus.
-func (in *RestApiStatus) DeepCopy() *RestApiStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUserSpec.
+func (in *SlurmUserSpec) DeepCopy() *SlurmUserSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RestApiStatus)
+	out := new(SlurmUserSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollingUpdateNodeSetStrategy) DeepCopyInto(out *RollingUpdateNodeSetStrategy) {
+func (in *SlurmUserStatus) DeepCopyInto(out *SlurmUserStatus) {
 	*out = *in
-	if in.MaxUnavailable != nil {
-		in, out := &in.MaxUnavailable, &out.MaxUnavailable
-		*out = new(intstr.IntOrString)
-		**out = **in
+	if in.Observed != nil {
+		in, out := &in.Observed, &out.Observed
+		*out = new(SlurmUserParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastDriftTime != nil {
+		in, out := &in.LastDriftTime, &out.LastDriftTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateNodeSetStrategy.
-func (in *RollingUpdateNodeSetStrategy) DeepCopy() *RollingUpdateNodeSetStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUserStatus.
+func (in *SlurmUserStatus) DeepCopy() *SlurmUserStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RollingUpdateNodeSetStrategy)
+	out := new(SlurmUserStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceMonitor) DeepCopyInto(out *ServiceMonitor) {
+func (in *SlurmrestdEndpoint) DeepCopyInto(out *SlurmrestdEndpoint) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
-	if in.MetricEndpoints != nil {
-		in, out := &in.MetricEndpoints, &out.MetricEndpoints
-		*out = make([]MetricEndpoint, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitor.
-func (in *ServiceMonitor) DeepCopy() *ServiceMonitor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmrestdEndpoint.
+func (in *SlurmrestdEndpoint) DeepCopy() *SlurmrestdEndpoint {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceMonitor)
+	out := new(SlurmrestdEndpoint)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+func (in *SlurmrestdStatus) DeepCopyInto(out *SlurmrestdStatus) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
-	in.ServiceSpecWrapper.DeepCopyInto(&out.ServiceSpecWrapper)
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]SlurmrestdEndpoint, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
-func (in *ServiceSpec) DeepCopy() *ServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmrestdStatus.
+func (in *SlurmrestdStatus) DeepCopy() *SlurmrestdStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceSpec)
+	out := new(SlurmrestdStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpecWrapper) DeepCopyInto(out *ServiceSpecWrapper) {
-	clone := in.DeepCopy()
-	*out = *clone
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
 	*out = *in
@@ -929,10 +2565,147 @@ func (in *TokenList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenPolicy) DeepCopyInto(out *TokenPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenPolicy.
+func (in *TokenPolicy) DeepCopy() *TokenPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TokenPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenPolicyList) DeepCopyInto(out *TokenPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TokenPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenPolicyList.
+func (in *TokenPolicyList) DeepCopy() *TokenPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TokenPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenPolicySpec) DeepCopyInto(out *TokenPolicySpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]ServiceAccountSelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxLifetime != nil {
+		in, out := &in.MaxLifetime, &out.MaxLifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenPolicySpec.
+func (in *TokenPolicySpec) DeepCopy() *TokenPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenPolicyStatus) DeepCopyInto(out *TokenPolicyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenPolicyStatus.
+func (in *TokenPolicyStatus) DeepCopy() *TokenPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenSecretFormat) DeepCopyInto(out *TokenSecretFormat) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenSecretFormat.
+func (in *TokenSecretFormat) DeepCopy() *TokenSecretFormat {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenSecretFormat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenSecretTemplate) DeepCopyInto(out *TokenSecretTemplate) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]TokenSecretFormat, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenSecretTemplate.
+func (in *TokenSecretTemplate) DeepCopy() *TokenSecretTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenSecretTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TokenSpec) DeepCopyInto(out *TokenSpec) {
 	*out = *in
 	in.JwtHs256KeyRef.DeepCopyInto(&out.JwtHs256KeyRef)
+	out.ControllerRef = in.ControllerRef
 	if in.Lifetime != nil {
 		in, out := &in.Lifetime, &out.Lifetime
 		*out = new(v1.Duration)
@@ -943,6 +2716,11 @@ func (in *TokenSpec) DeepCopyInto(out *TokenSpec) {
 		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecretTemplate != nil {
+		in, out := &in.SecretTemplate, &out.SecretTemplate
+		*out = new(TokenSecretTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenSpec.
@@ -962,6 +2740,14 @@ func (in *TokenStatus) DeepCopyInto(out *TokenStatus) {
 		in, out := &in.IssuedAt, &out.IssuedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRefreshAt != nil {
+		in, out := &in.NextRefreshAt, &out.NextRefreshAt
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -980,3 +2766,23 @@ func (in *TokenStatus) DeepCopy() *TokenStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserGrant) DeepCopyInto(out *UserGrant) {
+	*out = *in
+	if in.Usernames != nil {
+		in, out := &in.Usernames, &out.Usernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserGrant.
+func (in *UserGrant) DeepCopy() *UserGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(UserGrant)
+	in.DeepCopyInto(out)
+	return out
+}
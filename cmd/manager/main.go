@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
@@ -31,7 +32,11 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/restapi"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmclient"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmjob"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmmaintenance"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/token"
+	"github.com/SlinkyProject/slurm-operator/internal/tokenexchange"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -55,6 +60,10 @@ type Flags struct {
 	metricsAddr          string
 	secureMetrics        bool
 	enableHTTP2          bool
+
+	tokenExchangePort     int
+	tokenExchangeCertDir  string
+	tokenExchangeAudience string
 }
 
 func parseFlags(flags *Flags) {
@@ -81,6 +90,25 @@ func parseFlags(flags *Flags) {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&flags.enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(
+		&flags.tokenExchangePort,
+		"token-exchange-port",
+		0,
+		("The port the ServiceAccount token exchange endpoint binds to. " +
+			"The endpoint is disabled when 0."),
+	)
+	flag.StringVar(
+		&flags.tokenExchangeCertDir,
+		"token-exchange-cert-dir",
+		"/tmp/token-exchange/serving-certs",
+		"The directory of the TLS certificate (tls.crt) and key (tls.key) of the token exchange endpoint.",
+	)
+	flag.StringVar(
+		&flags.tokenExchangeAudience,
+		"token-exchange-audience",
+		tokenexchange.DefaultAudience,
+		"The audience which exchanged ServiceAccount tokens must be issued for.",
+	)
 	flag.Parse()
 }
 
@@ -125,15 +153,16 @@ func main() {
 
 	clientMap := clientmap.NewClientMap()
 	eventCh := make(chan event.GenericEvent, 100)
+	jobEventCh := make(chan event.GenericEvent, 100)
 	if err := controller.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Controller")
 		os.Exit(1)
 	}
-	if err := restapi.NewReconciler(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+	if err := restapi.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Restapi")
 		os.Exit(1)
 	}
-	if err := accounting.NewReconciler(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+	if err := accounting.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Accounting")
 		os.Exit(1)
 	}
@@ -145,7 +174,7 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "LoginSet")
 		os.Exit(1)
 	}
-	if err := slurmclient.NewReconciler(mgr.GetClient(), clientMap, eventCh).SetupWithManager(mgr); err != nil {
+	if err := slurmclient.NewReconciler(mgr.GetClient(), clientMap, eventCh, jobEventCh).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SlurmClient")
 		os.Exit(1)
 	}
@@ -153,6 +182,39 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Token")
 		os.Exit(1)
 	}
+	if err := slurmmaintenance.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SlurmMaintenance")
+		os.Exit(1)
+	}
+	if err := slurmjob.NewReconciler(mgr.GetClient(), clientMap, jobEventCh).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SlurmJob")
+		os.Exit(1)
+	}
+	if err := slurmdb.NewSlurmAccountReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SlurmAccount")
+		os.Exit(1)
+	}
+	if err := slurmdb.NewSlurmUserReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SlurmUser")
+		os.Exit(1)
+	}
+	if err := slurmdb.NewSlurmQOSReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SlurmQOS")
+		os.Exit(1)
+	}
+
+	if flags.tokenExchangePort != 0 {
+		tokenExchangeServer := webhook.NewServer(webhook.Options{
+			Port:    flags.tokenExchangePort,
+			CertDir: flags.tokenExchangeCertDir,
+			TLSOpts: tlsOpts,
+		})
+		tokenExchangeServer.Register(tokenexchange.Path, tokenexchange.NewHandler(mgr.GetClient(), []string{flags.tokenExchangeAudience}))
+		if err := mgr.Add(tokenExchangeServer); err != nil {
+			setupLog.Error(err, "unable to set up token exchange server")
+			os.Exit(1)
+		}
+	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
@@ -20,4 +20,10 @@ func Test_parseFlags(t *testing.T) {
 	if !flags.enableLeaderElection {
 		t.Errorf("Test_parseFlags() server = %v, want %v", flags.enableLeaderElection, true)
 	}
+	if flags.tokenExchangePort != 0 {
+		t.Errorf("Test_parseFlags() tokenExchangePort = %v, want %v", flags.tokenExchangePort, 0)
+	}
+	if flags.tokenExchangeAudience != "slurm-operator" {
+		t.Errorf("Test_parseFlags() tokenExchangeAudience = %v, want %v", flags.tokenExchangeAudience, "slurm-operator")
+	}
 }
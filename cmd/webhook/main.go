@@ -139,7 +139,9 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Accounting")
 		os.Exit(1)
 	}
-	if err := (&slinkywebhook.NodeSetWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&slinkywebhook.NodeSetWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "NodeSet")
 		os.Exit(1)
 	}
@@ -151,6 +153,34 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Token")
 		os.Exit(1)
 	}
+	if err = (&slinkywebhook.TokenPolicyWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "TokenPolicy")
+		os.Exit(1)
+	}
+	if err = (&slinkywebhook.SlurmMaintenanceWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SlurmMaintenance")
+		os.Exit(1)
+	}
+	if err = (&slinkywebhook.SlurmAccountWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SlurmAccount")
+		os.Exit(1)
+	}
+	if err = (&slinkywebhook.SlurmJobWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SlurmJob")
+		os.Exit(1)
+	}
+	if err = (&slinkywebhook.SlurmUserWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SlurmUser")
+		os.Exit(1)
+	}
+	if err = (&slinkywebhook.SlurmQOSWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SlurmQOS")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -17,6 +17,21 @@ Define auth/slurm secret ref key
 {{- print "slurm.key" -}}
 {{- end }}
 
+{{/*
+Define the Slurm auth, when it is not the default auth/slurm
+*/}}
+{{- define "slurm.auth" -}}
+{{- if eq .Values.auth.type "auth/munge" -}}
+auth:
+  type: {{ .Values.auth.type }}
+  mungeKeyRef:
+    {{- toYaml .Values.auth.mungeKeyRef | nindent 4 }}
+  munged:
+    {{- $_ := set .Values.auth.munged "imagePullPolicy" (default .Values.imagePullPolicy .Values.auth.munged.imagePullPolicy) -}}
+    {{- include "format-container" .Values.auth.munged | nindent 4 }}
+{{- end }}{{- /* if eq .Values.auth.type "auth/munge" */}}
+{{- end }}
+
 {{/*
 Define auth/jwt HS256 secret ref name
 */}}
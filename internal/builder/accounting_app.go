@@ -75,6 +75,14 @@ func (b *Builder) accountingPodTemplate(accounting *slinkyv1beta1.Accounting) (c
 	if err != nil {
 		return corev1.PodTemplateSpec{}, err
 	}
+	hasJwks, err := b.HasAccountingJwks(ctx, accounting)
+	if err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
+	rotation, err := b.getSlurmKeyRotationController(ctx, accounting)
+	if err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
 
 	objectMeta := metadata.NewBuilder(key).
 		WithLabels(labels.NewBuilder().WithAccountingLabels(accounting).Build()).
@@ -82,6 +90,7 @@ func (b *Builder) accountingPodTemplate(accounting *slinkyv1beta1.Accounting) (c
 		WithAnnotations(map[string]string{
 			annotationDefaultContainer: labels.AccountingApp,
 		}).
+		WithAnnotations(slurmJwksAnnotations(rotation, slinkyv1beta1.KeyRotationComponentAccounting)).
 		Build()
 
 	spec := accounting.Spec
@@ -104,58 +113,56 @@ func (b *Builder) accountingPodTemplate(accounting *slinkyv1beta1.Accounting) (c
 				RunAsGroup:   ptr.To(slurmUserGid),
 				FSGroup:      ptr.To(slurmUserGid),
 			},
-			Volumes: accountingVolumes(accounting),
+			Volumes: accountingVolumes(accounting, hasJwks, rotation),
 		},
 		merge: template.PodSpec,
 	}
 
-	return b.buildPodTemplate(opts), nil
+	podTemplate := b.buildPodTemplate(opts)
+	b.withMunged(spec.Auth, &podTemplate)
+
+	return podTemplate, nil
 }
 
-func accountingVolumes(accounting *slinkyv1beta1.Accounting) []corev1.Volume {
+func accountingVolumes(accounting *slinkyv1beta1.Accounting, hasJwks bool, rotation *slinkyv1beta1.Controller) []corev1.Volume {
+	sources := []corev1.VolumeProjection{
+		{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: accounting.ConfigKey().Name,
+				},
+				Items: []corev1.KeyToPath{
+					{Key: slurmdbdConfFile, Path: slurmdbdConfFile},
+				},
+			},
+		},
+	}
+	sources = append(sources, slurmAuthVolumeProjections(accounting.Spec.Auth, accounting.AuthSlurmRef(), rotation, slinkyv1beta1.KeyRotationComponentAccounting)...)
+	sources = append(sources, corev1.VolumeProjection{
+		Secret: &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: accounting.AuthJwtHs256Ref().Name,
+			},
+			Items: []corev1.KeyToPath{
+				{Key: accounting.AuthJwtHs256Ref().Key, Path: JwtHs256KeyFile},
+			},
+		},
+	})
 	out := []corev1.Volume{
 		{
 			Name: slurmEtcVolume,
 			VolumeSource: corev1.VolumeSource{
 				Projected: &corev1.ProjectedVolumeSource{
 					DefaultMode: ptr.To[int32](0o600),
-					Sources: []corev1.VolumeProjection{
-						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: accounting.ConfigKey().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: slurmdbdConfFile, Path: slurmdbdConfFile},
-								},
-							},
-						},
-						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: accounting.AuthSlurmRef().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: accounting.AuthSlurmRef().Key, Path: slurmKeyFile},
-								},
-							},
-						},
-						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: accounting.AuthJwtHs256Ref().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: accounting.AuthJwtHs256Ref().Key, Path: JwtHs256KeyFile},
-								},
-							},
-						},
-					},
+					Sources:     sources,
 				},
 			},
 		},
 		pidfileVolume(),
 	}
+	if hasJwks {
+		out[0].Projected.Sources = append(out[0].Projected.Sources, jwksVolumeProjection(accounting.JwksKey().Name))
+	}
 	return out
 }
 
@@ -212,22 +219,39 @@ func (b *Builder) getAccountingHashes(ctx context.Context, accounting *slinkyv1b
 	}
 	slurmdbdConfHash := crypto.CheckSumFromMap(dbdConfig.Data)
 
+	// slurmdbd only reads the `jwks` file on start.
+	jwks := &corev1.Secret{}
+	if err := b.client.Get(ctx, accounting.JwksKey(), jwks); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
 	hashMap = structutils.MergeMaps(hashMap, map[string]string{
 		annotationSlurmdbdConfHash: slurmdbdConfHash,
 	})
+	if len(jwks.Data) > 0 {
+		hashMap[annotationJwksHash] = crypto.CheckSumFromMap(jwks.Data)
+	}
 
 	return hashMap, nil
 }
 
 func (b *Builder) getAuthHashesFromAccounting(ctx context.Context, accounting *slinkyv1beta1.Accounting) (map[string]string, error) {
-	authSlurm := &corev1.Secret{}
-	authSlurmKey := accounting.AuthSlurmKey()
-	if err := b.client.Get(ctx, authSlurmKey, authSlurm); err != nil {
+	// The key of the Slurm authentication: `auth/slurm` or `auth/munge`.
+	authKey := &corev1.Secret{}
+	authKeyKey := accounting.AuthSlurmKey()
+	authKeyAnnotation := annotationAuthSlurmKeyHash
+	if accounting.IsMungeAuth() {
+		authKeyKey = accounting.AuthMungeKey()
+		authKeyAnnotation = annotationAuthMungeKeyHash
+	}
+	if err := b.client.Get(ctx, authKeyKey, authKey); err != nil {
 		if !apierrors.IsNotFound(err) {
 			return nil, err
 		}
 	}
-	authSlurmKeyHash := crypto.CheckSumFromMap(authSlurm.Data)
+	authKeyHash := crypto.CheckSumFromMap(authKey.Data)
 
 	authJwtHs256 := &corev1.Secret{}
 	authJwtHs256Key := accounting.AuthJwtHs256Key()
@@ -239,9 +263,25 @@ func (b *Builder) getAuthHashesFromAccounting(ctx context.Context, accounting *s
 	authJwtHs256KeyHash := crypto.CheckSumFromMap(authJwtHs256.Data)
 
 	hashMap := map[string]string{
-		annotationAuthSlurmKeyHash:    authSlurmKeyHash,
+		authKeyAnnotation:             authKeyHash,
 		annotationAuthJwtHs256KeyHash: authJwtHs256KeyHash,
 	}
 
 	return hashMap, nil
 }
+
+// getSlurmKeyRotationController returns the Controller of the Accounting which
+// rolls it in a rotation of the `auth/slurm` key, if any.
+func (b *Builder) getSlurmKeyRotationController(ctx context.Context, accounting *slinkyv1beta1.Accounting) (*slinkyv1beta1.Controller, error) {
+	controllerList, err := b.refResolver.GetControllersForAccounting(ctx, accounting)
+	if err != nil {
+		return nil, err
+	}
+	for i := range controllerList.Items {
+		controller := &controllerList.Items[i]
+		if controller.SlurmKeyRotationPhase(slinkyv1beta1.KeyRotationComponentAccounting) != "" {
+			return controller, nil
+		}
+	}
+	return nil, nil
+}
@@ -15,7 +15,12 @@ import (
 )
 
 func (b *Builder) BuildAccountingConfig(accounting *slinkyv1beta1.Accounting) (*corev1.Secret, error) {
-	storagePass, err := b.refResolver.GetSecretKeyRef(context.TODO(), accounting.AuthStorageRef(), accounting.Namespace)
+	ctx := context.TODO()
+	storagePass, err := b.refResolver.GetSecretKeyRef(ctx, accounting.AuthStorageRef(), accounting.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	hasJwks, err := b.HasAccountingJwks(ctx, accounting)
 	if err != nil {
 		return nil, err
 	}
@@ -24,7 +29,7 @@ func (b *Builder) BuildAccountingConfig(accounting *slinkyv1beta1.Accounting) (*
 		Key:      accounting.ConfigKey(),
 		Metadata: accounting.Spec.Template.PodMetadata,
 		StringData: map[string]string{
-			slurmdbdConfFile: buildSlurmdbdConf(accounting, string(storagePass)),
+			slurmdbdConfFile: buildSlurmdbdConf(accounting, string(storagePass), hasJwks),
 		},
 	}
 
@@ -34,7 +39,7 @@ func (b *Builder) BuildAccountingConfig(accounting *slinkyv1beta1.Accounting) (*
 }
 
 // https://slurm.schedmd.com/slurmdbd.conf.html
-func buildSlurmdbdConf(accounting *slinkyv1beta1.Accounting, storagePass string) string {
+func buildSlurmdbdConf(accounting *slinkyv1beta1.Accounting, storagePass string, hasJwks bool) string {
 	dbdHost := accounting.PrimaryName()
 	storageHost := accounting.Spec.StorageConfig.Host
 	storagePort := accounting.Spec.StorageConfig.Port
@@ -51,10 +56,10 @@ func buildSlurmdbdConf(accounting *slinkyv1beta1.Accounting, storagePass string)
 
 	conf.AddProperty(config.NewPropertyRaw("#"))
 	conf.AddProperty(config.NewPropertyRaw("### PLUGINS & PARAMETERS ###"))
-	conf.AddProperty(config.NewProperty("AuthType", authType))
+	conf.AddProperty(config.NewProperty("AuthType", slurmAuthType(accounting.Spec.Auth)))
 	conf.AddProperty(config.NewProperty("AuthAltTypes", authAltTypes))
-	conf.AddProperty(config.NewProperty("AuthAltParameters", authAltParameters))
-	conf.AddProperty(config.NewProperty("AuthInfo", authInfo))
+	conf.AddProperty(config.NewProperty("AuthAltParameters", jwtAuthAltParameters(hasJwks)))
+	conf.AddProperty(config.NewProperty("AuthInfo", slurmAuthInfo(accounting.Spec.Auth)))
 
 	conf.AddProperty(config.NewPropertyRaw("#"))
 	conf.AddProperty(config.NewPropertyRaw("### STORAGE ###"))
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+)
+
+// BuildAccountingJwks returns the Secret of the Slurm `jwks` file, with the
+// public keys of the JWT keys of all Controllers of the Accounting, so
+// slurmdbd accepts the JWTs which slurmctld does.
+func (b *Builder) BuildAccountingJwks(accounting *slinkyv1beta1.Accounting) (*corev1.Secret, error) {
+	ctx := context.TODO()
+
+	controllerList, err := b.refResolver.GetControllersForAccounting(ctx, accounting)
+	if err != nil {
+		return nil, err
+	}
+	keys := []*slurmjwt.SigningKey{}
+	for _, controller := range controllerList.Items {
+		published, err := b.getPublishedJwtKeys(ctx, &controller)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, published...)
+	}
+	jwks, err := slurmjwt.NewJWKS(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := SecretOpts{
+		Key:      accounting.JwksKey(),
+		Metadata: accounting.Spec.Template.PodMetadata,
+		Data: map[string][]byte{
+			JwksFile: jwks,
+		},
+	}
+
+	opts.Metadata.Labels = structutils.MergeMaps(opts.Metadata.Labels, labels.NewBuilder().WithAccountingLabels(accounting).Build())
+
+	return b.BuildSecret(opts, accounting)
+}
+
+// HasAccountingJwks reports if any Controller of the Accounting has JWT keys,
+// which slurmdbd must then accept too.
+func (b *Builder) HasAccountingJwks(ctx context.Context, accounting *slinkyv1beta1.Accounting) (bool, error) {
+	controllerList, err := b.refResolver.GetControllersForAccounting(ctx, accounting)
+	if err != nil {
+		return false, err
+	}
+	hasJwks := slices.ContainsFunc(controllerList.Items, func(controller slinkyv1beta1.Controller) bool {
+		return controller.Spec.JwtKeys != nil
+	})
+	return hasJwks, nil
+}
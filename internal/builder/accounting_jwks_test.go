@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func TestBuilder_BuildAccountingJwks(t *testing.T) {
+	accounting := testutils.NewAccounting("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), testutils.NewPasswordRef("slurm"))
+	withKeys, withKeysObjs := newJwtKeysController("with-keys", accounting, "a", "b")
+	withoutKeys, withoutKeysObjs := newJwtKeysController("without-keys", accounting)
+	other, otherObjs := newJwtKeysController("other", nil, "c")
+	tests := []struct {
+		name        string
+		objs        []client.Object
+		wantHasJwks bool
+		wantKeyIDs  []string
+	}{
+		{
+			name:       "No Controllers",
+			wantKeyIDs: []string{},
+		},
+		{
+			name:       "Without keys",
+			objs:       append([]client.Object{withoutKeys, other}, append(withoutKeysObjs, otherObjs...)...),
+			wantKeyIDs: []string{},
+		},
+		{
+			name:        "With keys",
+			objs:        append([]client.Object{withKeys, withoutKeys, other}, slices.Concat(withKeysObjs, withoutKeysObjs, otherObjs)...),
+			wantHasJwks: true,
+			wantKeyIDs:  []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewClientBuilder().WithObjects(tt.objs...).Build())
+			hasJwks, err := b.HasAccountingJwks(context.TODO(), accounting)
+			if err != nil {
+				t.Fatalf("Builder.HasAccountingJwks() error = %v", err)
+			}
+			if hasJwks != tt.wantHasJwks {
+				t.Errorf("Builder.HasAccountingJwks() = %v, want %v", hasJwks, tt.wantHasJwks)
+			}
+			got, err := b.BuildAccountingJwks(accounting)
+			if err != nil {
+				t.Fatalf("Builder.BuildAccountingJwks() error = %v", err)
+			}
+			if got.Name != accounting.JwksKey().Name {
+				t.Errorf("got.Name = %v, want %v", got.Name, accounting.JwksKey().Name)
+			}
+			jwks := slurmjwt.JWKS{}
+			if err := json.Unmarshal(got.Data[JwksFile], &jwks); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			gotKeyIDs := []string{}
+			for _, key := range jwks.Keys {
+				gotKeyIDs = append(gotKeyIDs, key.KeyID)
+			}
+			if !slices.Equal(gotKeyIDs, tt.wantKeyIDs) {
+				t.Errorf("jwks key IDs = %v, want %v", gotKeyIDs, tt.wantKeyIDs)
+			}
+		})
+	}
+}
@@ -41,6 +41,8 @@ const (
 	JwtHs256KeyFile   = "jwt_hs256.key"
 	jwtHs256KeyPath   = slurmEtcDir + "/" + JwtHs256KeyFile
 	authAltParameters = "jwt_key=" + jwtHs256KeyPath
+	JwksFile          = "jwks.json"
+	jwksPath          = slurmEtcDir + "/" + JwksFile
 
 	logTimeFormat = "iso8601,format_stderr"
 
@@ -49,9 +51,34 @@ const (
 
 const (
 	annotationAuthSlurmKeyHash    = slinkyv1beta1.SlinkyPrefix + "slurm-key-hash"
+	annotationAuthMungeKeyHash    = slinkyv1beta1.SlinkyPrefix + "munge-key-hash"
 	annotationAuthJwtHs256KeyHash = slinkyv1beta1.SlinkyPrefix + "jwt-hs256-key-hash"
+	annotationJwksHash            = slinkyv1beta1.SlinkyPrefix + "jwks-hash"
 )
 
+// jwtAuthAltParameters returns the `AuthAltParameters`, with the `jwks` file
+// when there are JWT keys to publish.
+func jwtAuthAltParameters(hasJwks bool) string {
+	if !hasJwks {
+		return authAltParameters
+	}
+	return authAltParameters + ",jwks=" + jwksPath
+}
+
+// jwksVolumeProjection projects the `jwks` file of the Secret.
+func jwksVolumeProjection(name string) corev1.VolumeProjection {
+	return corev1.VolumeProjection{
+		Secret: &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: name,
+			},
+			Items: []corev1.KeyToPath{
+				{Key: JwksFile, Path: JwksFile},
+			},
+		},
+	}
+}
+
 func configlessArgs(controller *slinkyv1beta1.Controller) []string {
 	host := controller.ServiceFQDNShort()
 	port := SlurmctldPort
@@ -60,9 +87,17 @@ func configlessArgs(controller *slinkyv1beta1.Controller) []string {
 		host = externalConfig.Host
 		port = externalConfig.Port
 	}
+	servers := []string{fmt.Sprintf("%s:%d", host, port)}
+	if controller.IsHighlyAvailable() {
+		// Try the primary first, then the backups.
+		servers = make([]string, 0, controller.Replicas())
+		for i := range controller.Replicas() {
+			servers = append(servers, fmt.Sprintf("%s:%d", controller.HostServiceFQDNShort(i), port))
+		}
+	}
 	args := []string{
 		"--conf-server",
-		fmt.Sprintf("%s:%d", host, port),
+		strings.Join(servers, ","),
 	}
 	return args
 }
@@ -10,6 +10,10 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 )
 
 func Test_mergeEnvVar(t *testing.T) {
@@ -146,3 +150,86 @@ func Test_mergeEnvVar(t *testing.T) {
 		})
 	}
 }
+
+func Test_configlessArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		want       []string
+	}{
+		{
+			name: "default",
+			controller: &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "slurm",
+					Name:      "slurm",
+				},
+			},
+			want: []string{"--conf-server", "slurm-controller.slurm:6817"},
+		},
+		{
+			name: "external",
+			controller: &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "slurm",
+					Name:      "slurm",
+				},
+				Spec: slinkyv1beta1.ControllerSpec{
+					Replicas: ptr.To[int32](2),
+					External: true,
+					ExternalConfig: slinkyv1beta1.ExternalConfig{
+						Host: "slurmctld.example.com",
+						Port: 6817,
+					},
+				},
+			},
+			want: []string{"--conf-server", "slurmctld.example.com:6817"},
+		},
+		{
+			name: "with backup controllers",
+			controller: &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "slurm",
+					Name:      "slurm",
+				},
+				Spec: slinkyv1beta1.ControllerSpec{
+					Replicas: ptr.To[int32](2),
+				},
+			},
+			want: []string{"--conf-server", "slurm-controller-0.slurm:6817,slurm-controller-1.slurm:6817"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := configlessArgs(tt.controller); !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("configlessArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_jwtAuthAltParameters(t *testing.T) {
+	tests := []struct {
+		name    string
+		hasJwks bool
+		want    string
+	}{
+		{
+			name:    "without jwks",
+			hasJwks: false,
+			want:    "jwt_key=/etc/slurm/jwt_hs256.key",
+		},
+		{
+			name:    "with jwks",
+			hasJwks: true,
+			want:    "jwt_key=/etc/slurm/jwt_hs256.key,jwks=/etc/slurm/jwks.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jwtAuthAltParameters(tt.hasJwks); got != tt.want {
+				t.Errorf("jwtAuthAltParameters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -56,7 +56,7 @@ func (b *Builder) BuildController(controller *slinkyv1beta1.Controller) (*appsv1
 		ObjectMeta: objectMeta,
 		Spec: appsv1.StatefulSetSpec{
 			PodManagementPolicy:  appsv1.ParallelPodManagement,
-			Replicas:             ptr.To[int32](1),
+			Replicas:             ptr.To(controller.Replicas()),
 			RevisionHistoryLimit: ptr.To[int32](0),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: selectorLabels,
@@ -130,11 +130,17 @@ func (b *Builder) controllerPodTemplate(controller *slinkyv1beta1.Controller) (c
 		WithAnnotations(map[string]string{
 			annotationDefaultContainer: labels.ControllerApp,
 		}).
+		WithAnnotations(slurmJwksAnnotations(controller, slinkyv1beta1.KeyRotationComponentController)).
 		Build()
 
 	spec := controller.Spec
 	template := spec.Template.PodSpecWrapper
 
+	var affinity *corev1.Affinity
+	if controller.IsHighlyAvailable() {
+		affinity = controllerAntiAffinity(controller)
+	}
+
 	opts := PodTemplateOpts{
 		Key: key,
 		Metadata: slinkyv1beta1.Metadata{
@@ -142,6 +148,7 @@ func (b *Builder) controllerPodTemplate(controller *slinkyv1beta1.Controller) (c
 			Labels:      objectMeta.Labels,
 		},
 		base: corev1.PodSpec{
+			Affinity:                     affinity,
 			AutomountServiceAccountToken: ptr.To(false),
 			Containers: []corev1.Container{
 				b.slurmctldContainer(spec.Slurmctld.Container, controller.ClusterName()),
@@ -161,45 +168,60 @@ func (b *Builder) controllerPodTemplate(controller *slinkyv1beta1.Controller) (c
 		merge: template.PodSpec,
 	}
 
-	return b.buildPodTemplate(opts), nil
+	podTemplate := b.buildPodTemplate(opts)
+	b.withMunged(spec.Auth, &podTemplate)
+
+	return podTemplate, nil
+}
+
+// controllerAntiAffinity prefers spreading the slurmctld replicas across
+// Kubernetes nodes, so a node failure does not take down the backups too.
+func controllerAntiAffinity(controller *slinkyv1beta1.Controller) *corev1.Affinity {
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: labels.NewBuilder().WithControllerSelectorLabels(controller).Build(),
+						},
+						TopologyKey: corev1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
 }
 
 func controllerVolumes(controller *slinkyv1beta1.Controller, extra []string) []corev1.Volume {
+	sources := []corev1.VolumeProjection{
+		{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: controller.ConfigKey().Name,
+				},
+			},
+		},
+	}
+	sources = append(sources, slurmAuthVolumeProjections(controller.Spec.Auth, controller.AuthSlurmRef(), controller, slinkyv1beta1.KeyRotationComponentController)...)
+	sources = append(sources, corev1.VolumeProjection{
+		Secret: &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: controller.AuthJwtHs256Ref().Name,
+			},
+			Items: []corev1.KeyToPath{
+				{Key: controller.AuthJwtHs256Ref().Key, Path: JwtHs256KeyFile},
+			},
+		},
+	})
 	out := []corev1.Volume{
 		{
 			Name: slurmEtcVolume,
 			VolumeSource: corev1.VolumeSource{
 				Projected: &corev1.ProjectedVolumeSource{
 					DefaultMode: ptr.To[int32](0o610),
-					Sources: []corev1.VolumeProjection{
-						{
-							ConfigMap: &corev1.ConfigMapProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: controller.ConfigKey().Name,
-								},
-							},
-						},
-						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: controller.AuthSlurmRef().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: controller.AuthSlurmRef().Key, Path: slurmKeyFile},
-								},
-							},
-						},
-						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: controller.AuthJwtHs256Ref().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: controller.AuthJwtHs256Ref().Key, Path: JwtHs256KeyFile},
-								},
-							},
-						},
-					},
+					Sources:     sources,
 				},
 			},
 		},
@@ -212,6 +234,9 @@ func controllerVolumes(controller *slinkyv1beta1.Controller, extra []string) []c
 			},
 		},
 	}
+	if controller.Spec.JwtKeys != nil {
+		out[0].Projected.Sources = append(out[0].Projected.Sources, jwksVolumeProjection(controller.JwksKey().Name))
+	}
 	for _, name := range extra {
 		volumeProjection := corev1.VolumeProjection{
 			ConfigMap: &corev1.ConfigMapProjection{
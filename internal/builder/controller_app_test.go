@@ -79,6 +79,26 @@ func TestBuilder_BuildController(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with backup controllers",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm",
+					},
+					Spec: slinkyv1beta1.ControllerSpec{
+						Replicas: ptr.To[int32](3),
+						Persistence: slinkyv1beta1.ControllerPersistence{
+							Enabled:       true,
+							ExistingClaim: "pvc",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -119,6 +139,14 @@ func TestBuilder_BuildController(t *testing.T) {
 			case got.Spec.Template.Spec.Containers[0].Ports[0].ContainerPort != SlurmctldPort:
 				t.Errorf("Template.Spec.Containers[0].Ports[0].ContainerPort = %v , want = %v",
 					got.Spec.Template.Spec.Containers[0].Ports[0].Name, SlurmctldPort)
+
+			case ptr.Deref(got.Spec.Replicas, 0) != tt.args.controller.Replicas():
+				t.Errorf("Spec.Replicas = %v , want = %v",
+					ptr.Deref(got.Spec.Replicas, 0), tt.args.controller.Replicas())
+
+			case tt.args.controller.IsHighlyAvailable() != (got.Spec.Template.Spec.Affinity != nil):
+				t.Errorf("Template.Spec.Affinity = %v , IsHighlyAvailable = %v",
+					got.Spec.Template.Spec.Affinity, tt.args.controller.IsHighlyAvailable())
 			}
 		})
 	}
@@ -6,8 +6,10 @@ package builder
 import (
 	"context"
 	"fmt"
+	"maps"
 	"path"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -22,7 +24,7 @@ import (
 )
 
 const (
-	slurmConfFile  = "slurm.conf"
+	SlurmConfFile  = "slurm.conf"
 	cgroupConfFile = "cgroup.conf"
 )
 
@@ -57,11 +59,15 @@ func (b *Builder) BuildControllerConfig(controller *slinkyv1beta1.Controller) (*
 	}
 	cgroupEnabled := true
 	hasCgroupConfFile := false
+	hasTopologyConfFile := false
 	for _, configMap := range configFilesList.Items {
 		if contents, ok := configMap.Data[cgroupConfFile]; ok {
 			hasCgroupConfFile = true
 			cgroupEnabled = isCgroupEnabled(contents)
 		}
+		if _, ok := configMap.Data[topologyConfFile]; ok {
+			hasTopologyConfFile = true
+		}
 	}
 
 	metricsEnabled := controller.Spec.Metrics.Enabled
@@ -130,7 +136,7 @@ func (b *Builder) BuildControllerConfig(controller *slinkyv1beta1.Controller) (*
 		Key:      controller.ConfigKey(),
 		Metadata: controller.Spec.Template.PodMetadata,
 		Data: map[string]string{
-			slurmConfFile: buildSlurmConf(
+			SlurmConfFile: buildSlurmConf(
 				controller, accounting, nodesetList,
 				prologScripts, epilogScripts,
 				prologSlurmctldScripts, epilogSlurmctldScripts,
@@ -140,12 +146,56 @@ func (b *Builder) BuildControllerConfig(controller *slinkyv1beta1.Controller) (*
 	if !hasCgroupConfFile {
 		opts.Data[cgroupConfFile] = buildCgroupConf()
 	}
+	if controller.IsTopologyEnabled() && !hasTopologyConfFile {
+		nodes, err := b.getTopologyNodes(ctx, nodesetList)
+		if err != nil {
+			return nil, err
+		}
+		opts.Data[topologyConfFile] = buildTopologyConf(controller.Spec.Topology, nodes)
+	}
 
 	opts.Metadata.Labels = structutils.MergeMaps(opts.Metadata.Labels, labels.NewBuilder().WithControllerLabels(controller).Build())
 
 	return b.BuildConfigMap(opts, controller)
 }
 
+// slurmctldHosts returns the SlurmctldHost values, ordered primary to backups.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SlurmctldHost
+func slurmctldHosts(controller *slinkyv1beta1.Controller) []string {
+	if !controller.IsHighlyAvailable() {
+		return []string{fmt.Sprintf("%s(%s)", controller.PrimaryName(), controller.ServiceFQDNShort())}
+	}
+	hosts := make([]string, 0, controller.Replicas())
+	for i := range controller.Replicas() {
+		hosts = append(hosts, fmt.Sprintf("%s(%s)", controller.HostName(i), controller.HostServiceFQDNShort(i)))
+	}
+	return hosts
+}
+
+type slurmConfigOverride struct {
+	key   string
+	value string
+}
+
+// slurmConfigOverrides returns the Controller SlurmConfig parameters, by
+// lowercase name, which are merged over the operator defaults. Reserved
+// parameters are ignored, and of the names which only differ by case, the
+// first in sorted order is used.
+func slurmConfigOverrides(controller *slinkyv1beta1.Controller) map[string]slurmConfigOverride {
+	overrides := make(map[string]slurmConfigOverride, len(controller.Spec.SlurmConfig))
+	for _, key := range controller.SlurmConfigKeys() {
+		lower := strings.ToLower(key)
+		if _, ok := overrides[lower]; ok || slinkyv1beta1.IsReservedSlurmConfigKey(key) {
+			continue
+		}
+		overrides[lower] = slurmConfigOverride{
+			key:   key,
+			value: controller.Spec.SlurmConfig[key].String(),
+		}
+	}
+	return overrides
+}
+
 // https://slurm.schedmd.com/slurm.conf.html
 func buildSlurmConf(
 	controller *slinkyv1beta1.Controller,
@@ -155,66 +205,77 @@ func buildSlurmConf(
 	prologSlurmctldScripts, epilogSlurmctldScripts []string,
 	cgroupEnabled, metricsEnabled bool,
 ) string {
-	controllerHost := fmt.Sprintf("%s(%s)", controller.PrimaryName(), controller.ServiceFQDNShort())
-
 	conf := config.NewBuilder()
+	overrides := slurmConfigOverrides(controller)
+	addProperty := func(key string, val any) {
+		if override, ok := overrides[strings.ToLower(key)]; ok {
+			val = override.value
+			delete(overrides, strings.ToLower(key))
+		}
+		conf.AddProperty(config.NewProperty(key, val))
+	}
 
 	conf.AddProperty(config.NewPropertyRaw("#"))
 	conf.AddProperty(config.NewPropertyRaw("### GENERAL ###"))
 	conf.AddProperty(config.NewProperty("ClusterName", controller.ClusterName()))
-	conf.AddProperty(config.NewProperty("SlurmUser", slurmUser))
-	conf.AddProperty(config.NewProperty("SlurmctldHost", controllerHost))
+	addProperty("SlurmUser", slurmUser)
+	for _, controllerHost := range slurmctldHosts(controller) {
+		conf.AddProperty(config.NewProperty("SlurmctldHost", controllerHost))
+	}
 	conf.AddProperty(config.NewProperty("SlurmctldPort", SlurmctldPort))
 	conf.AddProperty(config.NewProperty("StateSaveLocation", clusterSpoolDir(controller.ClusterName())))
-	conf.AddProperty(config.NewProperty("SlurmdUser", slurmdUser))
+	addProperty("SlurmdUser", slurmdUser)
 	conf.AddProperty(config.NewProperty("SlurmdPort", SlurmdPort))
-	conf.AddProperty(config.NewProperty("SlurmdSpoolDir", slurmdSpoolDir))
-	conf.AddProperty(config.NewProperty("ReturnToService", 2))
-	conf.AddProperty(config.NewProperty("MaxNodeCount", 1024))
-	conf.AddProperty(config.NewProperty("GresTypes", "gpu"))
+	addProperty("SlurmdSpoolDir", slurmdSpoolDir)
+	addProperty("ReturnToService", 2)
+	addProperty("MaxNodeCount", 1024)
+	addProperty("GresTypes", "gpu")
 
 	conf.AddProperty(config.NewPropertyRaw("#"))
 	conf.AddProperty(config.NewPropertyRaw("### LOGGING ###"))
-	conf.AddProperty(config.NewProperty("SlurmctldLogFile", slurmctldLogFilePath))
-	conf.AddProperty(config.NewProperty("SlurmSchedLogFile", slurmctldLogFilePath))
-	conf.AddProperty(config.NewProperty("SlurmdLogFile", slurmdLogFilePath))
-	conf.AddProperty(config.NewProperty("LogTimeFormat", logTimeFormat))
+	addProperty("SlurmctldLogFile", slurmctldLogFilePath)
+	addProperty("SlurmSchedLogFile", slurmctldLogFilePath)
+	addProperty("SlurmdLogFile", slurmdLogFilePath)
+	addProperty("LogTimeFormat", logTimeFormat)
 
 	conf.AddProperty(config.NewPropertyRaw("#"))
 	conf.AddProperty(config.NewPropertyRaw("### PLUGINS & PARAMETERS ###"))
-	conf.AddProperty(config.NewProperty("AuthType", authType))
-	conf.AddProperty(config.NewProperty("CredType", credType))
-	conf.AddProperty(config.NewProperty("AuthAltTypes", authAltTypes))
-	conf.AddProperty(config.NewProperty("AuthAltParameters", authAltParameters))
-	conf.AddProperty(config.NewProperty("AuthInfo", authInfo))
-	conf.AddProperty(config.NewProperty("CommunicationParameters", "block_null_hash"))
-	conf.AddProperty(config.NewProperty("SelectTypeParameters", "CR_Core_Memory"))
+	conf.AddProperty(config.NewProperty("AuthType", slurmAuthType(controller.Spec.Auth)))
+	addProperty("CredType", slurmCredType(controller.Spec.Auth))
+	addProperty("AuthAltTypes", authAltTypes)
+	addProperty("AuthAltParameters", jwtAuthAltParameters(controller.Spec.JwtKeys != nil))
+	addProperty("AuthInfo", slurmAuthInfo(controller.Spec.Auth))
+	addProperty("CommunicationParameters", "block_null_hash")
+	addProperty("SelectTypeParameters", "CR_Core_Memory")
+	if controller.IsTopologyEnabled() {
+		addProperty("TopologyPlugin", controller.Spec.Topology.TopologyPlugin())
+	}
 	if cgroupEnabled {
-		conf.AddProperty(config.NewProperty("SlurmctldParameters", "enable_configless,enable_stepmgr"))
-		conf.AddProperty(config.NewProperty("ProctrackType", "proctrack/cgroup"))
-		conf.AddProperty(config.NewProperty("PrologFlags", "Contain"))
-		conf.AddProperty(config.NewProperty("TaskPlugin", "task/cgroup,task/affinity"))
+		addProperty("SlurmctldParameters", "enable_configless,enable_stepmgr")
+		addProperty("ProctrackType", "proctrack/cgroup")
+		addProperty("PrologFlags", "Contain")
+		addProperty("TaskPlugin", "task/cgroup,task/affinity")
 	} else {
-		conf.AddProperty(config.NewProperty("SlurmctldParameters", "enable_configless"))
-		conf.AddProperty(config.NewProperty("TaskPlugin", "task/affinity"))
+		addProperty("SlurmctldParameters", "enable_configless")
+		addProperty("TaskPlugin", "task/affinity")
 	}
 	if metricsEnabled {
-		conf.AddProperty(config.NewProperty("MetricsType", "metrics/openmetrics"))
+		addProperty("MetricsType", "metrics/openmetrics")
 	}
 
 	conf.AddProperty(config.NewPropertyRaw("#"))
 	conf.AddProperty(config.NewPropertyRaw("### ACCOUNTING ###"))
 	if accounting != nil {
-		conf.AddProperty(config.NewProperty("AccountingStorageType", "accounting_storage/slurmdbd"))
-		conf.AddProperty(config.NewProperty("AccountingStorageHost", accounting.ServiceKey().Name))
-		conf.AddProperty(config.NewProperty("AccountingStoragePort", SlurmdbdPort))
-		conf.AddProperty(config.NewProperty("AccountingStorageTRES", "gres/gpu"))
+		addProperty("AccountingStorageType", "accounting_storage/slurmdbd")
+		addProperty("AccountingStorageHost", accounting.ServiceKey().Name)
+		addProperty("AccountingStoragePort", SlurmdbdPort)
+		addProperty("AccountingStorageTRES", "gres/gpu")
 		if cgroupEnabled {
-			conf.AddProperty(config.NewProperty("JobAcctGatherType", "jobacct_gather/cgroup"))
+			addProperty("JobAcctGatherType", "jobacct_gather/cgroup")
 		}
 	} else {
-		conf.AddProperty(config.NewProperty("AccountingStorageType", "accounting_storage/none"))
-		conf.AddProperty(config.NewProperty("JobAcctGatherType", "jobacct_gather/none"))
+		addProperty("AccountingStorageType", "accounting_storage/none")
+		addProperty("JobAcctGatherType", "jobacct_gather/none")
 	}
 
 	if len(prologSlurmctldScripts) > 0 || len(epilogSlurmctldScripts) > 0 {
@@ -241,16 +302,22 @@ func buildSlurmConf(
 		conf.AddProperty(config.NewProperty("Epilog", filename))
 	}
 
-	if len(nodesetList.Items) > 0 {
+	if len(overrides) > 0 {
+		conf.AddProperty(config.NewPropertyRaw("#"))
+		conf.AddProperty(config.NewPropertyRaw("### SLURM CONFIG ###"))
+	}
+	for _, override := range slices.SortedFunc(maps.Values(overrides), func(a, b slurmConfigOverride) int {
+		return strings.Compare(a.key, b.key)
+	}) {
+		conf.AddProperty(config.NewProperty(override.key, override.value))
+	}
+
+	if len(nodesetList.Items) > 0 || len(controller.Spec.Partitions) > 0 {
 		conf.AddProperty(config.NewPropertyRaw("#"))
 		conf.AddProperty(config.NewPropertyRaw("### COMPUTE & PARTITION ###"))
 	}
 	for _, nodeset := range nodesetList.Items {
-		name := nodeset.Name
-		template := nodeset.Spec.Template.PodSpecWrapper
-		if template.Hostname != "" {
-			name = strings.Trim(template.Hostname, "-")
-		}
+		name := nodeset.SlurmName()
 		nodesetLine := []string{
 			fmt.Sprintf("NodeSet=%v", name),
 			fmt.Sprintf("Feature=%v", name),
@@ -269,6 +336,17 @@ func buildSlurmConf(
 		partitionLineRendered := strings.Join(partitionLine, " ")
 		conf.AddProperty(config.NewPropertyRaw(partitionLineRendered))
 	}
+	for _, partition := range controller.Spec.Partitions {
+		partitionLine := []string{
+			fmt.Sprintf("PartitionName=%v", partition.Name),
+		}
+		if nodes := partitionNodeSets(&partition, nodesetList); len(nodes) > 0 {
+			partitionLine = append(partitionLine, fmt.Sprintf("Nodes=%v", strings.Join(nodes, ",")))
+		}
+		partitionLine = append(partitionLine, partition.Config)
+		partitionLineRendered := strings.Join(partitionLine, " ")
+		conf.AddProperty(config.NewPropertyRaw(partitionLineRendered))
+	}
 
 	extraConf := controller.Spec.ExtraConf
 	conf.AddProperty(config.NewPropertyRaw("#"))
@@ -278,6 +356,19 @@ func buildSlurmConf(
 	return conf.Build()
 }
 
+// partitionNodeSets returns the sorted Slurm NodeSet names in the partition.
+func partitionNodeSets(partition *slinkyv1beta1.ControllerPartition, nodesetList *slinkyv1beta1.NodeSetList) []string {
+	names := []string{}
+	for i := range nodesetList.Items {
+		nodeset := &nodesetList.Items[i]
+		if partition.SelectsNodeSet(nodeset) {
+			names = append(names, nodeset.SlurmName())
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
 // https://slurm.schedmd.com/cgroup.conf.html
 func buildCgroupConf() string {
 	conf := config.NewBuilder()
@@ -309,7 +400,7 @@ func (b *Builder) BuildControllerConfigExternal(controller *slinkyv1beta1.Contro
 		Key:      controller.ConfigKey(),
 		Metadata: controller.Spec.Template.PodMetadata,
 		Data: map[string]string{
-			slurmConfFile: buildSlurmConfMinimal(controller, accounting),
+			SlurmConfFile: buildSlurmConfMinimal(controller, accounting),
 		},
 	}
 
@@ -334,9 +425,12 @@ func buildSlurmConfMinimal(
 
 	conf.AddProperty(config.NewPropertyRaw("#"))
 	conf.AddProperty(config.NewPropertyRaw("### PLUGINS & PARAMETERS ###"))
-	conf.AddProperty(config.NewProperty("AuthType", authType))
-	conf.AddProperty(config.NewProperty("CredType", credType))
+	conf.AddProperty(config.NewProperty("AuthType", slurmAuthType(controller.Spec.Auth)))
+	conf.AddProperty(config.NewProperty("CredType", slurmCredType(controller.Spec.Auth)))
 	conf.AddProperty(config.NewProperty("AuthAltTypes", authAltTypes))
+	if controller.IsMungeAuth() {
+		conf.AddProperty(config.NewProperty("AuthInfo", slurmAuthInfo(controller.Spec.Auth)))
+	}
 
 	conf.AddProperty(config.NewPropertyRaw("#"))
 	conf.AddProperty(config.NewPropertyRaw("### ACCOUNTING ###"))
@@ -4,12 +4,14 @@
 package builder
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -157,8 +159,8 @@ func TestBuilder_BuildControllerConfig(t *testing.T) {
 			case err != nil:
 				return
 
-			case got.Data[slurmConfFile] == "" && got.BinaryData[slurmConfFile] == nil:
-				t.Errorf("got.Data[%s] = %v", slurmConfFile, got.Data[slurmConfFile])
+			case got.Data[SlurmConfFile] == "" && got.BinaryData[SlurmConfFile] == nil:
+				t.Errorf("got.Data[%s] = %v", SlurmConfFile, got.Data[SlurmConfFile])
 			}
 		})
 	}
@@ -214,3 +216,261 @@ ignoresystemd=yes`,
 		})
 	}
 }
+
+func Test_slurmctldHosts(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		want       []string
+	}{
+		{
+			name: "default",
+			controller: &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "slurm",
+					Name:      "slurm",
+				},
+			},
+			want: []string{
+				"slurm-controller-0(slurm-controller.slurm)",
+			},
+		},
+		{
+			name: "with backup controllers",
+			controller: &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "slurm",
+					Name:      "slurm",
+				},
+				Spec: slinkyv1beta1.ControllerSpec{
+					Replicas: ptr.To[int32](3),
+				},
+			},
+			want: []string{
+				"slurm-controller-0(slurm-controller-0.slurm)",
+				"slurm-controller-1(slurm-controller-1.slurm)",
+				"slurm-controller-2(slurm-controller-2.slurm)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slurmctldHosts(tt.controller); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("slurmctldHosts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_buildSlurmConf_slurmConfig(t *testing.T) {
+	newController := func(slurmConfig map[string]slinkyv1beta1.SlurmConfigValue, extraConf string) *slinkyv1beta1.Controller {
+		return &slinkyv1beta1.Controller{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "slurm",
+				Name:      "slurm",
+			},
+			Spec: slinkyv1beta1.ControllerSpec{
+				SlurmConfig: slurmConfig,
+				ExtraConf:   extraConf,
+			},
+		}
+	}
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		want       []string
+		wantNot    []string
+	}{
+		{
+			name:       "defaults",
+			controller: newController(nil, ""),
+			want: []string{
+				"MaxNodeCount=1024\n",
+				"SelectTypeParameters=CR_Core_Memory\n",
+			},
+			wantNot: []string{
+				"### SLURM CONFIG ###",
+			},
+		},
+		{
+			name: "override defaults",
+			controller: newController(map[string]slinkyv1beta1.SlurmConfigValue{
+				"maxnodecount":         {"2048"},
+				"SelectTypeParameters": {"CR_Core", "CR_ONE_TASK_PER_CORE"},
+			}, ""),
+			want: []string{
+				"MaxNodeCount=2048\n",
+				"SelectTypeParameters=CR_Core,CR_ONE_TASK_PER_CORE\n",
+			},
+			wantNot: []string{
+				"MaxNodeCount=1024",
+				"maxnodecount=",
+				"### SLURM CONFIG ###",
+			},
+		},
+		{
+			name: "add parameters",
+			controller: newController(map[string]slinkyv1beta1.SlurmConfigValue{
+				"MinJobAge":       {"2"},
+				"DebugFlags":      {"Backfill", "Gres"},
+				"ReturnToService": {"1"},
+			}, ""),
+			want: []string{
+				"ReturnToService=1\n",
+				"### SLURM CONFIG ###\nDebugFlags=Backfill,Gres\nMinJobAge=2\n",
+			},
+			wantNot: []string{
+				"ReturnToService=2",
+			},
+		},
+		{
+			name: "reserved parameters",
+			controller: newController(map[string]slinkyv1beta1.SlurmConfigValue{
+				"AuthType":          {"auth/munge"},
+				"StateSaveLocation": {"/tmp"},
+			}, ""),
+			want: []string{
+				"AuthType=auth/slurm\n",
+			},
+			wantNot: []string{
+				"auth/munge",
+				"StateSaveLocation=/tmp",
+			},
+		},
+		{
+			name: "auth/munge",
+			controller: func() *slinkyv1beta1.Controller {
+				controller := newController(nil, "")
+				controller.Spec.Auth = &slinkyv1beta1.SlurmAuth{Type: slinkyv1beta1.SlurmAuthTypeMunge}
+				return controller
+			}(),
+			want: []string{
+				"AuthType=auth/munge\n",
+				"CredType=cred/munge\n",
+				"AuthInfo=socket=/run/munge/munge.socket.2\n",
+			},
+			wantNot: []string{
+				"auth/slurm",
+				"use_client_ids",
+			},
+		},
+		{
+			name: "extraConf after slurmConfig",
+			controller: newController(map[string]slinkyv1beta1.SlurmConfigValue{
+				"MinJobAge": {"2"},
+			}, "MinJobAge=4"),
+			want: []string{
+				"MinJobAge=2\n#\n### EXTRA CONFIG ###\nMinJobAge=4\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildSlurmConf(tt.controller, nil, &slinkyv1beta1.NodeSetList{}, nil, nil, nil, nil, true, false)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("buildSlurmConf() = %v, want %q", got, want)
+				}
+			}
+			for _, wantNot := range tt.wantNot {
+				if strings.Contains(got, wantNot) {
+					t.Errorf("buildSlurmConf() = %v, do not want %q", got, wantNot)
+				}
+			}
+		})
+	}
+}
+
+func Test_buildSlurmConf_partitions(t *testing.T) {
+	newNodeSet := func(name string, labels map[string]string, partitionEnabled bool) slinkyv1beta1.NodeSet {
+		return slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "slurm",
+				Name:      name,
+				Labels:    labels,
+			},
+			Spec: slinkyv1beta1.NodeSetSpec{
+				Partition: slinkyv1beta1.NodeSetPartition{
+					Enabled: partitionEnabled,
+				},
+			},
+		}
+	}
+	nodesetList := &slinkyv1beta1.NodeSetList{
+		Items: []slinkyv1beta1.NodeSet{
+			newNodeSet("gpu-a", map[string]string{"tier": "gpu"}, true),
+			newNodeSet("gpu-b", map[string]string{"tier": "gpu"}, false),
+			newNodeSet("cpu", nil, true),
+		},
+	}
+	tests := []struct {
+		name       string
+		partitions []slinkyv1beta1.ControllerPartition
+		want       []string
+		wantNot    []string
+	}{
+		{
+			name: "NodeSet partitions",
+			want: []string{
+				"PartitionName=gpu-a Nodes=gpu-a \n",
+				"PartitionName=cpu Nodes=cpu \n",
+			},
+			wantNot: []string{
+				"PartitionName=gpu-b",
+			},
+		},
+		{
+			name: "Partitions spanning NodeSets",
+			partitions: []slinkyv1beta1.ControllerPartition{
+				{
+					Name: "gpu",
+					NodeSetSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"tier": "gpu"},
+					},
+					Config: "PriorityTier=10",
+				},
+				{
+					Name:     "debug",
+					NodeSets: []string{"cpu", "gpu-a"},
+					NodeSetSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"tier": "gpu"},
+					},
+				},
+				{
+					Name: "empty",
+				},
+			},
+			want: []string{
+				"PartitionName=gpu-a Nodes=gpu-a \n",
+				"PartitionName=cpu Nodes=cpu \n",
+				"PartitionName=gpu Nodes=gpu-a,gpu-b PriorityTier=10\n",
+				"PartitionName=debug Nodes=cpu,gpu-a,gpu-b \n",
+				"PartitionName=empty \n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "slurm",
+					Name:      "slurm",
+				},
+				Spec: slinkyv1beta1.ControllerSpec{
+					Partitions: tt.partitions,
+				},
+			}
+			got := buildSlurmConf(controller, nil, nodesetList, nil, nil, nil, nil, true, false)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("buildSlurmConf() = %v, want %q", got, want)
+				}
+			}
+			for _, wantNot := range tt.wantNot {
+				if strings.Contains(got, wantNot) {
+					t.Errorf("buildSlurmConf() = %v, do not want %q", got, wantNot)
+				}
+			}
+		})
+	}
+}
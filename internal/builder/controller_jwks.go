@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+)
+
+// BuildControllerJwks returns the Secret of the Slurm `jwks` file, with the
+// public keys of the JWT keys of the Controller.
+func (b *Builder) BuildControllerJwks(controller *slinkyv1beta1.Controller) (*corev1.Secret, error) {
+	keys, err := b.getPublishedJwtKeys(context.TODO(), controller)
+	if err != nil {
+		return nil, err
+	}
+	jwks, err := slurmjwt.NewJWKS(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := SecretOpts{
+		Key:      controller.JwksKey(),
+		Metadata: controller.Spec.Template.PodMetadata,
+		Data: map[string][]byte{
+			JwksFile: jwks,
+		},
+	}
+
+	opts.Metadata.Labels = structutils.MergeMaps(opts.Metadata.Labels, labels.NewBuilder().WithControllerLabels(controller).Build())
+
+	return b.BuildSecret(opts, controller)
+}
+
+// getPublishedJwtKeys returns the JWT keys of the Controller which are
+// published in the `jwks` file; the HS256 key is not.
+func (b *Builder) getPublishedJwtKeys(ctx context.Context, controller *slinkyv1beta1.Controller) ([]*slurmjwt.SigningKey, error) {
+	if controller.Spec.JwtKeys == nil {
+		return nil, nil
+	}
+	keys, err := b.refResolver.GetJwtKeys(ctx, controller)
+	if err != nil {
+		return nil, err
+	}
+	keys = slices.DeleteFunc(keys, func(key *slurmjwt.SigningKey) bool {
+		return key.KeyID == ""
+	})
+	return keys, nil
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newJwtKeysController(name string, accounting *slinkyv1beta1.Accounting, keyIDs ...string) (*slinkyv1beta1.Controller, []client.Object) {
+	jwtHs256KeyRef := testutils.NewJwtHs256KeyRef(name)
+	controller := testutils.NewController(name, testutils.NewSlurmKeyRef(name), jwtHs256KeyRef, accounting)
+	objs := []client.Object{
+		testutils.NewJwtHs256KeySecret(jwtHs256KeyRef),
+	}
+	if len(keyIDs) == 0 {
+		return controller, objs
+	}
+	controller.Spec.JwtKeys = &slinkyv1beta1.JwtKeys{
+		SigningKeyID: keyIDs[0],
+	}
+	for _, keyID := range keyIDs {
+		ref := testutils.NewJwtKeyRef(name + "-" + keyID)
+		controller.Spec.JwtKeys.Keys = append(controller.Spec.JwtKeys.Keys, slinkyv1beta1.JwtKey{
+			KeyID:         keyID,
+			Algorithm:     slinkyv1beta1.JwtKeyAlgorithmRS256,
+			PrivateKeyRef: ref,
+		})
+		objs = append(objs, testutils.NewJwtKeySecret(ref))
+	}
+	return controller, objs
+}
+
+func TestBuilder_BuildControllerJwks(t *testing.T) {
+	controller, objs := newJwtKeysController("slurm", nil, "a", "b")
+	missing, _ := newJwtKeysController("missing", nil, "a")
+	tests := []struct {
+		name       string
+		client     client.Client
+		controller *slinkyv1beta1.Controller
+		wantKeyIDs []string
+		wantErr    bool
+	}{
+		{
+			name:       "With keys",
+			client:     fake.NewClientBuilder().WithObjects(objs...).Build(),
+			controller: controller,
+			wantKeyIDs: []string{"a", "b"},
+		},
+		{
+			name:       "Missing key Secret",
+			client:     fake.NewFakeClient(),
+			controller: missing,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.client)
+			got, err := b.BuildControllerJwks(tt.controller)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Builder.BuildControllerJwks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Name != tt.controller.JwksKey().Name {
+				t.Errorf("got.Name = %v, want %v", got.Name, tt.controller.JwksKey().Name)
+			}
+			jwks := slurmjwt.JWKS{}
+			if err := json.Unmarshal(got.Data[JwksFile], &jwks); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			gotKeyIDs := []string{}
+			for _, key := range jwks.Keys {
+				gotKeyIDs = append(gotKeyIDs, key.KeyID)
+			}
+			if !slices.Equal(gotKeyIDs, tt.wantKeyIDs) {
+				t.Errorf("jwks key IDs = %v, want %v", gotKeyIDs, tt.wantKeyIDs)
+			}
+		})
+	}
+}
@@ -4,6 +4,9 @@
 package builder
 
 import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -35,3 +38,38 @@ func (b *Builder) BuildControllerService(controller *slinkyv1beta1.Controller) (
 
 	return b.BuildService(opts, controller)
 }
+
+// BuildControllerHostService builds the Service of a single slurmctld replica,
+// which gives each SlurmctldHost a stable address.
+func (b *Builder) BuildControllerHostService(controller *slinkyv1beta1.Controller, ordinal int32) (*corev1.Service, error) {
+	spec := controller.Spec.Service
+	opts := ServiceOpts{
+		Key:      controller.HostServiceKey(ordinal),
+		Metadata: controller.Spec.Service.Metadata,
+		ServiceSpec: corev1.ServiceSpec{
+			PublishNotReadyAddresses: true,
+		},
+		Selector: labels.NewBuilder().
+			WithControllerSelectorLabels(controller).
+			WithLabels(map[string]string{
+				appsv1.StatefulSetPodNameLabel: controller.HostName(ordinal),
+			}).
+			Build(),
+	}
+
+	opts.Metadata.Labels = structutils.MergeMaps(opts.Metadata.Labels, labels.NewBuilder().WithControllerLabels(controller).Build())
+
+	port := corev1.ServicePort{
+		Name:       labels.ControllerApp,
+		Protocol:   corev1.ProtocolTCP,
+		Port:       defaultPort(int32(spec.Port), SlurmctldPort),
+		TargetPort: intstr.FromString(labels.ControllerApp),
+	}
+	opts.Ports = append(opts.Ports, port)
+
+	out, err := b.BuildService(opts, controller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build host service (%d): %w", ordinal, err)
+	}
+	return out, nil
+}
@@ -7,8 +7,10 @@ import (
 	"testing"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"k8s.io/utils/set"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -72,3 +74,36 @@ func TestBuilder_BuildControllerService(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilder_BuildControllerHostService(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			Replicas: ptr.To[int32](2),
+		},
+	}
+	b := New(fake.NewFakeClient())
+	got, err := b.BuildControllerHostService(controller, 1)
+	if err != nil {
+		t.Fatalf("Builder.BuildControllerHostService() error = %v", err)
+	}
+	sts, err := b.BuildController(controller)
+	if err != nil {
+		t.Fatalf("Builder.BuildController() error = %v", err)
+	}
+	switch {
+	case got.Name != "slurm-controller-1":
+		t.Errorf("Name = %v , want = %v", got.Name, "slurm-controller-1")
+
+	case got.Spec.Selector[appsv1.StatefulSetPodNameLabel] != "slurm-controller-1":
+		t.Errorf("Selector = %v , want pod name = %v", got.Spec.Selector, "slurm-controller-1")
+
+	case !set.KeySet(sts.Spec.Template.Labels).HasAll(set.KeySet(sts.Spec.Selector.MatchLabels).UnsortedList()...):
+		t.Errorf("Labels = %v , Selector = %v", sts.Spec.Template.Labels, got.Spec.Selector)
+
+	case !got.Spec.PublishNotReadyAddresses:
+		t.Errorf("PublishNotReadyAddresses = %v , want = %v", got.Spec.PublishNotReadyAddresses, true)
+	}
+}
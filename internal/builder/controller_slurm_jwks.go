@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+)
+
+const (
+	slurmJwksFile = "slurm.jwks"
+
+	slurmJwksStaging   = "staging.jwks"
+	slurmJwksSwitching = "switching.jwks"
+	slurmJwksRetiring  = "retiring.jwks"
+)
+
+// slurmJwk is an `auth/slurm` key in the `slurm.jwks` file.
+// Ref: https://slurm.schedmd.com/authentication.html#slurm_jwks
+type slurmJwk struct {
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Key       string `json:"k"`
+	Use       string `json:"use,omitempty"`
+}
+
+// slurmJwks is the `slurm.jwks` file.
+type slurmJwks struct {
+	Keys []slurmJwk `json:"keys"`
+}
+
+// SlurmJwksFileKey returns the key of the `slurm.jwks` file which a component
+// mounts in the phase of a rotation of the `auth/slurm` key, which is empty
+// when it mounts the `slurm.key` file.
+func SlurmJwksFileKey(phase slinkyv1beta1.KeyRotationPhase) string {
+	switch phase {
+	case slinkyv1beta1.KeyRotationPhaseStaging,
+		slinkyv1beta1.KeyRotationPhaseRollingBack,
+		slinkyv1beta1.KeyRotationPhaseRolledBack:
+		return slurmJwksStaging
+	case slinkyv1beta1.KeyRotationPhaseSwitching:
+		return slurmJwksSwitching
+	case slinkyv1beta1.KeyRotationPhaseRetiring,
+		slinkyv1beta1.KeyRotationPhaseComplete:
+		return slurmJwksRetiring
+	default:
+		return ""
+	}
+}
+
+// SlurmKeyID returns the `kid` of an `auth/slurm` key, derived from the key.
+func SlurmKeyID(key []byte) string {
+	return crypto.CheckSum(key)[:16]
+}
+
+// BuildControllerSlurmJwks returns the Secret of the `slurm.jwks` files of a
+// rotation of the `auth/slurm` key, one for each step of the rotation: the
+// current key with the new key, the new key with the current key, and only the
+// new key. The first key is the default, which signs new credentials.
+func (b *Builder) BuildControllerSlurmJwks(controller *slinkyv1beta1.Controller) (*corev1.Secret, error) {
+	opts := SecretOpts{
+		Key:      controller.SlurmJwksKey(),
+		Metadata: controller.Spec.Template.PodMetadata,
+		Data:     map[string][]byte{},
+	}
+	opts.Metadata.Labels = structutils.MergeMaps(opts.Metadata.Labels, labels.NewBuilder().WithControllerLabels(controller).Build())
+
+	if controller.Spec.SlurmKeyRotation != nil {
+		ctx := context.TODO()
+		currentKey, err := b.refResolver.GetSecretKeyRef(ctx, controller.AuthSlurmRef(), controller.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current auth/slurm key: %w", err)
+		}
+		newKey, err := b.refResolver.GetSecretKeyRef(ctx, controller.AuthSlurmNewRef(), controller.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get new auth/slurm key: %w", err)
+		}
+		files := map[string][][]byte{
+			slurmJwksStaging:   {currentKey, newKey},
+			slurmJwksSwitching: {newKey, currentKey},
+			slurmJwksRetiring:  {newKey},
+		}
+		for name, keys := range files {
+			jwks, err := newSlurmJwks(keys)
+			if err != nil {
+				return nil, err
+			}
+			opts.Data[name] = jwks
+		}
+	}
+
+	return b.BuildSecret(opts, controller)
+}
+
+// newSlurmJwks returns the `slurm.jwks` file of the keys, the first of which
+// is the default.
+func newSlurmJwks(keys [][]byte) ([]byte, error) {
+	jwks := slurmJwks{
+		Keys: make([]slurmJwk, 0, len(keys)),
+	}
+	for i, key := range keys {
+		jwk := slurmJwk{
+			KeyType:   "oct",
+			Algorithm: "HS256",
+			KeyID:     SlurmKeyID(key),
+			Key:       base64.RawURLEncoding.EncodeToString(key),
+		}
+		if i == 0 {
+			jwk.Use = "default"
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return json.Marshal(jwks)
+}
+
+// slurmKeyVolumeProjection projects the `slurm.key` file of the ref, or the
+// `slurm.jwks` file when the component is in a rotation of the key of the
+// Controller.
+func slurmKeyVolumeProjection(ref *corev1.SecretKeySelector, controller *slinkyv1beta1.Controller, component slinkyv1beta1.KeyRotationComponent) corev1.VolumeProjection {
+	if fileKey := slurmJwksFileKeyOf(controller, component); fileKey != "" {
+		return corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: controller.SlurmJwksKey().Name,
+				},
+				Items: []corev1.KeyToPath{
+					{Key: fileKey, Path: slurmJwksFile},
+				},
+			},
+		}
+	}
+	return corev1.VolumeProjection{
+		Secret: &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: ref.Name,
+			},
+			Items: []corev1.KeyToPath{
+				{Key: ref.Key, Path: slurmKeyFile},
+			},
+		},
+	}
+}
+
+// slurmJwksAnnotations returns the pod annotations of the `slurm.jwks` file,
+// which roll the pods of the component when it changes.
+func slurmJwksAnnotations(controller *slinkyv1beta1.Controller, component slinkyv1beta1.KeyRotationComponent) map[string]string {
+	fileKey := slurmJwksFileKeyOf(controller, component)
+	if fileKey == "" {
+		return nil
+	}
+	return map[string]string{
+		slinkyv1beta1.AnnotationSlurmJwks: fileKey,
+	}
+}
+
+func slurmJwksFileKeyOf(controller *slinkyv1beta1.Controller, component slinkyv1beta1.KeyRotationComponent) string {
+	if controller == nil {
+		return ""
+	}
+	return SlurmJwksFileKey(controller.SlurmKeyRotationPhase(component))
+}
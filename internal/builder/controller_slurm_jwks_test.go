@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newSlurmKeyRotationController(name string, phases map[slinkyv1beta1.KeyRotationComponent]slinkyv1beta1.KeyRotationPhase) (*slinkyv1beta1.Controller, []client.Object) {
+	slurmKeyRef := testutils.NewSlurmKeyRef(name)
+	newSlurmKeyRef := testutils.NewSlurmKeyRef(name + "-new")
+	controller := testutils.NewController(name, slurmKeyRef, testutils.NewJwtHs256KeyRef(name), nil)
+	controller.Spec.SlurmKeyRotation = &slinkyv1beta1.SlurmKeyRotation{
+		NewSlurmKeyRef: newSlurmKeyRef,
+	}
+	controller.Status.KeyRotation = &slinkyv1beta1.KeyRotationStatus{
+		Phase: slinkyv1beta1.KeyRotationPhaseStaging,
+	}
+	for _, component := range slinkyv1beta1.KeyRotationComponents {
+		if phase, ok := phases[component]; ok {
+			controller.Status.KeyRotation.Components = append(controller.Status.KeyRotation.Components, slinkyv1beta1.KeyRotationComponentStatus{
+				Name:  component,
+				Phase: phase,
+			})
+		}
+	}
+	objs := []client.Object{
+		testutils.NewSlurmKeySecret(slurmKeyRef),
+		testutils.NewSlurmKeySecret(newSlurmKeyRef),
+	}
+	return controller, objs
+}
+
+func TestSlurmJwksFileKey(t *testing.T) {
+	tests := []struct {
+		phase slinkyv1beta1.KeyRotationPhase
+		want  string
+	}{
+		{phase: "", want: ""},
+		{phase: slinkyv1beta1.KeyRotationPhaseStaging, want: slurmJwksStaging},
+		{phase: slinkyv1beta1.KeyRotationPhaseSwitching, want: slurmJwksSwitching},
+		{phase: slinkyv1beta1.KeyRotationPhaseRetiring, want: slurmJwksRetiring},
+		{phase: slinkyv1beta1.KeyRotationPhaseComplete, want: slurmJwksRetiring},
+		{phase: slinkyv1beta1.KeyRotationPhaseRollingBack, want: slurmJwksStaging},
+		{phase: slinkyv1beta1.KeyRotationPhaseRolledBack, want: slurmJwksStaging},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.phase), func(t *testing.T) {
+			if got := SlurmJwksFileKey(tt.phase); got != tt.want {
+				t.Errorf("SlurmJwksFileKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilder_BuildControllerSlurmJwks(t *testing.T) {
+	controller, objs := newSlurmKeyRotationController("slurm", nil)
+	currentKeyID := SlurmKeyID(objs[0].(*corev1.Secret).Data["slurm.key"])
+	newKeyID := SlurmKeyID(objs[1].(*corev1.Secret).Data["slurm.key"])
+	tests := []struct {
+		name       string
+		client     client.Client
+		controller *slinkyv1beta1.Controller
+		want       map[string][]string
+		wantErr    bool
+	}{
+		{
+			name:       "Without rotation",
+			client:     fake.NewFakeClient(),
+			controller: testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil),
+			want:       map[string][]string{},
+		},
+		{
+			name:       "With rotation",
+			client:     fake.NewClientBuilder().WithObjects(objs...).Build(),
+			controller: controller,
+			want: map[string][]string{
+				slurmJwksStaging:   {currentKeyID, newKeyID},
+				slurmJwksSwitching: {newKeyID, currentKeyID},
+				slurmJwksRetiring:  {newKeyID},
+			},
+		},
+		{
+			name:       "Missing new key",
+			client:     fake.NewClientBuilder().WithObjects(objs[0]).Build(),
+			controller: controller,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.client)
+			got, err := b.BuildControllerSlurmJwks(tt.controller)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Builder.BuildControllerSlurmJwks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Name != tt.controller.SlurmJwksKey().Name {
+				t.Errorf("got.Name = %v, want %v", got.Name, tt.controller.SlurmJwksKey().Name)
+			}
+			if len(got.Data) != len(tt.want) {
+				t.Fatalf("got.Data keys = %v, want %v", len(got.Data), len(tt.want))
+			}
+			for name, wantKeyIDs := range tt.want {
+				jwks := slurmJwks{}
+				if err := json.Unmarshal(got.Data[name], &jwks); err != nil {
+					t.Fatalf("json.Unmarshal(%s) error = %v", name, err)
+				}
+				gotKeyIDs := []string{}
+				for i, key := range jwks.Keys {
+					gotKeyIDs = append(gotKeyIDs, key.KeyID)
+					if wantUse := (i == 0); (key.Use == "default") != wantUse {
+						t.Errorf("%s key[%d].Use = %v", name, i, key.Use)
+					}
+					if key.KeyType != "oct" || key.Key == "" {
+						t.Errorf("%s key[%d] = %+v, want an oct key", name, i, key)
+					}
+				}
+				if !slices.Equal(gotKeyIDs, wantKeyIDs) {
+					t.Errorf("%s key IDs = %v, want %v", name, gotKeyIDs, wantKeyIDs)
+				}
+			}
+		})
+	}
+}
+
+func Test_slurmKeyVolumeProjection(t *testing.T) {
+	controller, _ := newSlurmKeyRotationController("slurm", map[slinkyv1beta1.KeyRotationComponent]slinkyv1beta1.KeyRotationPhase{
+		slinkyv1beta1.KeyRotationComponentAccounting: slinkyv1beta1.KeyRotationPhaseSwitching,
+	})
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		component  slinkyv1beta1.KeyRotationComponent
+		wantName   string
+		wantItem   corev1.KeyToPath
+	}{
+		{
+			name:       "Without Controller",
+			controller: nil,
+			component:  slinkyv1beta1.KeyRotationComponentAccounting,
+			wantName:   controller.AuthSlurmRef().Name,
+			wantItem:   corev1.KeyToPath{Key: controller.AuthSlurmRef().Key, Path: slurmKeyFile},
+		},
+		{
+			name:       "Component not rolled yet",
+			controller: controller,
+			component:  slinkyv1beta1.KeyRotationComponentNodeSet,
+			wantName:   controller.AuthSlurmRef().Name,
+			wantItem:   corev1.KeyToPath{Key: controller.AuthSlurmRef().Key, Path: slurmKeyFile},
+		},
+		{
+			name:       "Component rolled",
+			controller: controller,
+			component:  slinkyv1beta1.KeyRotationComponentAccounting,
+			wantName:   controller.SlurmJwksKey().Name,
+			wantItem:   corev1.KeyToPath{Key: slurmJwksSwitching, Path: slurmJwksFile},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slurmKeyVolumeProjection(controller.AuthSlurmRef(), tt.controller, tt.component)
+			if got.Secret.Name != tt.wantName {
+				t.Errorf("slurmKeyVolumeProjection() name = %v, want %v", got.Secret.Name, tt.wantName)
+			}
+			if len(got.Secret.Items) != 1 || got.Secret.Items[0] != tt.wantItem {
+				t.Errorf("slurmKeyVolumeProjection() items = %v, want %v", got.Secret.Items, tt.wantItem)
+			}
+			annotations := slurmJwksAnnotations(tt.controller, tt.component)
+			if got, want := annotations[slinkyv1beta1.AnnotationSlurmJwks], tt.wantItem.Key; tt.wantItem.Path == slurmJwksFile && got != want {
+				t.Errorf("slurmJwksAnnotations() = %v, want %v", got, want)
+			}
+		})
+	}
+}
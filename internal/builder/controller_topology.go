@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
+)
+
+const (
+	topologyConfFile = "topology.conf"
+
+	// topologyUnknown is the label value of nodes lacking a topology label.
+	topologyUnknown = "unknown"
+	// topologyRootSwitch is the switch joining the top level switches.
+	topologyRootSwitch = "root"
+)
+
+var topologyNameInvalidChars = regexp.MustCompile(`[^A-Za-z0-9.-]`)
+
+// topologyNode is a Slurm node, with the labels of the Kubernetes node it runs on.
+type topologyNode struct {
+	Name   string
+	Labels map[string]string
+}
+
+// getTopologyNodes returns the Slurm nodes of the NodeSet pods which are
+// scheduled, with the labels of their Kubernetes node.
+func (b *Builder) getTopologyNodes(ctx context.Context, nodesetList *slinkyv1beta1.NodeSetList) ([]topologyNode, error) {
+	nodes := []topologyNode{}
+	k8sNodeLabels := make(map[string]map[string]string)
+	for i := range nodesetList.Items {
+		nodeset := &nodesetList.Items[i]
+		selectorLabels := labels.NewBuilder().WithWorkerSelectorLabels(nodeset).Build()
+		podList := &corev1.PodList{}
+		opts := []client.ListOption{
+			client.InNamespace(nodeset.Namespace),
+			client.MatchingLabels(selectorLabels),
+		}
+		if err := b.client.List(ctx, podList, opts...); err != nil {
+			return nil, err
+		}
+		for _, pod := range podList.Items {
+			if pod.Spec.NodeName == "" || !pod.DeletionTimestamp.IsZero() {
+				continue
+			}
+			nodeLabels, ok := k8sNodeLabels[pod.Spec.NodeName]
+			if !ok {
+				node := &corev1.Node{}
+				key := types.NamespacedName{Name: pod.Spec.NodeName}
+				if err := b.client.Get(ctx, key, node); err != nil && !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				nodeLabels = node.Labels
+				k8sNodeLabels[pod.Spec.NodeName] = nodeLabels
+			}
+			name := pod.Labels[slinkyv1beta1.LabelNodeSetPodHostname]
+			if name == "" {
+				name = pod.Name
+			}
+			nodes = append(nodes, topologyNode{Name: name, Labels: nodeLabels})
+		}
+	}
+	return nodes, nil
+}
+
+// topologyPath returns the switch or block names of the node, from the top of
+// the topology to the node.
+func topologyPath(labelKeys []string, node topologyNode) []string {
+	path := make([]string, 0, len(labelKeys))
+	values := make([]string, 0, len(labelKeys))
+	for _, key := range labelKeys {
+		value := node.Labels[key]
+		if value == "" {
+			value = topologyUnknown
+		}
+		values = append(values, topologyNameInvalidChars.ReplaceAllString(value, "-"))
+		// Qualify the name with its parents, so equal values under different
+		// parents remain distinct.
+		path = append(path, strings.Join(values, "_"))
+	}
+	return path
+}
+
+// https://slurm.schedmd.com/topology.conf.html
+func buildTopologyConf(topology *slinkyv1beta1.ControllerTopology, nodes []topologyNode) string {
+	conf := config.NewBuilder()
+
+	conf.AddProperty(config.NewPropertyRaw("#"))
+	conf.AddProperty(config.NewPropertyRaw("### TOPOLOGY ###"))
+
+	switch topology.TopologyPlugin() {
+	case slinkyv1beta1.TopologyPluginBlock:
+		blocks := make(map[string][]string)
+		for _, node := range nodes {
+			path := topologyPath(topology.LabelKeys, node)
+			block := path[len(path)-1]
+			blocks[block] = append(blocks[block], node.Name)
+		}
+		for _, block := range slices.Sorted(maps.Keys(blocks)) {
+			nodeNames := slices.Compact(slices.Sorted(slices.Values(blocks[block])))
+			conf.AddProperty(config.NewPropertyRaw(fmt.Sprintf("BlockName=%s Nodes=%s", block, strings.Join(nodeNames, ","))))
+		}
+		if len(topology.BlockSizes) > 0 {
+			sizes := make([]string, 0, len(topology.BlockSizes))
+			for _, size := range topology.BlockSizes {
+				sizes = append(sizes, fmt.Sprint(size))
+			}
+			conf.AddProperty(config.NewProperty("BlockSizes", strings.Join(sizes, ",")))
+		}
+	default:
+		levels := len(topology.LabelKeys)
+		// children of each switch, by level
+		switches := make([]map[string][]string, levels)
+		for i := range switches {
+			switches[i] = make(map[string][]string)
+		}
+		for _, node := range nodes {
+			path := topologyPath(topology.LabelKeys, node)
+			switches[levels-1][path[levels-1]] = append(switches[levels-1][path[levels-1]], node.Name)
+			for i := range levels - 1 {
+				switches[i][path[i]] = append(switches[i][path[i]], path[i+1])
+			}
+		}
+		for i := levels - 1; i >= 0; i-- {
+			childKey := "Switches"
+			if i == levels-1 {
+				childKey = "Nodes"
+			}
+			for _, name := range slices.Sorted(maps.Keys(switches[i])) {
+				children := slices.Compact(slices.Sorted(slices.Values(switches[i][name])))
+				conf.AddProperty(config.NewPropertyRaw(fmt.Sprintf("SwitchName=%s %s=%s", name, childKey, strings.Join(children, ","))))
+			}
+		}
+		// Without a common switch, a job cannot span the top level switches.
+		if len(switches[0]) > 1 {
+			children := slices.Sorted(maps.Keys(switches[0]))
+			conf.AddProperty(config.NewPropertyRaw(fmt.Sprintf("SwitchName=%s Switches=%s", topologyRootSwitch, strings.Join(children, ","))))
+		}
+	}
+
+	return conf.Build()
+}
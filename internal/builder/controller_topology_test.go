@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+)
+
+func Test_buildTopologyConf(t *testing.T) {
+	const (
+		zoneKey = "topology.kubernetes.io/zone"
+		rackKey = "example.com/rack"
+	)
+	nodes := []topologyNode{
+		{Name: "gpu-0", Labels: map[string]string{zoneKey: "us-east-1a", rackKey: "r1"}},
+		{Name: "gpu-1", Labels: map[string]string{zoneKey: "us-east-1a", rackKey: "r1"}},
+		{Name: "gpu-2", Labels: map[string]string{zoneKey: "us-east-1a", rackKey: "r2"}},
+		{Name: "gpu-3", Labels: map[string]string{zoneKey: "us-east-1b", rackKey: "r1"}},
+		{Name: "gpu-4", Labels: map[string]string{zoneKey: "us-east-1b"}},
+	}
+	tests := []struct {
+		name     string
+		topology *slinkyv1beta1.ControllerTopology
+		nodes    []topologyNode
+		want     []string
+	}{
+		{
+			name: "Empty",
+			topology: &slinkyv1beta1.ControllerTopology{
+				LabelKeys: []string{zoneKey},
+			},
+			want: []string{
+				"#",
+				"### TOPOLOGY ###",
+			},
+		},
+		{
+			name: "Tree, one level",
+			topology: &slinkyv1beta1.ControllerTopology{
+				LabelKeys: []string{zoneKey},
+			},
+			nodes: nodes[:3],
+			want: []string{
+				"#",
+				"### TOPOLOGY ###",
+				"SwitchName=us-east-1a Nodes=gpu-0,gpu-1,gpu-2",
+			},
+		},
+		{
+			name: "Tree, two levels",
+			topology: &slinkyv1beta1.ControllerTopology{
+				Plugin:    slinkyv1beta1.TopologyPluginTree,
+				LabelKeys: []string{zoneKey, rackKey},
+			},
+			nodes: nodes,
+			want: []string{
+				"#",
+				"### TOPOLOGY ###",
+				"SwitchName=us-east-1a_r1 Nodes=gpu-0,gpu-1",
+				"SwitchName=us-east-1a_r2 Nodes=gpu-2",
+				"SwitchName=us-east-1b_r1 Nodes=gpu-3",
+				"SwitchName=us-east-1b_unknown Nodes=gpu-4",
+				"SwitchName=us-east-1a Switches=us-east-1a_r1,us-east-1a_r2",
+				"SwitchName=us-east-1b Switches=us-east-1b_r1,us-east-1b_unknown",
+				"SwitchName=root Switches=us-east-1a,us-east-1b",
+			},
+		},
+		{
+			name: "Block",
+			topology: &slinkyv1beta1.ControllerTopology{
+				Plugin:     slinkyv1beta1.TopologyPluginBlock,
+				LabelKeys:  []string{zoneKey, rackKey},
+				BlockSizes: []int32{1, 2},
+			},
+			nodes: nodes,
+			want: []string{
+				"#",
+				"### TOPOLOGY ###",
+				"BlockName=us-east-1a_r1 Nodes=gpu-0,gpu-1",
+				"BlockName=us-east-1a_r2 Nodes=gpu-2",
+				"BlockName=us-east-1b_r1 Nodes=gpu-3",
+				"BlockName=us-east-1b_unknown Nodes=gpu-4",
+				"BlockSizes=1,2",
+			},
+		},
+		{
+			name: "Invalid characters",
+			topology: &slinkyv1beta1.ControllerTopology{
+				LabelKeys: []string{rackKey},
+			},
+			nodes: []topologyNode{
+				{Name: "cpu-0", Labels: map[string]string{rackKey: "row_1.rack_2"}},
+			},
+			want: []string{
+				"#",
+				"### TOPOLOGY ###",
+				"SwitchName=row-1.rack-2 Nodes=cpu-0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := strings.Join(tt.want, "\n") + "\n"
+			if got := buildTopologyConf(tt.topology, tt.nodes); got != want {
+				t.Errorf("buildTopologyConf() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestBuilder_getTopologyNodes(t *testing.T) {
+	const zoneKey = "topology.kubernetes.io/zone"
+	nodeset := slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "slurm",
+			Name:      "gpu",
+		},
+	}
+	newPod := func(name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "slurm",
+				Name:      name,
+				Labels: labels.NewBuilder().
+					WithWorkerSelectorLabels(&nodeset).
+					WithLabels(map[string]string{slinkyv1beta1.LabelNodeSetPodHostname: name + "-host"}).
+					Build(),
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+			},
+		}
+	}
+	newNode := func(name, zone string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{zoneKey: zone},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		client  client.Client
+		want    []topologyNode
+		wantErr bool
+	}{
+		{
+			name:   "Empty",
+			client: fake.NewFakeClient(),
+			want:   []topologyNode{},
+		},
+		{
+			name: "Scheduled pods",
+			client: fake.NewFakeClient(
+				newPod("gpu-0", "node-a"),
+				newPod("gpu-1", "node-b"),
+				newPod("gpu-2", ""),
+				newPod("gpu-3", "node-c"),
+				newNode("node-a", "us-east-1a"),
+				newNode("node-b", "us-east-1b"),
+			),
+			want: []topologyNode{
+				{Name: "gpu-0-host", Labels: map[string]string{zoneKey: "us-east-1a"}},
+				{Name: "gpu-1-host", Labels: map[string]string{zoneKey: "us-east-1b"}},
+				{Name: "gpu-3-host"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.client)
+			nodesetList := &slinkyv1beta1.NodeSetList{Items: []slinkyv1beta1.NodeSet{nodeset}}
+			got, err := b.getTopologyNodes(context.TODO(), nodesetList)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Builder.getTopologyNodes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Builder.getTopologyNodes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+)
+
+const (
+	// slurmKeyLength is the length of a generated `auth/slurm` key.
+	// Ref: https://slurm.schedmd.com/authentication.html#slurm
+	slurmKeyLength = 1024
+	// jwtHs256KeyLength is the length of a generated `auth/jwt` HS256 key.
+	// Ref: https://slurm.schedmd.com/jwt.html#setup
+	jwtHs256KeyLength = 32
+)
+
+// generatedKey is a key of a Secret to generate.
+type generatedKey struct {
+	ref    *corev1.SecretKeySelector
+	length int
+}
+
+// BuildControllerGeneratedKeys returns the Secrets of the key refs of the
+// Controller which are generated.
+func (b *Builder) BuildControllerGeneratedKeys(controller *slinkyv1beta1.Controller) ([]*corev1.Secret, error) {
+	keys := []generatedKey{}
+	if controller.Spec.SlurmKeyRef.Generate {
+		keys = append(keys, generatedKey{ref: controller.AuthSlurmRef(), length: slurmKeyLength})
+	}
+	if controller.Spec.JwtHs256KeyRef.Generate {
+		keys = append(keys, generatedKey{ref: controller.AuthJwtHs256Ref(), length: jwtHs256KeyLength})
+	}
+
+	secretLabels := labels.NewBuilder().WithControllerLabels(controller).Build()
+
+	return b.buildGeneratedKeys(keys, controller.Namespace, secretLabels, controller)
+}
+
+// BuildAccountingGeneratedKeys returns the Secrets of the key refs of the
+// Accounting which are generated.
+func (b *Builder) BuildAccountingGeneratedKeys(accounting *slinkyv1beta1.Accounting) ([]*corev1.Secret, error) {
+	keys := []generatedKey{}
+	if accounting.Spec.SlurmKeyRef.Generate {
+		keys = append(keys, generatedKey{ref: accounting.AuthSlurmRef(), length: slurmKeyLength})
+	}
+	if accounting.Spec.JwtHs256KeyRef.Generate {
+		keys = append(keys, generatedKey{ref: accounting.AuthJwtHs256Ref(), length: jwtHs256KeyLength})
+	}
+
+	secretLabels := labels.NewBuilder().WithAccountingLabels(accounting).Build()
+
+	return b.buildGeneratedKeys(keys, accounting.Namespace, secretLabels, accounting)
+}
+
+// buildGeneratedKeys returns an immutable Secret with random keys for each
+// Secret of the keys. Keys of the same Secret are generated together.
+func (b *Builder) buildGeneratedKeys(keys []generatedKey, namespace string, secretLabels map[string]string, owner metav1.Object) ([]*corev1.Secret, error) {
+	names := []string{}
+	data := map[string]map[string][]byte{}
+	for _, key := range keys {
+		if _, ok := data[key.ref.Name]; !ok {
+			names = append(names, key.ref.Name)
+			data[key.ref.Name] = map[string][]byte{}
+		}
+		data[key.ref.Name][key.ref.Key] = crypto.NewSigningKeyWithLength(key.length)
+	}
+
+	out := make([]*corev1.Secret, 0, len(names))
+	for _, name := range names {
+		generatedKeys := structutils.Keys(data[name])
+		slices.Sort(generatedKeys)
+		opts := SecretOpts{
+			Key: types.NamespacedName{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Metadata: slinkyv1beta1.Metadata{
+				Annotations: map[string]string{
+					slinkyv1beta1.AnnotationGeneratedKeys: strings.Join(generatedKeys, ","),
+				},
+				Labels: secretLabels,
+			},
+			Data:      data[name],
+			Immutable: true,
+		}
+		secret, err := b.BuildSecret(opts, owner)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, secret)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func TestBuilder_BuildControllerGeneratedKeys(t *testing.T) {
+	type want struct {
+		name        string
+		keyLengths  map[string]int
+		annotations string
+	}
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		want       []want
+	}{
+		{
+			name:       "Not generated",
+			controller: testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil),
+		},
+		{
+			name: "Separate Secrets",
+			controller: func() *slinkyv1beta1.Controller {
+				controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+				controller.Spec.SlurmKeyRef.Generate = true
+				controller.Spec.JwtHs256KeyRef.Generate = true
+				return controller
+			}(),
+			want: []want{
+				{
+					name:        testutils.NewSlurmKeyRef("slurm").Name,
+					keyLengths:  map[string]int{testutils.NewSlurmKeyRef("slurm").Key: slurmKeyLength},
+					annotations: testutils.NewSlurmKeyRef("slurm").Key,
+				},
+				{
+					name:        testutils.NewJwtHs256KeyRef("slurm").Name,
+					keyLengths:  map[string]int{testutils.NewJwtHs256KeyRef("slurm").Key: jwtHs256KeyLength},
+					annotations: testutils.NewJwtHs256KeyRef("slurm").Key,
+				},
+			},
+		},
+		{
+			name: "Shared Secret",
+			controller: func() *slinkyv1beta1.Controller {
+				controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+				controller.Spec.SlurmKeyRef.Name = "slurm-auth"
+				controller.Spec.SlurmKeyRef.Key = "slurm.key"
+				controller.Spec.SlurmKeyRef.Generate = true
+				controller.Spec.JwtHs256KeyRef.Name = "slurm-auth"
+				controller.Spec.JwtHs256KeyRef.Key = "jwt_hs256.key"
+				controller.Spec.JwtHs256KeyRef.Generate = true
+				return controller
+			}(),
+			want: []want{
+				{
+					name:        "slurm-auth",
+					keyLengths:  map[string]int{"slurm.key": slurmKeyLength, "jwt_hs256.key": jwtHs256KeyLength},
+					annotations: "jwt_hs256.key,slurm.key",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewFakeClient())
+			got, err := b.BuildControllerGeneratedKeys(tt.controller)
+			if err != nil {
+				t.Fatalf("Builder.BuildControllerGeneratedKeys() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("len(got) = %v, want %v", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				secret := got[i]
+				if secret.Name != want.name {
+					t.Errorf("got[%d].Name = %v, want %v", i, secret.Name, want.name)
+				}
+				if !ptr.Deref(secret.Immutable, false) {
+					t.Errorf("got[%d].Immutable = %v, want true", i, secret.Immutable)
+				}
+				if len(secret.OwnerReferences) != 1 {
+					t.Errorf("got[%d].OwnerReferences = %v, want the Controller", i, secret.OwnerReferences)
+				}
+				if got := secret.Annotations[slinkyv1beta1.AnnotationGeneratedKeys]; got != want.annotations {
+					t.Errorf("got[%d].Annotations[%s] = %v, want %v", i, slinkyv1beta1.AnnotationGeneratedKeys, got, want.annotations)
+				}
+				if len(secret.Data) != len(want.keyLengths) {
+					t.Errorf("got[%d].Data has %d keys, want %d", i, len(secret.Data), len(want.keyLengths))
+				}
+				for key, length := range want.keyLengths {
+					if len(secret.Data[key]) != length {
+						t.Errorf("len(got[%d].Data[%s]) = %v, want %v", i, key, len(secret.Data[key]), length)
+					}
+				}
+			}
+		})
+	}
+}
@@ -122,6 +122,7 @@ func (b *Builder) loginPodTemplate(loginset *slinkyv1beta1.LoginSet) (corev1.Pod
 		WithAnnotations(map[string]string{
 			annotationDefaultContainer: labels.LoginApp,
 		}).
+		WithAnnotations(slurmJwksAnnotations(controller, slinkyv1beta1.KeyRotationComponentLoginSet)).
 		Build()
 
 	spec := loginset.Spec
@@ -149,7 +150,10 @@ func (b *Builder) loginPodTemplate(loginset *slinkyv1beta1.LoginSet) (corev1.Pod
 		merge: template.PodSpec,
 	}
 
-	return b.buildPodTemplate(opts), nil
+	podTemplate := b.buildPodTemplate(opts)
+	b.withMunged(controller.Spec.Auth, &podTemplate)
+
+	return podTemplate, nil
 }
 
 func loginVolumes(loginset *slinkyv1beta1.LoginSet, controller *slinkyv1beta1.Controller) []corev1.Volume {
@@ -167,18 +171,7 @@ func loginVolumes(loginset *slinkyv1beta1.LoginSet, controller *slinkyv1beta1.Co
 			VolumeSource: corev1.VolumeSource{
 				Projected: &corev1.ProjectedVolumeSource{
 					DefaultMode: ptr.To[int32](0o600),
-					Sources: []corev1.VolumeProjection{
-						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: controller.AuthSlurmRef().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: controller.AuthSlurmRef().Key, Path: slurmKeyFile},
-								},
-							},
-						},
-					},
+					Sources:     slurmAuthVolumeProjections(controller.Spec.Auth, controller.AuthSlurmRef(), controller, slinkyv1beta1.KeyRotationComponentLoginSet),
 				},
 			},
 		},
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+const (
+	authTypeMunge = "auth/munge"
+	credTypeMunge = "cred/munge"
+
+	mungedContainerName = "munged"
+
+	mungeKeyVolume = "munge-key"
+	mungeKeyDir    = "/etc/munge"
+	mungeKeyFile   = "munge.key"
+	mungeKeyPath   = mungeKeyDir + "/" + mungeKeyFile
+
+	mungeSocketVolume = "munge-socket"
+	mungeSocketDir    = "/run/munge"
+	mungeSocketPath   = mungeSocketDir + "/munge.socket.2"
+
+	authInfoMunge = "socket=" + mungeSocketPath
+)
+
+// slurmAuthType returns the `AuthType` of the Slurm authentication.
+func slurmAuthType(auth *slinkyv1beta1.SlurmAuth) string {
+	if auth.IsMunge() {
+		return authTypeMunge
+	}
+	return authType
+}
+
+// slurmCredType returns the `CredType` of the Slurm authentication.
+func slurmCredType(auth *slinkyv1beta1.SlurmAuth) string {
+	if auth.IsMunge() {
+		return credTypeMunge
+	}
+	return credType
+}
+
+// slurmAuthInfo returns the `AuthInfo` of the Slurm authentication.
+func slurmAuthInfo(auth *slinkyv1beta1.SlurmAuth) string {
+	if auth.IsMunge() {
+		return authInfoMunge
+	}
+	return authInfo
+}
+
+func (b *Builder) mungedContainer(auth *slinkyv1beta1.SlurmAuth) corev1.Container {
+	opts := ContainerOpts{
+		base: corev1.Container{
+			Name: mungedContainerName,
+			Command: []string{
+				"munged",
+				"--foreground",
+				// The kubelet owns the key and the socket directory, which
+				// munged would otherwise reject as insecure.
+				"--force",
+				"--key-file=" + mungeKeyPath,
+				"--socket=" + mungeSocketPath,
+				"--pid-file=" + mungeSocketDir + "/munged.pid",
+				"--seed-file=" + mungeSocketDir + "/munged.seed",
+			},
+			RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: mungeKeyVolume, MountPath: mungeKeyDir, ReadOnly: true},
+				{Name: mungeSocketVolume, MountPath: mungeSocketDir},
+			},
+		},
+		merge: auth.Munged.Container,
+	}
+
+	return b.BuildContainer(opts)
+}
+
+func mungeVolumes(auth *slinkyv1beta1.SlurmAuth) []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: mungeKeyVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  auth.MungeKeyRef.Name,
+					DefaultMode: ptr.To[int32](0o440),
+					Items: []corev1.KeyToPath{
+						{Key: auth.MungeKeyRef.Key, Path: mungeKeyFile},
+					},
+				},
+			},
+		},
+		{
+			Name: mungeSocketVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumMemory,
+				},
+			},
+		},
+	}
+}
+
+// withMunged adds the munged sidecar to the pod, when the Slurm
+// authentication is `auth/munge`, and mounts its socket into the other
+// containers. The sidecar starts first, so munged is running before any Slurm
+// daemon or client.
+func (b *Builder) withMunged(auth *slinkyv1beta1.SlurmAuth, template *corev1.PodTemplateSpec) {
+	if !auth.IsMunge() {
+		return
+	}
+	spec := &template.Spec
+
+	socketMount := corev1.VolumeMount{Name: mungeSocketVolume, MountPath: mungeSocketDir}
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].VolumeMounts = append(spec.InitContainers[i].VolumeMounts, socketMount)
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, socketMount)
+	}
+
+	spec.InitContainers = slices.Insert(spec.InitContainers, 0, b.mungedContainer(auth))
+	spec.Volumes = append(spec.Volumes, mungeVolumes(auth)...)
+}
+
+// slurmAuthVolumeProjections projects the `auth/slurm` key of the ref, unless
+// the Slurm authentication is `auth/munge`, whose key is only mounted into the
+// munged sidecar.
+func slurmAuthVolumeProjections(
+	auth *slinkyv1beta1.SlurmAuth,
+	ref *corev1.SecretKeySelector,
+	controller *slinkyv1beta1.Controller,
+	component slinkyv1beta1.KeyRotationComponent,
+) []corev1.VolumeProjection {
+	if auth.IsMunge() {
+		return nil
+	}
+	return []corev1.VolumeProjection{
+		slurmKeyVolumeProjection(ref, controller, component),
+	}
+}
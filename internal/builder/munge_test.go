@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func TestBuilder_withMunged(t *testing.T) {
+	nodeset := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm-foo",
+		},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			ControllerRef: slinkyv1beta1.ObjectReference{
+				Name: "slurm",
+			},
+		},
+	}
+	newController := func(auth *slinkyv1beta1.SlurmAuth) *slinkyv1beta1.Controller {
+		return &slinkyv1beta1.Controller{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "slurm",
+			},
+			Spec: slinkyv1beta1.ControllerSpec{
+				Auth: auth,
+				SlurmKeyRef: slinkyv1beta1.GeneratedSecretKeySelector{
+					SecretKeySelector: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "slurm-auth-slurm"},
+						Key:                  "slurm.key",
+					},
+				},
+			},
+		}
+	}
+	mungeAuth := &slinkyv1beta1.SlurmAuth{
+		Type: slinkyv1beta1.SlurmAuthTypeMunge,
+		MungeKeyRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "munge"},
+			Key:                  "munge.key",
+		},
+		Munged: slinkyv1beta1.ContainerWrapper{
+			Container: corev1.Container{
+				Image: "munge",
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		controller   *slinkyv1beta1.Controller
+		wantMunged   bool
+		wantSlurmKey bool
+	}{
+		{
+			name:         "default",
+			controller:   newController(nil),
+			wantMunged:   false,
+			wantSlurmKey: true,
+		},
+		{
+			name:         "auth/slurm",
+			controller:   newController(&slinkyv1beta1.SlurmAuth{Type: slinkyv1beta1.SlurmAuthTypeSlurm}),
+			wantMunged:   false,
+			wantSlurmKey: true,
+		},
+		{
+			name:         "auth/munge",
+			controller:   newController(mungeAuth),
+			wantMunged:   true,
+			wantSlurmKey: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewFakeClient())
+			got := b.BuildWorkerPodTemplate(nodeset, tt.controller)
+
+			hasMunged := len(got.Spec.InitContainers) > 0 && got.Spec.InitContainers[0].Name == mungedContainerName
+			if hasMunged != tt.wantMunged {
+				t.Fatalf("InitContainers = %v, want munged %v", got.Spec.InitContainers, tt.wantMunged)
+			}
+			if hasMunged {
+				munged := got.Spec.InitContainers[0]
+				if munged.Image != "munge" {
+					t.Errorf("munged.Image = %v, want %v", munged.Image, "munge")
+				}
+				if munged.RestartPolicy == nil || *munged.RestartPolicy != corev1.ContainerRestartPolicyAlways {
+					t.Errorf("munged.RestartPolicy = %v, want %v", munged.RestartPolicy, corev1.ContainerRestartPolicyAlways)
+				}
+				for _, container := range slices.Concat(got.Spec.InitContainers[1:], got.Spec.Containers) {
+					if !slices.ContainsFunc(container.VolumeMounts, func(m corev1.VolumeMount) bool {
+						return m.Name == mungeSocketVolume && m.MountPath == mungeSocketDir
+					}) {
+						t.Errorf("%s.VolumeMounts = %v, want %v", container.Name, container.VolumeMounts, mungeSocketVolume)
+					}
+				}
+				idx := slices.IndexFunc(got.Spec.Volumes, func(v corev1.Volume) bool { return v.Name == mungeKeyVolume })
+				if idx < 0 || got.Spec.Volumes[idx].Secret.SecretName != "munge" {
+					t.Errorf("Volumes = %v, want %v", got.Spec.Volumes, mungeKeyVolume)
+				}
+			}
+
+			hasSlurmKey := false
+			for _, volume := range got.Spec.Volumes {
+				if volume.Name != slurmEtcVolume {
+					continue
+				}
+				for _, source := range volume.Projected.Sources {
+					if source.Secret != nil && source.Secret.Name == "slurm-auth-slurm" {
+						hasSlurmKey = true
+					}
+				}
+			}
+			if hasSlurmKey != tt.wantSlurmKey {
+				t.Errorf("Volumes = %v, want slurm.key %v", got.Spec.Volumes, tt.wantSlurmKey)
+			}
+		})
+	}
+}
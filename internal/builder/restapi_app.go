@@ -76,12 +76,19 @@ func (b *Builder) restapiPodTemplate(restapi *slinkyv1beta1.RestApi) (corev1.Pod
 
 	hasAccounting := !apiequality.Semantic.DeepEqual(controller.Spec.AccountingRef, slinkyv1beta1.ObjectReference{})
 
+	hashMap, err := b.getRestapiHashes(ctx, restapi)
+	if err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
+
 	objectMeta := metadata.NewBuilder(key).
 		WithMetadata(restapi.Spec.Template.PodMetadata).
 		WithLabels(labels.NewBuilder().WithRestapiLabels(restapi).Build()).
 		WithAnnotations(map[string]string{
 			annotationDefaultContainer: labels.RestapiApp,
 		}).
+		WithAnnotations(hashMap).
+		WithAnnotations(slurmJwksAnnotations(controller, slinkyv1beta1.KeyRotationComponentRestApi)).
 		Build()
 
 	spec := restapi.Spec
@@ -96,7 +103,7 @@ func (b *Builder) restapiPodTemplate(restapi *slinkyv1beta1.RestApi) (corev1.Pod
 		base: corev1.PodSpec{
 			AutomountServiceAccountToken: ptr.To(false),
 			Containers: []corev1.Container{
-				b.slurmrestdContainer(spec.Slurmrestd.Container, hasAccounting),
+				b.slurmrestdContainer(spec.Slurmrestd.Container, hasAccounting, restapi.IsTLSEnabled()),
 			},
 			SecurityContext: &corev1.PodSecurityContext{
 				RunAsNonRoot: ptr.To(true),
@@ -104,43 +111,64 @@ func (b *Builder) restapiPodTemplate(restapi *slinkyv1beta1.RestApi) (corev1.Pod
 				RunAsGroup:   ptr.To(slurmrestdUserGid),
 				FSGroup:      ptr.To(slurmrestdUserGid),
 			},
-			Volumes: restapiVolumes(controller),
+			Volumes: restapiVolumes(restapi, controller),
 		},
 		merge: template.PodSpec,
 	}
 
-	return b.buildPodTemplate(opts), nil
+	podTemplate := b.buildPodTemplate(opts)
+	b.withMunged(controller.Spec.Auth, &podTemplate)
+
+	return podTemplate, nil
 }
 
-func restapiVolumes(controller *slinkyv1beta1.Controller) []corev1.Volume {
+func restapiVolumes(restapi *slinkyv1beta1.RestApi, controller *slinkyv1beta1.Controller) []corev1.Volume {
+	sources := []corev1.VolumeProjection{
+		{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: controller.ConfigKey().Name,
+				},
+				Items: []corev1.KeyToPath{
+					{Key: SlurmConfFile, Path: SlurmConfFile},
+				},
+			},
+		},
+	}
+	sources = append(sources, slurmAuthVolumeProjections(controller.Spec.Auth, controller.AuthSlurmRef(), controller, slinkyv1beta1.KeyRotationComponentRestApi)...)
+	if restapi.IsTLSEnabled() {
+		sources = append(sources,
+			corev1.VolumeProjection{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: restapi.ConfigKey().Name,
+					},
+					Items: []corev1.KeyToPath{
+						{Key: slurmrestdConfFile, Path: slurmrestdConfFile},
+					},
+				},
+			},
+			corev1.VolumeProjection{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: restapi.TLSKey().Name,
+					},
+					Items: []corev1.KeyToPath{
+						{Key: corev1.ServiceAccountRootCAKey, Path: slurmrestdCAFile},
+						{Key: corev1.TLSCertKey, Path: slurmrestdCertFile},
+						{Key: corev1.TLSPrivateKeyKey, Path: slurmrestdKeyFile},
+					},
+				},
+			},
+		)
+	}
 	out := []corev1.Volume{
 		{
 			Name: slurmEtcVolume,
 			VolumeSource: corev1.VolumeSource{
 				Projected: &corev1.ProjectedVolumeSource{
 					DefaultMode: ptr.To[int32](0o600),
-					Sources: []corev1.VolumeProjection{
-						{
-							ConfigMap: &corev1.ConfigMapProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: controller.ConfigKey().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: slurmConfFile, Path: slurmConfFile},
-								},
-							},
-						},
-						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: controller.AuthSlurmRef().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: controller.AuthSlurmRef().Key, Path: slurmKeyFile},
-								},
-							},
-						},
-					},
+					Sources:     sources,
 				},
 			},
 		},
@@ -148,17 +176,22 @@ func restapiVolumes(controller *slinkyv1beta1.Controller) []corev1.Volume {
 	return out
 }
 
-func (b *Builder) slurmrestdContainer(merge corev1.Container, hasAccounting bool) corev1.Container {
+func (b *Builder) slurmrestdContainer(merge corev1.Container, hasAccounting, hasTLS bool) corev1.Container {
+	env := []corev1.EnvVar{
+		{Name: "SLURM_JWT", Value: "daemon"},
+		{Name: "SLURMRESTD_SECURITY", Value: strings.Join([]string{
+			"disable_unshare_files",
+			"disable_unshare_sysv",
+		}, ",")},
+	}
+	if hasTLS {
+		env = append(env, corev1.EnvVar{Name: "SLURM_CONF", Value: slurmrestdConfPath})
+	}
+
 	opts := ContainerOpts{
 		base: corev1.Container{
 			Name: labels.RestapiApp,
-			Env: []corev1.EnvVar{
-				{Name: "SLURM_JWT", Value: "daemon"},
-				{Name: "SLURMRESTD_SECURITY", Value: strings.Join([]string{
-					"disable_unshare_files",
-					"disable_unshare_sysv",
-				}, ",")},
-			},
+			Env:  env,
 			Args: slurmrestdArgs(hasAccounting),
 			Ports: []corev1.ContainerPort{
 				{
@@ -5,10 +5,12 @@ package builder
 
 import (
 	_ "embed"
+	"slices"
 	"testing"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"k8s.io/utils/set"
@@ -53,6 +55,31 @@ func TestBuilder_BuildRestapi(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TLS",
+			fields: fields{
+				client: fake.NewClientBuilder().
+					WithObjects(&slinkyv1beta1.Controller{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "slurm",
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				restapi: &slinkyv1beta1.RestApi{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm",
+					},
+					Spec: slinkyv1beta1.RestApiSpec{
+						ControllerRef: slinkyv1beta1.ObjectReference{
+							Name: "slurm",
+						},
+						TLS: &slinkyv1beta1.RestApiTLS{},
+					},
+				},
+			},
+		},
 		{
 			name: "failure",
 			fields: fields{
@@ -107,6 +134,15 @@ func TestBuilder_BuildRestapi(t *testing.T) {
 			case got.Spec.Template.Spec.Containers[0].Ports[0].ContainerPort != SlurmrestdPort:
 				t.Errorf("Template.Spec.Containers[0].Ports[0].ContainerPort = %v , want = %v",
 					got.Spec.Template.Spec.Containers[0].Ports[0].Name, SlurmrestdPort)
+
+			case tt.args.restapi.IsTLSEnabled() && !slices.Contains(got.Spec.Template.Spec.Containers[0].Env,
+				corev1.EnvVar{Name: "SLURM_CONF", Value: slurmrestdConfPath}):
+				t.Errorf("Template.Spec.Containers[0].Env = %v , want SLURM_CONF = %v",
+					got.Spec.Template.Spec.Containers[0].Env, slurmrestdConfPath)
+
+			case tt.args.restapi.IsTLSEnabled() && len(got.Spec.Template.Spec.Volumes[0].Projected.Sources) != 4:
+				t.Errorf("Template.Spec.Volumes[0].Projected.Sources = %v , want TLS sources",
+					got.Spec.Template.Spec.Volumes[0].Projected.Sources)
 			}
 		})
 	}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+)
+
+const (
+	slurmrestdConfFile = "slurmrestd.conf"
+	slurmrestdConfPath = slurmEtcDir + "/" + slurmrestdConfFile
+
+	slurmrestdCertFile = "slurmrestd.crt"
+	slurmrestdKeyFile  = "slurmrestd.key"
+	slurmrestdCAFile   = "ca.crt"
+
+	tlsType = "tls/s2n"
+
+	// TLSClientCertKey is the client certificate in a generated TLS Secret.
+	TLSClientCertKey = "client.crt"
+	// TLSClientPrivateKeyKey is the client private key in a generated TLS Secret.
+	TLSClientPrivateKeyKey = "client.key"
+)
+
+const (
+	annotationTLSHash = slinkyv1beta1.SlinkyPrefix + "tls-hash"
+)
+
+// BuildRestapiTLS returns a Secret with a self-signed CA, the slurmrestd server
+// certificate, and the client certificate of the slurm-operator.
+func (b *Builder) BuildRestapiTLS(restapi *slinkyv1beta1.RestApi) (*corev1.Secret, error) {
+	ca, err := crypto.NewCertificateAuthority(restapi.Key().Name + "-ca")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA: %w", err)
+	}
+	server, err := ca.NewServerCertificate(restapi.Key().Name, restapiDNSNames(restapi)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server certificate: %w", err)
+	}
+	client, err := ca.NewClientCertificate("slurm-operator")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client certificate: %w", err)
+	}
+
+	opts := SecretOpts{
+		Key:      restapi.TLSKey(),
+		Metadata: restapi.Spec.Template.PodMetadata,
+		Data: map[string][]byte{
+			corev1.ServiceAccountRootCAKey: ca.Certificate(),
+			corev1.TLSCertKey:              server.Cert,
+			corev1.TLSPrivateKeyKey:        server.Key,
+			TLSClientCertKey:               client.Cert,
+			TLSClientPrivateKeyKey:         client.Key,
+		},
+		Immutable: true,
+	}
+
+	opts.Metadata.Labels = structutils.MergeMaps(opts.Metadata.Labels, labels.NewBuilder().WithRestapiLabels(restapi).Build())
+
+	return b.BuildSecret(opts, restapi)
+}
+
+// restapiDNSNames returns the names which slurmrestd is reachable by.
+func restapiDNSNames(restapi *slinkyv1beta1.RestApi) []string {
+	key := restapi.ServiceKey()
+	return []string{
+		key.Name,
+		restapi.ServiceFQDNShort(),
+		fmt.Sprintf("%s.svc", restapi.ServiceFQDNShort()),
+		restapi.ServiceFQDN(),
+		"localhost",
+	}
+}
+
+// RestapiTLSClientKeys returns the keys of the client certificate and private
+// key in the Secret of RestApi.TLSClientKey().
+func RestapiTLSClientKeys(restapi *slinkyv1beta1.RestApi) (certKey, privateKeyKey string) {
+	if restapi.IsTLSEnabled() && !restapi.IsTLSGenerated() && restapi.Spec.TLS.ClientSecretRef != nil {
+		return corev1.TLSCertKey, corev1.TLSPrivateKeyKey
+	}
+	return TLSClientCertKey, TLSClientPrivateKeyKey
+}
+
+// BuildRestapiConfig returns a ConfigMap with the slurmrestd configuration,
+// which extends the `slurm.conf` with the TLS parameters of slurmrestd.
+func (b *Builder) BuildRestapiConfig(restapi *slinkyv1beta1.RestApi) (*corev1.ConfigMap, error) {
+	opts := ConfigMapOpts{
+		Key:      restapi.ConfigKey(),
+		Metadata: restapi.Spec.Template.PodMetadata,
+		Data: map[string]string{
+			slurmrestdConfFile: buildSlurmrestdConf(),
+		},
+	}
+
+	opts.Metadata.Labels = structutils.MergeMaps(opts.Metadata.Labels, labels.NewBuilder().WithRestapiLabels(restapi).Build())
+
+	return b.BuildConfigMap(opts, restapi)
+}
+
+// https://slurm.schedmd.com/tls.html
+func buildSlurmrestdConf() string {
+	conf := config.NewBuilder()
+
+	conf.AddProperty(config.NewPropertyRaw("#"))
+	conf.AddProperty(config.NewPropertyRaw("### GENERAL ###"))
+	conf.AddProperty(config.NewPropertyRaw("Include " + slurmEtcDir + "/" + SlurmConfFile))
+
+	conf.AddProperty(config.NewPropertyRaw("#"))
+	conf.AddProperty(config.NewPropertyRaw("### TLS ###"))
+	conf.AddProperty(config.NewProperty("TLSType", tlsType))
+	conf.AddProperty(config.NewProperty("TLSParameters", strings.Join([]string{
+		"ca_cert_file=" + slurmEtcDir + "/" + slurmrestdCAFile,
+		"restd_cert_file=" + slurmEtcDir + "/" + slurmrestdCertFile,
+		"restd_cert_key_file=" + slurmEtcDir + "/" + slurmrestdKeyFile,
+	}, ",")))
+
+	return conf.Build()
+}
+
+func (b *Builder) getRestapiHashes(ctx context.Context, restapi *slinkyv1beta1.RestApi) (map[string]string, error) {
+	if !restapi.IsTLSEnabled() {
+		return nil, nil
+	}
+
+	tls := &corev1.Secret{}
+	if err := b.client.Get(ctx, restapi.TLSKey(), tls); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	tlsHash := crypto.CheckSumFromMap(tls.Data)
+
+	hashMap := map[string]string{
+		annotationTLSHash: tlsHash,
+	}
+
+	return hashMap, nil
+}
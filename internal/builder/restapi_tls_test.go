@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBuilder_BuildRestapiTLS(t *testing.T) {
+	type fields struct {
+		client client.Client
+	}
+	type args struct {
+		restapi *slinkyv1beta1.RestApi
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "default",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				restapi: &slinkyv1beta1.RestApi{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "slurm",
+						Name:      "slurm",
+					},
+					Spec: slinkyv1beta1.RestApiSpec{
+						TLS: &slinkyv1beta1.RestApiTLS{},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.fields.client)
+			got, err := b.BuildRestapiTLS(tt.args.restapi)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Builder.BuildRestapiTLS() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.Name != tt.args.restapi.TLSKey().Name {
+				t.Errorf("got.Name = %v, want %v", got.Name, tt.args.restapi.TLSKey().Name)
+			}
+			if !ptr.Deref(got.Immutable, false) {
+				t.Errorf("got.Immutable = %v, want true", got.Immutable)
+			}
+
+			roots := x509.NewCertPool()
+			if !roots.AppendCertsFromPEM(got.Data[corev1.ServiceAccountRootCAKey]) {
+				t.Fatalf("got.Data[%s] is not a PEM certificate", corev1.ServiceAccountRootCAKey)
+			}
+			verify := func(certKey, keyKey string, opts x509.VerifyOptions) {
+				if _, err := tls.X509KeyPair(got.Data[certKey], got.Data[keyKey]); err != nil {
+					t.Fatalf("tls.X509KeyPair(%s, %s) error = %v", certKey, keyKey, err)
+				}
+				block, _ := pem.Decode(got.Data[certKey])
+				cert, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					t.Fatalf("x509.ParseCertificate(%s) error = %v", certKey, err)
+				}
+				opts.Roots = roots
+				if _, err := cert.Verify(opts); err != nil {
+					t.Errorf("got.Data[%s] does not verify: %v", certKey, err)
+				}
+			}
+			verify(corev1.TLSCertKey, corev1.TLSPrivateKeyKey, x509.VerifyOptions{
+				DNSName:   tt.args.restapi.ServiceFQDNShort(),
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			})
+			verify(TLSClientCertKey, TLSClientPrivateKeyKey, x509.VerifyOptions{
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			})
+		})
+	}
+}
+
+func TestBuilder_BuildRestapiConfig(t *testing.T) {
+	restapi := &slinkyv1beta1.RestApi{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "slurm",
+			Name:      "slurm",
+		},
+		Spec: slinkyv1beta1.RestApiSpec{
+			TLS: &slinkyv1beta1.RestApiTLS{},
+		},
+	}
+	b := New(fake.NewFakeClient())
+	got, err := b.BuildRestapiConfig(restapi)
+	if err != nil {
+		t.Fatalf("Builder.BuildRestapiConfig() error = %v", err)
+	}
+	want := `#
+### GENERAL ###
+Include /etc/slurm/slurm.conf
+#
+### TLS ###
+TLSType=tls/s2n
+TLSParameters=ca_cert_file=/etc/slurm/ca.crt,restd_cert_file=/etc/slurm/slurmrestd.crt,restd_cert_key_file=/etc/slurm/slurmrestd.key
+`
+	if got.Name != restapi.ConfigKey().Name {
+		t.Errorf("got.Name = %v, want %v", got.Name, restapi.ConfigKey().Name)
+	}
+	if got.Data[slurmrestdConfFile] != want {
+		t.Errorf("got.Data[%s] = %v, want %v", slurmrestdConfFile, got.Data[slurmrestdConfFile], want)
+	}
+}
+
+func TestRestapiTLSClientKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		tls            *slinkyv1beta1.RestApiTLS
+		wantCertKey    string
+		wantPrivateKey string
+	}{
+		{
+			name:           "Generated",
+			tls:            &slinkyv1beta1.RestApiTLS{ClientCertificate: true},
+			wantCertKey:    TLSClientCertKey,
+			wantPrivateKey: TLSClientPrivateKeyKey,
+		},
+		{
+			name: "Generated, client Secret ignored",
+			tls: &slinkyv1beta1.RestApiTLS{
+				ClientCertificate: true,
+				ClientSecretRef:   &corev1.LocalObjectReference{Name: "operator-tls"},
+			},
+			wantCertKey:    TLSClientCertKey,
+			wantPrivateKey: TLSClientPrivateKeyKey,
+		},
+		{
+			name: "Client Secret",
+			tls: &slinkyv1beta1.RestApiTLS{
+				SecretRef:         &corev1.LocalObjectReference{Name: "restapi-tls"},
+				ClientCertificate: true,
+				ClientSecretRef:   &corev1.LocalObjectReference{Name: "operator-tls"},
+			},
+			wantCertKey:    corev1.TLSCertKey,
+			wantPrivateKey: corev1.TLSPrivateKeyKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restapi := &slinkyv1beta1.RestApi{
+				Spec: slinkyv1beta1.RestApiSpec{TLS: tt.tls},
+			}
+			certKey, privateKey := RestapiTLSClientKeys(restapi)
+			if certKey != tt.wantCertKey || privateKey != tt.wantPrivateKey {
+				t.Errorf("RestapiTLSClientKeys() = (%v, %v), want (%v, %v)",
+					certKey, privateKey, tt.wantCertKey, tt.wantPrivateKey)
+			}
+		})
+	}
+}
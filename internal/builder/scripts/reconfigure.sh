@@ -6,17 +6,27 @@ set -euo pipefail
 
 SLURM_DIR="/etc/slurm"
 INTERVAL="5"
+TIMEOUT="30"
+MAX_DELAY="30"
 
 function getHash() {
 	echo "$(find "$SLURM_DIR" -type f -exec sha256sum {} \; | sort -k2 | sha256sum)"
 }
 
 function reconfigure() {
-	# Issue cluster reconfigure request
+	local delay=2
+
+	# Issue cluster reconfigure request.
+	# With backup controllers, scontrol reaches whichever slurmctld is in
+	# control, so keep trying while a takeover is in progress.
 	echo "[$(date)] Reconfiguring Slurm..."
-	until scontrol reconfigure; do
-		echo "[$(date)] Failed to reconfigure, try again..."
-		sleep 2
+	until timeout "$TIMEOUT" scontrol reconfigure; do
+		echo "[$(date)] Failed to reconfigure, try again in ${delay}s..."
+		sleep "$delay"
+		delay=$((delay * 2))
+		if [ "$delay" -gt "$MAX_DELAY" ]; then
+			delay="$MAX_DELAY"
+		fi
 	done
 	echo "[$(date)] SUCCESS"
 }
@@ -5,24 +5,36 @@ package builder
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 
+	jwt "github.com/golang-jwt/jwt/v5"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
 )
 
+// tokenJSON is the JSON format of the JWT.
+type tokenJSON struct {
+	Token         string        `json:"token"`
+	Claims        jwt.MapClaims `json:"claims"`
+	SlurmrestdURL string        `json:"slurmrestdURL,omitempty"`
+}
+
 func (b *Builder) BuildTokenSecret(token *slinkyv1beta1.Token) (*corev1.Secret, error) {
 	ctx := context.TODO()
 
-	jwtHs256Ref := token.JwtHs256Ref()
-	signingKey, err := b.refResolver.GetSecretKeyRef(ctx, &jwtHs256Ref.SecretKeySelector, jwtHs256Ref.Namespace)
+	signingKey, owner, err := b.getTokenSigningKey(ctx, token)
 	if err != nil {
 		return nil, err
 	}
 
-	authToken, err := slurmjwt.NewToken(signingKey).
+	authToken, err := slurmjwt.NewTokenWithKey(signingKey).
 		WithUsername(token.Username()).
 		WithLifetime(token.Lifetime()).
 		NewSignedToken()
@@ -38,15 +50,136 @@ func (b *Builder) BuildTokenSecret(token *slinkyv1beta1.Token) (*corev1.Secret,
 		Immutable: !token.Spec.Refresh,
 	}
 
-	jwtHs256Secret := &corev1.Secret{}
-	if err := b.client.Get(ctx, token.JwtHs256Key(), jwtHs256Secret); err != nil {
-		return nil, err
+	if template := token.Spec.SecretTemplate; template != nil {
+		opts.Metadata = template.Metadata
+		if len(template.Formats) > 0 {
+			slurmrestdURL, err := b.getTokenSlurmrestdURL(ctx, token)
+			if err != nil {
+				return nil, err
+			}
+			for _, format := range template.Formats {
+				data, err := formatToken(format.Type, authToken, signingKey, token.Username(), slurmrestdURL)
+				if err != nil {
+					return nil, fmt.Errorf("failed to format Slurm auth token (%s): %w", format.Key, err)
+				}
+				opts.StringData[format.Key] = data
+			}
+		}
 	}
 
-	o, err := b.BuildSecret(opts, jwtHs256Secret)
+	o, err := b.BuildSecret(opts, owner)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build token secret: %w", err)
 	}
 
 	return o, nil
 }
+
+// formatToken returns the JWT in the format.
+func formatToken(
+	format slinkyv1beta1.TokenSecretFormatType,
+	authToken string,
+	signingKey *slurmjwt.SigningKey,
+	username, slurmrestdURL string,
+) (string, error) {
+	switch format {
+	case slinkyv1beta1.TokenSecretFormatEnv:
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "SLURM_JWT=%s\n", authToken)
+		if slurmrestdURL != "" {
+			fmt.Fprintf(&sb, "SLURMRESTD_URL=%s\n", slurmrestdURL)
+		}
+		return sb.String(), nil
+
+	case slinkyv1beta1.TokenSecretFormatNetrc:
+		machine := "default"
+		if slurmrestdURL != "" {
+			u, err := url.Parse(slurmrestdURL)
+			if err != nil {
+				return "", fmt.Errorf("failed to parse slurmrestd URL: %w", err)
+			}
+			machine = "machine " + u.Hostname()
+		}
+		return fmt.Sprintf("%s login %s password %s\n", machine, username, authToken), nil
+
+	case slinkyv1beta1.TokenSecretFormatJSON:
+		claims, err := slurmjwt.ParseTokenClaimsWithKeys(authToken, []*slurmjwt.SigningKey{signingKey})
+		if err != nil {
+			return "", err
+		}
+		data, err := json.Marshal(tokenJSON{
+			Token:         authToken,
+			Claims:        claims,
+			SlurmrestdURL: slurmrestdURL,
+		})
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+
+	default:
+		return "", fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+// getTokenSlurmrestdURL returns the URL of slurmrestd of the Token, which
+// defaults to the URL of the first Restapi of the Controller, by name.
+func (b *Builder) getTokenSlurmrestdURL(ctx context.Context, token *slinkyv1beta1.Token) (string, error) {
+	if token.Spec.SecretTemplate != nil && token.Spec.SecretTemplate.SlurmrestdURL != "" {
+		return token.Spec.SecretTemplate.SlurmrestdURL, nil
+	}
+	if !token.HasControllerRef() {
+		return "", nil
+	}
+
+	controller, err := b.refResolver.GetController(ctx, token.Spec.ControllerRef)
+	if err != nil {
+		return "", err
+	}
+	restapiList, err := b.refResolver.GetRestapisForController(ctx, controller)
+	if err != nil {
+		return "", err
+	}
+	if len(restapiList.Items) == 0 {
+		return "", nil
+	}
+	restapi := slices.MinFunc(restapiList.Items, func(a, b slinkyv1beta1.RestApi) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	scheme := "http"
+	if restapi.IsTLSEnabled() {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, restapi.ServiceFQDNShort(), SlurmrestdPort), nil
+}
+
+// getTokenSigningKey returns the key which signs the JWT of the Token, and
+// the object which owns the token Secret: either the Controller, or the
+// Secret of the HS256 key.
+func (b *Builder) getTokenSigningKey(ctx context.Context, token *slinkyv1beta1.Token) (*slurmjwt.SigningKey, metav1.Object, error) {
+	if token.HasControllerRef() {
+		controller, err := b.refResolver.GetController(ctx, token.Spec.ControllerRef)
+		if err != nil {
+			return nil, nil, err
+		}
+		signingKey, err := b.refResolver.GetJwtSigningKey(ctx, controller)
+		if err != nil {
+			return nil, nil, err
+		}
+		return signingKey, controller, nil
+	}
+
+	jwtHs256Ref := token.JwtHs256Ref()
+	key, err := b.refResolver.GetSecretKeyRef(ctx, &jwtHs256Ref.SecretKeySelector, jwtHs256Ref.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jwtHs256Secret := &corev1.Secret{}
+	if err := b.client.Get(ctx, token.JwtHs256Key(), jwtHs256Secret); err != nil {
+		return nil, nil, err
+	}
+
+	return slurmjwt.NewHS256SigningKey(key), jwtHs256Secret, nil
+}
@@ -5,9 +5,12 @@ package builder
 
 import (
 	_ "embed"
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -107,3 +110,94 @@ func TestBuilder_BuildTokenSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilder_BuildTokenSecret_SecretTemplate(t *testing.T) {
+	jwtHs256KeyRef := testutils.NewJwtHs256KeyRef("slurm")
+	jwtHs256KeySecret := testutils.NewJwtHs256KeySecret(jwtHs256KeyRef)
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), jwtHs256KeyRef, nil)
+	restapi := testutils.NewRestapi("slurm", controller)
+	restapiB := testutils.NewRestapi("slurm-b", controller)
+
+	token := testutils.NewToken("slurm", jwtHs256KeySecret)
+	token.Spec.JwtHs256KeyRef = slinkyv1beta1.JwtSecretKeySelector{}
+	token.Spec.ControllerRef = slinkyv1beta1.ObjectReference{Namespace: controller.Namespace, Name: controller.Name}
+	token.Spec.SecretTemplate = &slinkyv1beta1.TokenSecretTemplate{
+		Metadata: slinkyv1beta1.Metadata{
+			Labels: map[string]string{"foo": "bar"},
+		},
+		Formats: []slinkyv1beta1.TokenSecretFormat{
+			{Key: "slurm.env", Type: slinkyv1beta1.TokenSecretFormatEnv},
+			{Key: ".netrc", Type: slinkyv1beta1.TokenSecretFormatNetrc},
+			{Key: "token.json", Type: slinkyv1beta1.TokenSecretFormatJSON},
+		},
+	}
+	tokenWithURL := token.DeepCopy()
+	tokenWithURL.Spec.SecretTemplate.SlurmrestdURL = "https://slurmrestd.example.com:6820"
+
+	tests := []struct {
+		name          string
+		client        client.Client
+		token         *slinkyv1beta1.Token
+		wantURL       string
+		wantNetrcHost string
+	}{
+		{
+			name:          "Without Restapi",
+			client:        fake.NewClientBuilder().WithObjects(jwtHs256KeySecret, controller).Build(),
+			token:         token,
+			wantURL:       "",
+			wantNetrcHost: "default",
+		},
+		{
+			name:          "With Restapi",
+			client:        fake.NewClientBuilder().WithObjects(jwtHs256KeySecret, controller, restapiB, restapi).Build(),
+			token:         token,
+			wantURL:       fmt.Sprintf("http://%s:%d", restapi.ServiceFQDNShort(), SlurmrestdPort),
+			wantNetrcHost: "machine " + restapi.ServiceFQDNShort(),
+		},
+		{
+			name:          "With slurmrestd URL",
+			client:        fake.NewClientBuilder().WithObjects(jwtHs256KeySecret, controller, restapi).Build(),
+			token:         tokenWithURL,
+			wantURL:       "https://slurmrestd.example.com:6820",
+			wantNetrcHost: "machine slurmrestd.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.client)
+			got, err := b.BuildTokenSecret(tt.token)
+			if err != nil {
+				t.Fatalf("Builder.BuildTokenSecret() error = %v", err)
+			}
+			if got.Labels["foo"] != "bar" {
+				t.Errorf("Labels = %v, want foo=bar", got.Labels)
+			}
+			authToken := got.StringData[tt.token.SecretRef().Key]
+
+			wantEnv := fmt.Sprintf("SLURM_JWT=%s\n", authToken)
+			if tt.wantURL != "" {
+				wantEnv += fmt.Sprintf("SLURMRESTD_URL=%s\n", tt.wantURL)
+			}
+			if got := got.StringData["slurm.env"]; got != wantEnv {
+				t.Errorf("slurm.env = %q, want %q", got, wantEnv)
+			}
+
+			wantNetrc := fmt.Sprintf("%s login %s password %s\n", tt.wantNetrcHost, tt.token.Username(), authToken)
+			if got := got.StringData[".netrc"]; got != wantNetrc {
+				t.Errorf(".netrc = %q, want %q", got, wantNetrc)
+			}
+
+			gotJSON := tokenJSON{}
+			if err := json.Unmarshal([]byte(got.StringData["token.json"]), &gotJSON); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if gotJSON.Token != authToken || gotJSON.SlurmrestdURL != tt.wantURL {
+				t.Errorf("token.json = %+v", gotJSON)
+			}
+			if gotJSON.Claims["sun"] != tt.token.Username() {
+				t.Errorf("token.json claims[sun] = %v, want %v", gotJSON.Claims["sun"], tt.token.Username())
+			}
+		})
+	}
+}
@@ -6,12 +6,15 @@ package builder
 import (
 	_ "embed"
 	"fmt"
+	"maps"
 	"sort"
+	"strconv"
 	"strings"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
@@ -41,6 +44,7 @@ func (b *Builder) BuildWorkerPodTemplate(nodeset *slinkyv1beta1.NodeSet, control
 		WithAnnotations(map[string]string{
 			annotationDefaultContainer: labels.WorkerApp,
 		}).
+		WithAnnotations(slurmJwksAnnotations(controller, slinkyv1beta1.KeyRotationComponentNodeSet)).
 		Build()
 
 	spec := nodeset.Spec
@@ -75,7 +79,10 @@ func (b *Builder) BuildWorkerPodTemplate(nodeset *slinkyv1beta1.NodeSet, control
 		merge: template.PodSpec,
 	}
 
-	return b.buildPodTemplate(opts)
+	podTemplate := b.buildPodTemplate(opts)
+	b.withMunged(controller.Spec.Auth, &podTemplate)
+
+	return podTemplate
 }
 
 func nodesetVolumes(controller *slinkyv1beta1.Controller) []corev1.Volume {
@@ -85,18 +92,7 @@ func nodesetVolumes(controller *slinkyv1beta1.Controller) []corev1.Volume {
 			VolumeSource: corev1.VolumeSource{
 				Projected: &corev1.ProjectedVolumeSource{
 					DefaultMode: ptr.To[int32](0o600),
-					Sources: []corev1.VolumeProjection{
-						{
-							Secret: &corev1.SecretProjection{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: controller.AuthSlurmRef().Name,
-								},
-								Items: []corev1.KeyToPath{
-									{Key: controller.AuthSlurmRef().Key, Path: slurmKeyFile},
-								},
-							},
-						},
-					},
+					Sources:     slurmAuthVolumeProjections(controller.Spec.Auth, controller.AuthSlurmRef(), controller, slinkyv1beta1.KeyRotationComponentNodeSet),
 				},
 			},
 		},
@@ -188,20 +184,41 @@ func slurmdArgs(nodeset *slinkyv1beta1.NodeSet, controller *slinkyv1beta1.Contro
 }
 
 func slurmdConfArgs(nodeset *slinkyv1beta1.NodeSet) []string {
+	confMap := slurmdConf(nodeset)
+
+	confList := []string{}
+	for key, val := range confMap {
+		confList = append(confList, fmt.Sprintf("%s=%s", key, val))
+	}
+	sort.Strings(confList)
+
+	args := []string{
+		"--conf",
+		fmt.Sprintf("'%s'", strings.Join(confList, " ")),
+	}
+
+	return args
+}
+
+// WorkerFeatures returns the Slurm node features of the NodeSet pods, before
+// any derived from their Kubernetes node.
+func WorkerFeatures(nodeset *slinkyv1beta1.NodeSet) []string {
+	return strings.Split(slurmdConf(nodeset)["Features"], ",")
+}
+
+func slurmdConf(nodeset *slinkyv1beta1.NodeSet) map[string]string {
 	extraConf := []string{}
 	if nodeset.Spec.ExtraConf != "" {
 		extraConf = strings.Split(nodeset.Spec.ExtraConf, " ")
 	}
 
-	name := nodeset.Name
-	template := nodeset.Spec.Template.PodSpecWrapper
-	if template.Hostname != "" {
-		name = strings.Trim(template.Hostname, "-")
-	}
+	name := nodeset.SlurmName()
 
 	confMap := map[string]string{
 		"Features": name,
 	}
+	derived := slurmdNodeConf(nodeset)
+	maps.Copy(confMap, derived)
 	for _, item := range extraConf {
 		pair := strings.SplitN(item, "=", 2)
 		key := cases.Title(language.English).String(pair[0])
@@ -215,6 +232,13 @@ func slurmdConfArgs(nodeset *slinkyv1beta1.NodeSet) []string {
 			// least one feature but the user can request additional.
 			key = "Features"
 		}
+		for derivedKey := range derived {
+			// ExtraConf takes precedence over the derived parameters.
+			if strings.EqualFold(derivedKey, key) {
+				delete(confMap, derivedKey)
+				delete(derived, derivedKey)
+			}
+		}
 		if ret, ok := confMap[key]; !ok {
 			confMap[key] = val
 		} else {
@@ -222,16 +246,57 @@ func slurmdConfArgs(nodeset *slinkyv1beta1.NodeSet) []string {
 		}
 	}
 
-	confList := []string{}
-	for key, val := range confMap {
-		confList = append(confList, fmt.Sprintf("%s=%s", key, val))
+	return confMap
+}
+
+// slurmdNodeConf returns the Slurm node parameters derived from the slurmd
+// container resources.
+func slurmdNodeConf(nodeset *slinkyv1beta1.NodeSet) map[string]string {
+	nodeConf := nodeset.Spec.NodeConf
+	if nodeConf == nil || !nodeConf.FromResources {
+		return map[string]string{}
 	}
-	sort.Strings(confList)
 
-	args := []string{
-		"--conf",
-		fmt.Sprintf("'%s'", strings.Join(confList, " ")),
+	resources := nodeset.Spec.Slurmd.Resources
+	quantity := func(name corev1.ResourceName) (resource.Quantity, bool) {
+		if q, ok := resources.Limits[name]; ok {
+			return q, true
+		}
+		q, ok := resources.Requests[name]
+		return q, ok
 	}
 
-	return args
+	confMap := map[string]string{}
+	if cpu, ok := quantity(corev1.ResourceCPU); ok {
+		// Slurm only schedules whole CPUs.
+		cpus := max(cpu.MilliValue()/1000, 1)
+		confMap["CPUs"] = strconv.FormatInt(cpus, 10)
+	}
+	if memory, ok := quantity(corev1.ResourceMemory); ok {
+		bytes := memory.Value()
+		if nodeConf.MemoryReserve != nil {
+			bytes -= nodeConf.MemoryReserve.Value()
+		}
+		if realMemory := bytes / (1 << 20); realMemory > 0 {
+			confMap["RealMemory"] = strconv.FormatInt(realMemory, 10)
+		}
+	}
+	gres := []string{}
+	for _, mapping := range nodeConf.GresMappings() {
+		count, ok := quantity(mapping.Resource)
+		if !ok || count.Value() <= 0 {
+			continue
+		}
+		parts := []string{mapping.Name}
+		if mapping.Type != "" {
+			parts = append(parts, mapping.Type)
+		}
+		parts = append(parts, strconv.FormatInt(count.Value(), 10))
+		gres = append(gres, strings.Join(parts, ":"))
+	}
+	if len(gres) > 0 {
+		confMap["Gres"] = strings.Join(gres, ",")
+	}
+
+	return confMap
 }
@@ -5,14 +5,17 @@ package builder
 
 import (
 	_ "embed"
+	"reflect"
 	"strings"
 	"testing"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
 	"k8s.io/utils/set"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -105,3 +108,82 @@ func TestBuilder_BuildWorkerPodTemplate(t *testing.T) {
 		})
 	}
 }
+
+func Test_slurmdConfArgs(t *testing.T) {
+	newNodeSet := func(extraConf string, nodeConf *slinkyv1beta1.NodeSetNodeConf, resources corev1.ResourceRequirements) *slinkyv1beta1.NodeSet {
+		return &slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+			},
+			Spec: slinkyv1beta1.NodeSetSpec{
+				ExtraConf: extraConf,
+				NodeConf:  nodeConf,
+				Slurmd: slinkyv1beta1.ContainerWrapper{
+					Container: corev1.Container{
+						Resources: resources,
+					},
+				},
+			},
+		}
+	}
+	gpuResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("7500m"),
+			corev1.ResourceMemory: resource.MustParse("64Gi"),
+			"nvidia.com/gpu":      resource.MustParse("8"),
+		},
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    []string
+	}{
+		{
+			name:    "Default",
+			nodeset: newNodeSet("", nil, gpuResources),
+			want:    []string{"--conf", "'Features=foo'"},
+		},
+		{
+			name:    "ExtraConf",
+			nodeset: newNodeSet("features=bar weight=5", nil, corev1.ResourceRequirements{}),
+			want:    []string{"--conf", "'Features=foo,bar Weight=5'"},
+		},
+		{
+			name: "From resources",
+			nodeset: newNodeSet("", &slinkyv1beta1.NodeSetNodeConf{
+				FromResources: true,
+				MemoryReserve: ptr.To(resource.MustParse("1Gi")),
+			}, gpuResources),
+			want: []string{"--conf", "'CPUs=7 Features=foo Gres=gpu:8 RealMemory=64512'"},
+		},
+		{
+			name: "From requests, with GRES type",
+			nodeset: newNodeSet("", &slinkyv1beta1.NodeSetNodeConf{
+				FromResources: true,
+				Gres: []slinkyv1beta1.NodeSetGres{
+					{Resource: "nvidia.com/gpu", Type: "h100"},
+				},
+			}, corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+					"nvidia.com/gpu":   resource.MustParse("4"),
+				},
+			}),
+			want: []string{"--conf", "'CPUs=1 Features=foo Gres=gpu:h100:4'"},
+		},
+		{
+			name: "ExtraConf takes precedence",
+			nodeset: newNodeSet("realmemory=1000", &slinkyv1beta1.NodeSetNodeConf{
+				FromResources: true,
+			}, gpuResources),
+			want: []string{"--conf", "'CPUs=7 Features=foo Gres=gpu:8 Realmemory=1000'"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slurmdConfArgs(tt.nodeset); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("slurmdConfArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
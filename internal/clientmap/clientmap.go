@@ -5,21 +5,26 @@ package clientmap
 
 import (
 	"context"
+	"net/http"
 	"sync"
 
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/SlinkyProject/slurm-client/pkg/client"
+
+	"github.com/SlinkyProject/slurm-operator/internal/metrics"
 )
 
 type ClientMap struct {
-	lock    sync.RWMutex
-	clients map[string]client.Client
+	lock      sync.RWMutex
+	clients   map[string]client.Client
+	endpoints map[string]*Endpoints
 }
 
 func NewClientMap() *ClientMap {
 	return &ClientMap{
-		clients: make(map[string]client.Client),
+		clients:   make(map[string]client.Client),
+		endpoints: make(map[string]*Endpoints),
 	}
 }
 
@@ -33,6 +38,45 @@ func (c *ClientMap) Get(name types.NamespacedName) client.Client {
 	return nil
 }
 
+// GetEndpoints returns the slurmrestd endpoints of the client, or nil if the
+// client does not route requests through Endpoints.
+func (c *ClientMap) GetEndpoints(name types.NamespacedName) *Endpoints {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.endpoints[name.String()]
+}
+
+// GetHTTPClient returns an http.Client whose requests are routed by the
+// slurmrestd endpoints of the client, and recorded by the slurmrestd metrics.
+// Requests made through it fail over across the endpoints, regardless of the
+// server they were addressed to. Returns nil if the client does not route
+// requests through Endpoints.
+func (c *ClientMap) GetHTTPClient(name types.NamespacedName) *http.Client {
+	endpoints := c.GetEndpoints(name)
+	if endpoints == nil {
+		return nil
+	}
+	return &http.Client{
+		Transport: metrics.NewSlurmrestdTransport(name.String(), endpoints),
+	}
+}
+
+// GetEndpointHTTPClient returns the server of the named slurmrestd endpoint of
+// the client, and an http.Client whose requests are only sent to it, recorded
+// by the slurmrestd metrics. Returns nil if there is no such endpoint.
+func (c *ClientMap) GetEndpointHTTPClient(name types.NamespacedName, endpoint string) (string, *http.Client) {
+	endpoints := c.GetEndpoints(name)
+	if endpoints == nil {
+		return "", nil
+	}
+	server, httpClient := endpoints.HTTPClientFor(endpoint)
+	if httpClient == nil {
+		return "", nil
+	}
+	httpClient.Transport = metrics.NewSlurmrestdTransport(name.String(), httpClient.Transport)
+	return server, httpClient
+}
+
 func (c *ClientMap) Has(names ...types.NamespacedName) bool {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -49,6 +93,7 @@ func (c *ClientMap) add(name types.NamespacedName, client client.Client) bool {
 		ctx := context.TODO()
 		go client.Start(ctx)
 		c.clients[name.String()] = client
+		metrics.SlurmClients.WithLabelValues(name.String()).Set(1)
 		return true
 	}
 	return false
@@ -61,10 +106,21 @@ func (c *ClientMap) Add(name types.NamespacedName, client client.Client) bool {
 	return c.add(name, client)
 }
 
+// AddWithEndpoints adds the client, whose requests are routed by the endpoints.
+func (c *ClientMap) AddWithEndpoints(name types.NamespacedName, client client.Client, endpoints *Endpoints) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.remove(name)
+	c.endpoints[name.String()] = endpoints
+	return c.add(name, client)
+}
+
 func (c *ClientMap) remove(name types.NamespacedName) bool {
 	if client, ok := c.clients[name.String()]; ok {
 		client.Stop()
 		delete(c.clients, name.String())
+		delete(c.endpoints, name.String())
+		metrics.SlurmClients.DeleteLabelValues(name.String())
 		return true
 	}
 	return false
@@ -4,6 +4,9 @@
 package clientmap
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"sync"
 	"testing"
@@ -11,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmapiclient "github.com/SlinkyProject/slurm-client/pkg/client/api/v0044"
 	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
 )
 
@@ -22,7 +26,8 @@ func TestNewClientMap(t *testing.T) {
 		{
 			name: "Test new clusters",
 			want: &ClientMap{
-				clients: make(map[string]client.Client),
+				clients:   make(map[string]client.Client),
+				endpoints: make(map[string]*Endpoints),
 			},
 		},
 	}
@@ -323,3 +328,104 @@ func TestClientMap_Remove(t *testing.T) {
 		})
 	}
 }
+
+func TestClientMap_GetHTTPClient(t *testing.T) {
+	newServer := func(statusCode int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte("{}"))
+		}))
+	}
+	unavailable := newServer(http.StatusServiceUnavailable)
+	defer unavailable.Close()
+	ok := newServer(http.StatusOK)
+	defer ok.Close()
+
+	name := types.NamespacedName{Namespace: "default", Name: "foo"}
+	c := NewClientMap()
+	if got := c.GetHTTPClient(name); got != nil {
+		t.Errorf("ClientMap.GetHTTPClient() = %v, want nil", got)
+	}
+
+	endpoints := NewEndpoints()
+	if err := endpoints.Set([]Endpoint{
+		{Name: "unavailable", Server: unavailable.URL, Ready: true},
+		{Name: "ok", Server: ok.URL, Ready: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	c.AddWithEndpoints(name, fake.NewFakeClient(), endpoints)
+	defer c.Remove(name)
+
+	httpClient := c.GetHTTPClient(name)
+	if httpClient == nil {
+		t.Fatal("ClientMap.GetHTTPClient() = nil, want client")
+	}
+	// Addressed to the unavailable server, as a client created before the
+	// failover would be.
+	apiClient, err := slurmapiclient.NewSlurmClient(unavailable.URL, "token", httpClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := apiClient.SlurmdbV0044GetPingWithResponse(context.Background())
+	if err != nil {
+		t.Fatalf("SlurmdbV0044GetPing() error = %v", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Errorf("SlurmdbV0044GetPing() status = %v, want %v", res.StatusCode(), http.StatusOK)
+	}
+	if got := endpoints.Active(); got != ok.URL {
+		t.Errorf("Endpoints.Active() = %v, want %v", got, ok.URL)
+	}
+}
+
+func TestClientMap_GetEndpointHTTPClient(t *testing.T) {
+	newServer := func(statusCode int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte("{}"))
+		}))
+	}
+	unavailable := newServer(http.StatusServiceUnavailable)
+	defer unavailable.Close()
+	ok := newServer(http.StatusOK)
+	defer ok.Close()
+
+	name := types.NamespacedName{Namespace: "default", Name: "foo"}
+	c := NewClientMap()
+	if _, got := c.GetEndpointHTTPClient(name, "unavailable"); got != nil {
+		t.Errorf("ClientMap.GetEndpointHTTPClient() = %v, want nil", got)
+	}
+
+	endpoints := NewEndpoints()
+	if err := endpoints.Set([]Endpoint{
+		{Name: "ok", Server: ok.URL, Ready: true},
+		{Name: "unavailable", Server: unavailable.URL, Ready: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	c.AddWithEndpoints(name, fake.NewFakeClient(), endpoints)
+	defer c.Remove(name)
+
+	if _, got := c.GetEndpointHTTPClient(name, "missing"); got != nil {
+		t.Errorf("ClientMap.GetEndpointHTTPClient() = %v, want nil", got)
+	}
+	server, httpClient := c.GetEndpointHTTPClient(name, "unavailable")
+	if server != unavailable.URL || httpClient == nil {
+		t.Fatalf("ClientMap.GetEndpointHTTPClient() = %v, %v, want %v, client", server, httpClient, unavailable.URL)
+	}
+	apiClient, err := slurmapiclient.NewSlurmClient(server, "token", httpClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Not failed over to the other server.
+	res, err := apiClient.SlurmdbV0044GetPingWithResponse(context.Background())
+	if err != nil {
+		t.Fatalf("SlurmdbV0044GetPing() error = %v", err)
+	}
+	if res.StatusCode() != http.StatusServiceUnavailable {
+		t.Errorf("SlurmdbV0044GetPing() status = %v, want %v", res.StatusCode(), http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,333 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package clientmap
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// endpointBackoffInitial is how long a server is avoided after its first
+	// failed request.
+	endpointBackoffInitial = 5 * time.Second
+	// endpointBackoffMax is the longest a server is avoided after repeated
+	// failed requests.
+	endpointBackoffMax = 2 * time.Minute
+)
+
+// Endpoint is a slurmrestd server.
+type Endpoint struct {
+	// Name identifies the endpoint (e.g. the RestApi name).
+	Name string
+	// Server is the URL of the slurmrestd (e.g. `http://slurm-restapi:6820`).
+	Server string
+	// Ready is true when the endpoint passed its health check.
+	Ready bool
+	// TLS verifies an `https` server, if set.
+	TLS *EndpointTLS
+}
+
+// EndpointTLS is the PEM encoded TLS configuration of an endpoint.
+type EndpointTLS struct {
+	// CA verifies the server certificate. If empty, the system CAs are used.
+	CA []byte
+	// Cert is the client certificate presented to the server, if any.
+	Cert []byte
+	// Key is the private key of the client certificate.
+	Key []byte
+}
+
+// Equal reports if the TLS configurations are the same.
+func (o *EndpointTLS) Equal(other *EndpointTLS) bool {
+	if o == nil || other == nil {
+		return o == other
+	}
+	return bytes.Equal(o.CA, other.CA) &&
+		bytes.Equal(o.Cert, other.Cert) &&
+		bytes.Equal(o.Key, other.Key)
+}
+
+// newTransport returns a transport which verifies the server with the CA, and
+// presents the client certificate.
+func (o *EndpointTLS) newTransport() (*http.Transport, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if len(o.CA) > 0 {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(o.CA) {
+			return nil, errors.New("failed to parse CA certificate")
+		}
+	}
+	if len(o.Cert) > 0 || len(o.Key) > 0 {
+		cert, err := tls.X509KeyPair(o.Cert, o.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	return transport, nil
+}
+
+// tlsTransport is the transport of an endpoint with TLS.
+type tlsTransport struct {
+	tls       *EndpointTLS
+	transport *http.Transport
+}
+
+// Endpoints is the set of slurmrestd servers of a Controller, and an
+// http.RoundTripper routing requests to them.
+//
+// Requests are sent to the active server. When it cannot be reached, or is
+// unavailable, the server backs off and the request fails over to the next
+// server, preferring ready servers which are not backing off. Only idempotent
+// requests fail over once sent, others only when the server cannot be dialed,
+// such that a request is never run twice by slurmctld.
+type Endpoints struct {
+	lock      sync.RWMutex
+	endpoints []Endpoint
+	active    string
+
+	backoff    *flowcontrol.Backoff
+	transport  http.RoundTripper
+	transports map[string]*tlsTransport
+}
+
+var _ http.RoundTripper = &Endpoints{}
+
+func NewEndpoints() *Endpoints {
+	return &Endpoints{
+		backoff:    flowcontrol.NewBackOff(endpointBackoffInitial, endpointBackoffMax),
+		transport:  http.DefaultTransport,
+		transports: make(map[string]*tlsTransport),
+	}
+}
+
+// HTTPClient returns an http.Client whose requests are routed by the Endpoints.
+func (e *Endpoints) HTTPClient() *http.Client {
+	return &http.Client{Transport: e}
+}
+
+// HTTPClientFor returns the server of the named endpoint, and an http.Client
+// whose requests are only sent to it, without failover. Returns nil if there
+// is no such endpoint.
+func (e *Endpoints) HTTPClientFor(name string) (string, *http.Client) {
+	e.lock.RLock()
+	i := slices.IndexFunc(e.endpoints, func(ep Endpoint) bool { return ep.Name == name })
+	server := ""
+	if i >= 0 {
+		server = e.endpoints[i].Server
+	}
+	e.lock.RUnlock()
+	if server == "" {
+		return "", nil
+	}
+	return server, &http.Client{Transport: e.transportFor(server)}
+}
+
+// Set replaces the endpoints, in order of preference.
+func (e *Endpoints) Set(endpoints []Endpoint) error {
+	transports := make(map[string]*tlsTransport)
+	for _, ep := range endpoints {
+		if ep.TLS == nil {
+			continue
+		}
+		if t := e.getTLSTransport(ep.Server); t != nil && t.tls.Equal(ep.TLS) {
+			transports[ep.Server] = t
+			continue
+		}
+		transport, err := ep.TLS.newTransport()
+		if err != nil {
+			return fmt.Errorf("endpoint %s: %w", ep.Name, err)
+		}
+		transports[ep.Server] = &tlsTransport{tls: ep.TLS, transport: transport}
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for server, t := range e.transports {
+		if transports[server] != t {
+			t.transport.CloseIdleConnections()
+		}
+	}
+	e.transports = transports
+	e.endpoints = slices.Clone(endpoints)
+	e.backoff.GC()
+	if !slices.ContainsFunc(e.endpoints, func(ep Endpoint) bool { return ep.Server == e.active && ep.Ready }) {
+		e.active = ""
+	}
+	return nil
+}
+
+func (e *Endpoints) getTLSTransport(server string) *tlsTransport {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.transports[server]
+}
+
+// transportFor returns the transport of the server.
+func (e *Endpoints) transportFor(server string) http.RoundTripper {
+	if t := e.getTLSTransport(server); t != nil {
+		return t.transport
+	}
+	return e.transport
+}
+
+// List returns the endpoints. An endpoint which is backing off is not ready.
+func (e *Endpoints) List() []Endpoint {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	now := e.backoff.Clock.Now()
+	out := slices.Clone(e.endpoints)
+	for i := range out {
+		out[i].Ready = out[i].Ready && !e.backoff.IsInBackOffSinceUpdate(out[i].Server, now)
+	}
+	return out
+}
+
+// Active returns the server which requests are sent to first.
+func (e *Endpoints) Active() string {
+	if servers := e.candidates(); len(servers) > 0 {
+		return servers[0]
+	}
+	return ""
+}
+
+// candidates returns the servers in the order they are tried: the active
+// server, the other ready servers, the ready servers which are backing off,
+// and then the remaining servers as a last resort.
+func (e *Endpoints) candidates() []string {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	now := e.backoff.Clock.Now()
+	preferred := []string{}
+	backingOff := []string{}
+	unready := []string{}
+	for _, ep := range e.endpoints {
+		switch {
+		case !ep.Ready:
+			unready = append(unready, ep.Server)
+		case e.backoff.IsInBackOffSinceUpdate(ep.Server, now):
+			backingOff = append(backingOff, ep.Server)
+		case ep.Server == e.active:
+			preferred = slices.Insert(preferred, 0, ep.Server)
+		default:
+			preferred = append(preferred, ep.Server)
+		}
+	}
+	return slices.Concat(preferred, backingOff, unready)
+}
+
+func (e *Endpoints) succeeded(server string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.backoff.Reset(server)
+	e.active = server
+}
+
+func (e *Endpoints) failed(server string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.backoff.Next(server, e.backoff.Clock.Now())
+	if e.active == server {
+		e.active = ""
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (e *Endpoints) RoundTrip(req *http.Request) (*http.Response, error) {
+	servers := e.candidates()
+	if len(servers) == 0 {
+		return e.transport.RoundTrip(req)
+	}
+	// A request body can only be sent again if it can be recreated.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		servers = servers[:1]
+	}
+
+	var resp *http.Response
+	var err error
+	for i, server := range servers {
+		var r *http.Request
+		r, err = rewriteRequest(req, server, i > 0)
+		if err != nil {
+			return nil, err
+		}
+		resp, err = e.transportFor(server).RoundTrip(r)
+		if err == nil && !isUnavailable(resp.StatusCode) {
+			e.succeeded(server)
+			return resp, nil
+		}
+		if req.Context().Err() != nil {
+			return resp, err
+		}
+		e.failed(server)
+		if !canFailOver(req, err) {
+			return resp, err
+		}
+		if resp != nil && i < len(servers)-1 {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+	}
+	return resp, err
+}
+
+// canFailOver reports if the request can be sent to another server, after it
+// failed with the error or an unavailable response.
+func canFailOver(req *http.Request, err error) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	// The request was never sent when the server could not be dialed.
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// rewriteRequest returns a copy of the request sent to the server.
+func rewriteRequest(req *http.Request, server string, resend bool) (*http.Request, error) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	r := req.Clone(req.Context())
+	r.URL.Scheme = u.Scheme
+	r.URL.Host = u.Host
+	r.Host = ""
+	if resend && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+// isUnavailable reports if the response indicates that the server, rather than
+// the request, is at fault.
+func isUnavailable(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
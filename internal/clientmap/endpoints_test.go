@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package clientmap
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/util/flowcontrol"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func newTestEndpoints(clock *testingclock.FakeClock) *Endpoints {
+	e := NewEndpoints()
+	e.backoff = flowcontrol.NewFakeBackOff(endpointBackoffInitial, endpointBackoffMax, clock)
+	return e
+}
+
+func TestEndpoints_candidates(t *testing.T) {
+	tests := []struct {
+		name       string
+		endpoints  []Endpoint
+		active     string
+		backingOff []string
+		want       []string
+	}{
+		{
+			name: "Empty",
+			want: nil,
+		},
+		{
+			name: "In order",
+			endpoints: []Endpoint{
+				{Name: "a", Server: "http://a:6820", Ready: true},
+				{Name: "b", Server: "http://b:6820", Ready: true},
+			},
+			want: []string{"http://a:6820", "http://b:6820"},
+		},
+		{
+			name: "Active first",
+			endpoints: []Endpoint{
+				{Name: "a", Server: "http://a:6820", Ready: true},
+				{Name: "b", Server: "http://b:6820", Ready: true},
+			},
+			active: "http://b:6820",
+			want:   []string{"http://b:6820", "http://a:6820"},
+		},
+		{
+			name: "Backing off, then unready",
+			endpoints: []Endpoint{
+				{Name: "a", Server: "http://a:6820", Ready: false},
+				{Name: "b", Server: "http://b:6820", Ready: true},
+				{Name: "c", Server: "http://c:6820", Ready: true},
+			},
+			backingOff: []string{"http://b:6820"},
+			want:       []string{"http://c:6820", "http://b:6820", "http://a:6820"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEndpoints(testingclock.NewFakeClock(time.Now()))
+			if err := e.Set(tt.endpoints); err != nil {
+				t.Fatal(err)
+			}
+			e.active = tt.active
+			for _, server := range tt.backingOff {
+				e.failed(server)
+			}
+			if got := e.candidates(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Endpoints.candidates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndpoints_RoundTrip(t *testing.T) {
+	newServer := func(statusCode int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			w.WriteHeader(statusCode)
+			_, _ = w.Write(body)
+		}))
+	}
+	unavailable := newServer(http.StatusServiceUnavailable)
+	defer unavailable.Close()
+	ok := newServer(http.StatusOK)
+	defer ok.Close()
+	notFound := newServer(http.StatusNotFound)
+	defer notFound.Close()
+	closed := newServer(http.StatusOK)
+	closed.Close()
+
+	tests := []struct {
+		name           string
+		method         string
+		endpoints      []Endpoint
+		wantStatusCode int
+		wantErr        bool
+		wantActive     string
+		wantReady      []bool
+	}{
+		{
+			name:   "Active server",
+			method: http.MethodPost,
+			endpoints: []Endpoint{
+				{Name: "ok", Server: ok.URL, Ready: true},
+				{Name: "unavailable", Server: unavailable.URL, Ready: true},
+			},
+			wantStatusCode: http.StatusOK,
+			wantActive:     ok.URL,
+			wantReady:      []bool{true, true},
+		},
+		{
+			name:   "Failover when unavailable",
+			method: http.MethodGet,
+			endpoints: []Endpoint{
+				{Name: "unavailable", Server: unavailable.URL, Ready: true},
+				{Name: "ok", Server: ok.URL, Ready: true},
+			},
+			wantStatusCode: http.StatusOK,
+			wantActive:     ok.URL,
+			wantReady:      []bool{false, true},
+		},
+		{
+			name:   "Failover when unreachable",
+			method: http.MethodGet,
+			endpoints: []Endpoint{
+				{Name: "closed", Server: closed.URL, Ready: true},
+				{Name: "ok", Server: ok.URL, Ready: true},
+			},
+			wantStatusCode: http.StatusOK,
+			wantActive:     ok.URL,
+			wantReady:      []bool{false, true},
+		},
+		{
+			name:   "Request errors do not fail over",
+			method: http.MethodGet,
+			endpoints: []Endpoint{
+				{Name: "notFound", Server: notFound.URL, Ready: true},
+				{Name: "ok", Server: ok.URL, Ready: true},
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantActive:     notFound.URL,
+			wantReady:      []bool{true, true},
+		},
+		{
+			name:   "No failover of sent requests",
+			method: http.MethodPost,
+			endpoints: []Endpoint{
+				{Name: "unavailable", Server: unavailable.URL, Ready: true},
+				{Name: "ok", Server: ok.URL, Ready: true},
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantReady:      []bool{false, true},
+		},
+		{
+			name:   "Failover of unsent requests",
+			method: http.MethodPost,
+			endpoints: []Endpoint{
+				{Name: "closed", Server: closed.URL, Ready: true},
+				{Name: "ok", Server: ok.URL, Ready: true},
+			},
+			wantStatusCode: http.StatusOK,
+			wantActive:     ok.URL,
+			wantReady:      []bool{false, true},
+		},
+		{
+			name:   "All unavailable",
+			method: http.MethodGet,
+			endpoints: []Endpoint{
+				{Name: "unavailable", Server: unavailable.URL, Ready: true},
+				{Name: "closed", Server: closed.URL, Ready: true},
+			},
+			wantErr:   true,
+			wantReady: []bool{false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEndpoints(testingclock.NewFakeClock(time.Now()))
+			if err := e.Set(tt.endpoints); err != nil {
+				t.Fatal(err)
+			}
+			req, err := http.NewRequest(tt.method, "http://slurm-restapi:6820/slurm/v0044/nodes", strings.NewReader("foo"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := e.HTTPClient().Do(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Endpoints.RoundTrip() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				defer resp.Body.Close() //nolint:errcheck
+				body, _ := io.ReadAll(resp.Body)
+				if resp.StatusCode != tt.wantStatusCode || string(body) != "foo" {
+					t.Errorf("Endpoints.RoundTrip() = %v %q, want %v", resp.StatusCode, body, tt.wantStatusCode)
+				}
+			}
+			if got := e.Active(); tt.wantActive != "" && got != tt.wantActive {
+				t.Errorf("Endpoints.Active() = %v, want %v", got, tt.wantActive)
+			}
+			ready := []bool{}
+			for _, ep := range e.List() {
+				ready = append(ready, ep.Ready)
+			}
+			if !reflect.DeepEqual(ready, tt.wantReady) {
+				t.Errorf("Endpoints.List() ready = %v, want %v", ready, tt.wantReady)
+			}
+		})
+	}
+}
+
+func TestEndpoints_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tests := []struct {
+		name       string
+		tls        *EndpointTLS
+		wantSetErr bool
+		wantErr    bool
+	}{
+		{
+			name: "Verified by CA",
+			tls:  &EndpointTLS{CA: ca},
+		},
+		{
+			name:    "Not verified by system CAs",
+			tls:     &EndpointTLS{},
+			wantErr: true,
+		},
+		{
+			name:       "Invalid CA",
+			tls:        &EndpointTLS{CA: []byte("foo")},
+			wantSetErr: true,
+		},
+		{
+			name:       "Invalid client certificate",
+			tls:        &EndpointTLS{CA: ca, Cert: []byte("foo")},
+			wantSetErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEndpoints(testingclock.NewFakeClock(time.Now()))
+			err := e.Set([]Endpoint{{Name: "tls", Server: server.URL, Ready: true, TLS: tt.tls}})
+			if (err != nil) != tt.wantSetErr {
+				t.Fatalf("Endpoints.Set() error = %v, wantErr %v", err, tt.wantSetErr)
+			}
+			if err != nil {
+				return
+			}
+			req, err := http.NewRequest(http.MethodGet, "http://slurm-restapi:6820/slurm/v0044/ping", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := e.HTTPClient().Do(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Endpoints.RoundTrip() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		})
+	}
+}
+
+func TestEndpointTLS_Equal(t *testing.T) {
+	tests := []struct {
+		name  string
+		o     *EndpointTLS
+		other *EndpointTLS
+		want  bool
+	}{
+		{
+			name: "Both nil",
+			want: true,
+		},
+		{
+			name:  "One nil",
+			o:     &EndpointTLS{},
+			other: nil,
+			want:  false,
+		},
+		{
+			name:  "Same",
+			o:     &EndpointTLS{CA: []byte("ca"), Cert: []byte("cert"), Key: []byte("key")},
+			other: &EndpointTLS{CA: []byte("ca"), Cert: []byte("cert"), Key: []byte("key")},
+			want:  true,
+		},
+		{
+			name:  "Different",
+			o:     &EndpointTLS{CA: []byte("ca")},
+			other: &EndpointTLS{CA: []byte("other")},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.Equal(tt.other); got != tt.want {
+				t.Errorf("EndpointTLS.Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/accounting/eventhandler"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
@@ -33,6 +34,10 @@ const (
 
 	// BackoffGCInterval is the time that has to pass before next iteration of backoff GC is run
 	BackoffGCInterval = 1 * time.Minute
+
+	// statusResyncPeriod is how often health is re-evaluated while it can change
+	// without any Kubernetes event.
+	statusResyncPeriod = 30 * time.Second
 )
 
 func init() {
@@ -54,6 +59,8 @@ type AccountingReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 
+	ClientMap *clientmap.ClientMap
+
 	builder       *builder.Builder
 	refResolver   *refresolver.RefResolver
 	eventRecorder record.EventRecorderLogger
@@ -62,7 +69,7 @@ type AccountingReconciler struct {
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=accountings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=accountings/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=accountings/finalizers,verbs=update
-// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
@@ -113,6 +120,7 @@ func (r *AccountingReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Secret{}).
 		Watches(&slinkyv1beta1.Accounting{}, eventhandler.NewAccountingEventHandler(r.Client)).
+		Watches(&slinkyv1beta1.Controller{}, eventhandler.NewControllerEventHandler(r.Client)).
 		Watches(&corev1.Secret{}, eventhandler.NewSecretEventHandler(r.Client)).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles,
@@ -120,13 +128,15 @@ func (r *AccountingReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func NewReconciler(c client.Client) *AccountingReconciler {
+func NewReconciler(c client.Client, cm *clientmap.ClientMap) *AccountingReconciler {
 	s := c.Scheme()
 	es := corev1.EventSource{Component: ControllerName}
 	return &AccountingReconciler{
 		Client: c,
 		Scheme: s,
 
+		ClientMap: cm,
+
 		builder:       builder.New(c),
 		refResolver:   refresolver.New(c),
 		eventRecorder: record.NewBroadcaster().NewRecorder(s, es),
@@ -36,6 +36,24 @@ func (r *AccountingReconciler) Sync(ctx context.Context, req reconcile.Request)
 	}
 
 	syncSteps := []SyncStep{
+		{
+			Name: "Keys",
+			Sync: func(ctx context.Context, accounting *slinkyv1beta1.Accounting) error {
+				if accounting.Spec.External {
+					return nil
+				}
+				objects, err := r.builder.BuildAccountingGeneratedKeys(accounting)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+				for _, object := range objects {
+					if err := objectutils.SyncGeneratedSecret(r.Client, ctx, object, accounting); err != nil {
+						return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+					}
+				}
+				return nil
+			},
+		},
 		{
 			Name: "Service",
 			Sync: func(ctx context.Context, accounting *slinkyv1beta1.Accounting) error {
@@ -52,6 +70,35 @@ func (r *AccountingReconciler) Sync(ctx context.Context, req reconcile.Request)
 				return nil
 			},
 		},
+		{
+			// Publish the keys before `slurmdbd.conf` refers to them.
+			Name: "Jwks",
+			Sync: func(ctx context.Context, accounting *slinkyv1beta1.Accounting) error {
+				if accounting.Spec.External {
+					return nil
+				}
+				object, err := r.builder.BuildAccountingJwks(accounting)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+
+				hasJwks, err := r.builder.HasAccountingJwks(ctx, accounting)
+				if err != nil {
+					return err
+				}
+				if !hasJwks {
+					if err := objectutils.DeleteObject(r.Client, ctx, object); err != nil {
+						return fmt.Errorf("failed to delete object (%s): %w", klog.KObj(object), err)
+					}
+					return nil
+				}
+
+				if err := objectutils.SyncObject(r.Client, ctx, object, true); err != nil {
+					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+				}
+				return nil
+			},
+		},
 		{
 			Name: "Config",
 			Sync: func(ctx context.Context, accounting *slinkyv1beta1.Accounting) error {
@@ -90,7 +137,7 @@ func (r *AccountingReconciler) Sync(ctx context.Context, req reconcile.Request)
 		if err := s.Sync(ctx, cluster); err != nil {
 			e := fmt.Errorf("[%s]: %w", s.Name, err)
 			errors := []error{e}
-			if err := r.syncStatus(ctx, cluster); err != nil {
+			if err := r.syncStatus(ctx, cluster, e); err != nil {
 				e := fmt.Errorf("[%s]: %w", s.Name, err)
 				errors = append(errors, e)
 			}
@@ -98,5 +145,5 @@ func (r *AccountingReconciler) Sync(ctx context.Context, req reconcile.Request)
 		}
 	}
 
-	return r.syncStatus(ctx, cluster)
+	return r.syncStatus(ctx, cluster, nil)
 }
@@ -5,32 +5,69 @@ package accounting
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 
+	appsv1 "k8s.io/api/apps/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmapi "github.com/SlinkyProject/slurm-client/pkg/client/api/v0044"
+
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/statusutils"
 )
 
 // syncStatus handles determining and updating the status.
 func (r *AccountingReconciler) syncStatus(
 	ctx context.Context,
 	accounting *slinkyv1beta1.Accounting,
+	syncErr error,
 ) error {
 	logger := log.FromContext(ctx)
 
+	workload, err := r.getWorkloadStatus(ctx, accounting)
+	if err != nil {
+		return err
+	}
+	obs := statusutils.Observations{
+		Generation: accounting.Generation,
+		Workload:   &workload,
+		SyncErr:    syncErr,
+		ConfigErr:  r.validateConfig(ctx, accounting),
+	}
+	if slurmClient, httpClient, err := r.getSlurmClient(ctx, accounting); err != nil {
+		return err
+	} else if slurmClient != nil {
+		obs.Pinged = true
+		obs.PingErr = pingSlurmdbd(ctx, slurmClient, httpClient)
+	}
+
+	generatedKeys, err := statusutils.NewGeneratedKeysStatus(ctx, r.Client, accounting.Namespace, accounting.GeneratedKeyRefs())
+	if err != nil {
+		return err
+	}
+
 	newStatus := &slinkyv1beta1.AccountingStatus{
-		Conditions: []metav1.Condition{},
+		ObservedGeneration: accounting.Generation,
+		GeneratedKeys:      generatedKeys,
+		Conditions:         statusutils.NewConditions(accounting.Status.Conditions, obs),
 	}
-	newStatus.Conditions = append(newStatus.Conditions, accounting.Status.Conditions...)
 
-	if apiequality.Semantic.DeepEqual(accounting.Status, newStatus) {
+	// Ping results change without any Kubernetes event, so poll.
+	if obs.Pinged || !statusutils.IsReady(newStatus.Conditions) {
+		durationStore.Push(objectutils.KeyFunc(accounting), statusResyncPeriod)
+	}
+
+	if apiequality.Semantic.DeepEqual(accounting.Status, *newStatus) {
 		logger.V(2).Info("Accounting Status has not changed, skipping status update",
 			"accounting", klog.KObj(accounting), "status", accounting.Status)
 		return nil
@@ -44,6 +81,84 @@ func (r *AccountingReconciler) syncStatus(
 	return nil
 }
 
+// validateConfig checks that the referenced Secrets can be resolved.
+func (r *AccountingReconciler) validateConfig(
+	ctx context.Context,
+	accounting *slinkyv1beta1.Accounting,
+) error {
+	errs := []error{}
+	if accounting.IsMungeAuth() {
+		if _, err := r.refResolver.GetSecretKeyRef(ctx, accounting.AuthMungeRef(), accounting.Namespace); err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve `auth.mungeKeyRef`: %w", err))
+		}
+	} else if _, err := r.refResolver.GetSecretKeyRef(ctx, accounting.AuthSlurmRef(), accounting.Namespace); err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve `slurmKeyRef`: %w", err))
+	}
+	if _, err := r.refResolver.GetSecretKeyRef(ctx, accounting.AuthJwtHs256Ref(), accounting.Namespace); err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve `jwtHs256KeyRef`: %w", err))
+	}
+	if _, err := r.refResolver.GetSecretKeyRef(ctx, accounting.AuthStorageRef(), accounting.Namespace); err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve `storageConfig.passwordKeyRef`: %w", err))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// getWorkloadStatus returns the status of the slurmdbd StatefulSet.
+func (r *AccountingReconciler) getWorkloadStatus(
+	ctx context.Context,
+	accounting *slinkyv1beta1.Accounting,
+) (statusutils.WorkloadStatus, error) {
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, accounting.Key(), sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			return statusutils.NewStatefulSetStatus(nil), nil
+		}
+		return statusutils.WorkloadStatus{}, err
+	}
+	return statusutils.NewStatefulSetStatus(sts), nil
+}
+
+// getSlurmClient returns the slurm client of a Controller using the Accounting,
+// and the HTTP client routed by its slurmrestd endpoints, or nil if there is
+// none.
+func (r *AccountingReconciler) getSlurmClient(
+	ctx context.Context,
+	accounting *slinkyv1beta1.Accounting,
+) (slurmclient.Client, *http.Client, error) {
+	controllerList, err := r.refResolver.GetControllersForAccounting(ctx, accounting)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, controller := range controllerList.Items {
+		if slurmClient := r.ClientMap.Get(controller.Key()); slurmClient != nil {
+			return slurmClient, r.ClientMap.GetHTTPClient(controller.Key()), nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// pingSlurmdbd returns an error if no slurmdbd responds to a ping, as relayed
+// by slurmrestd.
+var pingSlurmdbd = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client) error {
+	client, err := slurmapi.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	res, err := client.SlurmdbV0044GetPingWithResponse(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to ping slurmdbd: %w", err)
+	}
+	if res.StatusCode() != http.StatusOK || res.JSON200 == nil {
+		return fmt.Errorf("failed to ping slurmdbd: %s", http.StatusText(res.StatusCode()))
+	}
+	for _, ping := range res.JSON200.Pings {
+		if ping.Responding {
+			return nil
+		}
+	}
+	return errors.New("no slurmdbd is responding")
+}
+
 func (r *AccountingReconciler) updateStatus(
 	ctx context.Context,
 	cluster *slinkyv1beta1.Accounting,
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_ControllerEventHandler_Update(t *testing.T) {
+	slurmKeyRef := testutils.NewSlurmKeyRef("foo")
+	jwtHs256KeyRef := testutils.NewJwtHs256KeyRef("foo")
+	passwordRef := testutils.NewPasswordRef("foo")
+	accounting := testutils.NewAccounting("slurm1", slurmKeyRef, jwtHs256KeyRef, passwordRef)
+	accounting2 := testutils.NewAccounting("slurm2", slurmKeyRef, jwtHs256KeyRef, passwordRef)
+	controller := testutils.NewController("slurm", slurmKeyRef, jwtHs256KeyRef, accounting)
+	controller2 := testutils.NewController("slurm", slurmKeyRef, jwtHs256KeyRef, accounting2)
+	noAccounting := testutils.NewController("slurm", slurmKeyRef, jwtHs256KeyRef, nil)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "smoke",
+			fields: fields{
+				Reader: fake.NewFakeClient(accounting, controller),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: controller,
+					ObjectNew: controller,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Changed Accounting",
+			fields: fields{
+				Reader: fake.NewFakeClient(accounting, accounting2, controller2),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: controller,
+					ObjectNew: controller2,
+				},
+				q: newQueue(),
+			},
+			want: 2,
+		},
+		{
+			name: "No Accounting",
+			fields: fields{
+				Reader: fake.NewFakeClient(accounting, noAccounting),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: noAccounting,
+					ObjectNew: noAccounting,
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewControllerEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("ControllerEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,15 +6,20 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
 )
 
@@ -37,6 +42,24 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 	}
 
 	syncSteps := []SyncStep{
+		{
+			Name: "Keys",
+			Sync: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+				if controller.Spec.External {
+					return nil
+				}
+				objects, err := r.builder.BuildControllerGeneratedKeys(controller)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+				for _, object := range objects {
+					if err := objectutils.SyncGeneratedSecret(r.Client, ctx, object, controller); err != nil {
+						return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+					}
+				}
+				return nil
+			},
+		},
 		{
 			Name: "Service",
 			Sync: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
@@ -53,6 +76,59 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 				return nil
 			},
 		},
+		{
+			Name: "HostServices",
+			Sync: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+				if controller.Spec.External {
+					return nil
+				}
+				return r.syncHostServices(ctx, controller)
+			},
+		},
+		{
+			// Publish the keys before `slurm.conf` refers to them.
+			Name: "Jwks",
+			Sync: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+				object, err := r.builder.BuildControllerJwks(controller)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+
+				if controller.Spec.JwtKeys == nil {
+					if err := objectutils.DeleteObject(r.Client, ctx, object); err != nil {
+						return fmt.Errorf("failed to delete object (%s): %w", klog.KObj(object), err)
+					}
+					return nil
+				}
+
+				if err := objectutils.SyncObject(r.Client, ctx, object, true); err != nil {
+					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+				}
+				return nil
+			},
+		},
+		{
+			// Stage the keys before any component mounts them.
+			Name: "SlurmJwks",
+			Sync: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+				object, err := r.builder.BuildControllerSlurmJwks(controller)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+
+				if controller.Spec.External || controller.Spec.SlurmKeyRotation == nil {
+					if err := objectutils.DeleteObject(r.Client, ctx, object); err != nil {
+						return fmt.Errorf("failed to delete object (%s): %w", klog.KObj(object), err)
+					}
+					return nil
+				}
+
+				if err := objectutils.SyncObject(r.Client, ctx, object, true); err != nil {
+					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+				}
+				return nil
+			},
+		},
 		{
 			Name: "Config",
 			Sync: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
@@ -115,7 +191,7 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 		if err := s.Sync(ctx, controller); err != nil {
 			e := fmt.Errorf("[%s]: %w", s.Name, err)
 			errors := []error{e}
-			if err := r.syncStatus(ctx, controller); err != nil {
+			if err := r.syncStatus(ctx, controller, e); err != nil {
 				e := fmt.Errorf("[%s]: %w", s.Name, err)
 				errors = append(errors, e)
 			}
@@ -123,5 +199,59 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 		}
 	}
 
-	return r.syncStatus(ctx, controller)
+	return r.syncStatus(ctx, controller, nil)
+}
+
+// syncHostServices creates a Service per slurmctld replica when there are
+// backup controllers, and deletes the Services of removed replicas.
+func (r *ControllerReconciler) syncHostServices(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+	replicas := int32(0)
+	if controller.IsHighlyAvailable() {
+		replicas = controller.Replicas()
+	}
+
+	for i := range replicas {
+		object, err := r.builder.BuildControllerHostService(controller, i)
+		if err != nil {
+			return fmt.Errorf("failed to build: %w", err)
+		}
+		if err := objectutils.SyncObject(r.Client, ctx, object, true); err != nil {
+			return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+		}
+	}
+
+	serviceList := &corev1.ServiceList{}
+	opts := []client.ListOption{
+		client.InNamespace(controller.Namespace),
+		client.MatchingLabels(labels.NewBuilder().WithControllerLabels(controller).Build()),
+	}
+	if err := r.List(ctx, serviceList, opts...); err != nil {
+		return err
+	}
+	for i := range serviceList.Items {
+		service := &serviceList.Items[i]
+		if !metav1.IsControlledBy(service, controller) || !isStaleHostService(controller, service.Name, replicas) {
+			continue
+		}
+		if err := objectutils.DeleteObject(r.Client, ctx, service); err != nil {
+			return fmt.Errorf("failed to delete object (%s): %w", klog.KObj(service), err)
+		}
+	}
+
+	return nil
+}
+
+// isStaleHostService reports if the Service belongs to a slurmctld replica
+// with an ordinal beyond the replicas.
+func isStaleHostService(controller *slinkyv1beta1.Controller, name string, replicas int32) bool {
+	prefix := controller.Key().Name + "-"
+	suffix, ok := strings.CutPrefix(name, prefix)
+	if !ok {
+		return false
+	}
+	ordinal, err := strconv.ParseInt(suffix, 10, 32)
+	if err != nil || ordinal < 0 {
+		return false
+	}
+	return name == controller.HostName(int32(ordinal)) && int32(ordinal) >= replicas
 }
@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+)
+
+// keyRotationTarget is a workload of a component rolled by a rotation of the
+// `auth/slurm` key.
+type keyRotationTarget struct {
+	namespace string
+	selector  map[string]string
+	replicas  int32
+}
+
+// getKeyRotationStatus advances the rotation of the `auth/slurm` key. Each
+// phase rolls one component at a time, in order, and only once all pods of a
+// component are ready with the `slurm.jwks` file of the phase does the next
+// component roll. Once all components rolled, the next phase starts.
+func (r *ControllerReconciler) getKeyRotationStatus(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	now time.Time,
+) (*slinkyv1beta1.KeyRotationStatus, error) {
+	rotation := controller.Spec.SlurmKeyRotation
+	if rotation == nil || controller.Spec.External {
+		return nil, nil
+	}
+
+	status := &slinkyv1beta1.KeyRotationStatus{
+		Phase: slinkyv1beta1.KeyRotationPhaseStaging,
+	}
+	if controller.Status.KeyRotation != nil {
+		status = controller.Status.KeyRotation.DeepCopy()
+	}
+
+	if rotation.Rollback {
+		switch status.Phase {
+		case slinkyv1beta1.KeyRotationPhaseStaging, slinkyv1beta1.KeyRotationPhaseSwitching:
+			status.Phase = slinkyv1beta1.KeyRotationPhaseRollingBack
+			status.Message = "Rollback was requested"
+		case slinkyv1beta1.KeyRotationPhaseRetiring, slinkyv1beta1.KeyRotationPhaseComplete:
+			status.Message = "Cannot roll back once the current key is being retired"
+		}
+	}
+
+	switch status.Phase {
+	case slinkyv1beta1.KeyRotationPhaseComplete, slinkyv1beta1.KeyRotationPhaseRolledBack:
+		status.Component = ""
+		return status, nil
+	}
+
+	for _, component := range slinkyv1beta1.KeyRotationComponents {
+		idx := indexKeyRotationComponent(status, component)
+		if idx < 0 && status.Phase == slinkyv1beta1.KeyRotationPhaseRollingBack {
+			// The component never had the new key, so there is nothing to roll back.
+			continue
+		}
+		if idx < 0 {
+			status.Components = append(status.Components, slinkyv1beta1.KeyRotationComponentStatus{Name: component})
+			idx = len(status.Components) - 1
+		}
+		componentStatus := &status.Components[idx]
+
+		if componentStatus.Phase != status.Phase {
+			componentStatus.Phase = status.Phase
+			componentStatus.StartTime = metav1.NewTime(now)
+			componentStatus.Rolled = false
+			status.Component = component
+			return status, nil
+		}
+		if componentStatus.Rolled {
+			continue
+		}
+
+		status.Component = component
+		rolled, err := r.isKeyRotationComponentRolled(ctx, controller, component, builder.SlurmJwksFileKey(status.Phase))
+		if err != nil {
+			return nil, err
+		}
+		if !rolled {
+			deadline := time.Duration(rotation.ProgressDeadlineSeconds) * time.Second
+			if deadline <= 0 || now.Before(componentStatus.StartTime.Add(deadline)) {
+				return status, nil
+			}
+			switch status.Phase {
+			case slinkyv1beta1.KeyRotationPhaseStaging, slinkyv1beta1.KeyRotationPhaseSwitching:
+				status.Phase = slinkyv1beta1.KeyRotationPhaseRollingBack
+				status.Message = fmt.Sprintf("%s did not roll within the progress deadline, rolling back", component)
+			default:
+				status.Message = fmt.Sprintf("%s did not roll within the progress deadline", component)
+			}
+			return status, nil
+		}
+		componentStatus.Rolled = true
+	}
+
+	status.Component = ""
+	switch status.Phase {
+	case slinkyv1beta1.KeyRotationPhaseStaging:
+		status.Phase = slinkyv1beta1.KeyRotationPhaseSwitching
+	case slinkyv1beta1.KeyRotationPhaseSwitching:
+		status.Phase = slinkyv1beta1.KeyRotationPhaseRetiring
+	case slinkyv1beta1.KeyRotationPhaseRetiring:
+		status.Phase = slinkyv1beta1.KeyRotationPhaseComplete
+		status.Message = ""
+	case slinkyv1beta1.KeyRotationPhaseRollingBack:
+		status.Phase = slinkyv1beta1.KeyRotationPhaseRolledBack
+	}
+
+	return status, nil
+}
+
+// indexKeyRotationComponent returns the index of the component in the status,
+// or -1 when it is not there.
+func indexKeyRotationComponent(status *slinkyv1beta1.KeyRotationStatus, component slinkyv1beta1.KeyRotationComponent) int {
+	for i := range status.Components {
+		if status.Components[i].Name == component {
+			return i
+		}
+	}
+	return -1
+}
+
+// isKeyRotationComponentRolled reports if all workloads of the component have
+// their replicas ready with the `slurm.jwks` file, and no other pods.
+func (r *ControllerReconciler) isKeyRotationComponentRolled(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	component slinkyv1beta1.KeyRotationComponent,
+	fileKey string,
+) (bool, error) {
+	targets, err := r.getKeyRotationTargets(ctx, controller, component)
+	if err != nil {
+		return false, err
+	}
+
+	for _, target := range targets {
+		podList := &corev1.PodList{}
+		opts := []client.ListOption{
+			client.InNamespace(target.namespace),
+			client.MatchingLabels(target.selector),
+		}
+		if err := r.List(ctx, podList, opts...); err != nil {
+			return false, err
+		}
+		ready := int32(0)
+		for i := range podList.Items {
+			pod := &podList.Items[i]
+			if pod.Annotations[slinkyv1beta1.AnnotationSlurmJwks] != fileKey || !podutils.IsHealthy(pod) {
+				return false, nil
+			}
+			ready++
+		}
+		if ready < target.replicas {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// getKeyRotationTargets returns the workloads of the component.
+func (r *ControllerReconciler) getKeyRotationTargets(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	component slinkyv1beta1.KeyRotationComponent,
+) ([]keyRotationTarget, error) {
+	targets := []keyRotationTarget{}
+
+	switch component {
+	case slinkyv1beta1.KeyRotationComponentAccounting:
+		if controller.Spec.AccountingRef.Name == "" {
+			return targets, nil
+		}
+		accounting, err := r.refResolver.GetAccounting(ctx, controller.Spec.AccountingRef)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return targets, nil
+			}
+			return nil, err
+		}
+		if accounting.Spec.External {
+			return targets, nil
+		}
+		targets = append(targets, keyRotationTarget{
+			namespace: accounting.Namespace,
+			selector:  labels.NewBuilder().WithAccountingSelectorLabels(accounting).Build(),
+			replicas:  1,
+		})
+
+	case slinkyv1beta1.KeyRotationComponentController:
+		targets = append(targets, keyRotationTarget{
+			namespace: controller.Namespace,
+			selector:  labels.NewBuilder().WithControllerSelectorLabels(controller).Build(),
+			replicas:  controller.Replicas(),
+		})
+
+	case slinkyv1beta1.KeyRotationComponentRestApi:
+		list, err := r.refResolver.GetRestapisForController(ctx, controller)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			restapi := &list.Items[i]
+			targets = append(targets, keyRotationTarget{
+				namespace: restapi.Namespace,
+				selector:  labels.NewBuilder().WithRestapiSelectorLabels(restapi).Build(),
+				replicas:  ptr.Deref(restapi.Spec.Replicas, 1),
+			})
+		}
+
+	case slinkyv1beta1.KeyRotationComponentLoginSet:
+		list, err := r.refResolver.GetLoginSetsForController(ctx, controller)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			loginset := &list.Items[i]
+			targets = append(targets, keyRotationTarget{
+				namespace: loginset.Namespace,
+				selector:  labels.NewBuilder().WithLoginSelectorLabels(loginset).Build(),
+				replicas:  ptr.Deref(loginset.Spec.Replicas, 1),
+			})
+		}
+
+	case slinkyv1beta1.KeyRotationComponentNodeSet:
+		list, err := r.refResolver.GetNodeSetsForController(ctx, controller)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			nodeset := &list.Items[i]
+			targets = append(targets, keyRotationTarget{
+				namespace: nodeset.Namespace,
+				selector:  labels.NewBuilder().WithWorkerSelectorLabels(nodeset).Build(),
+				replicas:  ptr.Deref(nodeset.Spec.Replicas, 1),
+			})
+		}
+	}
+
+	return targets, nil
+}
+
+// isKeyRotationInProgress reports if the rotation has not finished.
+func isKeyRotationInProgress(status *slinkyv1beta1.KeyRotationStatus) bool {
+	if status == nil {
+		return false
+	}
+	switch status.Phase {
+	case slinkyv1beta1.KeyRotationPhaseComplete, slinkyv1beta1.KeyRotationPhaseRolledBack:
+		return false
+	default:
+		return true
+	}
+}
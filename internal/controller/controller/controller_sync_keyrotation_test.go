@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_getKeyRotationStatus(t *testing.T) {
+	now := time.Now()
+	start := metav1.NewTime(now.Add(-time.Minute))
+	newController := func(status *slinkyv1beta1.KeyRotationStatus, rollback bool) *slinkyv1beta1.Controller {
+		controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+		controller.Spec.SlurmKeyRotation = &slinkyv1beta1.SlurmKeyRotation{
+			NewSlurmKeyRef:          testutils.NewSlurmKeyRef("slurm-new"),
+			Rollback:                rollback,
+			ProgressDeadlineSeconds: 1800,
+		}
+		controller.Status.KeyRotation = status
+		return controller
+	}
+	newControllerPod := func(controller *slinkyv1beta1.Controller, phase slinkyv1beta1.KeyRotationPhase) client.Object {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      controller.HostName(0),
+				Namespace: controller.Namespace,
+				Labels:    labels.NewBuilder().WithControllerSelectorLabels(controller).Build(),
+				Annotations: map[string]string{
+					slinkyv1beta1.AnnotationSlurmJwks: builder.SlurmJwksFileKey(phase),
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+		return pod
+	}
+	rolled := func(phase slinkyv1beta1.KeyRotationPhase, components ...slinkyv1beta1.KeyRotationComponent) []slinkyv1beta1.KeyRotationComponentStatus {
+		out := []slinkyv1beta1.KeyRotationComponentStatus{}
+		for _, component := range components {
+			out = append(out, slinkyv1beta1.KeyRotationComponentStatus{
+				Name:      component,
+				Phase:     phase,
+				StartTime: start,
+				Rolled:    true,
+			})
+		}
+		return out
+	}
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		objs       []client.Object
+		want       *slinkyv1beta1.KeyRotationStatus
+	}{
+		{
+			name:       "Without rotation",
+			controller: testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil),
+			want:       nil,
+		},
+		{
+			name:       "Start",
+			controller: newController(nil, false),
+			want: &slinkyv1beta1.KeyRotationStatus{
+				Phase:     slinkyv1beta1.KeyRotationPhaseStaging,
+				Component: slinkyv1beta1.KeyRotationComponentAccounting,
+				Components: []slinkyv1beta1.KeyRotationComponentStatus{
+					{Name: slinkyv1beta1.KeyRotationComponentAccounting, Phase: slinkyv1beta1.KeyRotationPhaseStaging, StartTime: metav1.NewTime(now)},
+				},
+			},
+		},
+		{
+			name: "Component without workloads is rolled",
+			controller: newController(&slinkyv1beta1.KeyRotationStatus{
+				Phase:     slinkyv1beta1.KeyRotationPhaseStaging,
+				Component: slinkyv1beta1.KeyRotationComponentAccounting,
+				Components: []slinkyv1beta1.KeyRotationComponentStatus{
+					{Name: slinkyv1beta1.KeyRotationComponentAccounting, Phase: slinkyv1beta1.KeyRotationPhaseStaging, StartTime: start},
+				},
+			}, false),
+			want: &slinkyv1beta1.KeyRotationStatus{
+				Phase:     slinkyv1beta1.KeyRotationPhaseStaging,
+				Component: slinkyv1beta1.KeyRotationComponentController,
+				Components: []slinkyv1beta1.KeyRotationComponentStatus{
+					{Name: slinkyv1beta1.KeyRotationComponentAccounting, Phase: slinkyv1beta1.KeyRotationPhaseStaging, StartTime: start, Rolled: true},
+					{Name: slinkyv1beta1.KeyRotationComponentController, Phase: slinkyv1beta1.KeyRotationPhaseStaging, StartTime: metav1.NewTime(now)},
+				},
+			},
+		},
+		{
+			name: "Component pods not rolled",
+			controller: newController(&slinkyv1beta1.KeyRotationStatus{
+				Phase:     slinkyv1beta1.KeyRotationPhaseSwitching,
+				Component: slinkyv1beta1.KeyRotationComponentController,
+				Components: append(rolled(slinkyv1beta1.KeyRotationPhaseSwitching, slinkyv1beta1.KeyRotationComponentAccounting),
+					slinkyv1beta1.KeyRotationComponentStatus{Name: slinkyv1beta1.KeyRotationComponentController, Phase: slinkyv1beta1.KeyRotationPhaseSwitching, StartTime: start}),
+			}, false),
+			objs: []client.Object{newControllerPod(newController(nil, false), slinkyv1beta1.KeyRotationPhaseStaging)},
+			want: &slinkyv1beta1.KeyRotationStatus{
+				Phase:     slinkyv1beta1.KeyRotationPhaseSwitching,
+				Component: slinkyv1beta1.KeyRotationComponentController,
+				Components: append(rolled(slinkyv1beta1.KeyRotationPhaseSwitching, slinkyv1beta1.KeyRotationComponentAccounting),
+					slinkyv1beta1.KeyRotationComponentStatus{Name: slinkyv1beta1.KeyRotationComponentController, Phase: slinkyv1beta1.KeyRotationPhaseSwitching, StartTime: start}),
+			},
+		},
+		{
+			name: "Component pods rolled",
+			controller: newController(&slinkyv1beta1.KeyRotationStatus{
+				Phase:     slinkyv1beta1.KeyRotationPhaseSwitching,
+				Component: slinkyv1beta1.KeyRotationComponentController,
+				Components: append(rolled(slinkyv1beta1.KeyRotationPhaseSwitching, slinkyv1beta1.KeyRotationComponentAccounting),
+					slinkyv1beta1.KeyRotationComponentStatus{Name: slinkyv1beta1.KeyRotationComponentController, Phase: slinkyv1beta1.KeyRotationPhaseSwitching, StartTime: start}),
+			}, false),
+			objs: []client.Object{newControllerPod(newController(nil, false), slinkyv1beta1.KeyRotationPhaseSwitching)},
+			want: &slinkyv1beta1.KeyRotationStatus{
+				Phase:     slinkyv1beta1.KeyRotationPhaseSwitching,
+				Component: slinkyv1beta1.KeyRotationComponentRestApi,
+				Components: append(rolled(slinkyv1beta1.KeyRotationPhaseSwitching, slinkyv1beta1.KeyRotationComponentAccounting, slinkyv1beta1.KeyRotationComponentController),
+					slinkyv1beta1.KeyRotationComponentStatus{Name: slinkyv1beta1.KeyRotationComponentRestApi, Phase: slinkyv1beta1.KeyRotationPhaseSwitching, StartTime: metav1.NewTime(now)}),
+			},
+		},
+		{
+			name: "All components rolled",
+			controller: newController(&slinkyv1beta1.KeyRotationStatus{
+				Phase:      slinkyv1beta1.KeyRotationPhaseStaging,
+				Components: rolled(slinkyv1beta1.KeyRotationPhaseStaging, slinkyv1beta1.KeyRotationComponents...),
+			}, false),
+			want: &slinkyv1beta1.KeyRotationStatus{
+				Phase:      slinkyv1beta1.KeyRotationPhaseSwitching,
+				Components: rolled(slinkyv1beta1.KeyRotationPhaseStaging, slinkyv1beta1.KeyRotationComponents...),
+			},
+		},
+		{
+			name: "Progress deadline exceeded",
+			controller: newController(&slinkyv1beta1.KeyRotationStatus{
+				Phase:     slinkyv1beta1.KeyRotationPhaseSwitching,
+				Component: slinkyv1beta1.KeyRotationComponentController,
+				Components: append(rolled(slinkyv1beta1.KeyRotationPhaseSwitching, slinkyv1beta1.KeyRotationComponentAccounting),
+					slinkyv1beta1.KeyRotationComponentStatus{Name: slinkyv1beta1.KeyRotationComponentController, Phase: slinkyv1beta1.KeyRotationPhaseSwitching, StartTime: metav1.NewTime(now.Add(-time.Hour))}),
+			}, false),
+			want: &slinkyv1beta1.KeyRotationStatus{
+				Phase:     slinkyv1beta1.KeyRotationPhaseRollingBack,
+				Component: slinkyv1beta1.KeyRotationComponentController,
+				Components: append(rolled(slinkyv1beta1.KeyRotationPhaseSwitching, slinkyv1beta1.KeyRotationComponentAccounting),
+					slinkyv1beta1.KeyRotationComponentStatus{Name: slinkyv1beta1.KeyRotationComponentController, Phase: slinkyv1beta1.KeyRotationPhaseSwitching, StartTime: metav1.NewTime(now.Add(-time.Hour))}),
+				Message: "Controller did not roll within the progress deadline, rolling back",
+			},
+		},
+		{
+			name: "Rollback skips components without the new key",
+			controller: newController(&slinkyv1beta1.KeyRotationStatus{
+				Phase:      slinkyv1beta1.KeyRotationPhaseStaging,
+				Components: rolled(slinkyv1beta1.KeyRotationPhaseStaging, slinkyv1beta1.KeyRotationComponentAccounting),
+			}, true),
+			want: &slinkyv1beta1.KeyRotationStatus{
+				Phase:     slinkyv1beta1.KeyRotationPhaseRollingBack,
+				Component: slinkyv1beta1.KeyRotationComponentAccounting,
+				Components: []slinkyv1beta1.KeyRotationComponentStatus{
+					{Name: slinkyv1beta1.KeyRotationComponentAccounting, Phase: slinkyv1beta1.KeyRotationPhaseRollingBack, StartTime: metav1.NewTime(now)},
+				},
+				Message: "Rollback was requested",
+			},
+		},
+		{
+			name: "Rolled back",
+			controller: newController(&slinkyv1beta1.KeyRotationStatus{
+				Phase:      slinkyv1beta1.KeyRotationPhaseRollingBack,
+				Components: rolled(slinkyv1beta1.KeyRotationPhaseRollingBack, slinkyv1beta1.KeyRotationComponentAccounting),
+				Message:    "Rollback was requested",
+			}, true),
+			want: &slinkyv1beta1.KeyRotationStatus{
+				Phase:      slinkyv1beta1.KeyRotationPhaseRolledBack,
+				Components: rolled(slinkyv1beta1.KeyRotationPhaseRollingBack, slinkyv1beta1.KeyRotationComponentAccounting),
+				Message:    "Rollback was requested",
+			},
+		},
+		{
+			name: "Cannot roll back while retiring",
+			controller: newController(&slinkyv1beta1.KeyRotationStatus{
+				Phase:      slinkyv1beta1.KeyRotationPhaseRetiring,
+				Component:  slinkyv1beta1.KeyRotationComponentAccounting,
+				Components: rolled(slinkyv1beta1.KeyRotationPhaseRetiring, slinkyv1beta1.KeyRotationComponentAccounting),
+			}, true),
+			want: &slinkyv1beta1.KeyRotationStatus{
+				Phase:     slinkyv1beta1.KeyRotationPhaseRetiring,
+				Component: slinkyv1beta1.KeyRotationComponentController,
+				Components: append(rolled(slinkyv1beta1.KeyRotationPhaseRetiring, slinkyv1beta1.KeyRotationComponentAccounting),
+					slinkyv1beta1.KeyRotationComponentStatus{Name: slinkyv1beta1.KeyRotationComponentController, Phase: slinkyv1beta1.KeyRotationPhaseRetiring, StartTime: metav1.NewTime(now)}),
+				Message: "Cannot roll back once the current key is being retired",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReconciler(fake.NewClientBuilder().WithObjects(tt.objs...).Build(), clientmap.NewClientMap())
+			got, err := r.getKeyRotationStatus(context.TODO(), tt.controller, now)
+			if err != nil {
+				t.Fatalf("getKeyRotationStatus() error = %v", err)
+			}
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("getKeyRotationStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
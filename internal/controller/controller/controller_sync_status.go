@@ -5,32 +5,101 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/statusutils"
 )
 
 // syncStatus handles determining and updating the status.
 func (r *ControllerReconciler) syncStatus(
 	ctx context.Context,
 	controller *slinkyv1beta1.Controller,
+	syncErr error,
 ) error {
 	logger := log.FromContext(ctx)
 
+	obs := statusutils.Observations{
+		Generation: controller.Generation,
+		SyncErr:    syncErr,
+		ConfigErr:  r.validateConfig(ctx, controller),
+	}
+	if !controller.Spec.External {
+		workload, err := r.getWorkloadStatus(ctx, controller)
+		if err != nil {
+			return err
+		}
+		obs.Workload = &workload
+	}
+	if slurmClient := r.ClientMap.Get(controller.Key()); slurmClient != nil {
+		obs.Pinged = true
+		obs.PingErr = pingSlurmctld(ctx, slurmClient)
+	}
+
+	slurmConfig, err := r.getSlurmConfigStatus(ctx, controller)
+	if err != nil {
+		return err
+	}
+
+	jwtKeys, err := r.getJwtKeysStatus(ctx, controller)
+	if err != nil {
+		return err
+	}
+
+	generatedKeys, err := statusutils.NewGeneratedKeysStatus(ctx, r.Client, controller.Namespace, controller.GeneratedKeyRefs())
+	if err != nil {
+		return err
+	}
+
+	keyRotation, err := r.getKeyRotationStatus(ctx, controller, time.Now())
+	if err != nil {
+		return err
+	}
+
 	newStatus := &slinkyv1beta1.ControllerStatus{
-		Conditions: []metav1.Condition{},
+		ObservedGeneration: controller.Generation,
+		SlurmConfig:        slurmConfig,
+		Slurmrestd:         newSlurmrestdStatus(r.ClientMap.GetEndpoints(controller.Key())),
+		JwtKeys:            jwtKeys,
+		GeneratedKeys:      generatedKeys,
+		KeyRotation:        keyRotation,
+		Conditions:         statusutils.NewConditions(controller.Status.Conditions, obs),
 	}
-	newStatus.Conditions = append(newStatus.Conditions, controller.Status.Conditions...)
+	setJwtKeysRotatedCondition(&newStatus.Conditions, jwtKeys, controller.Generation)
 
-	if apiequality.Semantic.DeepEqual(controller.Status, newStatus) {
+	// Ping results, JWT signing, and the pods of other components change
+	// without any Kubernetes event, so poll.
+	if obs.Pinged || !statusutils.IsReady(newStatus.Conditions) ||
+		meta.IsStatusConditionFalse(newStatus.Conditions, slinkyv1beta1.ConditionJwtKeysRotated) ||
+		isKeyRotationInProgress(keyRotation) {
+		durationStore.Push(objectutils.KeyFunc(controller), statusResyncPeriod)
+	}
+
+	if apiequality.Semantic.DeepEqual(controller.Status, *newStatus) {
 		logger.V(2).Info("Controller Status has not changed, skipping status update",
 			"controller", klog.KObj(controller), "status", controller.Status)
 		return nil
@@ -44,6 +113,219 @@ func (r *ControllerReconciler) syncStatus(
 	return nil
 }
 
+// validateConfig checks that the referenced Secrets can be resolved and that
+// the SlurmConfig can be merged.
+func (r *ControllerReconciler) validateConfig(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+) error {
+	errs := []error{}
+	for _, key := range controller.SlurmConfigKeys() {
+		if slinkyv1beta1.IsReservedSlurmConfigKey(key) {
+			errs = append(errs, fmt.Errorf("`slurmConfig` parameter is reserved and ignored: %s", key))
+		}
+	}
+	if duplicates := controller.SlurmConfigDuplicates(); len(duplicates) > 0 {
+		errs = append(errs, fmt.Errorf("`slurmConfig` has duplicate parameters: %s", strings.Join(duplicates, ", ")))
+	}
+	if controller.IsMungeAuth() {
+		if _, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthMungeRef(), controller.Namespace); err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve `auth.mungeKeyRef`: %w", err))
+		}
+	} else if _, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthSlurmRef(), controller.Namespace); err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve `slurmKeyRef`: %w", err))
+	}
+	if _, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthJwtHs256Ref(), controller.Namespace); err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve `jwtHs256KeyRef`: %w", err))
+	}
+	if ref := controller.AuthSlurmNewRef(); ref != nil {
+		if _, err := r.refResolver.GetSecretKeyRef(ctx, ref, controller.Namespace); err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve `slurmKeyRotation.newSlurmKeyRef`: %w", err))
+		}
+	}
+	if controller.Spec.JwtKeys != nil {
+		if _, err := r.refResolver.GetJwtSigningKey(ctx, controller); err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve `jwtKeys`: %w", err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// getSlurmConfigStatus returns the observed state of the `slurm.conf`, as
+// rendered in the ConfigMap. External controllers have none.
+func (r *ControllerReconciler) getSlurmConfigStatus(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+) (*slinkyv1beta1.SlurmConfigStatus, error) {
+	if controller.Spec.External {
+		return nil, nil
+	}
+	status := &slinkyv1beta1.SlurmConfigStatus{
+		Conflicts: controller.SlurmConfigConflicts(),
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, controller.ConfigKey(), configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	status.Preview = configMap.Data[builder.SlurmConfFile]
+	if len(status.Conflicts) == 0 {
+		status.Conflicts = nil
+	}
+	return status, nil
+}
+
+// getJwtKeysStatus returns the observed state of the JWT keys, from the key
+// IDs of the JWTs of the Tokens of the Controller and of the slurm client. It
+// is nil when the Controller has no JWT keys.
+func (r *ControllerReconciler) getJwtKeysStatus(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+) (*slinkyv1beta1.JwtKeysStatus, error) {
+	logger := log.FromContext(ctx)
+
+	if controller.Spec.JwtKeys == nil {
+		return nil, nil
+	}
+
+	status := &slinkyv1beta1.JwtKeysStatus{
+		SigningKeyID:    controller.JwtSigningKeyID(),
+		PublishedKeyIDs: controller.JwtKeyIDs(),
+	}
+	inUse := sets.New(status.SigningKeyID)
+
+	tokenList, err := r.refResolver.GetTokensForController(ctx, controller)
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range tokenList.Items {
+		keyID, err := r.getTokenKeyID(ctx, &token)
+		if err != nil {
+			logger.V(1).Info("Token key ID is unknown", "token", klog.KObj(&token), "error", err)
+		} else {
+			inUse.Insert(keyID)
+		}
+		if err != nil || keyID != status.SigningKeyID {
+			status.PendingTokens = append(status.PendingTokens, objectutils.KeyFunc(&token))
+		}
+	}
+	slices.Sort(status.PendingTokens)
+
+	if slurmClient := r.ClientMap.Get(controller.Key()); slurmClient != nil {
+		keyID, err := slurmjwt.ParseKeyID(slurmClient.GetToken())
+		if err != nil {
+			logger.V(1).Info("Slurm client key ID is unknown", "error", err)
+		} else {
+			status.ClientKeyID = keyID
+			inUse.Insert(keyID)
+		}
+	}
+
+	for _, keyID := range status.PublishedKeyIDs {
+		if !inUse.Has(keyID) {
+			status.RetirableKeyIDs = append(status.RetirableKeyIDs, keyID)
+		}
+	}
+
+	return status, nil
+}
+
+// getTokenKeyID returns the key ID of the JWT of the Token.
+func (r *ControllerReconciler) getTokenKeyID(ctx context.Context, token *slinkyv1beta1.Token) (string, error) {
+	authToken, err := r.refResolver.GetSecretKeyRef(ctx, token.SecretRef(), token.Namespace)
+	if err != nil {
+		return "", err
+	}
+	return slurmjwt.ParseKeyID(string(authToken))
+}
+
+// setJwtKeysRotatedCondition sets the JwtKeysRotated condition, which tracks
+// the JWT key rotation: JWTs are signed again by the signing key, then the
+// other keys may be removed.
+func setJwtKeysRotatedCondition(
+	conditions *[]metav1.Condition,
+	status *slinkyv1beta1.JwtKeysStatus,
+	generation int64,
+) {
+	if status == nil {
+		meta.RemoveStatusCondition(conditions, slinkyv1beta1.ConditionJwtKeysRotated)
+		return
+	}
+
+	cond := metav1.Condition{
+		Type:               slinkyv1beta1.ConditionJwtKeysRotated,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             slinkyv1beta1.ReasonAsExpected,
+		Message:            fmt.Sprintf("All JWTs are signed by key (%s)", status.SigningKeyID),
+	}
+	pending := slices.Clone(status.PendingTokens)
+	if status.ClientKeyID != "" && status.ClientKeyID != status.SigningKeyID {
+		pending = append(pending, "slurm-operator")
+	}
+	switch {
+	case len(pending) > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = slinkyv1beta1.ReasonResigning
+		cond.Message = fmt.Sprintf("Waiting for JWTs to be signed by key (%s): %s",
+			status.SigningKeyID, strings.Join(pending, ", "))
+	case len(status.RetirableKeyIDs) > 0:
+		cond.Reason = slinkyv1beta1.ReasonKeysRetirable
+		cond.Message = fmt.Sprintf("Keys no longer sign any JWT, and may be removed: %s",
+			strings.Join(status.RetirableKeyIDs, ", "))
+	}
+	meta.SetStatusCondition(conditions, cond)
+}
+
+// getWorkloadStatus returns the status of the slurmctld StatefulSet.
+func (r *ControllerReconciler) getWorkloadStatus(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+) (statusutils.WorkloadStatus, error) {
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, controller.Key(), sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			return statusutils.NewStatefulSetStatus(nil), nil
+		}
+		return statusutils.WorkloadStatus{}, err
+	}
+	return statusutils.NewStatefulSetStatus(sts), nil
+}
+
+// newSlurmrestdStatus returns the observed state of the slurmrestd endpoints,
+// or nil when the slurm client does not route requests through them.
+func newSlurmrestdStatus(endpoints *clientmap.Endpoints) *slinkyv1beta1.SlurmrestdStatus {
+	if endpoints == nil {
+		return nil
+	}
+	status := &slinkyv1beta1.SlurmrestdStatus{
+		Active: endpoints.Active(),
+	}
+	for _, ep := range endpoints.List() {
+		status.Endpoints = append(status.Endpoints, slinkyv1beta1.SlurmrestdEndpoint{
+			Name:   ep.Name,
+			Server: ep.Server,
+			Ready:  ep.Ready,
+		})
+	}
+	return status
+}
+
+// pingSlurmctld returns an error if no slurmctld responds to a ping.
+func pingSlurmctld(ctx context.Context, slurmClient slurmclient.Client) error {
+	pingList := &slurmtypes.V0044ControllerPingList{}
+	if err := slurmClient.List(ctx, pingList, &slurmclient.ListOptions{SkipCache: true}); err != nil {
+		return fmt.Errorf("failed to ping slurmctld: %w", err)
+	}
+	for _, ping := range pingList.Items {
+		if ping.Responding {
+			return nil
+		}
+	}
+	return errors.New("no slurmctld is responding")
+}
+
 func (r *ControllerReconciler) updateStatus(
 	ctx context.Context,
 	controller *slinkyv1beta1.Controller,
@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_newSlurmrestdStatus(t *testing.T) {
+	newEndpoints := func(endpoints ...clientmap.Endpoint) *clientmap.Endpoints {
+		e := clientmap.NewEndpoints()
+		if err := e.Set(endpoints); err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+	tests := []struct {
+		name      string
+		endpoints *clientmap.Endpoints
+		want      *slinkyv1beta1.SlurmrestdStatus
+	}{
+		{
+			name: "No endpoints",
+			want: nil,
+		},
+		{
+			name: "Active is the first ready endpoint",
+			endpoints: newEndpoints(
+				clientmap.Endpoint{Name: "a", Server: "http://a:6820", Ready: false},
+				clientmap.Endpoint{Name: "b", Server: "http://b:6820", Ready: true},
+			),
+			want: &slinkyv1beta1.SlurmrestdStatus{
+				Active: "http://b:6820",
+				Endpoints: []slinkyv1beta1.SlurmrestdEndpoint{
+					{Name: "a", Server: "http://a:6820", Ready: false},
+					{Name: "b", Server: "http://b:6820", Ready: true},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newSlurmrestdStatus(tt.endpoints); !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("newSlurmrestdStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getJwtKeysStatus(t *testing.T) {
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+	controller.Spec.JwtKeys = &slinkyv1beta1.JwtKeys{
+		SigningKeyID: "b",
+		Keys: []slinkyv1beta1.JwtKey{
+			{KeyID: "a", PrivateKeyRef: testutils.NewJwtKeyRef("a")},
+			{KeyID: "b", PrivateKeyRef: testutils.NewJwtKeyRef("b")},
+			{KeyID: "c", PrivateKeyRef: testutils.NewJwtKeyRef("c")},
+		},
+	}
+	newToken := func(name, keyID string) []client.Object {
+		token := testutils.NewToken(name, testutils.NewJwtHs256KeySecret(testutils.NewJwtHs256KeyRef("slurm")))
+		token.Spec.JwtHs256KeyRef = slinkyv1beta1.JwtSecretKeySelector{}
+		token.Spec.ControllerRef = testutils.NewObjectRef(controller)
+		signingKey := slurmjwt.NewHS256SigningKey([]byte("secret"))
+		signingKey.KeyID = keyID
+		authToken, err := slurmjwt.NewTokenWithKey(signingKey).NewSignedToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: token.Namespace,
+				Name:      token.SecretRef().Name,
+			},
+			Data: map[string][]byte{
+				token.SecretRef().Key: []byte(authToken),
+			},
+		}
+		return []client.Object{token, secret}
+	}
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		objs       []client.Object
+		want       *slinkyv1beta1.JwtKeysStatus
+	}{
+		{
+			name:       "Without JWT keys",
+			controller: testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil),
+			want:       nil,
+		},
+		{
+			name:       "Resigning",
+			controller: controller,
+			objs:       append(newToken("old", "a"), newToken("new", "b")...),
+			want: &slinkyv1beta1.JwtKeysStatus{
+				SigningKeyID:    "b",
+				PublishedKeyIDs: []string{"a", "b", "c"},
+				PendingTokens:   []string{"default/old"},
+				RetirableKeyIDs: []string{"c"},
+			},
+		},
+		{
+			name:       "Rotated",
+			controller: controller,
+			objs:       newToken("new", "b"),
+			want: &slinkyv1beta1.JwtKeysStatus{
+				SigningKeyID:    "b",
+				PublishedKeyIDs: []string{"a", "b", "c"},
+				RetirableKeyIDs: []string{"a", "c"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReconciler(fake.NewClientBuilder().WithObjects(tt.objs...).Build(), clientmap.NewClientMap())
+			got, err := r.getJwtKeysStatus(context.TODO(), tt.controller)
+			if err != nil {
+				t.Fatalf("getJwtKeysStatus() error = %v", err)
+			}
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("getJwtKeysStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setJwtKeysRotatedCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     *slinkyv1beta1.JwtKeysStatus
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:   "Without JWT keys",
+			status: nil,
+		},
+		{
+			name: "Pending tokens",
+			status: &slinkyv1beta1.JwtKeysStatus{
+				SigningKeyID:  "b",
+				PendingTokens: []string{"default/token"},
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: slinkyv1beta1.ReasonResigning,
+		},
+		{
+			name: "Pending slurm client",
+			status: &slinkyv1beta1.JwtKeysStatus{
+				SigningKeyID: "b",
+				ClientKeyID:  "a",
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: slinkyv1beta1.ReasonResigning,
+		},
+		{
+			name: "Retirable keys",
+			status: &slinkyv1beta1.JwtKeysStatus{
+				SigningKeyID:    "b",
+				ClientKeyID:     "b",
+				RetirableKeyIDs: []string{"a"},
+			},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: slinkyv1beta1.ReasonKeysRetirable,
+		},
+		{
+			name: "Rotated",
+			status: &slinkyv1beta1.JwtKeysStatus{
+				SigningKeyID: "b",
+				ClientKeyID:  "b",
+			},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: slinkyv1beta1.ReasonAsExpected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions := []metav1.Condition{
+				{Type: slinkyv1beta1.ConditionJwtKeysRotated, Status: metav1.ConditionUnknown},
+			}
+			setJwtKeysRotatedCondition(&conditions, tt.status, 1)
+			got := meta.FindStatusCondition(conditions, slinkyv1beta1.ConditionJwtKeysRotated)
+			if tt.status == nil {
+				if got != nil {
+					t.Errorf("setJwtKeysRotatedCondition() = %v, want none", got)
+				}
+				return
+			}
+			if got == nil || got.Status != tt.wantStatus || got.Reason != tt.wantReason {
+				t.Errorf("setJwtKeysRotatedCondition() = %v, want status %v reason %v", got, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
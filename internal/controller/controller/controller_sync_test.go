@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func Test_isStaleHostService(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	type args struct {
+		name     string
+		replicas int32
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "Controller service",
+			args: args{name: "slurm-controller", replicas: 0},
+			want: false,
+		},
+		{
+			name: "Host service in use",
+			args: args{name: "slurm-controller-1", replicas: 2},
+			want: false,
+		},
+		{
+			name: "Host service of removed replica",
+			args: args{name: "slurm-controller-2", replicas: 2},
+			want: true,
+		},
+		{
+			name: "Host services without backups",
+			args: args{name: "slurm-controller-0", replicas: 0},
+			want: true,
+		},
+		{
+			name: "Not a host service",
+			args: args{name: "slurm-controller-foo", replicas: 0},
+			want: false,
+		},
+		{
+			name: "Not an ordinal",
+			args: args{name: "slurm-controller-01", replicas: 0},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isStaleHostService(controller, tt.args.name, tt.args.replicas); got != tt.want {
+				t.Errorf("isStaleHostService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+func NewNodeEventHandler(reader client.Reader) *NodeEventHandler {
+	return &NodeEventHandler{
+		Reader:      reader,
+		refResolver: refresolver.New(reader),
+	}
+}
+
+var _ handler.EventHandler = &NodeEventHandler{}
+
+// NodeEventHandler enqueues the Controller of NodeSet pods on a Kubernetes
+// node when its topology labels change.
+type NodeEventHandler struct {
+	client.Reader
+	refResolver *refresolver.RefResolver
+}
+
+// Create implements handler.EventHandler
+func (h *NodeEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+// Delete implements handler.EventHandler
+func (h *NodeEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+// Generic implements handler.EventHandler
+func (h *NodeEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+// Update implements handler.EventHandler
+func (h *NodeEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	oldNode, ok := evt.ObjectOld.(*corev1.Node)
+	if !ok {
+		return
+	}
+	newNode, ok := evt.ObjectNew.(*corev1.Node)
+	if !ok {
+		return
+	}
+
+	h.enqueueControllersForNode(ctx, oldNode, newNode, q)
+}
+
+func (h *NodeEventHandler) enqueueControllersForNode(
+	ctx context.Context,
+	oldNode, newNode *corev1.Node,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	logger := log.FromContext(ctx)
+
+	podList := &corev1.PodList{}
+	opts := []client.ListOption{
+		client.MatchingFields{
+			"spec.nodeName": newNode.Name,
+		},
+	}
+	if err := h.List(ctx, podList, opts...); err != nil {
+		logger.Error(err, "failed to list pods", "node", newNode.Name)
+		return
+	}
+
+	for _, pod := range podList.Items {
+		controller := getTopologyControllerForPod(ctx, h.Reader, h.refResolver, &pod)
+		if controller == nil {
+			continue
+		}
+		labelChanged := slices.ContainsFunc(controller.Spec.Topology.LabelKeys, func(key string) bool {
+			return oldNode.Labels[key] != newNode.Labels[key]
+		})
+		if !labelChanged {
+			continue
+		}
+		logger.Info("Node topology labels changed, reconcile Controller with Pod on Node",
+			"node", newNode.Name, "controller", klog.KObj(controller), "pod", klog.KObj(&pod))
+		objectutils.EnqueueRequest(q, controller)
+	}
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/indexes"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_NodeEventHandler_Update(t *testing.T) {
+	controller := newTopologyController("slurm")
+	nodeset := testutils.NewNodeset("gpu", controller, 1)
+	pod := newNodeSetPod(nodeset, "node-a")
+	newNode := func(labels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-a",
+				Labels: labels,
+			},
+		}
+	}
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Topology label changed",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(controller, nodeset, pod).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNode(map[string]string{corev1.LabelTopologyZone: "us-east-1a"}),
+					ObjectNew: newNode(map[string]string{corev1.LabelTopologyZone: "us-east-1b"}),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Other label changed",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(controller, nodeset, pod).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNode(map[string]string{corev1.LabelTopologyZone: "us-east-1a"}),
+					ObjectNew: newNode(map[string]string{corev1.LabelTopologyZone: "us-east-1a", "foo": "bar"}),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "No pods on node",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(controller, nodeset).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNode(nil),
+					ObjectNew: newNode(map[string]string{corev1.LabelTopologyZone: "us-east-1a"}),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewNodeEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("NodeEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
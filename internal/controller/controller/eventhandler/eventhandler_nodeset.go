@@ -64,6 +64,9 @@ func (e *NodesetEventHandler) Update(
 	evt event.UpdateEvent,
 	q workqueue.TypedRateLimitingInterface[reconcile.Request],
 ) {
+	// The NodeSet may have moved to another Controller, whose partition
+	// membership then changes too.
+	e.enqueueRequest(ctx, evt.ObjectOld, q)
 	e.enqueueRequest(ctx, evt.ObjectNew, q)
 }
 
@@ -162,7 +162,9 @@ func Test_NodeSetEventHandler_Update(t *testing.T) {
 	slurmKeyRef := testutils.NewSlurmKeyRef("foo")
 	jwtHs256KeyRef := testutils.NewJwtHs256KeyRef("foo")
 	controller := testutils.NewController("slurm1", slurmKeyRef, jwtHs256KeyRef, nil)
+	controller2 := testutils.NewController("slurm2", slurmKeyRef, jwtHs256KeyRef, nil)
 	nodeset := testutils.NewNodeset("slurmA", controller, 2)
+	nodeset2 := testutils.NewNodeset("slurmA", controller2, 2)
 	type fields struct {
 		Reader client.Reader
 	}
@@ -195,6 +197,25 @@ func Test_NodeSetEventHandler_Update(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "Moved to another Controller",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					controller,
+					controller2,
+					nodeset2,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectNew: nodeset2,
+					ObjectOld: nodeset,
+				},
+				q: newQueue(),
+			},
+			want: 2,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
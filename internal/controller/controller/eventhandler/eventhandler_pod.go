@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+func NewPodEventHandler(reader client.Reader) *PodEventHandler {
+	return &PodEventHandler{
+		Reader:      reader,
+		refResolver: refresolver.New(reader),
+	}
+}
+
+var _ handler.EventHandler = &PodEventHandler{}
+
+// PodEventHandler enqueues the Controller of NodeSet pods as they are
+// scheduled onto, or removed from, Kubernetes nodes, which changes the
+// Slurm network topology.
+type PodEventHandler struct {
+	client.Reader
+	refResolver *refresolver.RefResolver
+}
+
+// Create implements handler.EventHandler
+func (h *PodEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	pod, ok := evt.Object.(*corev1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return
+	}
+	h.enqueueRequest(ctx, pod, q)
+}
+
+// Delete implements handler.EventHandler
+func (h *PodEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	pod, ok := evt.Object.(*corev1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return
+	}
+	h.enqueueRequest(ctx, pod, q)
+}
+
+// Generic implements handler.EventHandler
+func (h *PodEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+// Update implements handler.EventHandler
+func (h *PodEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	oldPod, ok := evt.ObjectOld.(*corev1.Pod)
+	if !ok {
+		return
+	}
+	newPod, ok := evt.ObjectNew.(*corev1.Pod)
+	if !ok {
+		return
+	}
+
+	// Detect pod scheduling, and pod termination
+	if oldPod.Spec.NodeName != newPod.Spec.NodeName ||
+		oldPod.DeletionTimestamp.IsZero() != newPod.DeletionTimestamp.IsZero() {
+		h.enqueueRequest(ctx, newPod, q)
+	}
+}
+
+func (h *PodEventHandler) enqueueRequest(
+	ctx context.Context,
+	pod *corev1.Pod,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	controller := getTopologyControllerForPod(ctx, h.Reader, h.refResolver, pod)
+	if controller == nil {
+		return
+	}
+	objectutils.EnqueueRequest(q, controller)
+}
+
+// getTopologyControllerForPod returns the Controller of the NodeSet pod, if it
+// generates the Slurm network topology.
+func getTopologyControllerForPod(
+	ctx context.Context,
+	reader client.Reader,
+	refResolver *refresolver.RefResolver,
+	pod *corev1.Pod,
+) *slinkyv1beta1.Controller {
+	controllerRef := metav1.GetControllerOf(pod)
+	if controllerRef == nil {
+		return nil
+	}
+	if controllerRef.Kind != slinkyv1beta1.NodeSetKind || controllerRef.APIVersion != slinkyv1beta1.NodeSetAPIVersion {
+		return nil
+	}
+
+	nodeset := &slinkyv1beta1.NodeSet{}
+	key := types.NamespacedName{Namespace: pod.Namespace, Name: controllerRef.Name}
+	if err := reader.Get(ctx, key, nodeset); err != nil {
+		return nil
+	}
+	if nodeset.UID != controllerRef.UID {
+		return nil
+	}
+
+	controller, err := refResolver.GetController(ctx, nodeset.Spec.ControllerRef)
+	if err != nil || !controller.IsTopologyEnabled() {
+		return nil
+	}
+	return controller
+}
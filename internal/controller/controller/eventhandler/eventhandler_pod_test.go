@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newTopologyController(name string) *slinkyv1beta1.Controller {
+	controller := testutils.NewController(name, testutils.NewSlurmKeyRef(name), testutils.NewJwtHs256KeyRef(name), nil)
+	controller.Spec.Topology = &slinkyv1beta1.ControllerTopology{
+		LabelKeys: []string{corev1.LabelTopologyZone},
+	}
+	return controller
+}
+
+func newNodeSetPod(nodeset *slinkyv1beta1.NodeSet, nodeName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: nodeset.Namespace,
+			Name:      nodeset.Name + "-0",
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(nodeset, slinkyv1beta1.NodeSetGVK),
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+		},
+	}
+}
+
+func Test_PodEventHandler_Update(t *testing.T) {
+	controller := newTopologyController("slurm")
+	nodeset := testutils.NewNodeset("gpu", controller, 1)
+	controllerNoTopology := testutils.NewController("other", testutils.NewSlurmKeyRef("other"), testutils.NewJwtHs256KeyRef("other"), nil)
+	nodesetNoTopology := testutils.NewNodeset("cpu", controllerNoTopology, 1)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Pod scheduled",
+			fields: fields{
+				Reader: fake.NewFakeClient(controller, nodeset),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNodeSetPod(nodeset, ""),
+					ObjectNew: newNodeSetPod(nodeset, "node-a"),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Pod unchanged",
+			fields: fields{
+				Reader: fake.NewFakeClient(controller, nodeset),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNodeSetPod(nodeset, "node-a"),
+					ObjectNew: newNodeSetPod(nodeset, "node-a"),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "Controller without topology",
+			fields: fields{
+				Reader: fake.NewFakeClient(controllerNoTopology, nodesetNoTopology),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNodeSetPod(nodesetNoTopology, ""),
+					ObjectNew: newNodeSetPod(nodesetNoTopology, "node-a"),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewPodEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("PodEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package eventhandler
 
 import (
 	"context"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/workqueue"
@@ -84,7 +85,8 @@ func (e *SecretEventHandler) enqueueRequest(
 		slurmKeyKey := controller.AuthSlurmKey()
 		jwtHs256KeyKey := controller.AuthJwtHs256Key()
 		if secretKey.String() != slurmKeyKey.String() &&
-			secretKey.String() != jwtHs256KeyKey.String() {
+			secretKey.String() != jwtHs256KeyKey.String() &&
+			!slices.Contains(controller.JwtKeySecretKeys(), secretKey) {
 			continue
 		}
 
@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
@@ -23,6 +24,15 @@ func Test_SecretEventHandler_Create(t *testing.T) {
 	jwtHs256KeySecret := testutils.NewJwtHs256KeySecret(jwtHs256KeyRef)
 	controller := testutils.NewController("slurm", slurmKeyRef, jwtHs256KeyRef, nil)
 	nodeset := testutils.NewNodeset("slurm", controller, 2)
+	jwtKeyRef := testutils.NewJwtKeyRef("foo")
+	jwtKeySecret := testutils.NewJwtKeySecret(jwtKeyRef)
+	jwtKeysController := testutils.NewController("slurm", slurmKeyRef, jwtHs256KeyRef, nil)
+	jwtKeysController.Spec.JwtKeys = &slinkyv1beta1.JwtKeys{
+		SigningKeyID: "a",
+		Keys: []slinkyv1beta1.JwtKey{
+			{KeyID: "a", PrivateKeyRef: jwtKeyRef},
+		},
+	}
 	type fields struct {
 		Reader client.Reader
 	}
@@ -75,6 +85,40 @@ func Test_SecretEventHandler_Create(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "jwt key",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					jwtKeySecret,
+					jwtKeysController,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: jwtKeySecret,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "unreferenced jwt key",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					jwtKeySecret,
+					controller,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: jwtKeySecret,
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_TokenEventHandler_Update(t *testing.T) {
+	slurmKeyRef := testutils.NewSlurmKeyRef("foo")
+	jwtHs256KeyRef := testutils.NewJwtHs256KeyRef("foo")
+	jwtHs256KeySecret := testutils.NewJwtHs256KeySecret(jwtHs256KeyRef)
+	controller := testutils.NewController("slurm", slurmKeyRef, jwtHs256KeyRef, nil)
+	token := testutils.NewToken("foo", jwtHs256KeySecret)
+	controllerToken := testutils.NewToken("bar", jwtHs256KeySecret)
+	controllerToken.Spec.JwtHs256KeyRef = slinkyv1beta1.JwtSecretKeySelector{}
+	controllerToken.Spec.ControllerRef = testutils.NewObjectRef(controller)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "With controllerRef",
+			fields: fields{
+				Reader: fake.NewFakeClient(controller, controllerToken),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: controllerToken,
+					ObjectNew: controllerToken,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Without controllerRef",
+			fields: fields{
+				Reader: fake.NewFakeClient(controller, token),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: token,
+					ObjectNew: token,
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTokenEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("TokenEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -33,6 +33,10 @@ const (
 
 	// BackoffGCInterval is the time that has to pass before next iteration of backoff GC is run
 	BackoffGCInterval = 1 * time.Minute
+
+	// statusResyncPeriod is how often health is re-evaluated while it can change
+	// without any Kubernetes event.
+	statusResyncPeriod = 30 * time.Second
 )
 
 func init() {
@@ -101,7 +101,7 @@ func (r *LoginSetReconciler) Sync(ctx context.Context, req reconcile.Request) er
 		if err := s.Sync(ctx, loginset); err != nil {
 			e := fmt.Errorf("[%s]: %w", s.Name, err)
 			errors := []error{e}
-			if err := r.syncStatus(ctx, loginset); err != nil {
+			if err := r.syncStatus(ctx, loginset, e); err != nil {
 				e := fmt.Errorf("[%s]: %w", s.Name, err)
 				errors = append(errors, e)
 			}
@@ -109,5 +109,5 @@ func (r *LoginSetReconciler) Sync(ctx context.Context, req reconcile.Request) er
 		}
 	}
 
-	return r.syncStatus(ctx, loginset)
+	return r.syncStatus(ctx, loginset, nil)
 }
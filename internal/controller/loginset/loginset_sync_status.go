@@ -10,40 +10,56 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/statusutils"
 )
 
 // syncStatus handles determining and updating the status.
 func (r *LoginSetReconciler) syncStatus(
 	ctx context.Context,
 	loginset *slinkyv1beta1.LoginSet,
+	syncErr error,
 ) error {
 	logger := log.FromContext(ctx)
 
 	selectorLabels := labels.NewBuilder().WithLoginSelectorLabels(loginset).Build()
 	selector := k8slabels.SelectorFromSet(k8slabels.Set(selectorLabels))
 
-	replicaStatus, err := r.calculateReplicaStatus(ctx, loginset)
+	deployment, err := r.getDeployment(ctx, loginset)
 	if err != nil {
 		return err
 	}
+	replicaStatus := calculateReplicaStatus(deployment)
+	workload := statusutils.NewDeploymentStatus(deployment)
+
+	obs := statusutils.Observations{
+		Generation: loginset.Generation,
+		Workload:   &workload,
+		SyncErr:    syncErr,
+		ConfigErr:  r.validateConfig(ctx, loginset),
+	}
 
 	newStatus := &slinkyv1beta1.LoginSetStatus{
-		Replicas:   replicaStatus.Replicas,
-		Selector:   selector.String(),
-		Conditions: []metav1.Condition{},
+		Replicas:           replicaStatus.Replicas,
+		ObservedGeneration: loginset.Generation,
+		Selector:           selector.String(),
+		Conditions:         statusutils.NewConditions(loginset.Status.Conditions, obs),
+	}
+
+	if !statusutils.IsReady(newStatus.Conditions) {
+		durationStore.Push(objectutils.KeyFunc(loginset), statusResyncPeriod)
 	}
-	newStatus.Conditions = append(newStatus.Conditions, loginset.Status.Conditions...)
 
-	if apiequality.Semantic.DeepEqual(loginset.Status, newStatus) {
+	if apiequality.Semantic.DeepEqual(loginset.Status, *newStatus) {
 		logger.V(2).Info("LoginSet Status has not changed, skipping status update",
 			"loginset", klog.KObj(loginset), "status", loginset.Status)
 		return nil
@@ -57,26 +73,57 @@ func (r *LoginSetReconciler) syncStatus(
 	return nil
 }
 
-type replicaStatus struct {
-	Replicas int32
+// validateConfig checks that the referenced Controller and Secrets can be resolved.
+func (r *LoginSetReconciler) validateConfig(
+	ctx context.Context,
+	loginset *slinkyv1beta1.LoginSet,
+) error {
+	errs := []error{}
+	if _, err := r.refResolver.GetSecretKeyRef(ctx, loginset.SssdSecretRef(), loginset.Namespace); err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve `sssdConfRef`: %w", err))
+	}
+	controller, err := r.refResolver.GetController(ctx, loginset.Spec.ControllerRef)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve `controllerRef`: %w", err))
+		return utilerrors.NewAggregate(errs)
+	}
+	if controller.IsMungeAuth() {
+		if _, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthMungeRef(), controller.Namespace); err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve Controller `auth.mungeKeyRef`: %w", err))
+		}
+	} else if _, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthSlurmRef(), controller.Namespace); err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve Controller `slurmKeyRef`: %w", err))
+	}
+	return utilerrors.NewAggregate(errs)
 }
 
-// calculateReplicaStatus will calculate the status of the given pods.
-func (r *LoginSetReconciler) calculateReplicaStatus(
+// getDeployment returns the sackd Deployment, or nil if it does not exist.
+func (r *LoginSetReconciler) getDeployment(
 	ctx context.Context,
 	loginset *slinkyv1beta1.LoginSet,
-) (replicaStatus, error) {
+) (*appsv1.Deployment, error) {
 	deployment := &appsv1.Deployment{}
-	deploymentKey := loginset.Key()
-	if err := r.Get(ctx, deploymentKey, deployment); err != nil {
-		return replicaStatus{}, err
+	if err := r.Get(ctx, loginset.Key(), deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
 	}
+	return deployment, nil
+}
+
+type replicaStatus struct {
+	Replicas int32
+}
 
-	status := replicaStatus{
+// calculateReplicaStatus will calculate the status of the given deployment.
+func calculateReplicaStatus(deployment *appsv1.Deployment) replicaStatus {
+	if deployment == nil {
+		return replicaStatus{}
+	}
+	return replicaStatus{
 		Replicas: deployment.Status.Replicas,
 	}
-
-	return status, nil
 }
 
 func (r *LoginSetReconciler) updateStatus(
@@ -85,6 +85,27 @@ func (h *NodeEventHandler) Update(
 	if !apiequality.Semantic.DeepEqual(oldNode.Annotations, newNode.Annotations) {
 		h.enqueueNodeSetsForNode(ctx, newNode, q)
 	}
+
+	// Detect node label updates, which may be Slurm node features
+	if !apiequality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) {
+		h.enqueueNodeSetsForNode(ctx, newNode, q)
+	}
+
+	// Detect node health updates, which may match NodeHealth rules
+	if !apiequality.Semantic.DeepEqual(nodeConditionStatuses(oldNode), nodeConditionStatuses(newNode)) ||
+		!apiequality.Semantic.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints) {
+		h.enqueueNodeSetsForNode(ctx, newNode, q)
+	}
+}
+
+// nodeConditionStatuses returns the status of each node condition, ignoring
+// the heartbeats which update the conditions periodically.
+func nodeConditionStatuses(node *corev1.Node) map[corev1.NodeConditionType]corev1.ConditionStatus {
+	statuses := make(map[corev1.NodeConditionType]corev1.ConditionStatus, len(node.Status.Conditions))
+	for _, cond := range node.Status.Conditions {
+		statuses[cond.Type] = cond.Status
+	}
+	return statuses
 }
 
 func (h *NodeEventHandler) enqueueNodeSetsForNode(
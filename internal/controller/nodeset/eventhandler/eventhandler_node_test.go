@@ -6,6 +6,7 @@ package eventhandler
 import (
 	"context"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -190,6 +191,88 @@ func Test_NodeEventHandler_Update(t *testing.T) {
 			},
 			want: 1, // Should enqueue 1 NodeSet for reconciliation
 		},
+		{
+			name: "Node labels changed - should enqueue NodeSet",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(
+					nodeset,
+					newNodeSetPod(nodeset, 0, "test-node"),
+				).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNode("test-node", false),
+					ObjectNew: func() *corev1.Node {
+						node := newNode("test-node", false)
+						node.Labels = map[string]string{"topology.kubernetes.io/zone": "zone-a"}
+						return node
+					}(),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Node condition status changed - should enqueue NodeSet",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(
+					nodeset,
+					newNodeSetPod(nodeset, 0, "test-node"),
+				).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNodeWithCondition("test-node", corev1.NodeReady, corev1.ConditionTrue, time.Unix(0, 0)),
+					ObjectNew: newNodeWithCondition("test-node", corev1.NodeReady, corev1.ConditionFalse, time.Unix(0, 0)),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Node condition heartbeat - should not enqueue",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(
+					nodeset,
+					newNodeSetPod(nodeset, 0, "test-node"),
+				).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNodeWithCondition("test-node", corev1.NodeReady, corev1.ConditionTrue, time.Unix(0, 0)),
+					ObjectNew: newNodeWithCondition("test-node", corev1.NodeReady, corev1.ConditionTrue, time.Unix(60, 0)),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "Node tainted - should enqueue NodeSet",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(
+					nodeset,
+					newNodeSetPod(nodeset, 0, "test-node"),
+				).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNode("test-node", false),
+					ObjectNew: func() *corev1.Node {
+						node := newNode("test-node", false)
+						node.Spec.Taints = []corev1.Taint{
+							{Key: corev1.TaintNodeUnreachable, Effect: corev1.TaintEffectNoExecute},
+						}
+						return node
+					}(),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
 		{
 			name: "No cordon change - should not enqueue",
 			fields: fields{
@@ -250,3 +333,15 @@ func newNode(name string, unschedulable bool) *corev1.Node {
 	}
 	return node
 }
+
+func newNodeWithCondition(name string, condType corev1.NodeConditionType, status corev1.ConditionStatus, heartbeat time.Time) *corev1.Node {
+	node := newNode(name, false)
+	node.Status.Conditions = []corev1.NodeCondition{
+		{
+			Type:              condType,
+			Status:            status,
+			LastHeartbeatTime: metav1.NewTime(heartbeat),
+		},
+	}
+	return node
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func NewSlurmMaintenanceEventHandler() *SlurmMaintenanceEventHandler {
+	return &SlurmMaintenanceEventHandler{}
+}
+
+var _ handler.EventHandler = &SlurmMaintenanceEventHandler{}
+
+// SlurmMaintenanceEventHandler enqueues the NodeSets under maintenance, those
+// referenced by name and those resolved from the pod selector.
+type SlurmMaintenanceEventHandler struct{}
+
+func (e *SlurmMaintenanceEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *SlurmMaintenanceEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.ObjectOld, q)
+	e.enqueueRequest(ctx, evt.ObjectNew, q)
+}
+
+func (e *SlurmMaintenanceEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *SlurmMaintenanceEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+func (e *SlurmMaintenanceEventHandler) enqueueRequest(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	maintenance, ok := obj.(*slinkyv1beta1.SlurmMaintenance)
+	if !ok {
+		return
+	}
+
+	names := sets.New(maintenance.Spec.NodeSets...)
+	names.Insert(maintenance.Status.NodeSets...)
+	for _, name := range sets.List(names) {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: maintenance.Namespace,
+			Name:      name,
+		}})
+	}
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func newSlurmMaintenance(name string, nodesets []string, resolved []string) *slinkyv1beta1.SlurmMaintenance {
+	return &slinkyv1beta1.SlurmMaintenance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      name,
+		},
+		Spec: slinkyv1beta1.SlurmMaintenanceSpec{
+			NodeSets:  nodesets,
+			StartTime: metav1.NewTime(time.Now().Add(time.Hour)),
+			Duration:  metav1.Duration{Duration: time.Hour},
+		},
+		Status: slinkyv1beta1.SlurmMaintenanceStatus{
+			NodeSets: resolved,
+		},
+	}
+}
+
+func Test_SlurmMaintenanceEventHandler_Create(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		evt event.CreateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "Empty",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{},
+				q:   newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "Spec NodeSets",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: newSlurmMaintenance("foo", []string{"slurmA", "slurmB"}, nil),
+				},
+				q: newQueue(),
+			},
+			want: 2,
+		},
+		{
+			name: "Spec and Status NodeSets",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: newSlurmMaintenance("foo", []string{"slurmA"}, []string{"slurmA", "slurmC"}),
+				},
+				q: newQueue(),
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewSlurmMaintenanceEventHandler()
+			e.Create(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("SlurmMaintenanceEventHandler.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SlurmMaintenanceEventHandler_Update(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "Empty",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{},
+				q:   newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "NodeSet removed",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newSlurmMaintenance("foo", []string{"slurmA", "slurmB"}, []string{"slurmA", "slurmB"}),
+					ObjectNew: newSlurmMaintenance("foo", []string{"slurmA"}, []string{"slurmA", "slurmB"}),
+				},
+				q: newQueue(),
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewSlurmMaintenanceEventHandler()
+			e.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("SlurmMaintenanceEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SlurmMaintenanceEventHandler_Delete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		evt event.DeleteEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "Empty",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.DeleteEvent{},
+				q:   newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "Status NodeSets",
+			args: args{
+				ctx: context.TODO(),
+				evt: event.DeleteEvent{
+					Object: newSlurmMaintenance("foo", nil, []string{"slurmA"}),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewSlurmMaintenanceEventHandler()
+			e.Delete(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("SlurmMaintenanceEventHandler.Delete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/mathutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+const (
+	// autoscaleResyncPeriod is how often Slurm job demand is re-evaluated.
+	autoscaleResyncPeriod = 30 * time.Second
+)
+
+// autoscaleStore holds the autoscaler state of each NodeSet.
+var autoscaleStore = newAutoscalerStore()
+
+type timestampedRecommendation struct {
+	replicas  int32
+	timestamp time.Time
+}
+
+type autoscalerState struct {
+	recommendations []timestampedRecommendation
+	status          slinkyv1beta1.NodeSetAutoscalingStatus
+}
+
+// autoscalerStore tracks past replica recommendations, per NodeSet, for
+// stabilization, as well as the last observed autoscaler status.
+type autoscalerStore struct {
+	sync.Mutex
+	states map[string]*autoscalerState
+}
+
+func newAutoscalerStore() *autoscalerStore {
+	return &autoscalerStore{
+		states: make(map[string]*autoscalerState),
+	}
+}
+
+// Stabilize records the desired replicas and returns the stabilized replicas.
+// Scale-out uses the lowest recommendation within the scale-up window and
+// scale-in uses the highest recommendation within the scale-down window,
+// such that flapping demand does not cause flapping replicas.
+func (s *autoscalerStore) Stabilize(
+	key string,
+	current, desired int32,
+	upWindow, downWindow time.Duration,
+	now time.Time,
+) int32 {
+	s.Lock()
+	defer s.Unlock()
+
+	state, ok := s.states[key]
+	if !ok {
+		state = &autoscalerState{}
+		s.states[key] = state
+	}
+
+	upCutoff := now.Add(-upWindow)
+	downCutoff := now.Add(-downWindow)
+	upRecommendation := desired
+	downRecommendation := desired
+	retained := []timestampedRecommendation{}
+	for _, rec := range state.recommendations {
+		keep := false
+		if rec.timestamp.After(upCutoff) {
+			upRecommendation = min(upRecommendation, rec.replicas)
+			keep = true
+		}
+		if rec.timestamp.After(downCutoff) {
+			downRecommendation = max(downRecommendation, rec.replicas)
+			keep = true
+		}
+		if keep {
+			retained = append(retained, rec)
+		}
+	}
+	state.recommendations = append(retained, timestampedRecommendation{
+		replicas:  desired,
+		timestamp: now,
+	})
+
+	replicas := current
+	if replicas < upRecommendation {
+		replicas = upRecommendation
+	}
+	if replicas > downRecommendation {
+		replicas = downRecommendation
+	}
+	return replicas
+}
+
+// GetStatus returns the last stored autoscaler status, if any.
+func (s *autoscalerStore) GetStatus(key string) (slinkyv1beta1.NodeSetAutoscalingStatus, bool) {
+	s.Lock()
+	defer s.Unlock()
+	state, ok := s.states[key]
+	if !ok {
+		return slinkyv1beta1.NodeSetAutoscalingStatus{}, false
+	}
+	return state.status, true
+}
+
+// SetStatus stores the autoscaler status.
+func (s *autoscalerStore) SetStatus(key string, status slinkyv1beta1.NodeSetAutoscalingStatus) {
+	s.Lock()
+	defer s.Unlock()
+	state, ok := s.states[key]
+	if !ok {
+		state = &autoscalerState{}
+		s.states[key] = state
+	}
+	state.status = status
+}
+
+// Delete removes all autoscaler state for the key.
+func (s *autoscalerStore) Delete(key string) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.states, key)
+}
+
+// syncAutoscaling will size the NodeSet from Slurm job demand.
+//
+// The desired replicas is the number of busy Slurm nodes plus the nodes needed
+// to run the pending jobs of the NodeSet partition, bounded by min/max. Only
+// `replicas` is patched; syncNodeSet() then scales-out or scales-in (by the
+// usual cordon and drain) to match it. When Slurm cannot be observed, the
+// NodeSet is not rescaled, such that an outage never scales-in busy nodes.
+func (r *NodeSetReconciler) syncAutoscaling(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+
+	if !nodeset.IsAutoscalingEnabled() {
+		autoscaleStore.Delete(key)
+		return nil
+	}
+	autoscaling := nodeset.Spec.Autoscaling
+
+	// Demand changes without any Kubernetes event, so poll.
+	durationStore.Push(key, autoscaleResyncPeriod)
+
+	demand, err := r.slurmControl.GetPendingNodeDemand(ctx, nodeset)
+	if err != nil {
+		return err
+	}
+	slurmNodeStatus, err := r.slurmControl.CalculateNodeStatus(ctx, nodeset, pods)
+	if err != nil {
+		return err
+	}
+	if !demand.Observed || !slurmNodeStatus.Observed {
+		logger.V(1).Info("Skipped NodeSet autoscaling, Slurm is unavailable")
+		return nil
+	}
+	busy := slurmNodeStatus.Allocated + slurmNodeStatus.Mixed
+
+	minReplicas, maxReplicas := autoscaling.Bounds()
+	desired := mathutils.Clamp(busy+demand.Nodes, minReplicas, maxReplicas)
+	current := ptr.Deref(nodeset.Spec.Replicas, 0)
+	replicas := autoscaleStore.Stabilize(key, current, desired,
+		autoscaling.ScaleUpWindow(), autoscaling.ScaleDownWindow(), time.Now())
+
+	status, ok := autoscaleStore.GetStatus(key)
+	if !ok && nodeset.Status.Autoscaling != nil {
+		status = *nodeset.Status.Autoscaling.DeepCopy()
+	}
+	status.DesiredReplicas = desired
+	status.PendingJobs = demand.PendingJobs
+	defer func() {
+		autoscaleStore.SetStatus(key, status)
+	}()
+
+	logger.V(1).Info("Calculated NodeSet autoscaling",
+		"pendingJobs", demand.PendingJobs, "pendingNodes", demand.Nodes, "busyNodes", busy,
+		"desired", desired, "current", current, "replicas", replicas)
+	if replicas == current {
+		return nil
+	}
+
+	patch := client.MergeFrom(nodeset.DeepCopy())
+	nodeset.Spec.Replicas = ptr.To(replicas)
+	if err := r.Patch(ctx, nodeset, patch); err != nil {
+		return err
+	}
+	status.LastScaleTime = ptr.To(metav1.Now())
+
+	logger.Info("Autoscaled NodeSet", "from", current, "to", replicas)
+	r.eventRecorder.Eventf(nodeset, corev1.EventTypeNormal, SuccessfulRescaleReason,
+		"New size: %d; reason: %d pending jobs need %d nodes, %d nodes busy", replicas, demand.PendingJobs, demand.Nodes, busy)
+
+	return nil
+}
+
+// calculateAutoscalingStatus returns the autoscaler status of the NodeSet.
+func calculateAutoscalingStatus(nodeset *slinkyv1beta1.NodeSet) *slinkyv1beta1.NodeSetAutoscalingStatus {
+	if !nodeset.IsAutoscalingEnabled() {
+		return nil
+	}
+	if status, ok := autoscaleStore.GetStatus(objectutils.KeyFunc(nodeset)); ok {
+		return &status
+	}
+	return nodeset.Status.Autoscaling
+}
@@ -0,0 +1,371 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+func Test_autoscalerStore_Stabilize(t *testing.T) {
+	now := time.Now()
+	type recommendation struct {
+		desired int32
+		age     time.Duration
+	}
+	tests := []struct {
+		name       string
+		history    []recommendation
+		current    int32
+		desired    int32
+		upWindow   time.Duration
+		downWindow time.Duration
+		want       int32
+	}{
+		{
+			name:    "Scale-out immediately",
+			current: 1,
+			desired: 3,
+			want:    3,
+		},
+		{
+			name:       "Scale-in immediately",
+			current:    3,
+			desired:    1,
+			downWindow: 0,
+			want:       1,
+		},
+		{
+			name: "Scale-in stabilized",
+			history: []recommendation{
+				{desired: 3, age: 1 * time.Minute},
+			},
+			current:    3,
+			desired:    1,
+			downWindow: 5 * time.Minute,
+			want:       3,
+		},
+		{
+			name: "Scale-in after window",
+			history: []recommendation{
+				{desired: 3, age: 10 * time.Minute},
+				{desired: 2, age: 1 * time.Minute},
+			},
+			current:    3,
+			desired:    1,
+			downWindow: 5 * time.Minute,
+			want:       2,
+		},
+		{
+			name: "Scale-out stabilized",
+			history: []recommendation{
+				{desired: 1, age: 30 * time.Second},
+			},
+			current:  1,
+			desired:  4,
+			upWindow: 1 * time.Minute,
+			want:     1,
+		},
+		{
+			name: "Scale-out partially stabilized",
+			history: []recommendation{
+				{desired: 1, age: 2 * time.Minute},
+				{desired: 2, age: 30 * time.Second},
+			},
+			current:  1,
+			desired:  4,
+			upWindow: 1 * time.Minute,
+			want:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAutoscalerStore()
+			key := "default/foo"
+			for _, rec := range tt.history {
+				s.Stabilize(key, rec.desired, rec.desired, 0, 0, now.Add(-rec.age))
+			}
+			if got := s.Stabilize(key, tt.current, tt.desired, tt.upWindow, tt.downWindow, now); got != tt.want {
+				t.Errorf("autoscalerStore.Stabilize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncAutoscaling(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	newPendingJobList := func(partition string, nodes int32) *slurmtypes.V0044JobInfoList {
+		return &slurmtypes.V0044JobInfoList{
+			Items: []slurmtypes.V0044JobInfo{
+				{
+					V0044JobInfo: slurmapi.V0044JobInfo{
+						JobId:     ptr.To[int32](1),
+						JobState:  ptr.To([]slurmapi.V0044JobInfoJobState{slurmapi.V0044JobInfoJobStatePENDING}),
+						Partition: ptr.To(partition),
+						NodeCount: &slurmapi.V0044Uint32NoValStruct{Set: ptr.To(true), Number: ptr.To(nodes)},
+					},
+				},
+			},
+		}
+	}
+	type fields struct {
+		Client    client.Client
+		ClientMap *clientmap.ClientMap
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pods    []*corev1.Pod
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantReplicas int32
+		wantStatus   *slinkyv1beta1.NodeSetAutoscalingStatus
+		wantErr      bool
+	}{
+		func() struct {
+			name         string
+			fields       fields
+			args         args
+			wantReplicas int32
+			wantStatus   *slinkyv1beta1.NodeSetAutoscalingStatus
+			wantErr      bool
+		} {
+			nodeset := newNodeSet("disabled", controller.Name, 1)
+			k8sclient := fake.NewFakeClient(nodeset)
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, newPendingJobList(nodeset.Name, 3))
+			return struct {
+				name         string
+				fields       fields
+				args         args
+				wantReplicas int32
+				wantStatus   *slinkyv1beta1.NodeSetAutoscalingStatus
+				wantErr      bool
+			}{
+				name: "Disabled",
+				fields: fields{
+					Client:    k8sclient,
+					ClientMap: newClientMap(controller.Name, slurmClient),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+				},
+				wantReplicas: 1,
+				wantStatus:   nil,
+			}
+		}(),
+		func() struct {
+			name         string
+			fields       fields
+			args         args
+			wantReplicas int32
+			wantStatus   *slinkyv1beta1.NodeSetAutoscalingStatus
+			wantErr      bool
+		} {
+			nodeset := newNodeSet("scale-out", controller.Name, 1)
+			nodeset.Spec.Autoscaling = &slinkyv1beta1.NodeSetAutoscaling{
+				Enabled:     true,
+				MaxReplicas: 10,
+			}
+			k8sclient := fake.NewFakeClient(nodeset)
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, newPendingJobList(nodeset.Name, 3))
+			return struct {
+				name         string
+				fields       fields
+				args         args
+				wantReplicas int32
+				wantStatus   *slinkyv1beta1.NodeSetAutoscalingStatus
+				wantErr      bool
+			}{
+				name: "Scale-out",
+				fields: fields{
+					Client:    k8sclient,
+					ClientMap: newClientMap(controller.Name, slurmClient),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+				},
+				wantReplicas: 3,
+				wantStatus: &slinkyv1beta1.NodeSetAutoscalingStatus{
+					DesiredReplicas: 3,
+					PendingJobs:     1,
+				},
+			}
+		}(),
+		func() struct {
+			name         string
+			fields       fields
+			args         args
+			wantReplicas int32
+			wantStatus   *slinkyv1beta1.NodeSetAutoscalingStatus
+			wantErr      bool
+		} {
+			nodeset := newNodeSet("max-replicas", controller.Name, 1)
+			nodeset.Spec.Autoscaling = &slinkyv1beta1.NodeSetAutoscaling{
+				Enabled:     true,
+				MaxReplicas: 2,
+			}
+			k8sclient := fake.NewFakeClient(nodeset)
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, newPendingJobList(nodeset.Name, 8))
+			return struct {
+				name         string
+				fields       fields
+				args         args
+				wantReplicas int32
+				wantStatus   *slinkyv1beta1.NodeSetAutoscalingStatus
+				wantErr      bool
+			}{
+				name: "Bounded by maxReplicas",
+				fields: fields{
+					Client:    k8sclient,
+					ClientMap: newClientMap(controller.Name, slurmClient),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+				},
+				wantReplicas: 2,
+				wantStatus: &slinkyv1beta1.NodeSetAutoscalingStatus{
+					DesiredReplicas: 2,
+					PendingJobs:     1,
+				},
+			}
+		}(),
+		func() struct {
+			name         string
+			fields       fields
+			args         args
+			wantReplicas int32
+			wantStatus   *slinkyv1beta1.NodeSetAutoscalingStatus
+			wantErr      bool
+		} {
+			nodeset := newNodeSet("scale-in", controller.Name, 4)
+			nodeset.Spec.Autoscaling = &slinkyv1beta1.NodeSetAutoscaling{
+				Enabled:                      true,
+				MinReplicas:                  ptr.To[int32](1),
+				MaxReplicas:                  10,
+				ScaleDownStabilizationWindow: &metav1.Duration{Duration: 0},
+			}
+			k8sclient := fake.NewFakeClient(nodeset)
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, &slurmtypes.V0044JobInfoList{})
+			return struct {
+				name         string
+				fields       fields
+				args         args
+				wantReplicas int32
+				wantStatus   *slinkyv1beta1.NodeSetAutoscalingStatus
+				wantErr      bool
+			}{
+				name: "Scale-in to minReplicas",
+				fields: fields{
+					Client:    k8sclient,
+					ClientMap: newClientMap(controller.Name, slurmClient),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+				},
+				wantReplicas: 1,
+				wantStatus: &slinkyv1beta1.NodeSetAutoscalingStatus{
+					DesiredReplicas: 1,
+				},
+			}
+		}(),
+		func() struct {
+			name         string
+			fields       fields
+			args         args
+			wantReplicas int32
+			wantStatus   *slinkyv1beta1.NodeSetAutoscalingStatus
+			wantErr      bool
+		} {
+			nodeset := newNodeSet("no-client", controller.Name, 4)
+			nodeset.Spec.Autoscaling = &slinkyv1beta1.NodeSetAutoscaling{
+				Enabled:                      true,
+				MinReplicas:                  ptr.To[int32](1),
+				MaxReplicas:                  10,
+				ScaleDownStabilizationWindow: &metav1.Duration{Duration: 0},
+			}
+			k8sclient := fake.NewFakeClient(nodeset)
+			return struct {
+				name         string
+				fields       fields
+				args         args
+				wantReplicas int32
+				wantStatus   *slinkyv1beta1.NodeSetAutoscalingStatus
+				wantErr      bool
+			}{
+				name: "No slurm client, no scale-in",
+				fields: fields{
+					Client:    k8sclient,
+					ClientMap: clientmap.NewClientMap(),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+				},
+				wantReplicas: 4,
+				wantStatus:   nil,
+			}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			autoscaleStore.Delete(objectutils.KeyFunc(tt.args.nodeset))
+			r := newNodeSetController(tt.fields.Client, tt.fields.ClientMap)
+			replicas := ptr.Deref(tt.args.nodeset.Spec.Replicas, 0)
+			if err := r.syncAutoscaling(tt.args.ctx, tt.args.nodeset, tt.args.pods); (err != nil) != tt.wantErr {
+				t.Errorf("NodeSetReconciler.syncAutoscaling() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			checkNodeSet := &slinkyv1beta1.NodeSet{}
+			if err := r.Get(tt.args.ctx, tt.args.nodeset.Key(), checkNodeSet); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if got := ptr.Deref(checkNodeSet.Spec.Replicas, 0); got != tt.wantReplicas {
+				t.Errorf("NodeSet.Spec.Replicas = %v, want %v", got, tt.wantReplicas)
+			}
+
+			got := calculateAutoscalingStatus(tt.args.nodeset)
+			if (got == nil) != (tt.wantStatus == nil) {
+				t.Fatalf("calculateAutoscalingStatus() = %v, want %v", got, tt.wantStatus)
+			}
+			if got == nil {
+				return
+			}
+			if got.DesiredReplicas != tt.wantStatus.DesiredReplicas || got.PendingJobs != tt.wantStatus.PendingJobs {
+				t.Errorf("calculateAutoscalingStatus() = %v, want %v", got, tt.wantStatus)
+			}
+			if scaled := tt.wantReplicas != replicas; scaled == (got.LastScaleTime == nil) {
+				t.Errorf("calculateAutoscalingStatus().LastScaleTime = %v", got.LastScaleTime)
+			}
+		})
+	}
+}
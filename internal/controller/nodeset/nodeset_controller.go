@@ -50,6 +50,20 @@ const (
 	FailedPlacementReason = "FailedPlacement"
 	// FailedNodeSetPodReason is added to an event when the status of a Pod of a NodeSet is 'Failed'.
 	FailedNodeSetPodReason = "FailedNodeSetPod"
+	// SuccessfulRescaleReason is added to an event when the autoscaler changes the NodeSet replicas.
+	SuccessfulRescaleReason = "SuccessfulRescale"
+	// DrainTimeoutReason is added to an event when a Slurm node did not drain within the DrainPolicy timeout.
+	DrainTimeoutReason = "DrainTimeout"
+	// DrainGraceSignalReason is added to an event when the DrainPolicy grace signal is sent to the remaining jobs.
+	DrainGraceSignalReason = "DrainGraceSignal"
+	// MaintenanceRecreateReason is added to an event when a drained Pod is deleted for a SlurmMaintenance.
+	MaintenanceRecreateReason = "MaintenanceRecreate"
+	// RemediationRecreateReason is added to an event when a Pod is deleted for the unhealthy state of its Slurm node.
+	RemediationRecreateReason = "RemediationRecreate"
+	// CanaryFailedReason is added to an event when a rolling update is halted for an unhealthy canary Pod.
+	CanaryFailedReason = "CanaryFailed"
+	// CanaryRollbackReason is added to an event when a NodeSet is rolled back for an unhealthy canary Pod.
+	CanaryRollbackReason = "CanaryRollback"
 )
 
 func init() {
@@ -87,6 +101,7 @@ type NodeSetReconciler struct {
 //+kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets/finalizers,verbs=update
 //+kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmmaintenances,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
@@ -155,6 +170,7 @@ func (r *NodeSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		WatchesRawSource(source.Channel(r.EventCh, podEventHandler)).
 		Watches(&slinkyv1beta1.Controller{}, eventhandler.NewControllerEventHandler(r.Client)).
 		Watches(&corev1.Secret{}, eventhandler.NewSecretEventHandler(r.Client)).
+		Watches(&slinkyv1beta1.SlurmMaintenance{}, eventhandler.NewSlurmMaintenanceEventHandler()).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles,
 		}).
@@ -48,6 +48,9 @@ func newFakeClientList(interceptorFuncs interceptor.Funcs, initObjLists ...objec
 			o.State = ptr.To(stateSet.UnsortedList())
 			o.Comment = r.Comment
 			o.Reason = r.Reason
+			if r.Features != nil {
+				o.Features = r.Features
+			}
 		default:
 			return errors.New("failed to cast slurm object")
 		}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"math/bits"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils"
+)
+
+const (
+	// workloadTierIdle is a Slurm node without running jobs.
+	workloadTierIdle = iota
+	// workloadTierLow is a Slurm node running only preemptible or
+	// low-priority jobs.
+	workloadTierLow
+	// workloadTierBusy is a Slurm node running any other job.
+	workloadTierBusy
+)
+
+const (
+	// The deletion cost of each part of the workload value, such that the
+	// costs are ordered as the values are compared. A bucket is at most 64.
+	tierCostStep = 1_000_000
+	gpusCostStep = 10_000
+	cpusCostStep = 100
+)
+
+// workloadValue is the value of the workload of a Slurm node, in the order by
+// which its parts are weighed.
+type workloadValue struct {
+	tier     int
+	gpus     int64
+	cpus     int64
+	priority int64
+}
+
+// deletionCost returns the deletion cost of the workload value. Allocated
+// resources and priority are bucketed by their power of two, such that small
+// workload changes do not change the cost.
+func (v workloadValue) deletionCost() int32 {
+	return int32(v.tier)*tierCostStep +
+		int32(bits.Len64(uint64(max(v.gpus, 0))))*gpusCostStep +
+		int32(bits.Len64(uint64(max(v.cpus, 0))))*cpusCostStep +
+		int32(bits.Len64(uint64(max(v.priority, 0))))
+}
+
+// calculateWorkloadValue returns the value of the workload, given the deletion
+// cost policy.
+func calculateWorkloadValue(
+	deletionCost *slinkyv1beta1.NodeSetDeletionCost,
+	workload slurmcontrol.NodeWorkload,
+) workloadValue {
+	if workload.IsIdle() {
+		return workloadValue{tier: workloadTierIdle}
+	}
+	if deletionCost.DeletionCostPolicy() == slinkyv1beta1.NodeSetDeletionCostPolicyIdle {
+		return workloadValue{tier: workloadTierBusy}
+	}
+
+	value := workloadValue{
+		tier: workloadTierLow,
+		gpus: workload.Gpus,
+		cpus: workload.Cpus,
+	}
+	if len(workload.Jobs) == 0 {
+		// Resources are allocated, but the jobs are not yet known.
+		value.tier = workloadTierBusy
+	}
+	for _, job := range workload.Jobs {
+		value.priority += job.Priority
+		isPreemptible := slices.Contains(deletionCost.PreemptibleQOS, job.Qos)
+		if !isPreemptible && !deletionCost.IsLowPriority(job.Priority) {
+			value.tier = workloadTierBusy
+		}
+	}
+	return value
+}
+
+// calculateDeletionCosts returns the deletion cost of each Slurm node, from the
+// value of its workload alone, such that a workload change only changes the
+// cost of its own Slurm node.
+func calculateDeletionCosts(
+	deletionCost *slinkyv1beta1.NodeSetDeletionCost,
+	workloads map[string]slurmcontrol.NodeWorkload,
+	slurmNodeNames []string,
+) map[string]int32 {
+	costs := make(map[string]int32, len(slurmNodeNames))
+	for _, slurmNodeName := range slurmNodeNames {
+		costs[slurmNodeName] = calculateWorkloadValue(deletionCost, workloads[slurmNodeName]).deletionCost()
+	}
+	return costs
+}
+
+// syncSlurmDeletionCost handles the pod deletion cost from the workload of its
+// Slurm node, if the NodeSet has a DeletionCost policy. Otherwise, the deletion
+// costs which the policy set are removed.
+func (r *NodeSetReconciler) syncSlurmDeletionCost(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	if nodeset.Spec.DeletionCost == nil {
+		return r.clearSlurmDeletionCost(ctx, pods)
+	}
+
+	workloads, err := r.slurmControl.GetNodeWorkloads(ctx, nodeset, pods)
+	if err != nil {
+		return err
+	}
+	// Keep the last deletion costs until Slurm can be observed.
+	if workloads == nil {
+		return nil
+	}
+	slurmNodeNames := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		slurmNodeNames = append(slurmNodeNames, nodesetutils.GetNodeName(pod))
+	}
+	costs := calculateDeletionCosts(nodeset.Spec.DeletionCost, workloads, slurmNodeNames)
+
+	syncSlurmDeletionCostFn := func(i int) error {
+		pod := pods[i]
+		cost := strconv.Itoa(int(costs[nodesetutils.GetNodeName(pod)]))
+		if pod.Annotations[slinkyv1beta1.AnnotationPodDeletionCost] == cost &&
+			pod.Annotations[slinkyv1beta1.AnnotationPodDeletionCostManaged] == "true" {
+			return nil
+		}
+
+		toUpdate := pod.DeepCopy()
+		if toUpdate.Annotations == nil {
+			toUpdate.Annotations = make(map[string]string)
+		}
+		toUpdate.Annotations[slinkyv1beta1.AnnotationPodDeletionCost] = cost
+		toUpdate.Annotations[slinkyv1beta1.AnnotationPodDeletionCostManaged] = "true"
+		if err := r.Patch(ctx, toUpdate, client.StrategicMergeFrom(pod)); err != nil {
+			return err
+		}
+
+		return nil
+	}
+	if _, err := utils.SlowStartBatch(len(pods), utils.SlowStartInitialBatchSize, syncSlurmDeletionCostFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// clearSlurmDeletionCost removes the pod deletion costs which were set by the
+// DeletionCost policy, leaving those which were set otherwise.
+func (r *NodeSetReconciler) clearSlurmDeletionCost(
+	ctx context.Context,
+	pods []*corev1.Pod,
+) error {
+	managedPods := slices.DeleteFunc(slices.Clone(pods), func(pod *corev1.Pod) bool {
+		_, ok := pod.Annotations[slinkyv1beta1.AnnotationPodDeletionCostManaged]
+		return !ok
+	})
+
+	clearSlurmDeletionCostFn := func(i int) error {
+		pod := managedPods[i]
+		toUpdate := pod.DeepCopy()
+		delete(toUpdate.Annotations, slinkyv1beta1.AnnotationPodDeletionCost)
+		delete(toUpdate.Annotations, slinkyv1beta1.AnnotationPodDeletionCostManaged)
+		return r.Patch(ctx, toUpdate, client.StrategicMergeFrom(pod))
+	}
+	if _, err := utils.SlowStartBatch(len(managedPods), utils.SlowStartInitialBatchSize, clearSlurmDeletionCostFn); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+)
+
+func Test_calculateDeletionCosts(t *testing.T) {
+	workloads := map[string]slurmcontrol.NodeWorkload{
+		"busy-gpu": {
+			Cpus: 4,
+			Gpus: 1,
+			Jobs: []slurmcontrol.WorkloadJob{{Qos: "normal", Priority: 100}},
+		},
+		"busy-cpu": {
+			Cpus: 16,
+			Jobs: []slurmcontrol.WorkloadJob{{Qos: "normal", Priority: 100}},
+		},
+		"busy-cpu-urgent": {
+			Cpus: 16,
+			Jobs: []slurmcontrol.WorkloadJob{
+				{Qos: "normal", Priority: 100},
+				{Qos: "normal", Priority: 500},
+			},
+		},
+		"preemptible": {
+			Cpus: 64,
+			Gpus: 8,
+			Jobs: []slurmcontrol.WorkloadJob{{Qos: "scavenger", Priority: 1000}},
+		},
+		"low-priority": {
+			Cpus: 2,
+			Jobs: []slurmcontrol.WorkloadJob{
+				{Qos: "scavenger", Priority: 1000},
+				{Qos: "normal", Priority: 5},
+			},
+		},
+		"idle": {},
+	}
+	slurmNodeNames := []string{"busy-gpu", "busy-cpu", "busy-cpu-urgent", "preemptible", "low-priority", "idle", "unknown"}
+	tests := []struct {
+		name         string
+		deletionCost *slinkyv1beta1.NodeSetDeletionCost
+		want         map[string]int32
+	}{
+		{
+			name:         "Workload, default",
+			deletionCost: &slinkyv1beta1.NodeSetDeletionCost{},
+			want: map[string]int32{
+				"idle":            0,
+				"unknown":         0,
+				"low-priority":    2_000_210,
+				"busy-cpu":        2_000_507,
+				"busy-cpu-urgent": 2_000_510,
+				"busy-gpu":        2_010_307,
+				"preemptible":     2_040_710,
+			},
+		},
+		{
+			name: "Workload, preemptible and low-priority",
+			deletionCost: &slinkyv1beta1.NodeSetDeletionCost{
+				Policy:               slinkyv1beta1.NodeSetDeletionCostPolicyWorkload,
+				PreemptibleQOS:       []string{"scavenger"},
+				LowPriorityThreshold: ptr.To[int64](10),
+			},
+			want: map[string]int32{
+				"idle":            0,
+				"unknown":         0,
+				"low-priority":    1_000_210,
+				"preemptible":     1_040_710,
+				"busy-cpu":        2_000_507,
+				"busy-cpu-urgent": 2_000_510,
+				"busy-gpu":        2_010_307,
+			},
+		},
+		{
+			name: "Idle",
+			deletionCost: &slinkyv1beta1.NodeSetDeletionCost{
+				Policy:         slinkyv1beta1.NodeSetDeletionCostPolicyIdle,
+				PreemptibleQOS: []string{"scavenger"},
+			},
+			want: map[string]int32{
+				"idle":            0,
+				"unknown":         0,
+				"low-priority":    2_000_000,
+				"preemptible":     2_000_000,
+				"busy-cpu":        2_000_000,
+				"busy-cpu-urgent": 2_000_000,
+				"busy-gpu":        2_000_000,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateDeletionCosts(tt.deletionCost, workloads, slurmNodeNames)
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("calculateDeletionCosts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncSlurmDeletionCost(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	newPods := func(nodeset *slinkyv1beta1.NodeSet, managed bool) []*corev1.Pod {
+		pod0 := makePodHealthy(nodesetutils.NewNodeSetPod(nodeset, controller, 0, ""))
+		pod0.Annotations[slinkyv1beta1.AnnotationPodDeletionCost] = "-10"
+		if managed {
+			pod0.Annotations[slinkyv1beta1.AnnotationPodDeletionCostManaged] = "true"
+		}
+		pod1 := makePodHealthy(nodesetutils.NewNodeSetPod(nodeset, controller, 1, ""))
+		return []*corev1.Pod{pod0, pod1}
+	}
+	tests := []struct {
+		name         string
+		deletionCost *slinkyv1beta1.NodeSetDeletionCost
+		managed      bool
+		noClient     bool
+		want         []string
+	}{
+		{
+			name:         "Disabled",
+			deletionCost: nil,
+			want:         []string{"-10", ""},
+		},
+		{
+			name:         "Disabled, set by the policy",
+			deletionCost: nil,
+			managed:      true,
+			want:         []string{"", ""},
+		},
+		{
+			name:         "Workload",
+			deletionCost: &slinkyv1beta1.NodeSetDeletionCost{},
+			want:         []string{"2000300", "0"},
+		},
+		{
+			name:         "Workload, no slurm client",
+			deletionCost: &slinkyv1beta1.NodeSetDeletionCost{},
+			noClient:     true,
+			want:         []string{"-10", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := newNodeSet("foo", controller.Name, 2)
+			nodeset.Spec.DeletionCost = tt.deletionCost
+			pods := newPods(nodeset, tt.managed)
+			nodeList := &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					{V0044Node: slurmapi.V0044Node{
+						Name:      ptr.To(nodesetutils.GetNodeName(pods[0])),
+						AllocCpus: ptr.To[int32](4),
+					}},
+					{V0044Node: slurmapi.V0044Node{
+						Name: ptr.To(nodesetutils.GetNodeName(pods[1])),
+					}},
+				},
+			}
+			jobList := &slurmtypes.V0044JobInfoList{
+				Items: []slurmtypes.V0044JobInfo{
+					{V0044JobInfo: slurmapi.V0044JobInfo{
+						JobId:    ptr.To[int32](1),
+						JobState: ptr.To([]slurmapi.V0044JobInfoJobState{slurmapi.V0044JobInfoJobStateRUNNING}),
+						Nodes:    ptr.To(nodesetutils.GetNodeName(pods[0])),
+					}},
+				},
+			}
+			sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList, jobList)
+			k8sclient := fake.NewClientBuilder().WithObjects(nodeset.DeepCopy(), pods[0].DeepCopy(), pods[1].DeepCopy()).Build()
+			clientMap := newClientMap(controller.Name, sclient)
+			if tt.noClient {
+				clientMap = clientmap.NewClientMap()
+			}
+			r := newNodeSetController(k8sclient, clientMap)
+			if err := r.syncSlurmDeletionCost(context.TODO(), nodeset, pods); err != nil {
+				t.Fatalf("NodeSetReconciler.syncSlurmDeletionCost() error = %v", err)
+			}
+
+			for i, pod := range pods {
+				got := &corev1.Pod{}
+				if err := k8sclient.Get(context.TODO(), client.ObjectKeyFromObject(pod), got); err != nil {
+					t.Fatalf("failed to get pod: %v", err)
+				}
+				if cost := got.Annotations[slinkyv1beta1.AnnotationPodDeletionCost]; cost != tt.want[i] {
+					t.Errorf("NodeSetReconciler.syncSlurmDeletionCost() pod %s cost = %q, want %q", pod.Name, cost, tt.want[i])
+				}
+				_, managed := got.Annotations[slinkyv1beta1.AnnotationPodDeletionCostManaged]
+				if wantManaged := tt.deletionCost != nil && !tt.noClient; managed != wantManaged {
+					t.Errorf("NodeSetReconciler.syncSlurmDeletionCost() pod %s managed = %v, want %v", pod.Name, managed, wantManaged)
+				}
+			}
+		})
+	}
+}
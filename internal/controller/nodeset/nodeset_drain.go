@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// drainGraceSignalReason is the pod condition reason after the grace signal
+// was sent, but before the drain action was taken.
+const drainGraceSignalReason = "GraceSignal"
+
+// makePodDrainStart records when the pod started to drain, if not already
+// recorded, and returns how long the pod has been draining.
+func (r *NodeSetReconciler) makePodDrainStart(
+	ctx context.Context,
+	pod *corev1.Pod,
+) (time.Duration, error) {
+	now := time.Now()
+
+	if value, ok := pod.Annotations[slinkyv1beta1.AnnotationPodDrainStart]; ok {
+		if start, err := time.Parse(time.RFC3339, value); err == nil {
+			return now.Sub(start), nil
+		}
+	}
+
+	toUpdate := pod.DeepCopy()
+	if toUpdate.Annotations == nil {
+		toUpdate.Annotations = make(map[string]string)
+	}
+	toUpdate.Annotations[slinkyv1beta1.AnnotationPodDrainStart] = now.Format(time.RFC3339)
+	if err := r.Patch(ctx, toUpdate, client.StrategicMergeFrom(pod)); err != nil {
+		return 0, err
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(pod), pod); err != nil {
+		return 0, err
+	}
+
+	return 0, nil
+}
+
+// processDrainTimeout applies the NodeSet DrainPolicy to a condemned pod whose
+// Slurm node did not drain within the timeout.
+//
+// The grace signal, if any, is sent once to the remaining jobs and the action
+// is only taken after the grace period. Progress is recorded in the pod's
+// SlurmNodeDrainTimeout condition, so each step is only done once.
+func (r *NodeSetReconciler) processDrainTimeout(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+	elapsed time.Duration,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+	policy := nodeset.Spec.DrainPolicy
+	timeout, _ := policy.DrainTimeout()
+	action := policy.DrainAction()
+	slurmNodeName := nodesetutils.GetNodeName(pod)
+
+	jobIds, err := r.slurmControl.GetNodeJobs(ctx, nodeset, pod)
+	if err != nil {
+		return err
+	}
+	jobs := formatJobIds(jobIds)
+
+	reason := ""
+	if _, cond := podutil.GetPodCondition(&pod.Status, slurmconditions.PodConditionDrainTimeout); cond != nil {
+		reason = cond.Reason
+	}
+
+	if gracePeriod := policy.DrainGracePeriod(); gracePeriod > 0 {
+		if reason == "" {
+			logger.Info("Drain timed out, signaling remaining jobs",
+				"pod", klog.KObj(pod), "signal", policy.GraceSignal, "jobs", jobs)
+			if err := r.slurmControl.SignalNodeJobs(ctx, nodeset, pod, policy.GraceSignal); err != nil {
+				return err
+			}
+			r.eventRecorder.Eventf(nodeset, corev1.EventTypeNormal, DrainGraceSignalReason,
+				"Slurm node %s did not drain within %s; sent %s to jobs: %s",
+				slurmNodeName, timeout, policy.GraceSignal, jobs)
+			message := fmt.Sprintf("Drain timed out after %s, sent %s to jobs: %s", timeout, policy.GraceSignal, jobs)
+			if err := r.setPodDrainTimeoutCondition(ctx, pod, drainGraceSignalReason, message); err != nil {
+				return err
+			}
+			reason = drainGraceSignalReason
+		}
+		if remaining := timeout + gracePeriod - elapsed; remaining > 0 {
+			durationStore.Push(key, remaining)
+			return nil
+		}
+	}
+
+	// The action was already taken, wait for the jobs to leave the node.
+	if reason == string(action) {
+		if len(jobIds) == 0 && action != slinkyv1beta1.NodeSetDrainActionWait {
+			logger.V(2).Info("NodeSet Pod has no remaining jobs after drain timeout, terminating",
+				"pod", klog.KObj(pod), "action", action)
+			if err := r.podControl.DeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return err
+				}
+			}
+			observePodDrainDuration(nodeset, pod, time.Now())
+			return nil
+		}
+		durationStore.Push(key, 30*time.Second)
+		return nil
+	}
+
+	logger.Info("Drain timed out, applying drain action",
+		"pod", klog.KObj(pod), "action", action, "jobs", jobs)
+	switch action {
+	case slinkyv1beta1.NodeSetDrainActionRequeue:
+		requeueReason := fmt.Sprintf("Pod (%s) drain timed out, requeueing jobs", klog.KObj(pod))
+		if err := r.slurmControl.RequeueNodeJobs(ctx, nodeset, pod, requeueReason); err != nil {
+			return err
+		}
+	case slinkyv1beta1.NodeSetDrainActionCancel:
+		if err := r.slurmControl.CancelNodeJobs(ctx, nodeset, pod); err != nil {
+			return err
+		}
+	case slinkyv1beta1.NodeSetDrainActionForceDelete:
+		r.eventRecorder.Eventf(nodeset, corev1.EventTypeWarning, DrainTimeoutReason,
+			"Slurm node %s did not drain within %s; force deleting pod %s with jobs: %s",
+			slurmNodeName, timeout, pod.Name, jobs)
+		if err := r.podControl.DeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		observePodDrainDuration(nodeset, pod, time.Now())
+		return nil
+	}
+
+	r.eventRecorder.Eventf(nodeset, corev1.EventTypeWarning, DrainTimeoutReason,
+		"Slurm node %s did not drain within %s; action %s on jobs: %s",
+		slurmNodeName, timeout, action, jobs)
+	message := fmt.Sprintf("Drain timed out after %s, action %s on jobs: %s", timeout, action, jobs)
+	if err := r.setPodDrainTimeoutCondition(ctx, pod, string(action), message); err != nil {
+		return err
+	}
+	durationStore.Push(key, 30*time.Second)
+
+	return nil
+}
+
+// setPodDrainTimeoutCondition sets the SlurmNodeDrainTimeout condition on the pod.
+func (r *NodeSetReconciler) setPodDrainTimeoutCondition(
+	ctx context.Context,
+	pod *corev1.Pod,
+	reason, message string,
+) error {
+	toUpdate := pod.DeepCopy()
+	cond := &corev1.PodCondition{
+		Type:               slurmconditions.PodConditionDrainTimeout,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	if !podutil.UpdatePodCondition(&toUpdate.Status, cond) {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, toUpdate, client.StrategicMergeFrom(pod)); err != nil {
+		return err
+	}
+	pod.Status = toUpdate.Status
+
+	return nil
+}
+
+// removePodDrainTimeoutCondition removes the SlurmNodeDrainTimeout condition
+// from the pod, such that a later drain applies the DrainPolicy again.
+func (r *NodeSetReconciler) removePodDrainTimeoutCondition(
+	ctx context.Context,
+	pod *corev1.Pod,
+) error {
+	if _, cond := podutil.GetPodCondition(&pod.Status, slurmconditions.PodConditionDrainTimeout); cond == nil {
+		return nil
+	}
+	toUpdate := pod.DeepCopy()
+	toUpdate.Status.Conditions = slices.DeleteFunc(toUpdate.Status.Conditions, func(cond corev1.PodCondition) bool {
+		return cond.Type == slurmconditions.PodConditionDrainTimeout
+	})
+	if err := r.Status().Patch(ctx, toUpdate, client.StrategicMergeFrom(pod)); err != nil {
+		return err
+	}
+	pod.Status = toUpdate.Status
+
+	return nil
+}
+
+func formatJobIds(jobIds []int32) string {
+	if len(jobIds) == 0 {
+		return "none"
+	}
+	ids := make([]string, 0, len(jobIds))
+	for _, jobId := range jobIds {
+		ids = append(ids, strconv.Itoa(int(jobId)))
+	}
+	return strings.Join(ids, ",")
+}
@@ -0,0 +1,369 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// signalRecorder records the signals instead of sending them through slurmrestd.
+type signalRecorder struct {
+	slurmcontrol.SlurmControlInterface
+	signals []string
+}
+
+func (s *signalRecorder) SignalNodeJobs(_ context.Context, _ *slinkyv1beta1.NodeSet, _ *corev1.Pod, signal string) error {
+	s.signals = append(s.signals, signal)
+	return nil
+}
+
+func TestNodeSetReconciler_makePodDrainStart(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantMin     time.Duration
+		wantMax     time.Duration
+	}{
+		{
+			name:    "Not started",
+			wantMin: 0,
+			wantMax: 0,
+		},
+		{
+			name: "Started",
+			annotations: map[string]string{
+				slinkyv1beta1.AnnotationPodDrainStart: time.Now().Add(-time.Hour).Format(time.RFC3339),
+			},
+			wantMin: time.Hour,
+			wantMax: time.Hour + time.Minute,
+		},
+		{
+			name: "Invalid timestamp",
+			annotations: map[string]string{
+				slinkyv1beta1.AnnotationPodDrainStart: "foo",
+			},
+			wantMin: 0,
+			wantMax: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   corev1.NamespaceDefault,
+					Name:        "pod-0",
+					Annotations: tt.annotations,
+				},
+			}
+			r := newNodeSetController(fake.NewFakeClient(pod.DeepCopy()), nil)
+			got, err := r.makePodDrainStart(context.TODO(), pod)
+			if err != nil {
+				t.Fatalf("NodeSetReconciler.makePodDrainStart() error = %v", err)
+			}
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("NodeSetReconciler.makePodDrainStart() = %v, want [%v, %v]", got, tt.wantMin, tt.wantMax)
+			}
+			checkPod := &corev1.Pod{}
+			if err := r.Get(context.TODO(), client.ObjectKeyFromObject(pod), checkPod); err != nil {
+				t.Fatalf("Client.Get() error = %v", err)
+			}
+			if _, err := time.Parse(time.RFC3339, checkPod.Annotations[slinkyv1beta1.AnnotationPodDrainStart]); err != nil {
+				t.Errorf("Pod annotation %s is invalid: %v", slinkyv1beta1.AnnotationPodDrainStart, err)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_processDrainTimeout(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	tests := []struct {
+		name        string
+		policy      slinkyv1beta1.NodeSetDrainPolicy
+		reason      string
+		noJobs      bool
+		elapsed     time.Duration
+		wantReason  string
+		wantSignals int
+		wantJobs    int
+		wantDown    bool
+		wantDelete  bool
+	}{
+		{
+			name:       "Wait",
+			policy:     slinkyv1beta1.NodeSetDrainPolicy{Action: slinkyv1beta1.NodeSetDrainActionWait},
+			elapsed:    time.Hour,
+			wantReason: string(slinkyv1beta1.NodeSetDrainActionWait),
+			wantJobs:   2,
+		},
+		{
+			name:       "Default action",
+			policy:     slinkyv1beta1.NodeSetDrainPolicy{},
+			elapsed:    time.Hour,
+			wantReason: string(slinkyv1beta1.NodeSetDrainActionWait),
+			wantJobs:   2,
+		},
+		{
+			name:       "Requeue",
+			policy:     slinkyv1beta1.NodeSetDrainPolicy{Action: slinkyv1beta1.NodeSetDrainActionRequeue},
+			elapsed:    time.Hour,
+			wantReason: string(slinkyv1beta1.NodeSetDrainActionRequeue),
+			wantJobs:   2,
+			wantDown:   true,
+		},
+		{
+			name:       "Cancel",
+			policy:     slinkyv1beta1.NodeSetDrainPolicy{Action: slinkyv1beta1.NodeSetDrainActionCancel},
+			elapsed:    time.Hour,
+			wantReason: string(slinkyv1beta1.NodeSetDrainActionCancel),
+			wantJobs:   1,
+		},
+		{
+			name:       "Cancelled, no jobs remaining",
+			policy:     slinkyv1beta1.NodeSetDrainPolicy{Action: slinkyv1beta1.NodeSetDrainActionCancel},
+			reason:     string(slinkyv1beta1.NodeSetDrainActionCancel),
+			noJobs:     true,
+			elapsed:    time.Hour,
+			wantReason: string(slinkyv1beta1.NodeSetDrainActionCancel),
+			wantDelete: true,
+		},
+		{
+			name:       "ForceDelete",
+			policy:     slinkyv1beta1.NodeSetDrainPolicy{Action: slinkyv1beta1.NodeSetDrainActionForceDelete},
+			elapsed:    time.Hour,
+			wantJobs:   2,
+			wantDelete: true,
+		},
+		{
+			name: "Grace signal",
+			policy: slinkyv1beta1.NodeSetDrainPolicy{
+				Timeout:     &metav1.Duration{Duration: time.Hour},
+				Action:      slinkyv1beta1.NodeSetDrainActionCancel,
+				GraceSignal: "SIGUSR1",
+			},
+			elapsed:     time.Hour,
+			wantReason:  drainGraceSignalReason,
+			wantSignals: 1,
+			wantJobs:    2,
+		},
+		{
+			name: "Grace period",
+			policy: slinkyv1beta1.NodeSetDrainPolicy{
+				Timeout:     &metav1.Duration{Duration: time.Hour},
+				Action:      slinkyv1beta1.NodeSetDrainActionCancel,
+				GraceSignal: "SIGUSR1",
+			},
+			reason:     drainGraceSignalReason,
+			elapsed:    time.Hour + 30*time.Second,
+			wantReason: drainGraceSignalReason,
+			wantJobs:   2,
+		},
+		{
+			name: "After grace period",
+			policy: slinkyv1beta1.NodeSetDrainPolicy{
+				Timeout:     &metav1.Duration{Duration: time.Hour},
+				Action:      slinkyv1beta1.NodeSetDrainActionCancel,
+				GraceSignal: "SIGUSR1",
+			},
+			reason:     drainGraceSignalReason,
+			elapsed:    time.Hour + 2*time.Minute,
+			wantReason: string(slinkyv1beta1.NodeSetDrainActionCancel),
+			wantJobs:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			nodeset := newNodeSet("foo", controller.Name, 1)
+			nodeset.Spec.DrainPolicy = tt.policy.DeepCopy()
+			pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+			pod = makePodHealthy(pod)
+			if tt.reason != "" {
+				pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+					Type:   slurmconditions.PodConditionDrainTimeout,
+					Status: corev1.ConditionTrue,
+					Reason: tt.reason,
+				})
+			}
+			k8sclient := fake.NewClientBuilder().
+				WithRuntimeObjects(nodeset, pod.DeepCopy()).
+				WithStatusSubresource(&corev1.Pod{}).
+				Build()
+
+			slurmNodeName := nodesetutils.GetNodeName(pod)
+			slurmNodeList := &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					{
+						V0044Node: slurmapi.V0044Node{
+							Name:  ptr.To(slurmNodeName),
+							State: ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateMIXED, slurmapi.V0044NodeStateDRAIN}),
+						},
+					},
+				},
+			}
+			jobList := &slurmtypes.V0044JobInfoList{}
+			if !tt.noJobs {
+				for jobId, nodes := range map[int32]string{1: slurmNodeName, 2: "other-0"} {
+					jobList.Items = append(jobList.Items, slurmtypes.V0044JobInfo{
+						V0044JobInfo: slurmapi.V0044JobInfo{
+							JobId:    ptr.To(jobId),
+							JobState: ptr.To([]slurmapi.V0044JobInfoJobState{slurmapi.V0044JobInfoJobStateRUNNING}),
+							Nodes:    ptr.To(nodes),
+						},
+					})
+				}
+			}
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList, jobList)
+			r := newNodeSetController(k8sclient, newClientMap(controller.Name, slurmClient))
+			recorder := &signalRecorder{SlurmControlInterface: r.slurmControl}
+			r.slurmControl = recorder
+
+			if err := r.processDrainTimeout(ctx, nodeset, pod, tt.elapsed); err != nil {
+				t.Fatalf("NodeSetReconciler.processDrainTimeout() error = %v", err)
+			}
+
+			if len(recorder.signals) != tt.wantSignals {
+				t.Errorf("SignalNodeJobs() calls = %v, want %v", len(recorder.signals), tt.wantSignals)
+			}
+
+			checkJobList := &slurmtypes.V0044JobInfoList{}
+			if err := slurmClient.List(ctx, checkJobList); err != nil {
+				t.Fatalf("slurmClient.List() error = %v", err)
+			}
+			if len(checkJobList.Items) != tt.wantJobs {
+				t.Errorf("Slurm jobs = %v, want %v", len(checkJobList.Items), tt.wantJobs)
+			}
+
+			checkNode := &slurmtypes.V0044Node{}
+			if err := slurmClient.Get(ctx, slurmobject.ObjectKey(slurmNodeName), checkNode); err != nil {
+				t.Fatalf("slurmClient.Get() error = %v", err)
+			}
+			if isDown := checkNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN); isDown != tt.wantDown {
+				t.Errorf("Slurm node DOWN = %v, want %v", isDown, tt.wantDown)
+			}
+
+			checkPod := &corev1.Pod{}
+			err := r.Get(ctx, client.ObjectKeyFromObject(pod), checkPod)
+			if tt.wantDelete {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("Client.Get() error = %v, wantDelete %v", err, tt.wantDelete)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Client.Get() error = %v", err)
+			}
+			_, cond := podutil.GetPodCondition(&checkPod.Status, slurmconditions.PodConditionDrainTimeout)
+			if cond == nil {
+				t.Fatalf("Pod condition %s not found", slurmconditions.PodConditionDrainTimeout)
+			}
+			if cond.Reason != tt.wantReason {
+				t.Errorf("Pod condition %s reason = %v, want %v", cond.Type, cond.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_processDrainTimeout_redrain(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	ctx := context.TODO()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	nodeset.Spec.DrainPolicy = &slinkyv1beta1.NodeSetDrainPolicy{
+		Timeout:     &metav1.Duration{Duration: time.Hour},
+		Action:      slinkyv1beta1.NodeSetDrainActionCancel,
+		GraceSignal: "SIGUSR1",
+	}
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+	pod = makePodHealthy(pod)
+	pod.Annotations[slinkyv1beta1.AnnotationPodCordon] = "true"
+	k8sclient := fake.NewClientBuilder().
+		WithRuntimeObjects(nodeset, pod.DeepCopy()).
+		WithStatusSubresource(&corev1.Pod{}).
+		Build()
+	slurmClient := newFakeClientList(sinterceptor.Funcs{}, &slurmtypes.V0044NodeList{}, &slurmtypes.V0044JobInfoList{})
+	r := newNodeSetController(k8sclient, newClientMap(controller.Name, slurmClient))
+	recorder := &signalRecorder{SlurmControlInterface: r.slurmControl}
+	r.slurmControl = recorder
+
+	// Drain
+	if err := r.processDrainTimeout(ctx, nodeset, pod, time.Hour); err != nil {
+		t.Fatalf("NodeSetReconciler.processDrainTimeout() error = %v", err)
+	}
+	if len(recorder.signals) != 1 {
+		t.Fatalf("SignalNodeJobs() calls = %v, want %v", len(recorder.signals), 1)
+	}
+
+	// Uncordon
+	if err := r.makePodUncordon(ctx, pod); err != nil {
+		t.Fatalf("NodeSetReconciler.makePodUncordon() error = %v", err)
+	}
+	if _, cond := podutil.GetPodCondition(&pod.Status, slurmconditions.PodConditionDrainTimeout); cond != nil {
+		t.Fatalf("Pod condition %s = %v, want nil", cond.Type, cond)
+	}
+
+	// Drain again
+	if err := r.processDrainTimeout(ctx, nodeset, pod, time.Hour); err != nil {
+		t.Fatalf("NodeSetReconciler.processDrainTimeout() error = %v", err)
+	}
+	if len(recorder.signals) != 2 {
+		t.Errorf("SignalNodeJobs() calls = %v, want %v", len(recorder.signals), 2)
+	}
+}
+
+func Test_formatJobIds(t *testing.T) {
+	tests := []struct {
+		name   string
+		jobIds []int32
+		want   string
+	}{
+		{
+			name: "Empty",
+			want: "none",
+		},
+		{
+			name:   "Jobs",
+			jobIds: []int32{1, 2, 3},
+			want:   "1,2,3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatJobIds(tt.jobIds); got != tt.want {
+				t.Errorf("formatJobIds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/kubernetes/pkg/controller/history"
 	"k8s.io/utils/ptr"
 
@@ -209,3 +210,22 @@ func getPatch(nodeset *slinkyv1beta1.NodeSet) ([]byte, error) {
 	patch, err := json.Marshal(objCopy)
 	return patch, err
 }
+
+// applyRevision returns a new NodeSet constructed by restoring the state in revision to nodeset. If the returned error
+// is nil, the returned NodeSet is valid.
+func applyRevision(nodeset *slinkyv1beta1.NodeSet, revision *appsv1.ControllerRevision) (*slinkyv1beta1.NodeSet, error) {
+	clone := nodeset.DeepCopy()
+	crBytes, err := json.Marshal(clone)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(crBytes, revision.Data.Raw, clone)
+	if err != nil {
+		return nil, err
+	}
+	restored := &slinkyv1beta1.NodeSet{}
+	if err := json.Unmarshal(patched, restored); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
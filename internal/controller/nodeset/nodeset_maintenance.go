@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+)
+
+// getMaintenances returns the SlurmMaintenances in the NodeSet namespace whose
+// window has not ended yet.
+func (r *NodeSetReconciler) getMaintenances(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	now time.Time,
+) ([]*slinkyv1beta1.SlurmMaintenance, error) {
+	list := &slinkyv1beta1.SlurmMaintenanceList{}
+	if err := r.List(ctx, list, client.InNamespace(nodeset.Namespace)); err != nil {
+		return nil, err
+	}
+
+	maintenances := []*slinkyv1beta1.SlurmMaintenance{}
+	for i := range list.Items {
+		maintenance := &list.Items[i]
+		if !maintenance.DeletionTimestamp.IsZero() || !now.Before(maintenance.EndTime()) {
+			continue
+		}
+		maintenances = append(maintenances, maintenance)
+	}
+	return maintenances, nil
+}
+
+// activeMaintenanceForPod returns the SlurmMaintenance in progress for the pod, if any.
+func activeMaintenanceForPod(
+	maintenances []*slinkyv1beta1.SlurmMaintenance,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+	now time.Time,
+) *slinkyv1beta1.SlurmMaintenance {
+	for _, maintenance := range maintenances {
+		if maintenance.IsActive(now) && maintenance.SelectsNodeSetPod(nodeset.Name, pod.Labels) {
+			return maintenance
+		}
+	}
+	return nil
+}
+
+// maintenanceDeadline returns the effective workload deadline of the pod, the
+// earlier of the job deadline and the start of the pod's maintenance windows.
+// Pods without running jobs have no deadline.
+func maintenanceDeadline(
+	maintenances []*slinkyv1beta1.SlurmMaintenance,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+	deadline time.Time,
+) time.Time {
+	if deadline.IsZero() {
+		return deadline
+	}
+	for _, maintenance := range maintenances {
+		if !maintenance.SelectsNodeSetPod(nodeset.Name, pod.Labels) {
+			continue
+		}
+		if startTime := maintenance.Spec.StartTime.Time; startTime.Before(deadline) {
+			deadline = startTime
+		}
+	}
+	return deadline
+}
+
+// isPodUnderMaintenance reports if a SlurmMaintenance is in progress for the pod.
+func (r *NodeSetReconciler) isPodUnderMaintenance(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+) (bool, error) {
+	now := time.Now()
+	maintenances, err := r.getMaintenances(ctx, nodeset, now)
+	if err != nil {
+		return false, err
+	}
+	return activeMaintenanceForPod(maintenances, nodeset, pod, now) != nil, nil
+}
+
+// syncMaintenance handles NodeSet pods under a SlurmMaintenance in progress.
+//
+// The pods are cordoned and their Slurm nodes drained. When the SlurmMaintenance
+// recreates pods, the pods created before the maintenance window started are
+// deleted once their Slurm node is drained.
+func (r *NodeSetReconciler) syncMaintenance(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+	now := time.Now()
+
+	maintenances, err := r.getMaintenances(ctx, nodeset, now)
+	if err != nil {
+		return err
+	}
+	if len(maintenances) == 0 {
+		return nil
+	}
+
+	syncMaintenanceFn := func(i int) error {
+		pod := pods[i]
+		maintenance := activeMaintenanceForPod(maintenances, nodeset, pod, now)
+		if maintenance == nil || podutils.IsTerminating(pod) {
+			return nil
+		}
+
+		reason := fmt.Sprintf("SlurmMaintenance (%s) in progress", klog.KObj(maintenance))
+		if err := r.makePodCordonAndDrain(ctx, nodeset, pod, reason); err != nil {
+			return err
+		}
+
+		if !maintenance.Spec.RecreatePods || !pod.CreationTimestamp.Before(&maintenance.Spec.StartTime) {
+			return nil
+		}
+		isDrained, err := r.slurmControl.IsNodeDrained(ctx, nodeset, pod)
+		if err != nil {
+			return err
+		}
+		if !isDrained {
+			durationStore.Push(key, 30*time.Second)
+			return nil
+		}
+
+		logger.Info("Slurm node drained for maintenance, recreating pod",
+			"pod", klog.KObj(pod), "maintenance", klog.KObj(maintenance))
+		r.eventRecorder.Eventf(nodeset, corev1.EventTypeNormal, MaintenanceRecreateReason,
+			"Slurm node %s drained for SlurmMaintenance %s; recreating pod %s",
+			nodesetutils.GetNodeName(pod), maintenance.Name, pod.Name)
+		if err := r.podControl.DeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, err := utils.SlowStartBatch(len(pods), utils.SlowStartInitialBatchSize, syncMaintenanceFn); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_activeMaintenanceForPod(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	now := time.Now()
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+	active := testutils.NewSlurmMaintenance("active", []string{nodeset.Name}, now.Add(-time.Minute), time.Hour)
+	upcoming := testutils.NewSlurmMaintenance("upcoming", []string{nodeset.Name}, now.Add(time.Minute), time.Hour)
+	other := testutils.NewSlurmMaintenance("other", []string{"bar"}, now.Add(-time.Minute), time.Hour)
+	selector := testutils.NewSlurmMaintenance("selector", nil, now.Add(-time.Minute), time.Hour)
+	selector.Spec.PodSelector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"foo": "bar"},
+	}
+	tests := []struct {
+		name         string
+		maintenances []*slinkyv1beta1.SlurmMaintenance
+		want         *slinkyv1beta1.SlurmMaintenance
+	}{
+		{
+			name: "None",
+			want: nil,
+		},
+		{
+			name:         "Active",
+			maintenances: []*slinkyv1beta1.SlurmMaintenance{upcoming, active},
+			want:         active,
+		},
+		{
+			name:         "Upcoming",
+			maintenances: []*slinkyv1beta1.SlurmMaintenance{upcoming},
+			want:         nil,
+		},
+		{
+			name:         "Other NodeSet",
+			maintenances: []*slinkyv1beta1.SlurmMaintenance{other},
+			want:         nil,
+		},
+		{
+			name:         "Pod selector",
+			maintenances: []*slinkyv1beta1.SlurmMaintenance{other, selector},
+			want:         selector,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := activeMaintenanceForPod(tt.maintenances, nodeset, pod, now); got != tt.want {
+				t.Errorf("activeMaintenanceForPod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_maintenanceDeadline(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	now := time.Now()
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+	soon := testutils.NewSlurmMaintenance("soon", []string{nodeset.Name}, now.Add(time.Hour), time.Hour)
+	later := testutils.NewSlurmMaintenance("later", []string{nodeset.Name}, now.Add(3*time.Hour), time.Hour)
+	other := testutils.NewSlurmMaintenance("other", []string{"bar"}, now.Add(time.Minute), time.Hour)
+	tests := []struct {
+		name         string
+		maintenances []*slinkyv1beta1.SlurmMaintenance
+		deadline     time.Time
+		want         time.Time
+	}{
+		{
+			name:         "No jobs",
+			maintenances: []*slinkyv1beta1.SlurmMaintenance{soon},
+			deadline:     time.Time{},
+			want:         time.Time{},
+		},
+		{
+			name:     "No maintenance",
+			deadline: now.Add(2 * time.Hour),
+			want:     now.Add(2 * time.Hour),
+		},
+		{
+			name:         "Maintenance starts before the deadline",
+			maintenances: []*slinkyv1beta1.SlurmMaintenance{later, soon},
+			deadline:     now.Add(2 * time.Hour),
+			want:         soon.Spec.StartTime.Time,
+		},
+		{
+			name:         "Maintenance starts after the deadline",
+			maintenances: []*slinkyv1beta1.SlurmMaintenance{later},
+			deadline:     now.Add(2 * time.Hour),
+			want:         now.Add(2 * time.Hour),
+		},
+		{
+			name:         "Other NodeSet",
+			maintenances: []*slinkyv1beta1.SlurmMaintenance{other},
+			deadline:     now.Add(2 * time.Hour),
+			want:         now.Add(2 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maintenanceDeadline(tt.maintenances, nodeset, pod, tt.deadline); !got.Equal(tt.want) {
+				t.Errorf("maintenanceDeadline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncMaintenance(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	now := time.Now()
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	tests := []struct {
+		name         string
+		maintenance  *slinkyv1beta1.SlurmMaintenance
+		podCreated   time.Time
+		wantCordon   bool
+		wantDeletion bool
+	}{
+		{
+			name:         "No maintenance",
+			wantCordon:   false,
+			wantDeletion: false,
+		},
+		{
+			name:         "Upcoming",
+			maintenance:  testutils.NewSlurmMaintenance("maint", []string{nodeset.Name}, now.Add(time.Hour), time.Hour),
+			wantCordon:   false,
+			wantDeletion: false,
+		},
+		{
+			name:         "Active",
+			maintenance:  testutils.NewSlurmMaintenance("maint", []string{nodeset.Name}, now.Add(-time.Minute), time.Hour),
+			wantCordon:   true,
+			wantDeletion: false,
+		},
+		{
+			name: "Active, recreate pods",
+			maintenance: func() *slinkyv1beta1.SlurmMaintenance {
+				maintenance := testutils.NewSlurmMaintenance("maint", []string{nodeset.Name}, now.Add(-time.Minute), time.Hour)
+				maintenance.Spec.RecreatePods = true
+				return maintenance
+			}(),
+			podCreated:   now.Add(-time.Hour),
+			wantCordon:   true,
+			wantDeletion: true,
+		},
+		{
+			name: "Active, recreated pod",
+			maintenance: func() *slinkyv1beta1.SlurmMaintenance {
+				maintenance := testutils.NewSlurmMaintenance("maint", []string{nodeset.Name}, now.Add(-time.Minute), time.Hour)
+				maintenance.Spec.RecreatePods = true
+				return maintenance
+			}(),
+			podCreated:   now,
+			wantCordon:   true,
+			wantDeletion: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+			pod.CreationTimestamp = metav1.NewTime(tt.podCreated)
+			objs := []client.Object{nodeset.DeepCopy(), pod.DeepCopy()}
+			if tt.maintenance != nil {
+				objs = append(objs, tt.maintenance.DeepCopy())
+			}
+			r := newNodeSetController(fake.NewClientBuilder().WithObjects(objs...).Build(), clientmap.NewClientMap())
+			if err := r.syncMaintenance(context.TODO(), nodeset, []*corev1.Pod{pod}); err != nil {
+				t.Fatalf("NodeSetReconciler.syncMaintenance() error = %v", err)
+			}
+
+			checkPod := &corev1.Pod{}
+			err := r.Get(context.TODO(), client.ObjectKeyFromObject(pod), checkPod)
+			if tt.wantDeletion {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("NodeSetReconciler.syncMaintenance() pod was not deleted, err = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Client.Get() error = %v", err)
+			}
+			if got := podutils.IsPodCordon(checkPod); got != tt.wantCordon {
+				t.Errorf("IsPodCordon() = %v, want %v", got, tt.wantCordon)
+			}
+		})
+	}
+}
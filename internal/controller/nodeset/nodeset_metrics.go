@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/metrics"
+)
+
+// recordNodeSetMetrics records the replica and Slurm node state metrics of the NodeSet.
+func recordNodeSetMetrics(
+	nodeset *slinkyv1beta1.NodeSet,
+	replicaStatus replicaStatus,
+	slurmNodeStatus slurmcontrol.SlurmNodeStatus,
+) {
+	namespace, name := nodeset.Namespace, nodeset.Name
+
+	metrics.NodeSetReplicas.WithLabelValues(namespace, name).Set(float64(replicaStatus.Replicas))
+	metrics.NodeSetUpdatedReplicas.WithLabelValues(namespace, name).Set(float64(replicaStatus.Updated))
+
+	states := map[string]int32{
+		"total": slurmNodeStatus.Total,
+		// Base State
+		"allocated": slurmNodeStatus.Allocated,
+		"down":      slurmNodeStatus.Down,
+		"error":     slurmNodeStatus.Error,
+		"future":    slurmNodeStatus.Future,
+		"idle":      slurmNodeStatus.Idle,
+		"mixed":     slurmNodeStatus.Mixed,
+		"unknown":   slurmNodeStatus.Unknown,
+		// Flag State
+		"completing":     slurmNodeStatus.Completing,
+		"drain":          slurmNodeStatus.Drain,
+		"fail":           slurmNodeStatus.Fail,
+		"invalid":        slurmNodeStatus.Invalid,
+		"invalid_reg":    slurmNodeStatus.InvalidReg,
+		"maintenance":    slurmNodeStatus.Maintenance,
+		"not_responding": slurmNodeStatus.NotResponding,
+		"undrain":        slurmNodeStatus.Undrain,
+	}
+	for state, count := range states {
+		metrics.NodeSetSlurmNodes.WithLabelValues(namespace, name, state).Set(float64(count))
+	}
+}
+
+// observePodDrainDuration records how long the terminated pod was draining,
+// if it was drained.
+func observePodDrainDuration(nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, now time.Time) {
+	value, ok := pod.Annotations[slinkyv1beta1.AnnotationPodDrainStart]
+	if !ok {
+		return
+	}
+	start, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return
+	}
+	metrics.NodeSetPodDrainDuration.WithLabelValues(nodeset.Namespace, nodeset.Name).Observe(now.Sub(start).Seconds())
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/metrics"
+)
+
+func Test_recordNodeSetMetrics(t *testing.T) {
+	nodeset := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "record-metrics"},
+	}
+	replicas := replicaStatus{Replicas: 4, Updated: 2}
+	slurmNodeStatus := slurmcontrol.SlurmNodeStatus{Total: 4, Idle: 3, Allocated: 1, Drain: 1}
+
+	recordNodeSetMetrics(nodeset, replicas, slurmNodeStatus)
+
+	if got := testutil.ToFloat64(metrics.NodeSetReplicas.WithLabelValues(nodeset.Namespace, nodeset.Name)); got != 4 {
+		t.Errorf("NodeSetReplicas = %v, want %v", got, 4)
+	}
+	if got := testutil.ToFloat64(metrics.NodeSetUpdatedReplicas.WithLabelValues(nodeset.Namespace, nodeset.Name)); got != 2 {
+		t.Errorf("NodeSetUpdatedReplicas = %v, want %v", got, 2)
+	}
+	states := map[string]float64{
+		"total":     4,
+		"idle":      3,
+		"allocated": 1,
+		"drain":     1,
+		"down":      0,
+	}
+	for state, want := range states {
+		if got := testutil.ToFloat64(metrics.NodeSetSlurmNodes.WithLabelValues(nodeset.Namespace, nodeset.Name, state)); got != want {
+			t.Errorf("NodeSetSlurmNodes{state=%q} = %v, want %v", state, got, want)
+		}
+	}
+
+	metrics.DeleteNodeSet(nodeset.Namespace, nodeset.Name)
+	if got := testutil.CollectAndCount(metrics.NodeSetReplicas); got != 0 {
+		t.Errorf("NodeSetReplicas has %v series after DeleteNodeSet(), want 0", got)
+	}
+}
+
+func Test_observePodDrainDuration(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantCount   uint64
+	}{
+		{
+			name:      "Not drained",
+			wantCount: 0,
+		},
+		{
+			name: "Invalid drain start",
+			annotations: map[string]string{
+				slinkyv1beta1.AnnotationPodDrainStart: "foo",
+			},
+			wantCount: 0,
+		},
+		{
+			name: "Drained",
+			annotations: map[string]string{
+				slinkyv1beta1.AnnotationPodDrainStart: now.Add(-time.Minute).Format(time.RFC3339),
+			},
+			wantCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "drain-duration"},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+			}
+			defer metrics.DeleteNodeSet(nodeset.Namespace, nodeset.Name)
+
+			observePodDrainDuration(nodeset, pod, now)
+
+			if got := testutil.CollectAndCount(metrics.NodeSetPodDrainDuration); uint64(got) != tt.wantCount {
+				t.Errorf("NodeSetPodDrainDuration series = %v, want %v", got, tt.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// matchNodeHealthRule returns the first NodeHealth rule which the Kubernetes
+// node matches, if any.
+func matchNodeHealthRule(
+	health *slinkyv1beta1.NodeSetNodeHealth,
+	node *corev1.Node,
+) *slinkyv1beta1.NodeSetNodeHealthRule {
+	if health == nil {
+		return nil
+	}
+	for i := range health.Rules {
+		rule := &health.Rules[i]
+		switch {
+		case rule.Condition != nil:
+			for _, cond := range node.Status.Conditions {
+				if cond.Type == rule.Condition.Type && cond.Status == rule.Condition.ConditionStatus() {
+					return rule
+				}
+			}
+		case rule.Taint != nil:
+			for _, taint := range node.Spec.Taints {
+				if taint.Key == rule.Taint.Key && (rule.Taint.Effect == "" || taint.Effect == rule.Taint.Effect) {
+					return rule
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// nodeHealthReason returns the reason of the Slurm node for the NodeHealth rule.
+func nodeHealthReason(rule *slinkyv1beta1.NodeSetNodeHealthRule, node *corev1.Node) string {
+	switch {
+	case rule.Reason != "":
+		return rule.Reason
+	case rule.Condition != nil:
+		return fmt.Sprintf("Node (%s) has condition %s=%s",
+			node.Name, rule.Condition.Type, rule.Condition.ConditionStatus())
+	default:
+		return fmt.Sprintf("Node (%s) has taint %s", node.Name, rule.Taint.Key)
+	}
+}
+
+// syncSlurmNodeHealth sets the Slurm node of the pod into the state of the
+// NodeHealth rule.
+func (r *NodeSetReconciler) syncSlurmNodeHealth(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+	rule *slinkyv1beta1.NodeSetNodeHealthRule,
+	node *corev1.Node,
+) error {
+	reason := nodeHealthReason(rule, node)
+	switch rule.HealthAction() {
+	case slinkyv1beta1.NodeSetNodeHealthActionDown:
+		return r.slurmControl.MakeNodeDown(ctx, nodeset, pod, reason)
+	default:
+		return r.slurmControl.MakeNodeDrain(ctx, nodeset, pod, reason)
+	}
+}
+
+// isNodeUnhealthy returns true if the pod's node matches a NodeHealth rule.
+func (r *NodeSetReconciler) isNodeUnhealthy(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) bool {
+	if nodeset.Spec.NodeHealth == nil {
+		return false
+	}
+
+	node := &corev1.Node{}
+	nodeKey := types.NamespacedName{Name: pod.Spec.NodeName}
+	if err := r.Get(ctx, nodeKey, node); err != nil {
+		return false
+	}
+
+	return matchNodeHealthRule(nodeset.Spec.NodeHealth, node) != nil
+}
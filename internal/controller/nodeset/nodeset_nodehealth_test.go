@@ -0,0 +1,295 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+)
+
+func newNodeHealth() *slinkyv1beta1.NodeSetNodeHealth {
+	return &slinkyv1beta1.NodeSetNodeHealth{
+		Rules: []slinkyv1beta1.NodeSetNodeHealthRule{
+			{
+				Condition: &slinkyv1beta1.NodeSetNodeConditionMatch{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionFalse,
+				},
+				Action: slinkyv1beta1.NodeSetNodeHealthActionDrain,
+			},
+			{
+				Condition: &slinkyv1beta1.NodeSetNodeConditionMatch{
+					Type: "KernelDeadlock",
+				},
+				Action: slinkyv1beta1.NodeSetNodeHealthActionDown,
+				Reason: "kernel deadlock",
+			},
+			{
+				Taint: &slinkyv1beta1.NodeSetNodeTaintMatch{
+					Key: corev1.TaintNodeUnreachable,
+				},
+				Action: slinkyv1beta1.NodeSetNodeHealthActionDown,
+			},
+		},
+	}
+}
+
+func newHealthNode(name string, conditions []corev1.NodeCondition, taints []corev1.Taint) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: corev1.NodeSpec{
+			Taints: taints,
+		},
+		Status: corev1.NodeStatus{
+			Conditions: conditions,
+		},
+	}
+}
+
+func Test_matchNodeHealthRule(t *testing.T) {
+	health := newNodeHealth()
+	tests := []struct {
+		name       string
+		health     *slinkyv1beta1.NodeSetNodeHealth
+		node       *corev1.Node
+		want       *slinkyv1beta1.NodeSetNodeHealthRule
+		wantReason string
+	}{
+		{
+			name:   "No NodeHealth",
+			health: nil,
+			node: newHealthNode("node-0", []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+			}, nil),
+			want: nil,
+		},
+		{
+			name:   "Healthy",
+			health: health,
+			node: newHealthNode("node-0", []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: "KernelDeadlock", Status: corev1.ConditionFalse},
+			}, []corev1.Taint{
+				{Key: "foo", Effect: corev1.TaintEffectNoSchedule},
+			}),
+			want: nil,
+		},
+		{
+			name:   "NotReady",
+			health: health,
+			node: newHealthNode("node-0", []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+			}, nil),
+			want:       &health.Rules[0],
+			wantReason: "Node (node-0) has condition Ready=False",
+		},
+		{
+			name:   "Default condition status",
+			health: health,
+			node: newHealthNode("node-0", []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: "KernelDeadlock", Status: corev1.ConditionTrue},
+			}, nil),
+			want:       &health.Rules[1],
+			wantReason: "kernel deadlock",
+		},
+		{
+			name:   "Taint",
+			health: health,
+			node: newHealthNode("node-0", nil, []corev1.Taint{
+				{Key: corev1.TaintNodeUnreachable, Effect: corev1.TaintEffectNoExecute},
+			}),
+			want:       &health.Rules[2],
+			wantReason: "Node (node-0) has taint " + corev1.TaintNodeUnreachable,
+		},
+		{
+			name: "Taint effect mismatch",
+			health: &slinkyv1beta1.NodeSetNodeHealth{
+				Rules: []slinkyv1beta1.NodeSetNodeHealthRule{
+					{
+						Taint: &slinkyv1beta1.NodeSetNodeTaintMatch{
+							Key:    corev1.TaintNodeUnreachable,
+							Effect: corev1.TaintEffectNoSchedule,
+						},
+					},
+				},
+			},
+			node: newHealthNode("node-0", nil, []corev1.Taint{
+				{Key: corev1.TaintNodeUnreachable, Effect: corev1.TaintEffectNoExecute},
+			}),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchNodeHealthRule(tt.health, tt.node)
+			if got != tt.want {
+				t.Fatalf("matchNodeHealthRule() = %v, want %v", got, tt.want)
+			}
+			if got == nil {
+				return
+			}
+			if reason := nodeHealthReason(got, tt.node); reason != tt.wantReason {
+				t.Errorf("nodeHealthReason() = %v, want %v", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncCordon_nodeHealth(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	nodeset.Spec.NodeHealth = newNodeHealth()
+	tests := []struct {
+		name       string
+		node       *corev1.Node
+		state      []slurmapi.V0044NodeState
+		reason     string
+		wantState  slurmapi.V0044UpdateNodeMsgState
+		wantReason string
+		wantGets   int
+	}{
+		{
+			name: "NotReady, drain",
+			node: newHealthNode("node-0", []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+			}, nil),
+			state:      []slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE},
+			wantState:  slurmapi.V0044UpdateNodeMsgStateDRAIN,
+			wantReason: "slurm-operator: Node (node-0) has condition Ready=False",
+			wantGets:   2,
+		},
+		{
+			name: "Unreachable, down",
+			node: newHealthNode("node-0", nil, []corev1.Taint{
+				{Key: corev1.TaintNodeUnreachable, Effect: corev1.TaintEffectNoExecute},
+			}),
+			state:      []slurmapi.V0044NodeState{slurmapi.V0044NodeStateALLOCATED},
+			wantState:  slurmapi.V0044UpdateNodeMsgStateDOWN,
+			wantReason: "slurm-operator: Node (node-0) has taint " + corev1.TaintNodeUnreachable,
+			wantGets:   2,
+		},
+		{
+			name: "Unhealthy, external reason",
+			node: newHealthNode("node-0", []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+			}, nil),
+			state:    []slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDRAIN},
+			reason:   "admin maintenance",
+			wantGets: 1,
+		},
+		{
+			name: "Healthy again, undrain",
+			node: newHealthNode("node-0", []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			}, nil),
+			state:      []slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDRAIN},
+			reason:     "slurm-operator: Node (node-0) has condition Ready=False",
+			wantState:  slurmapi.V0044UpdateNodeMsgStateUNDRAIN,
+			wantReason: "slurm-operator: Pod (default/foo-0) was uncordoned",
+			wantGets:   2,
+		},
+		{
+			name: "Healthy again, resume",
+			node: newHealthNode("node-0", []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			}, nil),
+			state:      []slurmapi.V0044NodeState{slurmapi.V0044NodeStateDOWN},
+			reason:     "slurm-operator: Node (node-0) has taint " + corev1.TaintNodeUnreachable,
+			wantState:  slurmapi.V0044UpdateNodeMsgStateRESUME,
+			wantReason: "slurm-operator: Pod (default/foo-0) was uncordoned",
+			wantGets:   2,
+		},
+		{
+			name: "Healthy, nothing to undo",
+			node: newHealthNode("node-0", []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			}, nil),
+			state:    []slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE},
+			wantGets: 1,
+		},
+		{
+			name: "Healthy, down externally",
+			node: newHealthNode("node-0", []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			}, nil),
+			state:    []slurmapi.V0044NodeState{slurmapi.V0044NodeStateDOWN},
+			wantGets: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := makePodHealthy(nodesetutils.NewNodeSetPod(nodeset, controller, 0, ""))
+			pod.Spec.NodeName = tt.node.Name
+			slurmNode := &slurmtypes.V0044Node{
+				V0044Node: slurmapi.V0044Node{
+					Name:   ptr.To(nodesetutils.GetNodeName(pod)),
+					State:  ptr.To(tt.state),
+					Reason: ptr.To(tt.reason),
+				},
+			}
+			var gotReq *slurmapi.V0044UpdateNodeMsg
+			gets := 0
+			sclient := newFakeClientList(sinterceptor.Funcs{
+				Get: func(ctx context.Context, key slurmobject.ObjectKey, obj slurmobject.Object, opts ...slurmclient.GetOption) error {
+					gets++
+					*obj.(*slurmtypes.V0044Node) = *slurmNode
+					return nil
+				},
+				Update: func(ctx context.Context, obj slurmobject.Object, req any, opts ...slurmclient.UpdateOption) error {
+					r := req.(slurmapi.V0044UpdateNodeMsg)
+					gotReq = &r
+					return nil
+				},
+			}, &slurmtypes.V0044NodeList{Items: []slurmtypes.V0044Node{*slurmNode}})
+			k8sclient := fake.NewClientBuilder().WithObjects(nodeset.DeepCopy(), pod.DeepCopy(), tt.node.DeepCopy()).Build()
+			r := newNodeSetController(k8sclient, newClientMap(controller.Name, sclient))
+			if err := r.syncCordon(context.TODO(), nodeset, []*corev1.Pod{pod}); err != nil {
+				t.Fatalf("NodeSetReconciler.syncCordon() error = %v", err)
+			}
+			if gets != tt.wantGets {
+				t.Errorf("NodeSetReconciler.syncCordon() slurm node gets = %v, want %v", gets, tt.wantGets)
+			}
+
+			if tt.wantState == "" {
+				if gotReq != nil {
+					t.Errorf("NodeSetReconciler.syncCordon() request = %v, want none", gotReq)
+				}
+				return
+			}
+			if gotReq == nil {
+				t.Fatalf("NodeSetReconciler.syncCordon() did not update the Slurm node")
+			}
+			if got := ptr.Deref(gotReq.State, nil); len(got) != 1 || got[0] != tt.wantState {
+				t.Errorf("NodeSetReconciler.syncCordon() state = %v, want %v", got, tt.wantState)
+			}
+			if got := ptr.Deref(gotReq.Reason, ""); got != tt.wantReason {
+				t.Errorf("NodeSetReconciler.syncCordon() reason = %v, want %v", got, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// remediationStateConditions maps the remediation states to the pod
+// conditions which reflect them.
+var remediationStateConditions = map[slinkyv1beta1.NodeSetRemediationState]corev1.PodConditionType{
+	slinkyv1beta1.NodeSetRemediationStateDown:          slurmconditions.PodConditionDown,
+	slinkyv1beta1.NodeSetRemediationStateFail:          slurmconditions.PodConditionFail,
+	slinkyv1beta1.NodeSetRemediationStateNotResponding: slurmconditions.PodConditionNotResponding,
+	slinkyv1beta1.NodeSetRemediationStateInvalidReg:    slurmconditions.PodConditionInvalidReg,
+}
+
+// remediationBackoffKey returns the key of the Slurm node of the pod in
+// failedPodsBackoff, which outlives the pod.
+func remediationBackoffKey(nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) string {
+	return fmt.Sprintf("%s/remediation/%s", nodeset.UID, nodesetutils.GetNodeName(pod))
+}
+
+// matchRemediationRules returns the rules whose state the Slurm node of the pod
+// has been in for at least their minimum duration. Otherwise, it returns how
+// long until the first rule matches, or zero if none will.
+func matchRemediationRules(
+	rules []slinkyv1beta1.NodeSetRemediationRule,
+	pod *corev1.Pod,
+	now time.Time,
+) ([]*slinkyv1beta1.NodeSetRemediationRule, time.Duration) {
+	matched := []*slinkyv1beta1.NodeSetRemediationRule{}
+	var wait time.Duration
+	for i := range rules {
+		rule := &rules[i]
+		_, cond := podutil.GetPodCondition(&pod.Status, remediationStateConditions[rule.State])
+		if cond == nil || cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		remaining := rule.MinDuration.Duration - now.Sub(cond.LastTransitionTime.Time)
+		if remaining <= 0 {
+			matched = append(matched, rule)
+			continue
+		}
+		if wait == 0 || remaining < wait {
+			wait = remaining
+		}
+	}
+	if len(matched) > 0 {
+		return matched, 0
+	}
+	return nil, wait
+}
+
+// getRemediationRule returns the rule which remediates the pod, if any, or
+// else how long until a rule may.
+func (r *NodeSetReconciler) getRemediationRule(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+	now time.Time,
+) (*slinkyv1beta1.NodeSetRemediationRule, time.Duration, error) {
+	matched, wait := matchRemediationRules(nodeset.Spec.Remediation.Rules, pod, now)
+	for _, rule := range matched {
+		if !rule.OperatorReasonOnly {
+			return rule, 0, nil
+		}
+		ourReason, err := r.slurmControl.IsNodeReasonOurs(ctx, nodeset, pod)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ourReason {
+			return rule, 0, nil
+		}
+	}
+	return nil, wait, nil
+}
+
+// syncRemediation recreates the NodeSet pods whose Slurm node has been in an
+// unhealthy state of the Remediation rules for their minimum duration.
+//
+// Pods are only deleted while fewer than MaxConcurrent NodeSet pods are
+// unavailable, and repeated remediations of the same Slurm node back off
+// exponentially. Pods whose Slurm node is busy, or under a SlurmMaintenance,
+// are not remediated.
+func (r *NodeSetReconciler) syncRemediation(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+	now := time.Now()
+
+	remediation := nodeset.Spec.Remediation
+	if remediation == nil || len(remediation.Rules) == 0 {
+		return nil
+	}
+
+	unavailable := int32(0)
+	for _, pod := range pods {
+		if !podutils.IsRunningAndReady(pod) || podutils.IsTerminating(pod) {
+			unavailable++
+		}
+	}
+	budget := remediation.MaxConcurrentRemediations() - unavailable
+
+	for _, pod := range pods {
+		if !podutils.IsRunningAndReady(pod) || podutils.IsTerminating(pod) {
+			continue
+		}
+		if slurmconditions.IsNodeBusy(&pod.Status) {
+			continue
+		}
+
+		rule, wait, err := r.getRemediationRule(ctx, nodeset, pod, now)
+		if err != nil {
+			return err
+		}
+		if rule == nil {
+			if wait > 0 {
+				durationStore.Push(key, wait)
+			}
+			continue
+		}
+
+		if ok, err := r.isPodUnderMaintenance(ctx, nodeset, pod); err != nil {
+			return err
+		} else if ok {
+			logger.V(1).Info("Skipping remediation for pod under maintenance",
+				"pod", klog.KObj(pod))
+			continue
+		}
+
+		backoffKey := remediationBackoffKey(nodeset, pod)
+		if failedPodsBackoff.IsInBackOffSinceUpdate(backoffKey, now) {
+			delay := failedPodsBackoff.Get(backoffKey)
+			logger.V(1).Info("Remediation of pod is backing off",
+				"pod", klog.KObj(pod), "delay", delay)
+			durationStore.Push(key, delay)
+			continue
+		}
+		if budget <= 0 {
+			logger.V(1).Info("Remediation of pod is pending, too many NodeSet pods are unavailable",
+				"pod", klog.KObj(pod), "maxConcurrent", remediation.MaxConcurrentRemediations())
+			durationStore.Push(key, 30*time.Second)
+			continue
+		}
+
+		_, cond := podutil.GetPodCondition(&pod.Status, remediationStateConditions[rule.State])
+		slurmNodeName := nodesetutils.GetNodeName(pod)
+		logger.Info("Slurm node is unhealthy, remediating pod",
+			"pod", klog.KObj(pod), "state", rule.State, "reason", cond.Message)
+		r.eventRecorder.Eventf(nodeset, corev1.EventTypeWarning, RemediationRecreateReason,
+			"Slurm node %s has been %s for over %s (%s); recreating pod %s",
+			slurmNodeName, rule.State, rule.MinDuration.Duration, cond.Message, pod.Name)
+		failedPodsBackoff.Next(backoffKey, now)
+		if err := r.podControl.DeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		budget--
+	}
+
+	return nil
+}
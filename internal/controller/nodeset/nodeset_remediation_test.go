@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func makePodSlurmState(pod *corev1.Pod, condType corev1.PodConditionType, since time.Time) *corev1.Pod {
+	pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+		Type:               condType,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(since),
+	})
+	return pod
+}
+
+func Test_matchRemediationRules(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	now := time.Now()
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	rules := []slinkyv1beta1.NodeSetRemediationRule{
+		{
+			State:       slinkyv1beta1.NodeSetRemediationStateInvalidReg,
+			MinDuration: metav1.Duration{Duration: 5 * time.Minute},
+		},
+		{
+			State:       slinkyv1beta1.NodeSetRemediationStateDown,
+			MinDuration: metav1.Duration{Duration: 10 * time.Minute},
+		},
+	}
+	tests := []struct {
+		name      string
+		pod       *corev1.Pod
+		wantRules []slinkyv1beta1.NodeSetRemediationState
+		wantWait  time.Duration
+	}{
+		{
+			name:      "Healthy",
+			pod:       makePodSlurmState(nodesetutils.NewNodeSetPod(nodeset, controller, 0, ""), slurmconditions.PodConditionIdle, now.Add(-time.Hour)),
+			wantRules: nil,
+			wantWait:  0,
+		},
+		{
+			name:      "Below the minimum duration",
+			pod:       makePodSlurmState(nodesetutils.NewNodeSetPod(nodeset, controller, 0, ""), slurmconditions.PodConditionInvalidReg, now.Add(-time.Minute)),
+			wantRules: nil,
+			wantWait:  4 * time.Minute,
+		},
+		{
+			name:      "Above the minimum duration",
+			pod:       makePodSlurmState(nodesetutils.NewNodeSetPod(nodeset, controller, 0, ""), slurmconditions.PodConditionInvalidReg, now.Add(-6*time.Minute)),
+			wantRules: []slinkyv1beta1.NodeSetRemediationState{slinkyv1beta1.NodeSetRemediationStateInvalidReg},
+			wantWait:  0,
+		},
+		{
+			name: "Earliest of several states",
+			pod: func() *corev1.Pod {
+				pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+				pod = makePodSlurmState(pod, slurmconditions.PodConditionDown, now.Add(-8*time.Minute))
+				return makePodSlurmState(pod, slurmconditions.PodConditionInvalidReg, now.Add(-2*time.Minute))
+			}(),
+			wantRules: nil,
+			wantWait:  2 * time.Minute,
+		},
+		{
+			name: "Several states",
+			pod: func() *corev1.Pod {
+				pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+				pod = makePodSlurmState(pod, slurmconditions.PodConditionDown, now.Add(-time.Hour))
+				return makePodSlurmState(pod, slurmconditions.PodConditionInvalidReg, now.Add(-time.Hour))
+			}(),
+			wantRules: []slinkyv1beta1.NodeSetRemediationState{
+				slinkyv1beta1.NodeSetRemediationStateInvalidReg,
+				slinkyv1beta1.NodeSetRemediationStateDown,
+			},
+			wantWait: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRules, gotWait := matchRemediationRules(rules, tt.pod, now)
+			states := []slinkyv1beta1.NodeSetRemediationState{}
+			for _, rule := range gotRules {
+				states = append(states, rule.State)
+			}
+			if len(states) != len(tt.wantRules) {
+				t.Fatalf("matchRemediationRules() rules = %v, want %v", states, tt.wantRules)
+			}
+			for i := range states {
+				if states[i] != tt.wantRules[i] {
+					t.Errorf("matchRemediationRules() rules = %v, want %v", states, tt.wantRules)
+				}
+			}
+			if gotWait != tt.wantWait {
+				t.Errorf("matchRemediationRules() wait = %v, want %v", gotWait, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncRemediation(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	now := time.Now()
+	newRemediationNodeSet := func(operatorReasonOnly bool) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet("foo", controller.Name, 2)
+		nodeset.UID = types.UID(t.Name())
+		nodeset.Spec.Remediation = &slinkyv1beta1.NodeSetRemediation{
+			Rules: []slinkyv1beta1.NodeSetRemediationRule{
+				{
+					State:              slinkyv1beta1.NodeSetRemediationStateInvalidReg,
+					MinDuration:        metav1.Duration{Duration: 5 * time.Minute},
+					OperatorReasonOnly: operatorReasonOnly,
+				},
+			},
+		}
+		return nodeset
+	}
+	newUnhealthyPod := func(nodeset *slinkyv1beta1.NodeSet, ordinal int, since time.Time) *corev1.Pod {
+		pod := makePodHealthy(nodesetutils.NewNodeSetPod(nodeset, controller, ordinal, ""))
+		return makePodSlurmState(pod, slurmconditions.PodConditionInvalidReg, since)
+	}
+	newReasonClientMap := func(pods []*corev1.Pod, reason string) *clientmap.ClientMap {
+		nodeList := &slurmtypes.V0044NodeList{}
+		for _, pod := range pods {
+			nodeList.Items = append(nodeList.Items, slurmtypes.V0044Node{
+				V0044Node: slurmapi.V0044Node{
+					Name:   ptr.To(nodesetutils.GetNodeName(pod)),
+					State:  ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateDOWN, slurmapi.V0044NodeStateINVALIDREG}),
+					Reason: ptr.To(reason),
+				},
+			})
+		}
+		return newClientMap(controller.Name, newFakeClientList(sinterceptor.Funcs{}, nodeList))
+	}
+	tests := []struct {
+		name         string
+		nodeset      *slinkyv1beta1.NodeSet
+		pods         func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod
+		clientMap    func(pods []*corev1.Pod) *clientmap.ClientMap
+		maintenance  bool
+		backoff      bool
+		wantDeletion []bool
+	}{
+		{
+			name:    "Healthy",
+			nodeset: newRemediationNodeSet(false),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{
+					makePodHealthy(nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")),
+				}
+			},
+			wantDeletion: []bool{false},
+		},
+		{
+			name:    "Below the minimum duration",
+			nodeset: newRemediationNodeSet(false),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{newUnhealthyPod(nodeset, 0, now.Add(-time.Minute))}
+			},
+			wantDeletion: []bool{false},
+		},
+		{
+			name:    "Above the minimum duration",
+			nodeset: newRemediationNodeSet(false),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{newUnhealthyPod(nodeset, 0, now.Add(-time.Hour))}
+			},
+			wantDeletion: []bool{true},
+		},
+		{
+			name:    "Capped by MaxConcurrent",
+			nodeset: newRemediationNodeSet(false),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{
+					newUnhealthyPod(nodeset, 0, now.Add(-time.Hour)),
+					newUnhealthyPod(nodeset, 1, now.Add(-time.Hour)),
+				}
+			},
+			wantDeletion: []bool{true, false},
+		},
+		{
+			name:    "No budget while a pod is unavailable",
+			nodeset: newRemediationNodeSet(false),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{
+					makePodCreated(nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")),
+					newUnhealthyPod(nodeset, 1, now.Add(-time.Hour)),
+				}
+			},
+			wantDeletion: []bool{false, false},
+		},
+		{
+			name:    "Busy",
+			nodeset: newRemediationNodeSet(false),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				pod := newUnhealthyPod(nodeset, 0, now.Add(-time.Hour))
+				return []*corev1.Pod{makePodSlurmState(pod, slurmconditions.PodConditionCompleting, now)}
+			},
+			wantDeletion: []bool{false},
+		},
+		{
+			name:    "Under maintenance",
+			nodeset: newRemediationNodeSet(false),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{newUnhealthyPod(nodeset, 0, now.Add(-time.Hour))}
+			},
+			maintenance:  true,
+			wantDeletion: []bool{false},
+		},
+		{
+			name:    "Backing off",
+			nodeset: newRemediationNodeSet(false),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{newUnhealthyPod(nodeset, 0, now.Add(-time.Hour))}
+			},
+			backoff:      true,
+			wantDeletion: []bool{false},
+		},
+		{
+			name:    "Operator reason only, external reason",
+			nodeset: newRemediationNodeSet(true),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{newUnhealthyPod(nodeset, 0, now.Add(-time.Hour))}
+			},
+			clientMap: func(pods []*corev1.Pod) *clientmap.ClientMap {
+				return newReasonClientMap(pods, "Low RealMemory")
+			},
+			wantDeletion: []bool{false},
+		},
+		{
+			name:    "Operator reason only, no reason",
+			nodeset: newRemediationNodeSet(true),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{newUnhealthyPod(nodeset, 0, now.Add(-time.Hour))}
+			},
+			clientMap: func(pods []*corev1.Pod) *clientmap.ClientMap {
+				return newReasonClientMap(pods, "")
+			},
+			wantDeletion: []bool{true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods := tt.pods(tt.nodeset)
+			objs := []client.Object{tt.nodeset.DeepCopy()}
+			for _, pod := range pods {
+				objs = append(objs, pod.DeepCopy())
+			}
+			if tt.maintenance {
+				objs = append(objs, testutils.NewSlurmMaintenance("maint", []string{tt.nodeset.Name}, now.Add(-time.Minute), time.Hour))
+			}
+			clientMap := clientmap.NewClientMap()
+			if tt.clientMap != nil {
+				clientMap = tt.clientMap(pods)
+			}
+			for _, pod := range pods {
+				failedPodsBackoff.Reset(remediationBackoffKey(tt.nodeset, pod))
+				if tt.backoff {
+					failedPodsBackoff.Next(remediationBackoffKey(tt.nodeset, pod), now)
+				}
+			}
+			r := newNodeSetController(fake.NewClientBuilder().WithObjects(objs...).Build(), clientMap)
+			if err := r.syncRemediation(context.TODO(), tt.nodeset, pods); err != nil {
+				t.Fatalf("NodeSetReconciler.syncRemediation() error = %v", err)
+			}
+
+			for i, pod := range pods {
+				err := r.Get(context.TODO(), client.ObjectKeyFromObject(pod), &corev1.Pod{})
+				if got := apierrors.IsNotFound(err); got != tt.wantDeletion[i] {
+					t.Errorf("NodeSetReconciler.syncRemediation() pod (%s) deleted = %v, want %v", pod.Name, got, tt.wantDeletion[i])
+				}
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package nodeset
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -14,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
@@ -29,6 +31,7 @@ import (
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/metrics"
 	"github.com/SlinkyProject/slurm-operator/internal/utils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/mathutils"
@@ -52,6 +55,8 @@ func (r *NodeSetReconciler) Sync(ctx context.Context, req reconcile.Request) err
 		if apierrors.IsNotFound(err) {
 			logger.V(3).Info("NodeSet has been deleted.", "request", req)
 			r.expectations.DeleteExpectations(logger, req.String())
+			autoscaleStore.Delete(req.String())
+			metrics.DeleteNodeSet(req.Namespace, req.Name)
 			return nil
 		}
 		return err
@@ -235,14 +240,30 @@ func (r *NodeSetReconciler) sync(
 		return err
 	}
 
+	if err := r.syncSlurmDeletionCost(ctx, nodeset, pods); err != nil {
+		return err
+	}
+
 	if err := r.syncCordon(ctx, nodeset, pods); err != nil {
 		return err
 	}
 
+	if err := r.syncMaintenance(ctx, nodeset, pods); err != nil {
+		return err
+	}
+
+	if err := r.syncRemediation(ctx, nodeset, pods); err != nil {
+		return err
+	}
+
 	if err := r.syncTaint(ctx); err != nil {
 		return err
 	}
 
+	if err := r.syncAutoscaling(ctx, nodeset, pods); err != nil {
+		return err
+	}
+
 	if err := r.syncNodeSet(ctx, nodeset, pods, hash); err != nil {
 		return err
 	}
@@ -280,6 +301,8 @@ func (r *NodeSetReconciler) syncClusterWorkerService(ctx context.Context, nodese
 //
 // When the Kubernetes node is cordoned, the NodeSet pods on that node should have their Slurm node drained.
 // Conversely, when the Kubernetes node is uncordoned, the NodeSet pods on that node should have their Slurm node be undrained.
+// When the Kubernetes node matches a NodeHealth rule, the NodeSet pods on that node should have their Slurm node
+// drained or set down, which is reversed once the Kubernetes node is healthy again.
 // Otherwise the pods' pod-cordon label intent is propagated -- have the Slurm node drained or undrained.
 func (r *NodeSetReconciler) syncCordon(
 	ctx context.Context,
@@ -302,20 +325,29 @@ func (r *NodeSetReconciler) syncCordon(
 
 		nodeIsCordoned := node.Spec.Unschedulable
 		podIsCordoned := podutils.IsPodCordon(pod)
-		slurmNodeIsUnresponsive, err := r.slurmControl.IsNodeDownForUnresponsive(ctx, nodeset, pod)
-		if err != nil {
-			return err
-		}
-		ourReason, err := r.slurmControl.IsNodeReasonOurs(ctx, nodeset, pod)
+		healthRule := matchNodeHealthRule(nodeset.Spec.NodeHealth, node)
+		slurmNodeState, err := r.slurmControl.GetNodeState(ctx, nodeset, pod)
 		if err != nil {
 			return err
 		}
 
 		switch {
+		// If Slurm node cannot be observed, leave it be
+		case !slurmNodeState.Observed:
+			return nil
+
 		// If Slurm node was externally set into a state, preserve it
-		case !ourReason, slurmNodeIsUnresponsive:
+		case !slurmNodeState.ReasonOurs, slurmNodeState.Unresponsive:
 			return nil
 
+		// If Kubernetes node is unhealthy, drain or down the Slurm node
+		case healthRule != nil:
+			logger.V(1).Info("Kubernetes node is unhealthy, propagating into Slurm node",
+				"pod", klog.KObj(pod), "node", node.Name, "action", healthRule.HealthAction())
+			if err := r.syncSlurmNodeHealth(ctx, nodeset, pod, healthRule, node); err != nil {
+				return err
+			}
+
 		// If Kubernetes node is cordoned but pod isn't, cordon the pod
 		case nodeIsCordoned:
 			logger.Info("Kubernetes node cordoned externally, cordoning pod",
@@ -349,11 +381,18 @@ func (r *NodeSetReconciler) syncCordon(
 				return err
 			}
 
-		// If pod is uncordoned, undrain the Slurm node
+		// If pod is uncordoned, undrain and resume the Slurm node, if needed
 		case !podIsCordoned:
 			reason := fmt.Sprintf("Pod (%s) was uncordoned", klog.KObj(pod))
-			if err := r.slurmControl.MakeNodeUndrain(ctx, nodeset, pod, reason); err != nil {
-				return err
+			if slurmNodeState.Drain {
+				if err := r.slurmControl.MakeNodeUndrain(ctx, nodeset, pod, reason); err != nil {
+					return err
+				}
+			}
+			if slurmNodeState.DownByOperator {
+				if err := r.slurmControl.MakeNodeResume(ctx, nodeset, pod, reason); err != nil {
+					return err
+				}
 			}
 		}
 
@@ -410,6 +449,7 @@ func (r *NodeSetReconciler) syncTaint(
 		var toUpdate *corev1.Node
 		var updated bool
 		var err error
+		action := metrics.TaintActionAdd
 
 		// Taint the node if it has a NodeSet pod that is not terminating
 		if nodeSetWithPod.Has(node.Name) {
@@ -419,6 +459,7 @@ func (r *NodeSetReconciler) syncTaint(
 			}
 		} else {
 			// Remove the taint from nodes that don't have NodeSet pods
+			action = metrics.TaintActionRemove
 			toUpdate, updated, err = taints.RemoveTaint(&node, &slurmtaints.TaintNodeWorker)
 			if err != nil {
 				return err
@@ -433,6 +474,7 @@ func (r *NodeSetReconciler) syncTaint(
 		if err := r.Patch(ctx, toUpdate, patch); err != nil {
 			return err
 		}
+		metrics.TaintSyncActions.WithLabelValues(action).Inc()
 
 		return nil
 	}
@@ -453,11 +495,15 @@ func (r *NodeSetReconciler) syncSlurmDeadline(
 	if err != nil {
 		return err
 	}
+	maintenances, err := r.getMaintenances(ctx, nodeset, time.Now())
+	if err != nil {
+		return err
+	}
 
 	syncSlurmDeadlineFn := func(i int) error {
 		pod := pods[i]
 		slurmNodeName := nodesetutils.GetNodeName(pod)
-		deadline := nodeDeadlines.Peek(slurmNodeName)
+		deadline := maintenanceDeadline(maintenances, nodeset, pod, nodeDeadlines.Peek(slurmNodeName))
 
 		toUpdate := pod.DeepCopy()
 		if deadline.IsZero() {
@@ -703,7 +749,7 @@ func (r *NodeSetReconciler) processCondemned(
 ) error {
 	logger := klog.FromContext(ctx)
 	pod := condemned[i]
-	key := objectutils.KeyFunc(pod)
+	key := objectutils.KeyFunc(nodeset)
 
 	podKey := client.ObjectKeyFromObject(pod)
 	if err := r.Get(ctx, podKey, pod); err != nil {
@@ -726,9 +772,24 @@ func (r *NodeSetReconciler) processCondemned(
 			"pod", klog.KObj(pod))
 		// Decrement expectations and requeue reconcile because the Slurm node is not drained yet.
 		// We must wait until fully drained to terminate the pod.
-		durationStore.Push(key, 30*time.Second)
 		reason := fmt.Sprintf("Pod (%s) was cordoned pending termination", klog.KObj(pod))
-		return r.makePodCordonAndDrain(ctx, nodeset, pod, reason)
+		if err := r.makePodCordonAndDrain(ctx, nodeset, pod, reason); err != nil {
+			return err
+		}
+		elapsed, err := r.makePodDrainStart(ctx, pod)
+		if err != nil {
+			return err
+		}
+		timeout, ok := nodeset.Spec.DrainPolicy.DrainTimeout()
+		if !ok || elapsed < timeout {
+			requeueAfter := 30 * time.Second
+			if ok {
+				requeueAfter = min(requeueAfter, timeout-elapsed)
+			}
+			durationStore.Push(key, requeueAfter)
+			return nil
+		}
+		return r.processDrainTimeout(ctx, nodeset, pod, elapsed)
 	}
 
 	logger.V(2).Info("NodeSet Pod is terminating for scale-in",
@@ -738,6 +799,7 @@ func (r *NodeSetReconciler) processCondemned(
 			return err
 		}
 	}
+	observePodDrainDuration(nodeset, pod, time.Now())
 	return nil
 }
 
@@ -935,9 +997,15 @@ func (r *NodeSetReconciler) makePodUncordon(ctx context.Context, pod *corev1.Pod
 		return nil
 	}
 
+	// Removed first, such that it cannot outlive the drain it was set for.
+	if err := r.removePodDrainTimeoutCondition(ctx, pod); err != nil {
+		return err
+	}
+
 	toUpdate := pod.DeepCopy()
 	logger.Info("Uncordon Pod", "Pod", klog.KObj(toUpdate))
 	delete(toUpdate.Annotations, slinkyv1beta1.AnnotationPodCordon)
+	delete(toUpdate.Annotations, slinkyv1beta1.AnnotationPodDrainStart)
 	if err := r.Patch(ctx, toUpdate, client.StrategicMergeFrom(pod)); err != nil {
 		return err
 	}
@@ -959,6 +1027,22 @@ func (r *NodeSetReconciler) syncPodUncordon(ctx context.Context, nodeset *slinky
 		return nil // Skip
 	}
 
+	// The Kubernetes node which the pod is on may be unhealthy
+	if r.isNodeUnhealthy(ctx, nodeset, pod) {
+		logger.V(1).Info("Skipping uncordon for pod on unhealthy node",
+			"pod", klog.KObj(pod), "node", pod.Spec.NodeName)
+		return nil // Skip
+	}
+
+	// The Slurm node must stay drained for the maintenance window
+	if ok, err := r.isPodUnderMaintenance(ctx, nodeset, pod); err != nil {
+		return err
+	} else if ok {
+		logger.V(1).Info("Skipping uncordon for pod under maintenance",
+			"pod", klog.KObj(pod))
+		return nil // Skip
+	}
+
 	// Slurm node may have been externally set in down, drain, fail, etc...
 	if ok, err := r.slurmControl.IsNodeReasonOurs(ctx, nodeset, pod); err != nil {
 		return err
@@ -995,6 +1079,8 @@ func (r *NodeSetReconciler) syncUpdate(
 		return nil
 	case slinkyv1beta1.RollingUpdateNodeSetStrategyType:
 		return r.syncRollingUpdate(ctx, nodeset, pods, hash)
+	case slinkyv1beta1.OpportunisticNodeSetStrategyType:
+		return r.syncOpportunisticUpdate(ctx, nodeset, pods, hash)
 	default:
 		return nil
 	}
@@ -1009,9 +1095,22 @@ func (r *NodeSetReconciler) syncRollingUpdate(
 ) error {
 	logger := log.FromContext(ctx)
 
-	_, oldPods := findUpdatedPods(pods, hash)
+	newPods, oldPods := findUpdatedPods(pods, hash)
+	heldPods, oldPods := splitPartitionedPods(nodeset, oldPods)
+	if len(heldPods) > 0 {
+		logger.V(1).Info("Keep partitioned pods for Rolling Update",
+			"paused", nodeset.Spec.UpdateStrategy.RollingUpdate.IsPaused(),
+			"partition", nodeset.Spec.UpdateStrategy.RollingUpdate.PartitionOrdinal(),
+			"heldPods", len(heldPods))
+	}
+
+	numUpdate, err := r.syncCanary(ctx, nodeset, newPods, oldPods)
+	if err != nil || numUpdate == 0 {
+		return err
+	}
 
 	unhealthyPods, healthyPods := nodesetutils.SplitUnhealthyPods(oldPods)
+	unhealthyPods, _ = nodesetutils.SplitActivePods(unhealthyPods, numUpdate)
 	if len(unhealthyPods) > 0 {
 		logger.Info("Delete unhealthy pods for Rolling Update",
 			"unhealthyPods", len(unhealthyPods))
@@ -1019,8 +1118,10 @@ func (r *NodeSetReconciler) syncRollingUpdate(
 			return err
 		}
 	}
+	numUpdate -= len(unhealthyPods)
 
 	podsToDelete, _ := r.splitUpdatePods(ctx, nodeset, healthyPods, hash)
+	podsToDelete, _ = nodesetutils.SplitActivePods(podsToDelete, numUpdate)
 	if len(podsToDelete) > 0 {
 		logger.Info("Scale-in pods for Rolling Update",
 			"delete", len(podsToDelete))
@@ -1032,6 +1133,50 @@ func (r *NodeSetReconciler) syncRollingUpdate(
 	return nil
 }
 
+// syncOpportunisticUpdate will synchronize opportunistic updates for NodeSet
+// pods. Old pods are replaced once their Slurm node is idle, and only drained
+// like a rolling update once the deadline has passed.
+func (r *NodeSetReconciler) syncOpportunisticUpdate(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	hash string,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+
+	newPods, oldPods := findUpdatedPods(pods, hash)
+	if len(oldPods) == 0 {
+		return nil
+	}
+
+	unhealthyPods, healthyPods := nodesetutils.SplitUnhealthyPods(oldPods)
+	if len(unhealthyPods) > 0 {
+		logger.Info("Delete unhealthy pods for Opportunistic Update",
+			"unhealthyPods", len(unhealthyPods))
+		if err := r.doPodScaleIn(ctx, nodeset, unhealthyPods, nil); err != nil {
+			return err
+		}
+	}
+
+	podsToDelete, podsToKeep := r.splitUpdatePods(ctx, nodeset, slices.Concat(newPods, healthyPods), hash)
+	if len(podsToDelete) > 0 {
+		logger.Info("Scale-in pods for Opportunistic Update",
+			"delete", len(podsToDelete))
+		if err := r.doPodScaleIn(ctx, nodeset, podsToDelete, nil); err != nil {
+			return err
+		}
+	}
+
+	// Recheck the remaining old pods until their Slurm node is idle, or the
+	// deadline has passed.
+	if len(podsToKeep) > len(newPods) {
+		durationStore.Push(key, 30*time.Second)
+	}
+
+	return nil
+}
+
 // splitUpdatePods returns two pod lists based on UpdateStrategy type.
 func (r *NodeSetReconciler) splitUpdatePods(
 	ctx context.Context,
@@ -1041,37 +1186,55 @@ func (r *NodeSetReconciler) splitUpdatePods(
 ) (podsToDelete, podsToKeep []*corev1.Pod) {
 	logger := log.FromContext(ctx)
 
+	var newPods, oldPods, heldPods []*corev1.Pod
+	var maxUnavailableValue *intstr.IntOrString
+	splitFn := nodesetutils.SplitActivePods
 	switch nodeset.Spec.UpdateStrategy.Type {
 	case slinkyv1beta1.OnDeleteNodeSetStrategyType:
 		return nil, nil
 	case slinkyv1beta1.RollingUpdateNodeSetStrategyType:
-		newPods, oldPods := findUpdatedPods(pods, hash)
-
-		var numUnavailable int
-		now := metav1.Now()
-		for _, pod := range newPods {
-			if !podutil.IsPodAvailable(pod, nodeset.Spec.MinReadySeconds, now) {
-				numUnavailable++
+		newPods, oldPods = findUpdatedPods(pods, hash)
+		heldPods, oldPods = splitPartitionedPods(nodeset, oldPods)
+		maxUnavailableValue = nodeset.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable
+	case slinkyv1beta1.OpportunisticNodeSetStrategyType:
+		opportunistic := nodeset.Spec.UpdateStrategy.Opportunistic
+		newPods, oldPods = findUpdatedPods(pods, hash)
+		if !isUpdateOverdue(nodeset, hash) {
+			heldPods, oldPods = splitIdlePods(oldPods)
+		}
+		if opportunistic != nil {
+			maxUnavailableValue = opportunistic.MaxUnavailable
+			if opportunistic.PreferEarliestDeadline {
+				splitFn = nodesetutils.SplitPodsByDeadline
 			}
 		}
-
-		total := int(ptr.Deref(nodeset.Spec.Replicas, 0))
-		maxUnavailable := mathutils.GetScaledValueFromIntOrPercent(nodeset.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable, total, true, 1)
-		remainingUnavailable := mathutils.Clamp((maxUnavailable - numUnavailable), 0, maxUnavailable)
-		podsToDelete, remainingOldPods := nodesetutils.SplitActivePods(oldPods, remainingUnavailable)
-
-		remainingPods := make([]*corev1.Pod, len(newPods))
-		copy(remainingPods, newPods)
-		remainingPods = append(remainingPods, remainingOldPods...)
-
-		logger.V(1).Info("calculated pod lists for update",
-			"maxUnavailable", maxUnavailable,
-			"updatePods", len(podsToDelete),
-			"remainingPods", len(remainingPods))
-		return podsToDelete, remainingPods
 	default:
 		return nil, nil
 	}
+
+	var numUnavailable int
+	now := metav1.Now()
+	for _, pod := range newPods {
+		if !podutil.IsPodAvailable(pod, nodeset.Spec.MinReadySeconds, now) {
+			numUnavailable++
+		}
+	}
+
+	total := int(ptr.Deref(nodeset.Spec.Replicas, 0))
+	maxUnavailable := mathutils.GetScaledValueFromIntOrPercent(maxUnavailableValue, total, true, 1)
+	remainingUnavailable := mathutils.Clamp((maxUnavailable - numUnavailable), 0, maxUnavailable)
+	podsToDelete, remainingOldPods := splitFn(oldPods, remainingUnavailable)
+
+	remainingPods := make([]*corev1.Pod, len(newPods))
+	copy(remainingPods, newPods)
+	remainingPods = append(remainingPods, heldPods...)
+	remainingPods = append(remainingPods, remainingOldPods...)
+
+	logger.V(1).Info("calculated pod lists for update",
+		"maxUnavailable", maxUnavailable,
+		"updatePods", len(podsToDelete),
+		"remainingPods", len(remainingPods))
+	return podsToDelete, remainingPods
 }
 
 // findUpdatedPods looks at non-deleted pods and returns two lists, new and old pods, given the hash.
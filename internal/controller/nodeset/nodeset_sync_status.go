@@ -5,6 +5,7 @@ package nodeset
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
 	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
@@ -74,7 +76,10 @@ func (r *NodeSetReconciler) syncSlurmStatus(
 		if !podutils.IsHealthy(pod) {
 			return nil
 		}
-		return r.slurmControl.UpdateNodeWithPodInfo(ctx, nodeset, pod)
+		if err := r.slurmControl.UpdateNodeWithPodInfo(ctx, nodeset, pod); err != nil {
+			return err
+		}
+		return r.syncSlurmNodeFeatures(ctx, nodeset, pod)
 	}
 	if _, err := utils.SlowStartBatch(len(pods), utils.SlowStartInitialBatchSize, syncSlurmStatusFn); err != nil {
 		return err
@@ -83,6 +88,38 @@ func (r *NodeSetReconciler) syncSlurmStatus(
 	return nil
 }
 
+// syncSlurmNodeFeatures adds the values of the selected Kubernetes node labels
+// to the features of the Slurm node.
+func (r *NodeSetReconciler) syncSlurmNodeFeatures(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+) error {
+	nodeConf := nodeset.Spec.NodeConf
+	if nodeConf == nil || len(nodeConf.FeatureLabels) == 0 || pod.Spec.NodeName == "" {
+		return nil
+	}
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	features := builder.WorkerFeatures(nodeset)
+	for _, label := range nodeConf.FeatureLabels {
+		value := node.Labels[label]
+		if value == "" || slices.Contains(features, value) {
+			continue
+		}
+		features = append(features, value)
+	}
+
+	return r.slurmControl.UpdateNodeFeatures(ctx, nodeset, pod, features)
+}
+
 // syncSlurmStatus handles synchronizing NodeSet Status.
 func (r *NodeSetReconciler) syncNodeSetStatus(
 	ctx context.Context,
@@ -102,6 +139,7 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 	if err != nil {
 		return err
 	}
+	recordNodeSetMetrics(nodeset, replicaStatus, slurmNodeStatus)
 
 	newStatus := &slinkyv1beta1.NodeSetStatus{
 		Replicas:            replicaStatus.Replicas,
@@ -115,9 +153,11 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 		SlurmDrain:          slurmNodeStatus.Drain,
 		ObservedGeneration:  nodeset.Generation,
 		NodeSetHash:         hash,
+		UpdateStartTime:     calculateUpdateStartTime(nodeset, hash),
 		CollisionCount:      &collisionCount,
 		Selector:            selector.String(),
 		Conditions:          []metav1.Condition{},
+		Autoscaling:         calculateAutoscalingStatus(nodeset),
 	}
 	newStatus.Conditions = append(newStatus.Conditions, nodeset.Status.Conditions...)
 
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -240,6 +241,80 @@ func TestNodeSetReconciler_syncSlurmStatus(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_syncSlurmNodeFeatures(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-a",
+			Labels: map[string]string{
+				"node.kubernetes.io/instance-type": "p5.48xlarge",
+				"topology.kubernetes.io/zone":      "zone-a",
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		nodeConf *slinkyv1beta1.NodeSetNodeConf
+		nodeName string
+		want     []string
+	}{
+		{
+			name:     "No feature labels",
+			nodeConf: nil,
+			nodeName: node.Name,
+			want:     nil,
+		},
+		{
+			name: "Not scheduled",
+			nodeConf: &slinkyv1beta1.NodeSetNodeConf{
+				FeatureLabels: []string{"topology.kubernetes.io/zone"},
+			},
+			nodeName: "",
+			want:     nil,
+		},
+		{
+			name: "Feature labels",
+			nodeConf: &slinkyv1beta1.NodeSetNodeConf{
+				FeatureLabels: []string{
+					"node.kubernetes.io/instance-type",
+					"topology.kubernetes.io/zone",
+					"missing",
+				},
+			},
+			nodeName: node.Name,
+			want:     []string{"foo", "p5.48xlarge", "zone-a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := newNodeSet("foo", controller.Name, 1)
+			nodeset.Spec.NodeConf = tt.nodeConf
+			pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+			pod.Spec.NodeName = tt.nodeName
+			c := fake.NewClientBuilder().WithRuntimeObjects(nodeset, pod, node).Build()
+			slurmNodeList := &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{*newNodeSetPodSlurmNode(pod)},
+			}
+			sc := newFakeClientList(slurminterceptor.Funcs{}, slurmNodeList)
+			r := newNodeSetController(c, newClientMap(controller.Name, sc))
+			if err := r.syncSlurmNodeFeatures(context.TODO(), nodeset, pod); err != nil {
+				t.Fatalf("NodeSetReconciler.syncSlurmNodeFeatures() error = %v", err)
+			}
+			slurmNode := newNodeSetPodSlurmNode(pod)
+			if err := sc.Get(context.TODO(), slurmNode.GetKey(), slurmNode); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, ptr.Deref(slurmNode.Features, nil)); diff != "" {
+				t.Errorf("Features (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestNodeSetReconciler_syncNodeSetStatus(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
@@ -395,9 +470,13 @@ func TestNodeSetReconciler_syncNodeSetStatus(t *testing.T) {
 			got := &slinkyv1beta1.NodeSet{}
 			key := client.ObjectKeyFromObject(tt.args.nodeset)
 			if err := r.Get(tt.args.ctx, key, got); err == nil {
-				if diff := cmp.Diff(tt.wantStatus, &got.Status); diff != "" {
+				// The rollout of the update revision starts now.
+				if diff := cmp.Diff(tt.wantStatus, &got.Status, cmpopts.IgnoreFields(slinkyv1beta1.NodeSetStatus{}, "UpdateStartTime")); diff != "" {
 					t.Errorf("unexpected status (-want,+got):\n%s", diff)
 				}
+				if got.Status.UpdateStartTime == nil {
+					t.Errorf("NodeSet.Status.UpdateStartTime = nil, want now")
+				}
 			}
 		})
 	}
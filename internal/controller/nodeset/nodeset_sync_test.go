@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	appsv1 "k8s.io/api/apps/v1"
@@ -48,6 +49,7 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 	slurmtaints "github.com/SlinkyProject/slurm-operator/pkg/taints"
 )
 
@@ -925,6 +927,70 @@ func TestNodeSetReconciler_processCondemned(t *testing.T) {
 				wantDelete: false,
 			}
 		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 2)
+			nodeset.Spec.DrainPolicy = &slinkyv1beta1.NodeSetDrainPolicy{
+				Timeout: &metav1.Duration{Duration: time.Hour},
+				Action:  slinkyv1beta1.NodeSetDrainActionForceDelete,
+			}
+			pods := []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: corev1.NamespaceDefault,
+						Name:      "pod-0",
+						Annotations: map[string]string{
+							slinkyv1beta1.AnnotationPodCordon:     "true",
+							slinkyv1beta1.AnnotationPodDrainStart: time.Now().Add(-2 * time.Hour).Format(time.RFC3339),
+						},
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+						Conditions: []corev1.PodCondition{
+							{
+								Type:   corev1.PodReady,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			}
+			podList := &corev1.PodList{
+				Items: structutils.DereferenceList(pods),
+			}
+			client := fake.NewFakeClient(nodeset, podList)
+			slurmNodeList := &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					{
+						V0044Node: slurmapi.V0044Node{
+							Name: ptr.To(nodesetutils.GetNodeName(pods[0])),
+							State: ptr.To([]slurmapi.V0044NodeState{
+								slurmapi.V0044NodeStateMIXED,
+								slurmapi.V0044NodeStateDRAIN,
+							}),
+						},
+					},
+				},
+			}
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList)
+			clientMap := newClientMap(controller.Name, slurmClient)
+
+			return testCaseFields{
+				name: "force delete after drain timeout",
+				fields: fields{
+					Client:    client,
+					ClientMap: clientMap,
+				},
+				args: args{
+					ctx:       context.TODO(),
+					nodeset:   nodeset,
+					condemned: pods,
+					i:         0,
+				},
+				wantErr:    false,
+				wantDrain:  true,
+				wantDelete: true,
+			}
+		}(),
 		func() testCaseFields {
 			nodeset := newNodeSet("foo", controller.Name, 2)
 			pods := []*corev1.Pod{
@@ -2035,6 +2101,98 @@ func TestNodeSetReconciler_splitUpdatePods(t *testing.T) {
 			wantPodsToDelete: []string{},
 			wantPodsToKeep:   []string{"pod-0", "pod-1"},
 		},
+		{
+			name: "Opportunistic",
+			fields: fields{
+				Client: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				nodeset: func() *slinkyv1beta1.NodeSet {
+					nodeset := newNodeSet("foo", controller.Name, 3)
+					nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.OpportunisticNodeSetStrategyType
+					nodeset.Spec.UpdateStrategy.Opportunistic = &slinkyv1beta1.OpportunisticNodeSetStrategy{
+						MaxUnavailable: ptr.To(intstr.FromString("100%")),
+					}
+					return nodeset
+				}(),
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-0",
+							Labels: map[string]string{
+								history.ControllerRevisionHashLabel: "",
+							},
+						},
+						Status: corev1.PodStatus{
+							Phase: corev1.PodRunning,
+							Conditions: []corev1.PodCondition{
+								{
+									Type:               corev1.PodReady,
+									Status:             corev1.ConditionTrue,
+									LastTransitionTime: now,
+								},
+								{
+									Type:   slurmconditions.PodConditionIdle,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-1",
+							Labels: map[string]string{
+								history.ControllerRevisionHashLabel: "",
+							},
+						},
+						Status: corev1.PodStatus{
+							Phase: corev1.PodRunning,
+							Conditions: []corev1.PodCondition{
+								{
+									Type:               corev1.PodReady,
+									Status:             corev1.ConditionTrue,
+									LastTransitionTime: now,
+								},
+								{
+									Type:   slurmconditions.PodConditionAllocated,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-2",
+							Labels: map[string]string{
+								history.ControllerRevisionHashLabel: "",
+							},
+						},
+						Status: corev1.PodStatus{
+							Phase: corev1.PodRunning,
+							Conditions: []corev1.PodCondition{
+								{
+									Type:               corev1.PodReady,
+									Status:             corev1.ConditionTrue,
+									LastTransitionTime: now,
+								},
+								{
+									Type:   slurmconditions.PodConditionIdle,
+									Status: corev1.ConditionTrue,
+								},
+								{
+									Type:   slurmconditions.PodConditionCompleting,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+					},
+				},
+				hash: hash,
+			},
+			wantPodsToDelete: []string{"pod-0"},
+			wantPodsToKeep:   []string{"pod-1", "pod-2"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
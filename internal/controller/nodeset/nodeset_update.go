@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/history"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/mathutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// splitPartitionedPods returns two lists of old pods, those which the rolling
+// update must keep, because it is paused or their ordinal is below the
+// partition, and those which it may update.
+func splitPartitionedPods(
+	nodeset *slinkyv1beta1.NodeSet,
+	oldPods []*corev1.Pod,
+) (heldPods, updatePods []*corev1.Pod) {
+	rollingUpdate := nodeset.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate.IsPaused() {
+		return oldPods, nil
+	}
+	partition := rollingUpdate.PartitionOrdinal()
+	for _, pod := range oldPods {
+		if nodesetutils.GetOrdinal(pod) < partition {
+			heldPods = append(heldPods, pod)
+		} else {
+			updatePods = append(updatePods, pod)
+		}
+	}
+	return heldPods, updatePods
+}
+
+// splitIdlePods returns two lists of old pods, those whose Slurm node is busy,
+// and those which may be replaced without waiting on jobs, because their Slurm
+// node is idle or the pod is unhealthy.
+func splitIdlePods(oldPods []*corev1.Pod) (busyPods, idlePods []*corev1.Pod) {
+	for _, pod := range oldPods {
+		isIdle := slurmconditions.IsConditionTrue(&pod.Status, slurmconditions.PodConditionIdle) &&
+			!slurmconditions.IsNodeBusy(&pod.Status)
+		if isIdle || !podutils.IsHealthy(pod) {
+			idlePods = append(idlePods, pod)
+		} else {
+			busyPods = append(busyPods, pod)
+		}
+	}
+	return busyPods, idlePods
+}
+
+// isUpdateOverdue returns true if the rollout of the update revision started
+// longer ago than the Opportunistic deadline.
+func isUpdateOverdue(nodeset *slinkyv1beta1.NodeSet, hash string) bool {
+	deadline, ok := nodeset.Spec.UpdateStrategy.Opportunistic.UpdateDeadline()
+	if !ok {
+		return false
+	}
+
+	// The rollout starts now, if the status has yet to observe the revision.
+	if nodeset.Status.NodeSetHash != hash || nodeset.Status.UpdateStartTime == nil {
+		return false
+	}
+	return time.Since(nodeset.Status.UpdateStartTime.Time) >= deadline
+}
+
+// calculateUpdateStartTime returns when the rollout of the update revision
+// started, which is now if the update revision has changed.
+func calculateUpdateStartTime(nodeset *slinkyv1beta1.NodeSet, hash string) *metav1.Time {
+	if nodeset.Status.NodeSetHash == hash && nodeset.Status.UpdateStartTime != nil {
+		return nodeset.Status.UpdateStartTime
+	}
+	return ptr.To(metav1.Now())
+}
+
+// canaryStatus is the observed state of the canary pods of a rolling update.
+type canaryStatus struct {
+	// soaked is the number of canary pods whose Slurm node has been healthy
+	// for the soak period.
+	soaked int
+	// failed are the canary pods whose Slurm node is unhealthy.
+	failed []*corev1.Pod
+	// wait is how long until the next canary pod will have soaked.
+	wait time.Duration
+}
+
+// calculateCanaryStatus will calculate the canary status of the updated pods,
+// given the Slurm node states. The soak period of a pod starts when it last
+// became ready.
+func calculateCanaryStatus(
+	newPods []*corev1.Pod,
+	nodeStatus *slurmcontrol.SlurmNodeStatus,
+	soakPeriod time.Duration,
+	now time.Time,
+) canaryStatus {
+	status := canaryStatus{}
+	for _, pod := range newPods {
+		nodeStates, registered := nodeStatus.NodeStates[nodesetutils.GetNodeName(pod)]
+		if isCanaryFailed(pod, nodeStates) {
+			status.failed = append(status.failed, pod)
+			continue
+		}
+		if !registered || !podutils.IsRunningAndReady(pod) {
+			continue
+		}
+		remaining := soakPeriod
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady {
+				remaining -= now.Sub(cond.LastTransitionTime.Time)
+			}
+		}
+		if remaining <= 0 {
+			status.soaked++
+			continue
+		}
+		if status.wait == 0 || remaining < status.wait {
+			status.wait = remaining
+		}
+	}
+	return status
+}
+
+// isCanaryFailed returns true if the Slurm node of the canary pod is DOWN,
+// FAIL, NOT_RESPONDING, INVALID_REG, or DRAIN without the operator having
+// cordoned the pod.
+func isCanaryFailed(pod *corev1.Pod, nodeStates []corev1.PodCondition) bool {
+	states := make(map[corev1.PodConditionType]bool, len(nodeStates))
+	for _, cond := range nodeStates {
+		states[cond.Type] = true
+	}
+	switch {
+	case states[slurmconditions.PodConditionDown],
+		states[slurmconditions.PodConditionFail],
+		states[slurmconditions.PodConditionNotResponding],
+		states[slurmconditions.PodConditionInvalidReg]:
+		return true
+	case states[slurmconditions.PodConditionDrain] && !states[slurmconditions.PodConditionUndrain]:
+		return !podutils.IsPodCordon(pod)
+	}
+	return false
+}
+
+// syncCanary returns how many of the old pods the rolling update may replace,
+// given the canary configuration of the NodeSet.
+//
+// Until enough updated pods have soaked, only the canary pods are updated. When
+// the Slurm node of a canary pod becomes unhealthy in the meantime, the
+// rolling update is halted, or rolled back if AutoRollback is enabled.
+func (r *NodeSetReconciler) syncCanary(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	newPods, oldPods []*corev1.Pod,
+) (int, error) {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+
+	canary := nodeset.Spec.UpdateStrategy.RollingUpdate.CanaryConfig()
+	if canary == nil || len(oldPods) == 0 {
+		return len(oldPods), nil
+	}
+
+	total := int(ptr.Deref(nodeset.Spec.Replicas, 0))
+	canaryReplicas := max(mathutils.GetScaledValueFromIntOrPercent(canary.Replicas, total, true, 1), 1)
+
+	nodeStatus, err := r.slurmControl.CalculateNodeStatus(ctx, nodeset, newPods)
+	if err != nil {
+		return 0, err
+	}
+	status := calculateCanaryStatus(newPods, &nodeStatus, canary.SoakDuration(), time.Now())
+	if status.soaked >= canaryReplicas {
+		return len(oldPods), nil
+	}
+
+	if len(status.failed) > 0 {
+		pod := status.failed[0]
+		if !canary.AutoRollback {
+			logger.Info("Canary Slurm node is unhealthy, halting Rolling Update",
+				"pod", klog.KObj(pod), "failed", len(status.failed))
+			r.eventRecorder.Eventf(nodeset, corev1.EventTypeWarning, CanaryFailedReason,
+				"Slurm node %s of canary pod %s is unhealthy; halting rolling update",
+				nodesetutils.GetNodeName(pod), pod.Name)
+			return 0, nil
+		}
+		return 0, r.rollbackNodeSet(ctx, nodeset, slices.Concat(newPods, oldPods), pod)
+	}
+
+	if status.wait > 0 {
+		durationStore.Push(key, status.wait)
+	}
+	numUpdate := mathutils.Clamp(canaryReplicas-len(newPods), 0, len(oldPods))
+	logger.V(1).Info("Rolling Update is in the canary phase",
+		"canaryReplicas", canaryReplicas, "soaked", status.soaked, "update", numUpdate)
+	return numUpdate, nil
+}
+
+// rollbackNodeSet restores the NodeSet to the newest previous revision which
+// any of its pods still run, because the Slurm node of a canary pod of the
+// update revision is unhealthy.
+func (r *NodeSetReconciler) rollbackNodeSet(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	failedPod *corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+
+	revisions, err := r.listRevisions(nodeset)
+	if err != nil {
+		return err
+	}
+	history.SortControllerRevisions(revisions)
+
+	updateHash := historycontrol.GetRevision(failedPod.GetLabels())
+	liveHashes := set.New[string]()
+	for _, pod := range pods {
+		liveHashes.Insert(historycontrol.GetRevision(pod.GetLabels()))
+	}
+	var previous *appsv1.ControllerRevision
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revisionHash := historycontrol.GetRevision(revisions[i].GetLabels())
+		if revisionHash != updateHash && liveHashes.Has(revisionHash) {
+			previous = revisions[i]
+			break
+		}
+	}
+	if previous == nil {
+		logger.Info("Canary Slurm node is unhealthy, but there is no previous revision, halting Rolling Update",
+			"pod", klog.KObj(failedPod))
+		return nil
+	}
+
+	restored, err := applyRevision(nodeset, previous)
+	if err != nil {
+		return err
+	}
+	logger.Info("Canary Slurm node is unhealthy, rolling back NodeSet",
+		"pod", klog.KObj(failedPod), "revision", previous.Name)
+	r.eventRecorder.Eventf(nodeset, corev1.EventTypeWarning, CanaryRollbackReason,
+		"Slurm node %s of canary pod %s is unhealthy; rolling back to revision %s",
+		nodesetutils.GetNodeName(failedPod), failedPod.Name, previous.Name)
+	return r.Patch(ctx, restored, client.MergeFrom(nodeset))
+}
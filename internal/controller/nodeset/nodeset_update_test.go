@@ -0,0 +1,467 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// makePodReadySince makes the pod healthy, having become ready at the given time.
+func makePodReadySince(pod *corev1.Pod, since time.Time) *corev1.Pod {
+	makePodHealthy(pod)
+	pod.Status.Conditions[len(pod.Status.Conditions)-1].LastTransitionTime = metav1.NewTime(since)
+	return pod
+}
+
+// makePodCordon makes the pod cordoned by the operator.
+func makePodCordon(pod *corev1.Pod) *corev1.Pod {
+	pod.Annotations[slinkyv1beta1.AnnotationPodCordon] = "true"
+	return pod
+}
+
+// newNodeSetRevision returns the ControllerRevision of the NodeSet, as listed
+// by its selector.
+func newNodeSetRevision(nodeset *slinkyv1beta1.NodeSet, revision int64) *appsv1.ControllerRevision {
+	cr, err := newRevision(nodeset, revision, ptr.To[int32](0))
+	if err != nil {
+		panic(err)
+	}
+	cr.Namespace = nodeset.Namespace
+	maps.Copy(cr.Labels, labels.NewBuilder().WithWorkerSelectorLabels(nodeset).Build())
+	return cr
+}
+
+func Test_splitPartitionedPods(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	newPods := func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+		pods := []*corev1.Pod{}
+		for i := range 4 {
+			pods = append(pods, nodesetutils.NewNodeSetPod(nodeset, controller, i, ""))
+		}
+		return pods
+	}
+	tests := []struct {
+		name           string
+		rollingUpdate  *slinkyv1beta1.RollingUpdateNodeSetStrategy
+		wantHeldPods   []string
+		wantUpdatePods []string
+	}{
+		{
+			name:           "Default",
+			rollingUpdate:  nil,
+			wantHeldPods:   []string{},
+			wantUpdatePods: []string{"foo-0", "foo-1", "foo-2", "foo-3"},
+		},
+		{
+			name: "Partition",
+			rollingUpdate: &slinkyv1beta1.RollingUpdateNodeSetStrategy{
+				Partition: ptr.To[int32](2),
+			},
+			wantHeldPods:   []string{"foo-0", "foo-1"},
+			wantUpdatePods: []string{"foo-2", "foo-3"},
+		},
+		{
+			name: "Partition beyond replicas",
+			rollingUpdate: &slinkyv1beta1.RollingUpdateNodeSetStrategy{
+				Partition: ptr.To[int32](10),
+			},
+			wantHeldPods:   []string{"foo-0", "foo-1", "foo-2", "foo-3"},
+			wantUpdatePods: []string{},
+		},
+		{
+			name: "Paused",
+			rollingUpdate: &slinkyv1beta1.RollingUpdateNodeSetStrategy{
+				Paused: true,
+			},
+			wantHeldPods:   []string{"foo-0", "foo-1", "foo-2", "foo-3"},
+			wantUpdatePods: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := newNodeSet("foo", controller.Name, 4)
+			nodeset.Spec.UpdateStrategy.RollingUpdate = tt.rollingUpdate
+			gotHeldPods, gotUpdatePods := splitPartitionedPods(nodeset, newPods(nodeset))
+			gotHeldNames := []string{}
+			for _, pod := range gotHeldPods {
+				gotHeldNames = append(gotHeldNames, pod.Name)
+			}
+			gotUpdateNames := []string{}
+			for _, pod := range gotUpdatePods {
+				gotUpdateNames = append(gotUpdateNames, pod.Name)
+			}
+			if diff := cmp.Diff(tt.wantHeldPods, gotHeldNames); diff != "" {
+				t.Errorf("heldPods (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantUpdatePods, gotUpdateNames); diff != "" {
+				t.Errorf("updatePods (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_calculateCanaryStatus(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 4)
+	now := time.Now()
+	soakPeriod := 10 * time.Minute
+	tests := []struct {
+		name       string
+		pods       []*corev1.Pod
+		nodeStates map[string][]corev1.PodConditionType
+		wantSoaked int
+		wantFailed int
+		wantWait   time.Duration
+	}{
+		{
+			name: "Soaked",
+			pods: []*corev1.Pod{
+				makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, 0, ""), now.Add(-time.Hour)),
+			},
+			nodeStates: map[string][]corev1.PodConditionType{
+				"foo-0": {slurmconditions.PodConditionIdle},
+			},
+			wantSoaked: 1,
+		},
+		{
+			name: "Soaking",
+			pods: []*corev1.Pod{
+				makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, 0, ""), now.Add(-time.Hour)),
+				makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, 1, ""), now.Add(-4*time.Minute)),
+			},
+			nodeStates: map[string][]corev1.PodConditionType{
+				"foo-0": {slurmconditions.PodConditionAllocated},
+				"foo-1": {slurmconditions.PodConditionIdle},
+			},
+			wantSoaked: 1,
+			wantWait:   6 * time.Minute,
+		},
+		{
+			name: "Not registered",
+			pods: []*corev1.Pod{
+				makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, 0, ""), now.Add(-time.Hour)),
+			},
+			nodeStates: map[string][]corev1.PodConditionType{},
+		},
+		{
+			name: "Not ready",
+			pods: []*corev1.Pod{
+				nodesetutils.NewNodeSetPod(nodeset, controller, 0, ""),
+			},
+			nodeStates: map[string][]corev1.PodConditionType{
+				"foo-0": {slurmconditions.PodConditionIdle},
+			},
+		},
+		{
+			name: "Failed",
+			pods: []*corev1.Pod{
+				makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, 0, ""), now.Add(-time.Hour)),
+				makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, 1, ""), now.Add(-time.Hour)),
+				makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, 2, ""), now.Add(-time.Hour)),
+				makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, 3, ""), now.Add(-time.Hour)),
+			},
+			nodeStates: map[string][]corev1.PodConditionType{
+				"foo-0": {slurmconditions.PodConditionDown},
+				"foo-1": {slurmconditions.PodConditionIdle, slurmconditions.PodConditionFail},
+				"foo-2": {slurmconditions.PodConditionIdle, slurmconditions.PodConditionNotResponding},
+				"foo-3": {slurmconditions.PodConditionIdle, slurmconditions.PodConditionInvalidReg},
+			},
+			wantFailed: 4,
+		},
+		{
+			name: "Drained",
+			pods: []*corev1.Pod{
+				makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, 0, ""), now.Add(-time.Hour)),
+				makePodCordon(makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, 1, ""), now.Add(-time.Hour))),
+				makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, 2, ""), now.Add(-time.Hour)),
+			},
+			nodeStates: map[string][]corev1.PodConditionType{
+				"foo-0": {slurmconditions.PodConditionIdle, slurmconditions.PodConditionDrain},
+				"foo-1": {slurmconditions.PodConditionIdle, slurmconditions.PodConditionDrain},
+				"foo-2": {slurmconditions.PodConditionIdle, slurmconditions.PodConditionDrain, slurmconditions.PodConditionUndrain},
+			},
+			wantSoaked: 2,
+			wantFailed: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeStatus := &slurmcontrol.SlurmNodeStatus{
+				NodeStates: make(map[string][]corev1.PodCondition),
+			}
+			for nodeName, condTypes := range tt.nodeStates {
+				for _, condType := range condTypes {
+					nodeStatus.NodeStates[nodeName] = append(nodeStatus.NodeStates[nodeName], corev1.PodCondition{
+						Type:   condType,
+						Status: corev1.ConditionTrue,
+					})
+				}
+			}
+			got := calculateCanaryStatus(tt.pods, nodeStatus, soakPeriod, now)
+			if got.soaked != tt.wantSoaked {
+				t.Errorf("calculateCanaryStatus() soaked = %v, want %v", got.soaked, tt.wantSoaked)
+			}
+			if len(got.failed) != tt.wantFailed {
+				t.Errorf("calculateCanaryStatus() failed = %v, want %v", len(got.failed), tt.wantFailed)
+			}
+			if got.wait != tt.wantWait {
+				t.Errorf("calculateCanaryStatus() wait = %v, want %v", got.wait, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncCanary(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	now := time.Now()
+
+	previous := newNodeSet("foo", controller.Name, 4)
+	previous.Spec.Slurmd.Image = "slurmd:1"
+	previousRevision := newNodeSetRevision(previous, 1)
+	previousHash := historycontrol.GetRevision(previousRevision.GetLabels())
+
+	tests := []struct {
+		name      string
+		canary    *slinkyv1beta1.NodeSetCanary
+		newPods   int
+		readyFor  time.Duration
+		state     slurmapi.V0044NodeState
+		want      int
+		wantImage string
+	}{
+		{
+			name:      "No canary",
+			canary:    nil,
+			want:      4,
+			wantImage: "slurmd:2",
+		},
+		{
+			name: "Start canary",
+			canary: &slinkyv1beta1.NodeSetCanary{
+				Replicas: ptr.To(intstr.FromString("50%")),
+			},
+			want:      2,
+			wantImage: "slurmd:2",
+		},
+		{
+			name: "Canary pending",
+			canary: &slinkyv1beta1.NodeSetCanary{
+				Replicas: ptr.To(intstr.FromString("50%")),
+			},
+			newPods:   1,
+			readyFor:  time.Hour,
+			state:     slurmapi.V0044NodeStateIDLE,
+			want:      1,
+			wantImage: "slurmd:2",
+		},
+		{
+			name: "Canary soaking",
+			canary: &slinkyv1beta1.NodeSetCanary{
+				Replicas: ptr.To(intstr.FromString("50%")),
+			},
+			newPods:   2,
+			readyFor:  time.Minute,
+			state:     slurmapi.V0044NodeStateIDLE,
+			want:      0,
+			wantImage: "slurmd:2",
+		},
+		{
+			name: "Canary soaked",
+			canary: &slinkyv1beta1.NodeSetCanary{
+				Replicas:   ptr.To(intstr.FromString("50%")),
+				SoakPeriod: &metav1.Duration{Duration: 30 * time.Minute},
+			},
+			newPods:   2,
+			readyFor:  time.Hour,
+			state:     slurmapi.V0044NodeStateALLOCATED,
+			want:      2,
+			wantImage: "slurmd:2",
+		},
+		{
+			name: "Canary failed, halt",
+			canary: &slinkyv1beta1.NodeSetCanary{
+				Replicas: ptr.To(intstr.FromInt32(1)),
+			},
+			newPods:   1,
+			readyFor:  time.Minute,
+			state:     slurmapi.V0044NodeStateDOWN,
+			want:      0,
+			wantImage: "slurmd:2",
+		},
+		{
+			name: "Canary failed, rollback",
+			canary: &slinkyv1beta1.NodeSetCanary{
+				Replicas:     ptr.To(intstr.FromInt32(1)),
+				AutoRollback: true,
+			},
+			newPods:   1,
+			readyFor:  time.Minute,
+			state:     slurmapi.V0044NodeStateDOWN,
+			want:      0,
+			wantImage: "slurmd:1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := newNodeSet("foo", controller.Name, 4)
+			nodeset.Spec.Slurmd.Image = "slurmd:2"
+			nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+			nodeset.Spec.UpdateStrategy.RollingUpdate = &slinkyv1beta1.RollingUpdateNodeSetStrategy{
+				Canary: tt.canary,
+			}
+			updateRevision := newNodeSetRevision(nodeset, 2)
+			hash := historycontrol.GetRevision(updateRevision.GetLabels())
+
+			newPods := []*corev1.Pod{}
+			oldPods := []*corev1.Pod{}
+			slurmNodes := []slurmtypes.V0044Node{}
+			for i := range 4 {
+				if i < tt.newPods {
+					pod := makePodReadySince(nodesetutils.NewNodeSetPod(nodeset, controller, i, hash), now.Add(-tt.readyFor))
+					newPods = append(newPods, pod)
+					slurmNodes = append(slurmNodes, slurmtypes.V0044Node{
+						V0044Node: slurmapi.V0044Node{
+							Name:  ptr.To(nodesetutils.GetNodeName(pod)),
+							State: ptr.To([]slurmapi.V0044NodeState{tt.state}),
+						},
+					})
+				} else {
+					pod := makePodReadySince(nodesetutils.NewNodeSetPod(previous, controller, i, previousHash), now.Add(-24*time.Hour))
+					oldPods = append(oldPods, pod)
+				}
+			}
+
+			sclient := newFakeClientList(sinterceptor.Funcs{}, &slurmtypes.V0044NodeList{Items: slurmNodes})
+			k8sclient := fake.NewClientBuilder().
+				WithObjects(nodeset.DeepCopy(), previousRevision.DeepCopy(), updateRevision.DeepCopy()).
+				Build()
+			r := newNodeSetController(k8sclient, newClientMap(controller.Name, sclient))
+
+			toUpdate := &slinkyv1beta1.NodeSet{}
+			if err := k8sclient.Get(context.TODO(), nodeset.Key(), toUpdate); err != nil {
+				t.Fatalf("failed to get NodeSet: %v", err)
+			}
+			got, err := r.syncCanary(context.TODO(), toUpdate, newPods, oldPods)
+			if err != nil {
+				t.Fatalf("NodeSetReconciler.syncCanary() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NodeSetReconciler.syncCanary() = %v, want %v", got, tt.want)
+			}
+
+			checkNodeSet := &slinkyv1beta1.NodeSet{}
+			if err := k8sclient.Get(context.TODO(), nodeset.Key(), checkNodeSet); err != nil {
+				t.Fatalf("failed to get NodeSet: %v", err)
+			}
+			if checkNodeSet.Spec.Slurmd.Image != tt.wantImage {
+				t.Errorf("NodeSet.Spec.Slurmd.Image = %v, want %v", checkNodeSet.Spec.Slurmd.Image, tt.wantImage)
+			}
+		})
+	}
+}
+
+func Test_isUpdateOverdue(t *testing.T) {
+	tests := []struct {
+		name       string
+		deadline   *metav1.Duration
+		statusHash string
+		startAt    time.Time
+		want       bool
+	}{
+		{
+			name:       "No deadline",
+			deadline:   nil,
+			statusHash: "update",
+			startAt:    time.Now().Add(-24 * time.Hour),
+			want:       false,
+		},
+		{
+			name:       "Before deadline",
+			deadline:   &metav1.Duration{Duration: 2 * time.Hour},
+			statusHash: "update",
+			startAt:    time.Now().Add(-time.Hour),
+			want:       false,
+		},
+		{
+			name:       "After deadline",
+			deadline:   &metav1.Duration{Duration: 2 * time.Hour},
+			statusHash: "update",
+			startAt:    time.Now().Add(-3 * time.Hour),
+			want:       true,
+		},
+		{
+			name:       "Reverted to an older revision",
+			deadline:   &metav1.Duration{Duration: 2 * time.Hour},
+			statusHash: "current",
+			startAt:    time.Now().Add(-3 * time.Hour),
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := newNodeSet("foo", "slurm", 2)
+			nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.OpportunisticNodeSetStrategyType
+			nodeset.Spec.UpdateStrategy.Opportunistic = &slinkyv1beta1.OpportunisticNodeSetStrategy{
+				Deadline: tt.deadline,
+			}
+			nodeset.Status.NodeSetHash = tt.statusHash
+			nodeset.Status.UpdateStartTime = ptr.To(metav1.NewTime(tt.startAt))
+			if got := isUpdateOverdue(nodeset, "update"); got != tt.want {
+				t.Errorf("isUpdateOverdue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_calculateUpdateStartTime(t *testing.T) {
+	startTime := metav1.NewTime(time.Now().Add(-3 * time.Hour))
+	nodeset := newNodeSet("foo", "slurm", 2)
+	nodeset.Status.NodeSetHash = "update"
+	nodeset.Status.UpdateStartTime = &startTime
+
+	if got := calculateUpdateStartTime(nodeset, "update"); !got.Equal(&startTime) {
+		t.Errorf("calculateUpdateStartTime() = %v, want %v", got, startTime)
+	}
+	// A revert to an older revision, e.g. a canary rollback, restarts the rollout.
+	if got := calculateUpdateStartTime(nodeset, "current"); time.Since(got.Time) > time.Minute {
+		t.Errorf("calculateUpdateStartTime() = %v, want now", got)
+	}
+}
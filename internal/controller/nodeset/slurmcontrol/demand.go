@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// NodeDemand is the Slurm node demand of the pending jobs of a NodeSet.
+type NodeDemand struct {
+	// Observed is whether the Slurm jobs were listed.
+	Observed bool
+	// PendingJobs is the number of pending jobs that were considered.
+	PendingJobs int32
+	// Nodes is the number of additional nodes needed to start the pending jobs.
+	Nodes int32
+}
+
+// nodeResources describes the schedulable resources of a Slurm node.
+type nodeResources struct {
+	Cpus   int64
+	Memory int64 // in MiB
+	Gpus   int64
+}
+
+// fits reports whether the request fits into the resources.
+func (c nodeResources) fits(req nodeResources) bool {
+	return req.Cpus <= c.Cpus && req.Memory <= c.Memory && req.Gpus <= c.Gpus
+}
+
+func (c nodeResources) sub(req nodeResources) nodeResources {
+	return nodeResources{
+		Cpus:   c.Cpus - req.Cpus,
+		Memory: c.Memory - req.Memory,
+		Gpus:   c.Gpus - req.Gpus,
+	}
+}
+
+// jobRequest is the per-node resource request of a pending job.
+type jobRequest struct {
+	// Number of nodes the job needs.
+	Nodes int
+	// Resources needed on each node.
+	PerNode nodeResources
+	// Exclusive jobs do not share their nodes.
+	Exclusive bool
+}
+
+// nodeSetCapacity returns the schedulable resources of a NodeSet pod, as
+// derived from the slurmd container resource limits (or requests). CPU and
+// memory are zero when unknown.
+func nodeSetCapacity(nodeset *slinkyv1beta1.NodeSet) nodeResources {
+	resources := nodeset.Spec.Slurmd.Resources
+	get := func(name corev1.ResourceName) *resource.Quantity {
+		if q, ok := resources.Limits[name]; ok {
+			return &q
+		}
+		if q, ok := resources.Requests[name]; ok {
+			return &q
+		}
+		return nil
+	}
+
+	capacity := nodeResources{}
+	if q := get(corev1.ResourceCPU); q != nil {
+		capacity.Cpus = q.Value()
+	}
+	if q := get(corev1.ResourceMemory); q != nil {
+		capacity.Memory = q.Value() / (1024 * 1024)
+	}
+	names := set.New[corev1.ResourceName]()
+	for name := range resources.Limits {
+		names.Insert(name)
+	}
+	for name := range resources.Requests {
+		names.Insert(name)
+	}
+	for _, name := range names.UnsortedList() {
+		if strings.HasSuffix(string(name), "/gpu") {
+			capacity.Gpus += get(name).Value()
+		}
+	}
+	return capacity
+}
+
+// pendingReasonsIgnored are job pending reasons that new nodes cannot resolve.
+var pendingReasonsIgnored = set.New(
+	"BeginTime",
+	"Dependency",
+	"DependencyNeverSatisfied",
+	"JobHeldAdmin",
+	"JobHeldUser",
+	"PartitionDown",
+	"PartitionInactive",
+	"Reservation",
+)
+
+// isJobPendingForPartition reports if the job is pending on resources and
+// eligible to run in the partition.
+func isJobPendingForPartition(job slurmtypes.V0044JobInfo, partition string) bool {
+	if !job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStatePENDING) {
+		return false
+	}
+	if ptr.Deref(job.Hold, false) {
+		return false
+	}
+	if pendingReasonsIgnored.Has(ptr.Deref(job.StateReason, "")) {
+		return false
+	}
+	partitions := strings.Split(ptr.Deref(job.Partition, ""), ",")
+	return slices.Contains(partitions, partition)
+}
+
+// newJobRequest converts the pending job into a jobRequest.
+func newJobRequest(job slurmtypes.V0044JobInfo) jobRequest {
+	req := jobRequest{
+		Nodes: int(max(uint32NoVal(job.NodeCount), 1)),
+	}
+
+	shared := ptr.Deref(job.Shared, []slurmapi.V0044JobInfoShared{})
+	req.Exclusive = slices.Contains(shared, slurmapi.V0044JobInfoSharedNone)
+
+	cpus := int64(uint16NoVal(job.MinimumCpusPerNode))
+	if total := int64(uint32NoVal(job.Cpus)); total > 0 {
+		cpus = max(cpus, ceilDiv(total, int64(req.Nodes)))
+	}
+	if cpt, tpn := int64(uint16NoVal(job.CpusPerTask)), int64(uint16NoVal(job.TasksPerNode)); cpt > 0 && tpn > 0 {
+		cpus = max(cpus, cpt*tpn)
+	}
+	req.PerNode.Cpus = max(cpus, 1)
+
+	if mem := uint64NoVal(job.MemoryPerNode); mem > 0 {
+		req.PerNode.Memory = mem
+	} else if mem := uint64NoVal(job.MemoryPerCpu); mem > 0 {
+		req.PerNode.Memory = mem * req.PerNode.Cpus
+	}
+
+	if gpus := parseGpuTres(ptr.Deref(job.TresPerNode, "")); gpus > 0 {
+		req.PerNode.Gpus = gpus
+	} else if gpus := parseGpuTres(ptr.Deref(job.TresPerJob, "")); gpus > 0 {
+		req.PerNode.Gpus = ceilDiv(gpus, int64(req.Nodes))
+	}
+
+	return req
+}
+
+// gpuTresRegex matches GPU TRES, e.g. `gres/gpu:2`, `gres/gpu:a100:2`, `gres:gpu=2`.
+var gpuTresRegex = regexp.MustCompile(`^gres[/:]gpu(?::[^:=]+)?[:=](\d+)$`)
+
+// parseGpuTres returns the number of GPUs requested from a comma-separated
+// list of TRES.
+func parseGpuTres(tres string) int64 {
+	var gpus int64
+	for item := range strings.SplitSeq(tres, ",") {
+		matches := gpuTresRegex.FindStringSubmatch(strings.TrimSpace(item))
+		if matches == nil {
+			continue
+		}
+		n, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		gpus += n
+	}
+	return gpus
+}
+
+// arrayTaskCount returns the number of tasks expressed by the array task
+// string (e.g. `0-9:2,12%4`). The throttle (`%N`) caps the count.
+func arrayTaskCount(expr string) int {
+	if expr == "" {
+		return 1
+	}
+	limit := 0
+	if idx := strings.Index(expr, "%"); idx >= 0 {
+		limit, _ = strconv.Atoi(expr[idx+1:])
+		expr = expr[:idx]
+	}
+	count := 0
+	for item := range strings.SplitSeq(expr, ",") {
+		step := 1
+		if idx := strings.Index(item, ":"); idx >= 0 {
+			if s, err := strconv.Atoi(item[idx+1:]); err == nil && s > 0 {
+				step = s
+			}
+			item = item[:idx]
+		}
+		lo, hi, found := strings.Cut(item, "-")
+		start, err := strconv.Atoi(lo)
+		if err != nil {
+			continue
+		}
+		end := start
+		if found {
+			if end, err = strconv.Atoi(hi); err != nil {
+				continue
+			}
+		}
+		if end >= start {
+			count += (end-start)/step + 1
+		}
+	}
+	if limit > 0 {
+		count = min(count, limit)
+	}
+	return max(count, 1)
+}
+
+// calculateNodeDemand returns the number of nodes, of the given capacity,
+// needed to run all requests. Requests are packed onto nodes first-fit
+// decreasing; exclusive and multi-node requests are honored. Requests that
+// cannot fit on any single node are ignored, as no amount of scaling helps.
+// If the CPU capacity is unknown, every request is treated as exclusive.
+func calculateNodeDemand(requests []jobRequest, capacity nodeResources) int {
+	// Unknown CPU and memory capacity cannot constrain the requests.
+	allExclusive := capacity.Cpus == 0
+	if capacity.Cpus == 0 {
+		capacity.Cpus = math.MaxInt64
+	}
+	if capacity.Memory == 0 {
+		capacity.Memory = math.MaxInt64
+	}
+
+	sorted := make([]jobRequest, 0, len(requests))
+	for _, req := range requests {
+		if !capacity.fits(req.PerNode) {
+			continue
+		}
+		if allExclusive {
+			req.Exclusive = true
+		}
+		sorted = append(sorted, req)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Nodes != sorted[j].Nodes {
+			return sorted[i].Nodes > sorted[j].Nodes
+		}
+		return sorted[i].PerNode.Cpus > sorted[j].PerNode.Cpus
+	})
+
+	exclusiveNodes := 0
+	free := []nodeResources{}
+	for _, req := range sorted {
+		if req.Exclusive {
+			exclusiveNodes += req.Nodes
+			continue
+		}
+		// Each node of a multi-node job must be a distinct node.
+		placed := 0
+		for i := range free {
+			if placed == req.Nodes {
+				break
+			}
+			if free[i].fits(req.PerNode) {
+				free[i] = free[i].sub(req.PerNode)
+				placed++
+			}
+		}
+		for ; placed < req.Nodes; placed++ {
+			free = append(free, capacity.sub(req.PerNode))
+		}
+	}
+
+	return exclusiveNodes + len(free)
+}
+
+func ceilDiv(a, b int64) int64 {
+	if b <= 0 {
+		return a
+	}
+	return (a + b - 1) / b
+}
+
+func uint16NoVal(v *slurmapi.V0044Uint16NoValStruct) int32 {
+	if v == nil || !ptr.Deref(v.Set, false) || ptr.Deref(v.Infinite, false) {
+		return 0
+	}
+	return ptr.Deref(v.Number, 0)
+}
+
+func uint32NoVal(v *slurmapi.V0044Uint32NoValStruct) int64 {
+	if v == nil || !ptr.Deref(v.Set, false) || ptr.Deref(v.Infinite, false) {
+		return 0
+	}
+	return int64(ptr.Deref(v.Number, 0))
+}
+
+func uint64NoVal(v *slurmapi.V0044Uint64NoValStruct) int64 {
+	if v == nil || !ptr.Deref(v.Set, false) || ptr.Deref(v.Infinite, false) {
+		return 0
+	}
+	return ptr.Deref(v.Number, 0)
+}
@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func Test_nodeSetCapacity(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources corev1.ResourceRequirements
+		want      nodeResources
+	}{
+		{
+			name: "Empty",
+			want: nodeResources{},
+		},
+		{
+			name: "Limits",
+			resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("8"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+					"nvidia.com/gpu":      resource.MustParse("4"),
+				},
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("2"),
+				},
+			},
+			want: nodeResources{Cpus: 8, Memory: 16 * 1024, Gpus: 4},
+		},
+		{
+			name: "Requests",
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+			want: nodeResources{Cpus: 4, Memory: 1024},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := &slinkyv1beta1.NodeSet{}
+			nodeset.Spec.Slurmd.Resources = tt.resources
+			if got := nodeSetCapacity(nodeset); got != tt.want {
+				t.Errorf("nodeSetCapacity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isJobPendingForPartition(t *testing.T) {
+	tests := []struct {
+		name      string
+		job       types.V0044JobInfo
+		partition string
+		want      bool
+	}{
+		{
+			name: "Pending",
+			job: types.V0044JobInfo{V0044JobInfo: api.V0044JobInfo{
+				JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+				Partition: ptr.To("foo"),
+			}},
+			partition: "foo",
+			want:      true,
+		},
+		{
+			name: "Pending, multiple partitions",
+			job: types.V0044JobInfo{V0044JobInfo: api.V0044JobInfo{
+				JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+				Partition: ptr.To("bar,foo"),
+			}},
+			partition: "foo",
+			want:      true,
+		},
+		{
+			name: "Other partition",
+			job: types.V0044JobInfo{V0044JobInfo: api.V0044JobInfo{
+				JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+				Partition: ptr.To("bar"),
+			}},
+			partition: "foo",
+			want:      false,
+		},
+		{
+			name: "Running",
+			job: types.V0044JobInfo{V0044JobInfo: api.V0044JobInfo{
+				JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+				Partition: ptr.To("foo"),
+			}},
+			partition: "foo",
+			want:      false,
+		},
+		{
+			name: "Pending on dependency",
+			job: types.V0044JobInfo{V0044JobInfo: api.V0044JobInfo{
+				JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+				Partition:   ptr.To("foo"),
+				StateReason: ptr.To("Dependency"),
+			}},
+			partition: "foo",
+			want:      false,
+		},
+		{
+			name: "Held",
+			job: types.V0044JobInfo{V0044JobInfo: api.V0044JobInfo{
+				JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+				Partition: ptr.To("foo"),
+				Hold:      ptr.To(true),
+			}},
+			partition: "foo",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isJobPendingForPartition(tt.job, tt.partition); got != tt.want {
+				t.Errorf("isJobPendingForPartition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newJobRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		job  types.V0044JobInfo
+		want jobRequest
+	}{
+		{
+			name: "Empty",
+			job:  types.V0044JobInfo{},
+			want: jobRequest{Nodes: 1, PerNode: nodeResources{Cpus: 1}},
+		},
+		{
+			name: "Multi-node, exclusive",
+			job: types.V0044JobInfo{V0044JobInfo: api.V0044JobInfo{
+				NodeCount: &api.V0044Uint32NoValStruct{Set: ptr.To(true), Number: ptr.To[int32](4)},
+				Cpus:      &api.V0044Uint32NoValStruct{Set: ptr.To(true), Number: ptr.To[int32](16)},
+				Shared:    ptr.To([]api.V0044JobInfoShared{api.V0044JobInfoSharedNone}),
+			}},
+			want: jobRequest{Nodes: 4, PerNode: nodeResources{Cpus: 4}, Exclusive: true},
+		},
+		{
+			name: "Memory per CPU",
+			job: types.V0044JobInfo{V0044JobInfo: api.V0044JobInfo{
+				CpusPerTask:  &api.V0044Uint16NoValStruct{Set: ptr.To(true), Number: ptr.To[int32](2)},
+				TasksPerNode: &api.V0044Uint16NoValStruct{Set: ptr.To(true), Number: ptr.To[int32](3)},
+				MemoryPerCpu: &api.V0044Uint64NoValStruct{Set: ptr.To(true), Number: ptr.To[int64](100)},
+			}},
+			want: jobRequest{Nodes: 1, PerNode: nodeResources{Cpus: 6, Memory: 600}},
+		},
+		{
+			name: "GPUs per job",
+			job: types.V0044JobInfo{V0044JobInfo: api.V0044JobInfo{
+				NodeCount:     &api.V0044Uint32NoValStruct{Set: ptr.To(true), Number: ptr.To[int32](2)},
+				MemoryPerNode: &api.V0044Uint64NoValStruct{Set: ptr.To(true), Number: ptr.To[int64](1024)},
+				TresPerJob:    ptr.To("gres/gpu:a100:6"),
+			}},
+			want: jobRequest{Nodes: 2, PerNode: nodeResources{Cpus: 1, Memory: 1024, Gpus: 3}},
+		},
+		{
+			name: "GPUs per node",
+			job: types.V0044JobInfo{V0044JobInfo: api.V0044JobInfo{
+				TresPerNode: ptr.To("gres/gpu:2"),
+			}},
+			want: jobRequest{Nodes: 1, PerNode: nodeResources{Cpus: 1, Gpus: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newJobRequest(tt.job); got != tt.want {
+				t.Errorf("newJobRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseGpuTres(t *testing.T) {
+	tests := []struct {
+		tres string
+		want int64
+	}{
+		{tres: "", want: 0},
+		{tres: "cpu=4", want: 0},
+		{tres: "gres/gpu:2", want: 2},
+		{tres: "gres/gpu:h100:8", want: 8},
+		{tres: "gres:gpu=1", want: 1},
+		{tres: "cpu=2,gres/gpu:1,gres/gpu:a100:1", want: 2},
+		{tres: "gres/shard:4", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tres, func(t *testing.T) {
+			if got := parseGpuTres(tt.tres); got != tt.want {
+				t.Errorf("parseGpuTres() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_arrayTaskCount(t *testing.T) {
+	tests := []struct {
+		expr string
+		want int
+	}{
+		{expr: "", want: 1},
+		{expr: "3", want: 1},
+		{expr: "0-9", want: 10},
+		{expr: "0-9:2", want: 5},
+		{expr: "1,3,5-7", want: 5},
+		{expr: "0-99%4", want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			if got := arrayTaskCount(tt.expr); got != tt.want {
+				t.Errorf("arrayTaskCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_calculateNodeDemand(t *testing.T) {
+	capacity := nodeResources{Cpus: 8, Memory: 8192, Gpus: 2}
+	tests := []struct {
+		name     string
+		requests []jobRequest
+		capacity nodeResources
+		want     int
+	}{
+		{
+			name:     "Empty",
+			capacity: capacity,
+			want:     0,
+		},
+		{
+			name: "Packed",
+			requests: []jobRequest{
+				{Nodes: 1, PerNode: nodeResources{Cpus: 4}},
+				{Nodes: 1, PerNode: nodeResources{Cpus: 4}},
+				{Nodes: 1, PerNode: nodeResources{Cpus: 2}},
+			},
+			capacity: capacity,
+			want:     2,
+		},
+		{
+			name: "Multi-node",
+			requests: []jobRequest{
+				{Nodes: 3, PerNode: nodeResources{Cpus: 1}},
+				{Nodes: 1, PerNode: nodeResources{Cpus: 1}},
+			},
+			capacity: capacity,
+			want:     3,
+		},
+		{
+			name: "Exclusive",
+			requests: []jobRequest{
+				{Nodes: 2, PerNode: nodeResources{Cpus: 1}, Exclusive: true},
+				{Nodes: 1, PerNode: nodeResources{Cpus: 1}},
+			},
+			capacity: capacity,
+			want:     3,
+		},
+		{
+			name: "GPUs",
+			requests: []jobRequest{
+				{Nodes: 1, PerNode: nodeResources{Cpus: 1, Gpus: 2}},
+				{Nodes: 1, PerNode: nodeResources{Cpus: 1, Gpus: 1}},
+			},
+			capacity: capacity,
+			want:     2,
+		},
+		{
+			name: "Does not fit",
+			requests: []jobRequest{
+				{Nodes: 1, PerNode: nodeResources{Cpus: 16}},
+				{Nodes: 1, PerNode: nodeResources{Cpus: 1, Gpus: 4}},
+			},
+			capacity: capacity,
+			want:     0,
+		},
+		{
+			name: "Unknown capacity",
+			requests: []jobRequest{
+				{Nodes: 1, PerNode: nodeResources{Cpus: 1}},
+				{Nodes: 2, PerNode: nodeResources{Cpus: 1}},
+			},
+			capacity: nodeResources{},
+			want:     3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calculateNodeDemand(tt.requests, tt.capacity); got != tt.want {
+				t.Errorf("calculateNodeDemand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,8 +5,11 @@ package slurmcontrol
 
 import (
 	"context"
+	"errors"
 	"math"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +23,7 @@ import (
 
 	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
 	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmapiclient "github.com/SlinkyProject/slurm-client/pkg/client/api/v0044"
 	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
 	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
 
@@ -36,10 +40,16 @@ type SlurmControlInterface interface {
 	RefreshNodeCache(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error
 	// UpdateNodeWithPodInfo handles updating the Node with its pod info
 	UpdateNodeWithPodInfo(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
+	// UpdateNodeFeatures handles setting the features of the slurm node.
+	UpdateNodeFeatures(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, features []string) error
 	// MakeNodeDrain handles adding the DRAIN state to the slurm node.
 	MakeNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error
 	// MakeNodeUndrain handles removing the DRAIN state from the slurm node.
 	MakeNodeUndrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error
+	// MakeNodeDown handles setting the slurm node DOWN.
+	MakeNodeDown(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error
+	// MakeNodeResume handles resuming the slurm node, if the operator set it DOWN.
+	MakeNodeResume(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error
 	// IsNodeDrain checks if the slurm node has the DRAIN state.
 	IsNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
 	// IsNodeDrained checks if the slurm node is drained.
@@ -48,10 +58,24 @@ type SlurmControlInterface interface {
 	IsNodeDownForUnresponsive(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
 	// IsNodeReasonOurs reports if the node reason was set by the operator.
 	IsNodeReasonOurs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
+	// GetNodeState returns the state of the slurm node, from a single request.
+	GetNodeState(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (NodeState, error)
 	// CalculateNodeStatus returns the current state of the registered slurm nodes.
 	CalculateNodeStatus(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (SlurmNodeStatus, error)
 	// GetNodeDeadlines returns a map of node to its deadline time.Time calculated from running jobs.
 	GetNodeDeadlines(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (*timestore.TimeStore, error)
+	// GetNodeWorkloads returns a map of node to the workload running on it, or nil if Slurm cannot be observed.
+	GetNodeWorkloads(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (map[string]NodeWorkload, error)
+	// GetPendingNodeDemand returns the number of additional nodes needed by pending jobs of the NodeSet partition.
+	GetPendingNodeDemand(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (NodeDemand, error)
+	// GetNodeJobs returns the IDs of the jobs running on the slurm node.
+	GetNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) ([]int32, error)
+	// SignalNodeJobs sends the signal to the jobs running on the slurm node.
+	SignalNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, signal string) error
+	// CancelNodeJobs cancels the jobs running on the slurm node.
+	CancelNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
+	// RequeueNodeJobs sets the slurm node DOWN, requeueing the jobs running on it.
+	RequeueNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error
 }
 
 // realSlurmControl is the default implementation of SlurmControlInterface.
@@ -171,6 +195,48 @@ func (r *realSlurmControl) UpdateNodeWithPodInfo(ctx context.Context, nodeset *s
 	return nil
 }
 
+// UpdateNodeFeatures implements SlurmControlInterface.
+func (r *realSlurmControl) UpdateNodeFeatures(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, features []string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do UpdateNodeFeatures()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	want := set.New(features...)
+	if want.Equal(set.New(ptr.Deref(slurmNode.Features, nil)...)) {
+		logger.V(3).Info("Node already has features, skipping update request",
+			"node", slurmNode.GetKey(), "features", features)
+		return nil
+	}
+
+	logger.Info("Update Slurm Node features", "Node", slurmNode.Name, "features", features)
+	req := slurmapi.V0044UpdateNodeMsg{
+		Features:    ptr.To(want.SortedList()),
+		FeaturesAct: ptr.To(want.SortedList()),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
 const nodeReasonPrefix = "slurm-operator:"
 
 // MakeNodeDrain implements SlurmControlInterface.
@@ -272,6 +338,103 @@ func (r *realSlurmControl) MakeNodeUndrain(ctx context.Context, nodeset *slinkyv
 	return nil
 }
 
+// MakeNodeDown implements SlurmControlInterface.
+func (r *realSlurmControl) MakeNodeDown(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do MakeNodeDown()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	// If Slurm node is already down and the reasons match, no need to down it again
+	prefixedReason := nodeReasonPrefix + " " + reason
+	nodeReason := ptr.Deref(slurmNode.Reason, "")
+	if slurmNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN) && nodeReason == prefixedReason {
+		logger.V(1).Info("Node is already down, skipping down request",
+			"node", slurmNode.GetKey(), "nodeState", slurmNode.State, "nodeReason", nodeReason)
+		return nil
+	}
+
+	logger.V(1).Info("make slurm node down",
+		"pod", klog.KObj(pod))
+	req := slurmapi.V0044UpdateNodeMsg{
+		State:  ptr.To([]slurmapi.V0044UpdateNodeMsgState{slurmapi.V0044UpdateNodeMsgStateDOWN}),
+		Reason: ptr.To(prefixedReason),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MakeNodeResume implements SlurmControlInterface.
+func (r *realSlurmControl) MakeNodeResume(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do MakeNodeResume()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	// Only resume Slurm nodes which the operator set DOWN, others may be down
+	// for reasons unknown to the operator.
+	nodeReason := ptr.Deref(slurmNode.Reason, "")
+	if !slurmNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN) ||
+		!strings.HasPrefix(nodeReason, nodeReasonPrefix) {
+		logger.V(1).Info("Node is not down by the operator, skipping resume request",
+			"node", slurmNode.GetKey(), "nodeState", slurmNode.State, "nodeReason", nodeReason)
+		return nil
+	}
+
+	// If the reason is not empty, prefix it with nodeReasonPrefix
+	prefixedReason := ""
+	if reason != "" {
+		prefixedReason = nodeReasonPrefix + " " + reason
+	}
+
+	logger.V(1).Info("make slurm node resume",
+		"pod", klog.KObj(pod))
+	req := slurmapi.V0044UpdateNodeMsg{
+		State:  ptr.To([]slurmapi.V0044UpdateNodeMsgState{slurmapi.V0044UpdateNodeMsgStateRESUME}),
+		Reason: ptr.To(prefixedReason),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
 // IsNodeDrain implements SlurmControlInterface.
 func (r *realSlurmControl) IsNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
 	logger := log.FromContext(ctx)
@@ -345,13 +508,16 @@ func (r *realSlurmControl) IsNodeDownForUnresponsive(ctx context.Context, nodese
 		return false, err
 	}
 
+	return isNodeDownForUnresponsive(slurmNode), nil
+}
+
+// isNodeDownForUnresponsive reports if Slurm set the node DOWN for not responding.
+func isNodeDownForUnresponsive(slurmNode *slurmtypes.V0044Node) bool {
 	// Slurm sets unresponsive nodes as `State=DOWN`, `Reason+="Not responding"`.
 	// https://github.com/SchedMD/slurm/blob/slurm-25.05/src/slurmctld/ping_nodes.c#L243
 	isDown := slurmNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN)
 	reasonNotResponding := strings.Contains(ptr.Deref(slurmNode.Reason, ""), "Not responding")
-	wasUnresponsive := isDown && reasonNotResponding
-
-	return wasUnresponsive, nil
+	return isDown && reasonNotResponding
 }
 
 // IsNodeReasonOurs implements SlurmControlInterface.
@@ -374,17 +540,67 @@ func (r *realSlurmControl) IsNodeReasonOurs(ctx context.Context, nodeset *slinky
 		return false, err
 	}
 
+	return isNodeReasonOurs(slurmNode), nil
+}
+
+// isNodeReasonOurs reports if the node reason is unset or set by the operator.
+func isNodeReasonOurs(slurmNode *slurmtypes.V0044Node) bool {
 	// The operator will always prefix the node reason.
 	// External sources may not have a prefix or a different one.
 	nodeReason := ptr.Deref(slurmNode.Reason, "")
-	if nodeReason != "" && !strings.HasPrefix(nodeReason, nodeReasonPrefix) {
-		return false, nil
+	return nodeReason == "" || strings.HasPrefix(nodeReason, nodeReasonPrefix)
+}
+
+// NodeState is the state of a slurm node, as needed to sync its cordon.
+type NodeState struct {
+	// Observed is whether the slurm node was fetched.
+	Observed bool
+
+	// Unresponsive is true when Slurm set the node DOWN for not responding.
+	Unresponsive bool
+	// ReasonOurs is true when the node reason is unset or set by the operator.
+	ReasonOurs bool
+	// Drain is true when the node has the DRAIN state, and is not undraining.
+	Drain bool
+	// DownByOperator is true when the operator set the node DOWN.
+	DownByOperator bool
+}
+
+// GetNodeState implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodeState(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (NodeState, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetNodeState()",
+			"pod", klog.KObj(pod))
+		return NodeState{}, nil
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if tolerateError(err) {
+			return NodeState{}, nil
+		}
+		return NodeState{}, err
 	}
 
-	return true, nil
+	stateSet := slurmNode.GetStateAsSet()
+	return NodeState{
+		Observed:     true,
+		Unresponsive: isNodeDownForUnresponsive(slurmNode),
+		ReasonOurs:   isNodeReasonOurs(slurmNode),
+		Drain:        stateSet.Has(slurmapi.V0044NodeStateDRAIN) && !stateSet.Has(slurmapi.V0044NodeStateUNDRAIN),
+		DownByOperator: stateSet.Has(slurmapi.V0044NodeStateDOWN) &&
+			strings.HasPrefix(ptr.Deref(slurmNode.Reason, ""), nodeReasonPrefix),
+	}, nil
 }
 
 type SlurmNodeStatus struct {
+	// Observed is whether the Slurm nodes were listed.
+	Observed bool
+
 	Total int32
 
 	// Base State
@@ -430,6 +646,7 @@ func (r *realSlurmControl) CalculateNodeStatus(ctx context.Context, nodeset *sli
 		}
 		return status, err
 	}
+	status.Observed = true
 
 	podNodeNameSet := set.New[string]()
 	for _, pod := range pods {
@@ -577,6 +794,275 @@ func (r *realSlurmControl) GetNodeDeadlines(ctx context.Context, nodeset *slinky
 	return ts, nil
 }
 
+// GetNodeWorkloads implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodeWorkloads(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (map[string]NodeWorkload, error) {
+	logger := log.FromContext(ctx)
+	workloads := make(map[string]NodeWorkload)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetNodeWorkloads()")
+		return nil, nil
+	}
+
+	slurmNodeNamesSet := set.New[string]()
+	for _, pod := range pods {
+		slurmNodeName := nodesetutils.GetNodeName(pod)
+		slurmNodeNamesSet.Insert(slurmNodeName)
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		return nil, err
+	}
+	for _, node := range nodeList.Items {
+		slurmNodeName := ptr.Deref(node.Name, "")
+		if !slurmNodeNamesSet.Has(slurmNodeName) {
+			continue
+		}
+		workloads[slurmNodeName] = NodeWorkload{
+			Cpus: int64(ptr.Deref(node.AllocCpus, 0)),
+			Gpus: parseGpuGresUsed(ptr.Deref(node.GresUsed, "")),
+		}
+	}
+
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList); err != nil {
+		return nil, err
+	}
+	for _, job := range jobList.Items {
+		if !job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStateRUNNING) {
+			continue
+		}
+		slurmNodeNames, err := hostlist.Expand(ptr.Deref(job.Nodes, ""))
+		if err != nil {
+			logger.Error(err, "failed to expand job node hostlist",
+				"job", ptr.Deref(job.JobId, 0))
+			return nil, err
+		}
+		workloadJob := WorkloadJob{
+			Qos:      ptr.Deref(job.Qos, ""),
+			Priority: uint32NoVal(job.Priority),
+		}
+		for _, slurmNodeName := range slurmNodeNames {
+			if !slurmNodeNamesSet.Has(slurmNodeName) {
+				continue
+			}
+			workload := workloads[slurmNodeName]
+			workload.Jobs = append(workload.Jobs, workloadJob)
+			workloads[slurmNodeName] = workload
+		}
+	}
+
+	return workloads, nil
+}
+
+// GetPendingNodeDemand implements SlurmControlInterface.
+func (r *realSlurmControl) GetPendingNodeDemand(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (NodeDemand, error) {
+	logger := log.FromContext(ctx)
+	demand := NodeDemand{}
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetPendingNodeDemand()")
+		return demand, nil
+	}
+
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList); err != nil {
+		if tolerateError(err) {
+			return demand, nil
+		}
+		return demand, err
+	}
+	demand.Observed = true
+
+	requests := []jobRequest{}
+	for _, job := range jobList.Items {
+		if !isJobPendingForPartition(job, nodeset.Name) {
+			continue
+		}
+		req := newJobRequest(job)
+		for range arrayTaskCount(ptr.Deref(job.ArrayTaskString, "")) {
+			demand.PendingJobs++
+			requests = append(requests, req)
+		}
+	}
+
+	demand.Nodes = int32(calculateNodeDemand(requests, nodeSetCapacity(nodeset)))
+	return demand, nil
+}
+
+// GetNodeJobs implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) ([]int32, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetNodeJobs()",
+			"pod", klog.KObj(pod))
+		return nil, nil
+	}
+
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList, &slurmclient.ListOptions{RefreshCache: true}); err != nil {
+		if tolerateError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	slurmNodeName := nodesetutils.GetNodeName(pod)
+	jobIds := []int32{}
+	for _, job := range jobList.Items {
+		if !job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStateRUNNING) {
+			continue
+		}
+		slurmNodeNames, err := hostlist.Expand(ptr.Deref(job.Nodes, ""))
+		if err != nil {
+			logger.Error(err, "failed to expand job node hostlist",
+				"job", ptr.Deref(job.JobId, 0))
+			return nil, err
+		}
+		if !slices.Contains(slurmNodeNames, slurmNodeName) {
+			continue
+		}
+		jobIds = append(jobIds, ptr.Deref(job.JobId, 0))
+	}
+	slices.Sort(jobIds)
+
+	return jobIds, nil
+}
+
+// signalJob sends the signal to the job through slurmrestd.
+var signalJob = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, jobId int32, signal string) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	params := &slurmapi.SlurmV0044DeleteJobParams{
+		Signal: ptr.To(signal),
+	}
+	res, err := client.SlurmV0044DeleteJobWithResponse(ctx, strconv.Itoa(int(jobId)), params)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
+// SignalNodeJobs implements SlurmControlInterface.
+func (r *realSlurmControl) SignalNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, signal string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do SignalNodeJobs()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+	httpClient := r.clientMap.GetHTTPClient(nodeset.Spec.ControllerRef.NamespacedName())
+
+	jobIds, err := r.GetNodeJobs(ctx, nodeset, pod)
+	if err != nil {
+		return err
+	}
+
+	for _, jobId := range jobIds {
+		logger.V(1).Info("signal slurm job",
+			"pod", klog.KObj(pod), "job", jobId, "signal", signal)
+		if err := signalJob(ctx, slurmClient, httpClient, jobId, signal); err != nil {
+			if tolerateError(err) {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CancelNodeJobs implements SlurmControlInterface.
+func (r *realSlurmControl) CancelNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do CancelNodeJobs()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	jobIds, err := r.GetNodeJobs(ctx, nodeset, pod)
+	if err != nil {
+		return err
+	}
+
+	for _, jobId := range jobIds {
+		logger.V(1).Info("cancel slurm job",
+			"pod", klog.KObj(pod), "job", jobId)
+		job := &slurmtypes.V0044JobInfo{
+			V0044JobInfo: slurmapi.V0044JobInfo{
+				JobId: ptr.To(jobId),
+			},
+		}
+		if err := slurmClient.Delete(ctx, job); err != nil {
+			if tolerateError(err) {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RequeueNodeJobs implements SlurmControlInterface.
+func (r *realSlurmControl) RequeueNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do RequeueNodeJobs()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	if slurmNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN) {
+		logger.V(1).Info("Node is already down, skipping requeue request",
+			"node", slurmNode.GetKey(), "nodeState", slurmNode.State)
+		return nil
+	}
+
+	// Slurm requeues the jobs of a DOWN node, if they are eligible for requeue,
+	// otherwise they are terminated.
+	logger.V(1).Info("make slurm node down to requeue jobs",
+		"pod", klog.KObj(pod))
+	req := slurmapi.V0044UpdateNodeMsg{
+		State:  ptr.To([]slurmapi.V0044UpdateNodeMsgState{slurmapi.V0044UpdateNodeMsgStateDOWN}),
+		Reason: ptr.To(nodeReasonPrefix + " " + reason),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (r *realSlurmControl) lookupClient(nodeset *slinkyv1beta1.NodeSet) slurmclient.Client {
 	return r.clientMap.Get(nodeset.Spec.ControllerRef.NamespacedName())
 }
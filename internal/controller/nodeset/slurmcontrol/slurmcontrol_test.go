@@ -16,6 +16,7 @@ import (
 	"github.com/puttsk/hostlist"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
@@ -24,6 +25,7 @@ import (
 	api "github.com/SlinkyProject/slurm-client/api/v0044"
 	"github.com/SlinkyProject/slurm-client/pkg/client"
 	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
 	"github.com/SlinkyProject/slurm-client/pkg/object"
 	"github.com/SlinkyProject/slurm-client/pkg/types"
 
@@ -1235,6 +1237,91 @@ func Test_realSlurmControl_IsNodeReasonOurs(t *testing.T) {
 	}
 }
 
+func Test_realSlurmControl_GetNodeState(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+	type fields struct {
+		clientMap *clientmap.ClientMap
+	}
+	newFields := func(state []api.V0044NodeState, reason string) fields {
+		node := &types.V0044Node{
+			V0044Node: api.V0044Node{
+				Name:   ptr.To(nodesetutils.GetNodeName(pod)),
+				State:  ptr.To(state),
+				Reason: ptr.To(reason),
+			},
+		}
+		sclient := fake.NewClientBuilder().WithObjects(node).Build()
+		return fields{
+			clientMap: newSlurmClientMap(controller.Name, sclient),
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    NodeState
+		wantErr bool
+	}{
+		{
+			name: "No client",
+			fields: fields{
+				clientMap: clientmap.NewClientMap(),
+			},
+			want: NodeState{},
+		},
+		{
+			name:   "Idle",
+			fields: newFields([]api.V0044NodeState{api.V0044NodeStateIDLE}, ""),
+			want:   NodeState{Observed: true, ReasonOurs: true},
+		},
+		{
+			name:   "Drain by operator",
+			fields: newFields([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStateDRAIN}, nodeReasonPrefix+" foo"),
+			want:   NodeState{Observed: true, ReasonOurs: true, Drain: true},
+		},
+		{
+			name:   "Undraining",
+			fields: newFields([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStateDRAIN, api.V0044NodeStateUNDRAIN}, ""),
+			want:   NodeState{Observed: true, ReasonOurs: true},
+		},
+		{
+			name:   "Down by operator",
+			fields: newFields([]api.V0044NodeState{api.V0044NodeStateDOWN}, nodeReasonPrefix+" foo"),
+			want:   NodeState{Observed: true, ReasonOurs: true, DownByOperator: true},
+		},
+		{
+			name:   "Down externally",
+			fields: newFields([]api.V0044NodeState{api.V0044NodeStateDOWN}, "foo"),
+			want:   NodeState{Observed: true},
+		},
+		{
+			name:   "Down, not responding",
+			fields: newFields([]api.V0044NodeState{api.V0044NodeStateDOWN}, "Not responding"),
+			want:   NodeState{Observed: true, Unresponsive: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &realSlurmControl{
+				clientMap: tt.fields.clientMap,
+			}
+			got, err := r.GetNodeState(ctx, nodeset, pod)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.GetNodeState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("realSlurmControl.GetNodeState() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 	ctx := context.Background()
 	controller := &slinkyv1beta1.Controller{
@@ -1275,7 +1362,7 @@ func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 				nodeset: nodeset,
 				pods:    []*corev1.Pod{},
 			},
-			want:    SlurmNodeStatus{},
+			want:    SlurmNodeStatus{Observed: true},
 			wantErr: false,
 		},
 		{
@@ -1314,6 +1401,8 @@ func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 				},
 			},
 			want: SlurmNodeStatus{
+				Observed: true,
+
 				Total: 1,
 
 				Idle: 1,
@@ -1360,6 +1449,8 @@ func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 				},
 			},
 			want: SlurmNodeStatus{
+				Observed: true,
+
 				Total: 1,
 
 				Idle: 1,
@@ -1408,6 +1499,8 @@ func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 				},
 			},
 			want: SlurmNodeStatus{
+				Observed: true,
+
 				Total: 1,
 
 				Idle:  1,
@@ -1515,6 +1608,8 @@ func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 				},
 			},
 			want: SlurmNodeStatus{
+				Observed: true,
+
 				Total: 7,
 
 				Allocated: 1,
@@ -1674,6 +1769,8 @@ func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 				},
 			},
 			want: SlurmNodeStatus{
+				Observed: true,
+
 				Total: 8,
 
 				Completing:    1,
@@ -1849,6 +1946,8 @@ func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 				},
 			},
 			want: SlurmNodeStatus{
+				Observed: true,
+
 				Total: 8,
 
 				Allocated: 1,
@@ -1988,6 +2087,852 @@ func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 	}
 }
 
+func Test_realSlurmControl_GetPendingNodeDemand(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	nodeset.Spec.Slurmd.Resources = corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("4"),
+		},
+	}
+	type fields struct {
+		clientMap *clientmap.ClientMap
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    NodeDemand
+		wantErr bool
+	}{
+		{
+			name: "No client",
+			fields: fields{
+				clientMap: clientmap.NewClientMap(),
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+			},
+			want:    NodeDemand{},
+			wantErr: false,
+		},
+		{
+			name: "Pending jobs",
+			fields: func() fields {
+				jobList := &types.V0044JobInfoList{
+					Items: []types.V0044JobInfo{
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](1),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition: ptr.To(nodeset.Name),
+								NodeCount: &api.V0044Uint32NoValStruct{Set: ptr.To(true), Number: ptr.To[int32](2)},
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:           ptr.To[int32](2),
+								JobState:        ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition:       ptr.To(nodeset.Name),
+								ArrayTaskString: ptr.To("0-3"),
+								Cpus:            &api.V0044Uint32NoValStruct{Set: ptr.To(true), Number: ptr.To[int32](2)},
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](3),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition: ptr.To("bar"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](4),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+								Partition: ptr.To(nodeset.Name),
+							},
+						},
+					},
+				}
+				sclient := fake.NewClientBuilder().WithLists(jobList).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+			},
+			want: NodeDemand{
+				Observed:    true,
+				PendingJobs: 5,
+				Nodes:       3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "List error",
+			fields: func() fields {
+				sclient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, list object.ObjectList, opts ...client.ListOption) error {
+						return errors.New(http.StatusText(http.StatusInternalServerError))
+					},
+				}).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+			},
+			want:    NodeDemand{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &realSlurmControl{
+				clientMap: tt.fields.clientMap,
+			}
+			got, err := r.GetPendingNodeDemand(tt.args.ctx, tt.args.nodeset)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.GetPendingNodeDemand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("realSlurmControl.GetPendingNodeDemand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newRunningJobList(jobNodes map[int32]string) *types.V0044JobInfoList {
+	jobList := &types.V0044JobInfoList{}
+	for jobId, nodes := range jobNodes {
+		jobList.Items = append(jobList.Items, types.V0044JobInfo{
+			V0044JobInfo: api.V0044JobInfo{
+				JobId:    ptr.To(jobId),
+				JobState: ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+				Nodes:    ptr.To(nodes),
+			},
+		})
+	}
+	return jobList
+}
+
+func Test_realSlurmControl_GetNodeJobs(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 2)
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+	pod2 := nodesetutils.NewNodeSetPod(nodeset, controller, 1, "")
+	type fields struct {
+		clientMap *clientmap.ClientMap
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []int32
+		wantErr bool
+	}{
+		{
+			name: "No client",
+			fields: fields{
+				clientMap: clientmap.NewClientMap(),
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "Running jobs",
+			fields: func() fields {
+				jobList := newRunningJobList(map[int32]string{
+					3: nodesetutils.GetNodeName(pod),
+					1: nodesetutils.GetNodeName(pod) + "," + nodesetutils.GetNodeName(pod2),
+					2: nodesetutils.GetNodeName(pod2),
+				})
+				jobList.Items = append(jobList.Items, types.V0044JobInfo{
+					V0044JobInfo: api.V0044JobInfo{
+						JobId:    ptr.To[int32](4),
+						JobState: ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateCOMPLETED}),
+						Nodes:    ptr.To(nodesetutils.GetNodeName(pod)),
+					},
+				})
+				sclient := fake.NewClientBuilder().WithLists(jobList).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			want:    []int32{1, 3},
+			wantErr: false,
+		},
+		{
+			name: "List error",
+			fields: func() fields {
+				sclient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, list object.ObjectList, opts ...client.ListOption) error {
+						return errors.New(http.StatusText(http.StatusInternalServerError))
+					},
+				}).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &realSlurmControl{
+				clientMap: tt.fields.clientMap,
+			}
+			got, err := r.GetNodeJobs(tt.args.ctx, tt.args.nodeset, tt.args.pod)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.GetNodeJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("realSlurmControl.GetNodeJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_GetNodeWorkloads(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 2)
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+	pod2 := nodesetutils.NewNodeSetPod(nodeset, controller, 1, "")
+	type fields struct {
+		clientMap *clientmap.ClientMap
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pods    []*corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    map[string]NodeWorkload
+		wantErr bool
+	}{
+		{
+			name: "No client",
+			fields: fields{
+				clientMap: clientmap.NewClientMap(),
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods:    []*corev1.Pod{pod, pod2},
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "Running jobs",
+			fields: func() fields {
+				nodeList := &types.V0044NodeList{
+					Items: []types.V0044Node{
+						{V0044Node: api.V0044Node{
+							Name:      ptr.To(nodesetutils.GetNodeName(pod)),
+							AllocCpus: ptr.To[int32](8),
+							GresUsed:  ptr.To("gpu:a100:2(IDX:0-1)"),
+						}},
+						{V0044Node: api.V0044Node{
+							Name: ptr.To(nodesetutils.GetNodeName(pod2)),
+						}},
+						{V0044Node: api.V0044Node{
+							Name:      ptr.To("other-0"),
+							AllocCpus: ptr.To[int32](4),
+						}},
+					},
+				}
+				jobList := &types.V0044JobInfoList{
+					Items: []types.V0044JobInfo{
+						{V0044JobInfo: api.V0044JobInfo{
+							JobId:    ptr.To[int32](1),
+							JobState: ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+							Nodes:    ptr.To(nodesetutils.GetNodeName(pod) + ",other-0"),
+							Qos:      ptr.To("scavenger"),
+							Priority: &api.V0044Uint32NoValStruct{Set: ptr.To(true), Number: ptr.To[int32](10)},
+						}},
+						{V0044JobInfo: api.V0044JobInfo{
+							JobId:    ptr.To[int32](2),
+							JobState: ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateCOMPLETED}),
+							Nodes:    ptr.To(nodesetutils.GetNodeName(pod2)),
+						}},
+					},
+				}
+				sclient := fake.NewClientBuilder().WithLists(nodeList, jobList).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods:    []*corev1.Pod{pod, pod2},
+			},
+			want: map[string]NodeWorkload{
+				nodesetutils.GetNodeName(pod): {
+					Cpus: 8,
+					Gpus: 2,
+					Jobs: []WorkloadJob{{Qos: "scavenger", Priority: 10}},
+				},
+				nodesetutils.GetNodeName(pod2): {},
+			},
+			wantErr: false,
+		},
+		{
+			name: "List error",
+			fields: func() fields {
+				sclient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, list object.ObjectList, opts ...client.ListOption) error {
+						return errors.New(http.StatusText(http.StatusInternalServerError))
+					},
+				}).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods:    []*corev1.Pod{pod, pod2},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &realSlurmControl{
+				clientMap: tt.fields.clientMap,
+			}
+			got, err := r.GetNodeWorkloads(tt.args.ctx, tt.args.nodeset, tt.args.pods)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.GetNodeWorkloads() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("realSlurmControl.GetNodeWorkloads() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_SignalNodeJobs(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+	type fields struct {
+		clientMap *clientmap.ClientMap
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+		signal  string
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		signalErr   error
+		wantSignals map[int32]string
+		wantErr     bool
+	}{
+		{
+			name: "Signal running jobs",
+			fields: func() fields {
+				jobList := newRunningJobList(map[int32]string{
+					1: nodesetutils.GetNodeName(pod),
+					2: nodesetutils.GetNodeName(pod),
+					3: "other-0",
+				})
+				sclient := fake.NewClientBuilder().WithLists(jobList).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				signal:  "SIGUSR1",
+			},
+			wantSignals: map[int32]string{1: "SIGUSR1", 2: "SIGUSR1"},
+			wantErr:     false,
+		},
+		{
+			name: "Signal error",
+			fields: func() fields {
+				jobList := newRunningJobList(map[int32]string{
+					1: nodesetutils.GetNodeName(pod),
+				})
+				sclient := fake.NewClientBuilder().WithLists(jobList).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				signal:  "SIGTERM",
+			},
+			signalErr:   errors.New(http.StatusText(http.StatusInternalServerError)),
+			wantSignals: map[int32]string{1: "SIGTERM"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signals := map[int32]string{}
+			signalJobFn := signalJob
+			defer func() { signalJob = signalJobFn }()
+			signalJob = func(_ context.Context, _ client.Client, _ *http.Client, jobId int32, signal string) error {
+				signals[jobId] = signal
+				return tt.signalErr
+			}
+			r := &realSlurmControl{
+				clientMap: tt.fields.clientMap,
+			}
+			if err := r.SignalNodeJobs(tt.args.ctx, tt.args.nodeset, tt.args.pod, tt.args.signal); (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.SignalNodeJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(signals, tt.wantSignals) {
+				t.Errorf("realSlurmControl.SignalNodeJobs() signals = %v, want %v", signals, tt.wantSignals)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_CancelNodeJobs(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+	type fields struct {
+		sclient client.Client
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantJobs []int32
+		wantErr  bool
+	}{
+		{
+			name: "Cancel running jobs",
+			fields: fields{
+				sclient: fake.NewClientBuilder().WithLists(newRunningJobList(map[int32]string{
+					1: nodesetutils.GetNodeName(pod),
+					2: "other-0",
+				})).Build(),
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantJobs: []int32{2},
+			wantErr:  false,
+		},
+		{
+			name: "Delete error",
+			fields: fields{
+				sclient: fake.NewClientBuilder().
+					WithLists(newRunningJobList(map[int32]string{
+						1: nodesetutils.GetNodeName(pod),
+					})).
+					WithInterceptorFuncs(interceptor.Funcs{
+						Delete: func(ctx context.Context, obj object.Object, opts ...client.DeleteOption) error {
+							return errors.New(http.StatusText(http.StatusInternalServerError))
+						},
+					}).Build(),
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantJobs: []int32{1},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &realSlurmControl{
+				clientMap: newSlurmClientMap(controller.Name, tt.fields.sclient),
+			}
+			if err := r.CancelNodeJobs(tt.args.ctx, tt.args.nodeset, tt.args.pod); (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.CancelNodeJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			jobList := &types.V0044JobInfoList{}
+			if err := tt.fields.sclient.List(tt.args.ctx, jobList); err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			got := []int32{}
+			for _, job := range jobList.Items {
+				got = append(got, ptr.Deref(job.JobId, 0))
+			}
+			if !reflect.DeepEqual(got, tt.wantJobs) {
+				t.Errorf("realSlurmControl.CancelNodeJobs() remaining jobs = %v, want %v", got, tt.wantJobs)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_RequeueNodeJobs(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+		reason  string
+	}
+	tests := []struct {
+		name       string
+		state      []api.V0044NodeState
+		args       args
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name:  "Make DOWN",
+			state: []api.V0044NodeState{api.V0044NodeStateMIXED, api.V0044NodeStateDRAIN},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "drain timed out",
+			},
+			wantReason: nodeReasonPrefix + " drain timed out",
+			wantErr:    false,
+		},
+		{
+			name:  "Already DOWN",
+			state: []api.V0044NodeState{api.V0044NodeStateDOWN},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "drain timed out",
+			},
+			wantReason: "",
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &types.V0044Node{
+				V0044Node: api.V0044Node{
+					Name:  ptr.To(nodesetutils.GetNodeName(pod)),
+					State: ptr.To(tt.state),
+				},
+			}
+			var gotReq *api.V0044UpdateNodeMsg
+			sclient := fake.NewClientBuilder().WithObjects(node).WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+					r := req.(api.V0044UpdateNodeMsg)
+					gotReq = &r
+					return nil
+				},
+			}).Build()
+			r := &realSlurmControl{
+				clientMap: newSlurmClientMap(controller.Name, sclient),
+			}
+			if err := r.RequeueNodeJobs(tt.args.ctx, tt.args.nodeset, tt.args.pod, tt.args.reason); (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.RequeueNodeJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantReason == "" {
+				if gotReq != nil {
+					t.Errorf("realSlurmControl.RequeueNodeJobs() request = %v, want none", gotReq)
+				}
+				return
+			}
+			if gotReq == nil {
+				t.Fatalf("realSlurmControl.RequeueNodeJobs() did not update the node")
+			}
+			if got := ptr.Deref(gotReq.State, nil); !reflect.DeepEqual(got, []api.V0044UpdateNodeMsgState{api.V0044UpdateNodeMsgStateDOWN}) {
+				t.Errorf("realSlurmControl.RequeueNodeJobs() state = %v", got)
+			}
+			if got := ptr.Deref(gotReq.Reason, ""); got != tt.wantReason {
+				t.Errorf("realSlurmControl.RequeueNodeJobs() reason = %v, want %v", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_MakeNodeDown(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+		reason  string
+	}
+	tests := []struct {
+		name       string
+		state      []api.V0044NodeState
+		reason     string
+		args       args
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name:  "Make DOWN",
+			state: []api.V0044NodeState{api.V0044NodeStateMIXED, api.V0044NodeStateDRAIN},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "node is unhealthy",
+			},
+			wantReason: nodeReasonPrefix + " node is unhealthy",
+			wantErr:    false,
+		},
+		{
+			name:  "DOWN for another reason",
+			state: []api.V0044NodeState{api.V0044NodeStateDOWN},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "node is unhealthy",
+			},
+			wantReason: nodeReasonPrefix + " node is unhealthy",
+			wantErr:    false,
+		},
+		{
+			name:   "Already DOWN",
+			state:  []api.V0044NodeState{api.V0044NodeStateDOWN},
+			reason: nodeReasonPrefix + " node is unhealthy",
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "node is unhealthy",
+			},
+			wantReason: "",
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &types.V0044Node{
+				V0044Node: api.V0044Node{
+					Name:   ptr.To(nodesetutils.GetNodeName(pod)),
+					State:  ptr.To(tt.state),
+					Reason: ptr.To(tt.reason),
+				},
+			}
+			var gotReq *api.V0044UpdateNodeMsg
+			sclient := fake.NewClientBuilder().WithObjects(node).WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+					r := req.(api.V0044UpdateNodeMsg)
+					gotReq = &r
+					return nil
+				},
+			}).Build()
+			r := &realSlurmControl{
+				clientMap: newSlurmClientMap(controller.Name, sclient),
+			}
+			if err := r.MakeNodeDown(tt.args.ctx, tt.args.nodeset, tt.args.pod, tt.args.reason); (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.MakeNodeDown() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantReason == "" {
+				if gotReq != nil {
+					t.Errorf("realSlurmControl.MakeNodeDown() request = %v, want none", gotReq)
+				}
+				return
+			}
+			if gotReq == nil {
+				t.Fatalf("realSlurmControl.MakeNodeDown() did not update the node")
+			}
+			if got := ptr.Deref(gotReq.State, nil); !reflect.DeepEqual(got, []api.V0044UpdateNodeMsgState{api.V0044UpdateNodeMsgStateDOWN}) {
+				t.Errorf("realSlurmControl.MakeNodeDown() state = %v", got)
+			}
+			if got := ptr.Deref(gotReq.Reason, ""); got != tt.wantReason {
+				t.Errorf("realSlurmControl.MakeNodeDown() reason = %v, want %v", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_MakeNodeResume(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, 0, "")
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+		reason  string
+	}
+	tests := []struct {
+		name       string
+		state      []api.V0044NodeState
+		reason     string
+		args       args
+		wantResume bool
+		wantErr    bool
+	}{
+		{
+			name:   "DOWN by the operator",
+			state:  []api.V0044NodeState{api.V0044NodeStateDOWN},
+			reason: nodeReasonPrefix + " node is unhealthy",
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "node is healthy",
+			},
+			wantResume: true,
+			wantErr:    false,
+		},
+		{
+			name:   "DOWN externally",
+			state:  []api.V0044NodeState{api.V0044NodeStateDOWN},
+			reason: "Not responding",
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "node is healthy",
+			},
+			wantResume: false,
+			wantErr:    false,
+		},
+		{
+			name:   "Not DOWN",
+			state:  []api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStateDRAIN},
+			reason: nodeReasonPrefix + " node is unhealthy",
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "node is healthy",
+			},
+			wantResume: false,
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &types.V0044Node{
+				V0044Node: api.V0044Node{
+					Name:   ptr.To(nodesetutils.GetNodeName(pod)),
+					State:  ptr.To(tt.state),
+					Reason: ptr.To(tt.reason),
+				},
+			}
+			var gotReq *api.V0044UpdateNodeMsg
+			sclient := fake.NewClientBuilder().WithObjects(node).WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+					r := req.(api.V0044UpdateNodeMsg)
+					gotReq = &r
+					return nil
+				},
+			}).Build()
+			r := &realSlurmControl{
+				clientMap: newSlurmClientMap(controller.Name, sclient),
+			}
+			if err := r.MakeNodeResume(tt.args.ctx, tt.args.nodeset, tt.args.pod, tt.args.reason); (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.MakeNodeResume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := gotReq != nil; got != tt.wantResume {
+				t.Fatalf("realSlurmControl.MakeNodeResume() resumed = %v, want %v", got, tt.wantResume)
+			}
+			if !tt.wantResume {
+				return
+			}
+			if got := ptr.Deref(gotReq.State, nil); !reflect.DeepEqual(got, []api.V0044UpdateNodeMsgState{api.V0044UpdateNodeMsgStateRESUME}) {
+				t.Errorf("realSlurmControl.MakeNodeResume() state = %v", got)
+			}
+		})
+	}
+}
+
 func Test_tolerateError(t *testing.T) {
 	type args struct {
 		err error
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// NodeWorkload is the workload running on a Slurm node.
+type NodeWorkload struct {
+	// Cpus is the number of CPUs allocated to jobs.
+	Cpus int64
+	// Gpus is the number of GPUs allocated to jobs.
+	Gpus int64
+	// Jobs are the jobs running on the Slurm node.
+	Jobs []WorkloadJob
+}
+
+// IsIdle reports if no job is running on the Slurm node.
+func (w NodeWorkload) IsIdle() bool {
+	return len(w.Jobs) == 0 && w.Cpus == 0 && w.Gpus == 0
+}
+
+// WorkloadJob is a job running on a Slurm node.
+type WorkloadJob struct {
+	// Qos is the QOS of the job.
+	Qos string
+	// Priority is the priority of the job.
+	Priority int64
+}
+
+// gpuGresUsedRegex matches the GPU GRES in use, e.g. `gpu:2(IDX:0-1)`,
+// `gpu:a100:2(IDX:0,2)`.
+var gpuGresUsedRegex = regexp.MustCompile(`(?:^|,)gpu(?::[^:(),]+)?:(\d+)`)
+
+// parseGpuGresUsed returns the number of GPUs in use from the `gres_used` of
+// a Slurm node.
+func parseGpuGresUsed(gresUsed string) int64 {
+	var gpus int64
+	for _, matches := range gpuGresUsedRegex.FindAllStringSubmatch(gresUsed, -1) {
+		n, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		gpus += n
+	}
+	return gpus
+}
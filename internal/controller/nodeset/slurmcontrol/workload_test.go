@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"testing"
+)
+
+func Test_parseGpuGresUsed(t *testing.T) {
+	tests := []struct {
+		gresUsed string
+		want     int64
+	}{
+		{gresUsed: "", want: 0},
+		{gresUsed: "gpu:0", want: 0},
+		{gresUsed: "gpu:2(IDX:0-1)", want: 2},
+		{gresUsed: "gpu:a100:2(IDX:0,2)", want: 2},
+		{gresUsed: "gpu:a100:1(IDX:0),gpu:h100:3(IDX:1-3)", want: 4},
+		{gresUsed: "shard:4(0/4,0/4),gpu:1(IDX:0)", want: 1},
+		{gresUsed: "shard:4(IDX:0)", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.gresUsed, func(t *testing.T) {
+			if got := parseGpuGresUsed(tt.gresUsed); got != tt.want {
+				t.Errorf("parseGpuGresUsed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -133,6 +133,41 @@ func SplitActivePods(pods []*corev1.Pod, partition int) (pods1, pods2 []*corev1.
 	return pods1, pods2
 }
 
+// PodsByDeadline sorts a list of Pods by their deadline annotation, using ActivePods as a tie breaker.
+// Pods without a deadline are sorted first.
+type PodsByDeadline []*corev1.Pod
+
+func (o PodsByDeadline) Len() int {
+	return len(o)
+}
+
+func (o PodsByDeadline) Swap(i, j int) {
+	o[i], o[j] = o[j], o[i]
+}
+
+func (o PodsByDeadline) Less(i, j int) bool {
+	podDeadline1, _ := structutils.GetTimeFromAnnotations(o[i].Annotations, slinkyv1beta1.AnnotationPodDeadline)
+	podDeadline2, _ := structutils.GetTimeFromAnnotations(o[j].Annotations, slinkyv1beta1.AnnotationPodDeadline)
+	if !podDeadline1.Equal(podDeadline2) {
+		return podDeadline1.Before(podDeadline2)
+	}
+	return ActivePods(o).Less(i, j)
+}
+
+// SplitPodsByDeadline returns two list of pods partitioned by a number, preferring the earliest deadline.
+func SplitPodsByDeadline(pods []*corev1.Pod, partition int) (pods1, pods2 []*corev1.Pod) {
+	pivot := mathutils.Clamp(partition, 0, len(pods))
+
+	pods1 = make([]*corev1.Pod, pivot)
+	pods2 = make([]*corev1.Pod, len(pods)-pivot)
+
+	sort.Sort(PodsByDeadline(pods))
+	copy(pods1, pods[:pivot])
+	copy(pods2, pods[pivot:])
+
+	return pods1, pods2
+}
+
 // PodsByCreationTimestamp sorts a list of Pods by creation timestamp, using their names as a tie breaker.
 type PodsByCreationTimestamp []*corev1.Pod
 
@@ -441,6 +441,84 @@ func TestSplitActivePods(t *testing.T) {
 	}
 }
 
+func TestSplitPodsByDeadline(t *testing.T) {
+	now := time.Now()
+	newDeadlinePod := func(name string, deadline time.Time) *corev1.Pod {
+		annotations := map[string]string{}
+		if !deadline.IsZero() {
+			annotations[slinkyv1beta1.AnnotationPodDeadline] = deadline.Format(time.RFC3339)
+		}
+		pod := newRunningPod(name, annotations)
+		return &pod
+	}
+	type args struct {
+		pods      []*corev1.Pod
+		partition int
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantPods1Names []string
+		wantPods2Names []string
+	}{
+		{
+			name: "Empty",
+			args: args{
+				pods:      nil,
+				partition: 1,
+			},
+			wantPods1Names: []string{},
+			wantPods2Names: []string{},
+		},
+		{
+			name: "Earliest deadline",
+			args: args{
+				pods: []*corev1.Pod{
+					newDeadlinePod("foo-0", now.Add(2*time.Hour)),
+					newDeadlinePod("foo-1", now.Add(time.Hour)),
+					newDeadlinePod("foo-2", now.Add(3*time.Hour)),
+				},
+				partition: 2,
+			},
+			wantPods1Names: []string{"foo-1", "foo-0"},
+			wantPods2Names: []string{"foo-2"},
+		},
+		{
+			name: "No deadline first",
+			args: args{
+				pods: []*corev1.Pod{
+					newDeadlinePod("foo-0", now.Add(time.Hour)),
+					newDeadlinePod("foo-1", time.Time{}),
+				},
+				partition: 1,
+			},
+			wantPods1Names: []string{"foo-1"},
+			wantPods2Names: []string{"foo-0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPods1, gotPods2 := SplitPodsByDeadline(tt.args.pods, tt.args.partition)
+
+			gotPods1Names := make([]string, len(gotPods1))
+			for i := range gotPods1 {
+				gotPods1Names[i] = gotPods1[i].Name
+			}
+			gotPods2Names := make([]string, len(gotPods2))
+			for i := range gotPods2 {
+				gotPods2Names[i] = gotPods2[i].Name
+			}
+
+			if diff := cmp.Diff(tt.wantPods1Names, gotPods1Names); diff != "" {
+				t.Errorf("Sorted pod names (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantPods2Names, gotPods2Names); diff != "" {
+				t.Errorf("Sorted pod names (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSplitUnhealthyPods(t *testing.T) {
 	type args struct {
 		pods []*corev1.Pod
@@ -7,12 +7,15 @@ import (
 	"context"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
@@ -24,6 +27,16 @@ func Test_SecretEventHandler_Create(t *testing.T) {
 	jwtHs256KeySecret := testutils.NewJwtHs256KeySecret(jwtHs256KeyRef)
 	controller := testutils.NewController(name, slurmKeyRef, jwtHs256KeyRef, nil)
 	restapi := testutils.NewRestapi(name, controller)
+	restapiTLS := testutils.NewRestapi("tls", controller)
+	restapiTLS.Spec.TLS = &slinkyv1beta1.RestApiTLS{
+		SecretRef: &corev1.LocalObjectReference{Name: "restapi-tls"},
+	}
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: restapiTLS.Namespace,
+			Name:      "restapi-tls",
+		},
+	}
 	type fields struct {
 		Reader client.Reader
 	}
@@ -76,6 +89,24 @@ func Test_SecretEventHandler_Create(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "TLS secret",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					tlsSecret,
+					controller,
+					restapiTLS,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: tlsSecret,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
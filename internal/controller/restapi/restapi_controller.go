@@ -23,6 +23,7 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/restapi/eventhandler"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
@@ -33,6 +34,10 @@ const (
 
 	// BackoffGCInterval is the time that has to pass before next iteration of backoff GC is run
 	BackoffGCInterval = 1 * time.Minute
+
+	// statusResyncPeriod is how often health is re-evaluated while it can change
+	// without any Kubernetes event.
+	statusResyncPeriod = 30 * time.Second
 )
 
 func init() {
@@ -54,6 +59,8 @@ type RestapiReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 
+	ClientMap *clientmap.ClientMap
+
 	builder       *builder.Builder
 	refResolver   *refresolver.RefResolver
 	eventRecorder record.EventRecorderLogger
@@ -63,8 +70,8 @@ type RestapiReconciler struct {
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=restapis/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=restapis/finalizers,verbs=update
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 
@@ -117,13 +124,15 @@ func (r *RestapiReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func NewReconciler(c client.Client) *RestapiReconciler {
+func NewReconciler(c client.Client, cm *clientmap.ClientMap) *RestapiReconciler {
 	s := c.Scheme()
 	es := corev1.EventSource{Component: ControllerName}
 	return &RestapiReconciler{
 		Client: c,
 		Scheme: s,
 
+		ClientMap: cm,
+
 		builder:       builder.New(c),
 		refResolver:   refresolver.New(c),
 		eventRecorder: record.NewBroadcaster().NewRecorder(s, es),
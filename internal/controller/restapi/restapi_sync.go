@@ -49,6 +49,38 @@ func (r *RestapiReconciler) Sync(ctx context.Context, req reconcile.Request) err
 				return nil
 			},
 		},
+		{
+			Name: "TLS",
+			Sync: func(ctx context.Context, restapi *slinkyv1beta1.RestApi) error {
+				if !restapi.IsTLSGenerated() {
+					return nil
+				}
+				object, err := r.builder.BuildRestapiTLS(restapi)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+				if err := objectutils.SyncObject(r.Client, ctx, object, true); err != nil {
+					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+				}
+				return nil
+			},
+		},
+		{
+			Name: "Config",
+			Sync: func(ctx context.Context, restapi *slinkyv1beta1.RestApi) error {
+				if !restapi.IsTLSEnabled() {
+					return nil
+				}
+				object, err := r.builder.BuildRestapiConfig(restapi)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+				if err := objectutils.SyncObject(r.Client, ctx, object, true); err != nil {
+					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+				}
+				return nil
+			},
+		},
 		{
 			Name: "Deployment",
 			Sync: func(ctx context.Context, restapi *slinkyv1beta1.RestApi) error {
@@ -68,7 +100,7 @@ func (r *RestapiReconciler) Sync(ctx context.Context, req reconcile.Request) err
 		if err := s.Sync(ctx, cluster); err != nil {
 			e := fmt.Errorf("[%s]: %w", s.Name, err)
 			errors := []error{e}
-			if err := r.syncStatus(ctx, cluster); err != nil {
+			if err := r.syncStatus(ctx, cluster, e); err != nil {
 				e := fmt.Errorf("[%s]: %w", s.Name, err)
 				errors = append(errors, e)
 			}
@@ -76,5 +108,5 @@ func (r *RestapiReconciler) Sync(ctx context.Context, req reconcile.Request) err
 		}
 	}
 
-	return r.syncStatus(ctx, cluster)
+	return r.syncStatus(ctx, cluster, nil)
 }
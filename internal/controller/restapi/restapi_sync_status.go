@@ -6,31 +6,70 @@ package restapi
 import (
 	"context"
 	"fmt"
+	"net/http"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	slurmapi "github.com/SlinkyProject/slurm-client/pkg/client/api/v0044"
+
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/statusutils"
 )
 
 // syncStatus handles determining and updating the status.
 func (r *RestapiReconciler) syncStatus(
 	ctx context.Context,
 	restapi *slinkyv1beta1.RestApi,
+	syncErr error,
 ) error {
 	logger := log.FromContext(ctx)
 
+	workload, err := r.getWorkloadStatus(ctx, restapi)
+	if err != nil {
+		return err
+	}
+	obs := statusutils.Observations{
+		Generation: restapi.Generation,
+		Workload:   &workload,
+		SyncErr:    syncErr,
+	}
+	controller, err := r.refResolver.GetController(ctx, restapi.Spec.ControllerRef)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		obs.ConfigErr = fmt.Errorf("failed to resolve `controllerRef`: %w", err)
+	} else {
+		obs.ConfigErr = r.validateConfig(ctx, restapi, controller)
+		// Ping this slurmrestd, not whichever the slurm client is using.
+		slurmClient := r.ClientMap.Get(controller.Key())
+		server, httpClient := r.ClientMap.GetEndpointHTTPClient(controller.Key(), restapi.Name)
+		if slurmClient != nil && httpClient != nil {
+			obs.Pinged = true
+			obs.PingErr = pingSlurmrestd(ctx, server, slurmClient.GetToken(), httpClient)
+		}
+	}
+
 	newStatus := &slinkyv1beta1.RestApiStatus{
-		Conditions: []metav1.Condition{},
+		ObservedGeneration: restapi.Generation,
+		Conditions:         statusutils.NewConditions(restapi.Status.Conditions, obs),
 	}
-	newStatus.Conditions = append(newStatus.Conditions, restapi.Status.Conditions...)
 
-	if apiequality.Semantic.DeepEqual(restapi.Status, newStatus) {
+	// Ping results change without any Kubernetes event, so poll.
+	if obs.Pinged || !statusutils.IsReady(newStatus.Conditions) {
+		durationStore.Push(objectutils.KeyFunc(restapi), statusResyncPeriod)
+	}
+
+	if apiequality.Semantic.DeepEqual(restapi.Status, *newStatus) {
 		logger.V(2).Info("Restapi Status has not changed, skipping status update",
 			"restapi", klog.KObj(restapi), "status", restapi.Status)
 		return nil
@@ -44,6 +83,69 @@ func (r *RestapiReconciler) syncStatus(
 	return nil
 }
 
+// validateConfig checks that the Secrets referenced by the RestApi and its
+// Controller can be resolved.
+func (r *RestapiReconciler) validateConfig(
+	ctx context.Context,
+	restapi *slinkyv1beta1.RestApi,
+	controller *slinkyv1beta1.Controller,
+) error {
+	errs := []error{}
+	if restapi.IsTLSEnabled() && !restapi.IsTLSGenerated() {
+		if err := r.Get(ctx, restapi.TLSKey(), &corev1.Secret{}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve `tls.secretRef`: %w", err))
+		}
+		if restapi.Spec.TLS.ClientCertificate {
+			if err := r.Get(ctx, restapi.TLSClientKey(), &corev1.Secret{}); err != nil {
+				errs = append(errs, fmt.Errorf("failed to resolve `tls.clientSecretRef`: %w", err))
+			}
+		}
+	}
+	if controller.IsMungeAuth() {
+		if _, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthMungeRef(), controller.Namespace); err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve Controller `auth.mungeKeyRef`: %w", err))
+		}
+	} else if _, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthSlurmRef(), controller.Namespace); err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve Controller `slurmKeyRef`: %w", err))
+	}
+	if _, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthJwtHs256Ref(), controller.Namespace); err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve Controller `jwtHs256KeyRef`: %w", err))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// getWorkloadStatus returns the status of the slurmrestd Deployment.
+func (r *RestapiReconciler) getWorkloadStatus(
+	ctx context.Context,
+	restapi *slinkyv1beta1.RestApi,
+) (statusutils.WorkloadStatus, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, restapi.Key(), deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return statusutils.NewDeploymentStatus(nil), nil
+		}
+		return statusutils.WorkloadStatus{}, err
+	}
+	return statusutils.NewDeploymentStatus(deployment), nil
+}
+
+// pingSlurmrestd returns an error if the slurmrestd of the server cannot relay a
+// ping to slurmctld.
+func pingSlurmrestd(ctx context.Context, server, token string, httpClient *http.Client) error {
+	client, err := slurmapi.NewSlurmClient(server, token, httpClient)
+	if err != nil {
+		return err
+	}
+	res, err := client.SlurmV0044GetPingWithResponse(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to ping through slurmrestd: %w", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		return fmt.Errorf("failed to ping through slurmrestd: %s", http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
 func (r *RestapiReconciler) updateStatus(
 	ctx context.Context,
 	cluster *slinkyv1beta1.RestApi,
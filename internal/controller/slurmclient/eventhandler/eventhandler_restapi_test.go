@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_RestapiEventHandler_Update(t *testing.T) {
+	slurmKeyRef := testutils.NewSlurmKeyRef("foo")
+	jwtHs256KeyRef := testutils.NewJwtHs256KeyRef("foo")
+	controller := testutils.NewController("slurm", slurmKeyRef, jwtHs256KeyRef, nil)
+	restapi := testutils.NewRestapi("slurm", controller)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "smoke",
+			fields: fields{
+				Reader: fake.NewFakeClient(controller, restapi),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: restapi,
+					ObjectNew: restapi,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Controller not found",
+			fields: fields{
+				Reader: fake.NewFakeClient(restapi),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: restapi,
+					ObjectNew: restapi,
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewRestapiEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("RestapiEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
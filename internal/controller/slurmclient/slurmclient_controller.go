@@ -23,6 +23,7 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmclient/eventhandler"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
 )
@@ -32,6 +33,11 @@ const (
 
 	// BackoffGCInterval is the time that has to pass before next iteration of backoff GC is run
 	BackoffGCInterval = 1 * time.Minute
+
+	// unreadyGracePeriod is how long a slurm client is kept while no restapi
+	// is ready, covering a slurmrestd rollout or a backup slurmctld takeover
+	// (SlurmctldTimeout defaults to 120 seconds).
+	unreadyGracePeriod = 3 * time.Minute
 )
 
 func init() {
@@ -53,14 +59,20 @@ type SlurmClientReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 
-	ClientMap *clientmap.ClientMap
-	EventCh   chan event.GenericEvent
+	ClientMap  *clientmap.ClientMap
+	EventCh    chan event.GenericEvent
+	JobEventCh chan event.GenericEvent
 
 	refResolver   *refresolver.RefResolver
 	eventRecorder record.EventRecorderLogger
+
+	// unreadySince tracks when the restapi of a Controller became not ready.
+	unreadySince sync.Map
 }
 
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=restapis,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -100,13 +112,14 @@ func (r *SlurmClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(ControllerName).
 		For(&slinkyv1beta1.Controller{}).
+		Watches(&slinkyv1beta1.RestApi{}, eventhandler.NewRestapiEventHandler(r.Client)).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles,
 		}).
 		Complete(r)
 }
 
-func NewReconciler(c client.Client, cm *clientmap.ClientMap, ec chan event.GenericEvent) *SlurmClientReconciler {
+func NewReconciler(c client.Client, cm *clientmap.ClientMap, ec, jec chan event.GenericEvent) *SlurmClientReconciler {
 	s := c.Scheme()
 	es := corev1.EventSource{Component: ControllerName}
 	if cm == nil {
@@ -115,12 +128,16 @@ func NewReconciler(c client.Client, cm *clientmap.ClientMap, ec chan event.Gener
 	if ec == nil {
 		panic("EventCh cannot be nil")
 	}
+	if jec == nil {
+		panic("JobEventCh cannot be nil")
+	}
 	return &SlurmClientReconciler{
 		Client: c,
 		Scheme: s,
 
-		ClientMap: cm,
-		EventCh:   ec,
+		ClientMap:  cm,
+		EventCh:    ec,
+		JobEventCh: jec,
 
 		refResolver:   refresolver.New(c),
 		eventRecorder: record.NewBroadcaster().NewRecorder(s, es),
@@ -5,13 +5,15 @@ package slurmclient
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -23,8 +25,11 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/eventhandler"
+	jobeventhandler "github.com/SlinkyProject/slurm-operator/internal/controller/slurmjob/eventhandler"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/metrics"
 )
 
 // Sync implements control logic for synchronizing a Restapi.
@@ -42,37 +47,48 @@ func (r *SlurmClientReconciler) Sync(ctx context.Context, req reconcile.Request)
 	}
 	controllerKey := client.ObjectKeyFromObject(controller)
 
-	server, err := r.getRestApiServer(ctx, controller)
+	endpoints, err := r.getRestApiEndpoints(ctx, controller)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
-			_ = r.ClientMap.Remove(controllerKey)
-			durationStore.Push(controllerKey.String(), 10*time.Second)
-			return nil
-		}
 		return err
 	}
-
-	if ok, err := r.isRestapiReady(ctx, controller); err != nil || !ok {
+	if len(endpoints) == 0 {
 		_ = r.ClientMap.Remove(controllerKey)
 		durationStore.Push(controllerKey.String(), 10*time.Second)
-		return err
+		return nil
 	}
 
-	signingKey, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthJwtHs256Ref(), controller.Namespace)
+	if !slices.ContainsFunc(endpoints, func(ep clientmap.Endpoint) bool { return ep.Ready }) {
+		durationStore.Push(controllerKey.String(), 10*time.Second)
+		if r.isWithinGracePeriod(controllerKey, time.Now()) {
+			logger.Info("Restapi is not ready, keeping slurm client during grace period",
+				"controller", controllerKey.String())
+			if restapiEndpoints := r.ClientMap.GetEndpoints(controllerKey); restapiEndpoints != nil {
+				if err := restapiEndpoints.Set(endpoints); err != nil {
+					return fmt.Errorf("failed to set restapi endpoints: %w", err)
+				}
+			}
+			return nil
+		}
+		_ = r.ClientMap.Remove(controllerKey)
+		return nil
+	}
+	r.unreadySince.Delete(controllerKey)
+
+	signingKey, err := r.refResolver.GetJwtSigningKey(ctx, controller)
 	if err != nil {
 		return err
 	}
 
 	lifetime := 15 * time.Minute
 	refresh := lifetime * 4 / 5
-	authToken, err := slurmjwt.NewToken(signingKey).
+	authToken, err := slurmjwt.NewTokenWithKey(signingKey).
 		WithLifetime(lifetime).
 		NewSignedToken()
 	if err != nil {
 		return fmt.Errorf("failed to create Slurm auth token: %w", err)
 	}
 
-	authTokenClaims, err := slurmjwt.ParseTokenClaims(authToken, signingKey)
+	authTokenClaims, err := slurmjwt.ParseTokenClaimsWithKeys(authToken, []*slurmjwt.SigningKey{signingKey})
 	if err != nil {
 		return fmt.Errorf("failed to parse Slurm auth token: %w", err)
 	}
@@ -88,14 +104,25 @@ func (r *SlurmClientReconciler) Sync(ctx context.Context, req reconcile.Request)
 
 	// There is an existing client, handle in-place updates
 	if slurmClient := r.ClientMap.Get(controllerKey); slurmClient != nil {
-		slurmClient.SetServer(server)
-		slurmClient.SetToken(authToken)
-		return nil
+		if restapiEndpoints := r.ClientMap.GetEndpoints(controllerKey); restapiEndpoints != nil {
+			if err := restapiEndpoints.Set(endpoints); err != nil {
+				return fmt.Errorf("failed to set restapi endpoints: %w", err)
+			}
+			slurmClient.SetToken(authToken)
+			return nil
+		}
 	}
 
+	restapiEndpoints := clientmap.NewEndpoints()
+	if err := restapiEndpoints.Set(endpoints); err != nil {
+		return fmt.Errorf("failed to set restapi endpoints: %w", err)
+	}
 	config := &slurmclient.Config{
-		Server:    server,
+		Server:    restapiEndpoints.Active(),
 		AuthToken: authToken,
+		HTTPClient: &http.Client{
+			Transport: metrics.NewSlurmrestdTransport(controllerKey.String(), restapiEndpoints),
+		},
 	}
 	options := &slurmclient.ClientOptions{
 		DisableFor: []slurmobject.Object{
@@ -107,53 +134,114 @@ func (r *SlurmClientReconciler) Sync(ctx context.Context, req reconcile.Request)
 		return fmt.Errorf("failed to create slurm client: %w", err)
 	}
 	eventhandler.SetEventHandler(slurmClient, r.EventCh)
+	jobeventhandler.SetEventHandler(slurmClient, r.JobEventCh)
 
-	if r.ClientMap.Add(controllerKey, slurmClient) {
+	if r.ClientMap.AddWithEndpoints(controllerKey, slurmClient, restapiEndpoints) {
 		logger.Info("Added slurm client", "controller", controllerKey.String())
 	}
 
 	return nil
 }
 
-func (r *SlurmClientReconciler) getRestApiServer(ctx context.Context, controller *slinkyv1beta1.Controller) (string, error) {
+// getRestApiEndpoints returns the slurmrestd endpoints of every RestApi of the
+// Controller, ordered by name. An endpoint is ready when its Deployment has a
+// ready replica.
+func (r *SlurmClientReconciler) getRestApiEndpoints(ctx context.Context, controller *slinkyv1beta1.Controller) ([]clientmap.Endpoint, error) {
 	logger := log.FromContext(ctx)
 
 	restapiList, err := r.refResolver.GetRestapisForController(ctx, controller)
 	if err != nil {
-		return "", err
-	}
-	if len(restapiList.Items) == 0 {
-		return "", errors.New(http.StatusText(http.StatusNotFound))
+		return nil, err
 	}
+	slices.SortFunc(restapiList.Items, func(a, b slinkyv1beta1.RestApi) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	endpoints := make([]clientmap.Endpoint, 0, len(restapiList.Items))
+	for _, restapi := range restapiList.Items {
+		scheme := "http"
+		if restapi.IsTLSEnabled() {
+			scheme = "https"
+		}
+		endpoint := clientmap.Endpoint{
+			Name:   restapi.Name,
+			Server: fmt.Sprintf("%s://%s:%d", scheme, restapi.ServiceFQDNShort(), builder.SlurmrestdPort),
+		}
+		if val := os.Getenv("DEBUG"); val == "1" {
+			logger.Info("overriding restapi URL with localhost")
+			endpoint.Server = fmt.Sprintf("%s://localhost:%d", scheme, builder.SlurmrestdPort)
+		}
+		if restapi.IsTLSEnabled() {
+			endpointTLS, err := r.getRestApiTLS(ctx, &restapi)
+			if err != nil {
+				return nil, err
+			}
+			if endpointTLS == nil {
+				logger.V(1).Info("Restapi TLS Secret not found", "restapi", restapi.Name)
+				endpoints = append(endpoints, endpoint)
+				continue
+			}
+			endpoint.TLS = endpointTLS
+		}
 
-	server := fmt.Sprintf("http://%s:%d", restapiList.Items[0].ServiceFQDNShort(), builder.SlurmrestdPort)
-	if val := os.Getenv("DEBUG"); val == "1" {
-		logger.Info("overriding restapi URL with localhost")
-		server = fmt.Sprintf("http://localhost:%d", builder.SlurmrestdPort)
+		deployment := &appsv1.Deployment{}
+		if err := r.Get(ctx, restapi.Key(), deployment); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+		}
+		if deployment.Status.ReadyReplicas > 0 {
+			logger.V(2).Info("Restapi deployment ready replica count",
+				"restapi", restapi.Name, "replicas", deployment.Status.ReadyReplicas)
+			endpoint.Ready = true
+		}
+		endpoints = append(endpoints, endpoint)
 	}
 
-	return server, nil
+	return endpoints, nil
 }
 
-func (r *SlurmClientReconciler) isRestapiReady(ctx context.Context, controller *slinkyv1beta1.Controller) (bool, error) {
-	logger := log.FromContext(ctx)
-
-	restapiList, err := r.refResolver.GetRestapisForController(ctx, controller)
-	if err != nil {
-		return false, err
+// getRestApiTLS returns the TLS configuration which verifies the slurmrestd of
+// the RestApi, or nil if its Secrets do not exist yet.
+func (r *SlurmClientReconciler) getRestApiTLS(ctx context.Context, restapi *slinkyv1beta1.RestApi) (*clientmap.EndpointTLS, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, restapi.TLSKey(), secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	out := &clientmap.EndpointTLS{
+		CA: secret.Data[corev1.ServiceAccountRootCAKey],
 	}
 
-	for _, restapi := range restapiList.Items {
-		deployment := &appsv1.Deployment{}
-		deploymentKey := restapi.Key()
-		if err := r.Get(ctx, deploymentKey, deployment); err != nil {
-			return false, err
-		}
-		if deployment.Status.ReadyReplicas > 0 {
-			logger.V(2).Info("Restapi deployment ready replica count", "replicas", deployment.Status.ReadyReplicas)
-			return true, nil
+	if restapi.Spec.TLS.ClientCertificate {
+		clientSecret := &corev1.Secret{}
+		if err := r.Get(ctx, restapi.TLSClientKey(), clientSecret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
 		}
+		certKey, privateKeyKey := builder.RestapiTLSClientKeys(restapi)
+		out.Cert = clientSecret.Data[certKey]
+		out.Key = clientSecret.Data[privateKeyKey]
 	}
 
-	return false, nil
+	return out, nil
+}
+
+// isWithinGracePeriod reports if an existing slurm client should be kept while
+// no restapi is ready. The restapi may be briefly unavailable during a
+// slurmrestd rollout or while a backup slurmctld takes over, which should not
+// tear down the client that other controllers depend on. Its endpoints are
+// still health checked, such that it recovers once a restapi is ready.
+func (r *SlurmClientReconciler) isWithinGracePeriod(controllerKey client.ObjectKey, now time.Time) bool {
+	if r.ClientMap.Get(controllerKey) == nil {
+		r.unreadySince.Delete(controllerKey)
+		return false
+	}
+	val, _ := r.unreadySince.LoadOrStore(controllerKey, now)
+	since := val.(time.Time)
+	return now.Sub(since) < unreadyGracePeriod
 }
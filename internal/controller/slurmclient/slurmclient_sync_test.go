@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmclient
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8sfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+func TestSlurmClientReconciler_isWithinGracePeriod(t *testing.T) {
+	now := time.Now()
+	controllerKey := client.ObjectKey{Namespace: "default", Name: "slurm"}
+	tests := []struct {
+		name         string
+		hasClient    bool
+		unreadySince time.Time
+		want         bool
+	}{
+		{
+			name:      "No client",
+			hasClient: false,
+			want:      false,
+		},
+		{
+			name:      "Became unready",
+			hasClient: true,
+			want:      true,
+		},
+		{
+			name:         "Within grace period",
+			hasClient:    true,
+			unreadySince: now.Add(-time.Minute),
+			want:         true,
+		},
+		{
+			name:         "Grace period expired",
+			hasClient:    true,
+			unreadySince: now.Add(-unreadyGracePeriod),
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &SlurmClientReconciler{
+				ClientMap: clientmap.NewClientMap(),
+			}
+			if tt.hasClient {
+				r.ClientMap.Add(controllerKey, fake.NewFakeClient())
+			}
+			if !tt.unreadySince.IsZero() {
+				r.unreadySince.Store(controllerKey, tt.unreadySince)
+			}
+			if got := r.isWithinGracePeriod(controllerKey, now); got != tt.want {
+				t.Errorf("SlurmClientReconciler.isWithinGracePeriod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlurmClientReconciler_getRestApiEndpoints(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme))
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "slurm",
+		},
+	}
+	newRestapi := func(name, controllerName string) *slinkyv1beta1.RestApi {
+		return &slinkyv1beta1.RestApi{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+			},
+			Spec: slinkyv1beta1.RestApiSpec{
+				ControllerRef: slinkyv1beta1.ObjectReference{
+					Namespace: "default",
+					Name:      controllerName,
+				},
+			},
+		}
+	}
+	newDeployment := func(restapi *slinkyv1beta1.RestApi, readyReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: restapi.Key().Namespace,
+				Name:      restapi.Key().Name,
+			},
+			Status: appsv1.DeploymentStatus{
+				ReadyReplicas: readyReplicas,
+			},
+		}
+	}
+	restapiA := newRestapi("a", "slurm")
+	restapiB := newRestapi("b", "slurm")
+	restapiOther := newRestapi("other", "other")
+	restapiTLS := newRestapi("tls", "slurm")
+	restapiTLS.Spec.TLS = &slinkyv1beta1.RestApiTLS{ClientCertificate: true}
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      restapiTLS.TLSKey().Name,
+		},
+		Data: map[string][]byte{
+			corev1.ServiceAccountRootCAKey: []byte("ca"),
+			builder.TLSClientCertKey:       []byte("cert"),
+			builder.TLSClientPrivateKeyKey: []byte("key"),
+		},
+	}
+	tests := []struct {
+		name    string
+		objs    []client.Object
+		want    []clientmap.Endpoint
+		wantErr bool
+	}{
+		{
+			name: "No RestApis",
+			want: []clientmap.Endpoint{},
+		},
+		{
+			name: "Ordered by name, ready by Deployment",
+			objs: []client.Object{
+				restapiB,
+				restapiA,
+				restapiOther,
+				newDeployment(restapiA, 0),
+				newDeployment(restapiB, 1),
+				newDeployment(restapiOther, 1),
+			},
+			want: []clientmap.Endpoint{
+				{Name: "a", Server: "http://a-restapi.default:6820", Ready: false},
+				{Name: "b", Server: "http://b-restapi.default:6820", Ready: true},
+			},
+		},
+		{
+			name: "TLS",
+			objs: []client.Object{
+				restapiTLS,
+				tlsSecret,
+				newDeployment(restapiTLS, 1),
+			},
+			want: []clientmap.Endpoint{
+				{
+					Name:   "tls",
+					Server: "https://tls-restapi.default:6820",
+					Ready:  true,
+					TLS:    &clientmap.EndpointTLS{CA: []byte("ca"), Cert: []byte("cert"), Key: []byte("key")},
+				},
+			},
+		},
+		{
+			name: "TLS Secret not found",
+			objs: []client.Object{
+				restapiTLS,
+				newDeployment(restapiTLS, 1),
+			},
+			want: []clientmap.Endpoint{
+				{Name: "tls", Server: "https://tls-restapi.default:6820", Ready: false},
+			},
+		},
+		{
+			name: "Deployment not found",
+			objs: []client.Object{
+				restapiA,
+			},
+			want: []clientmap.Endpoint{
+				{Name: "a", Server: "http://a-restapi.default:6820", Ready: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := k8sfake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objs...).Build()
+			r := &SlurmClientReconciler{
+				Client:      c,
+				refResolver: refresolver.New(c),
+			}
+			got, err := r.getRestApiEndpoints(context.TODO(), controller)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SlurmClientReconciler.getRestApiEndpoints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SlurmClientReconciler.getRestApiEndpoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -86,14 +86,15 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred())
 
 	eventCh := make(chan event.GenericEvent, 10)
+	jobEventCh := make(chan event.GenericEvent, 10)
 	clientMap = clientmap.NewClientMap()
-	err = NewReconciler(k8sManager.GetClient(), clientMap, eventCh).SetupWithManager(k8sManager)
+	err = NewReconciler(k8sManager.GetClient(), clientMap, eventCh, jobEventCh).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = controller.NewReconciler(k8sManager.GetClient(), clientMap).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
-	err = restapi.NewReconciler(k8sManager.GetClient()).SetupWithManager(k8sManager)
+	err = restapi.NewReconciler(k8sManager.GetClient(), clientMap).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	go func() {
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+)
+
+const (
+	SlurmAccountControllerName = "slurmaccount-controller"
+)
+
+func init() {
+	flag.IntVar(&maxSlurmAccountConcurrentReconciles, "slurmaccount-workers", maxSlurmAccountConcurrentReconciles, "Max concurrent workers for SlurmAccount controller.")
+}
+
+var (
+	maxSlurmAccountConcurrentReconciles = 1
+)
+
+// SlurmAccountReconciler reconciles a SlurmAccount object
+type SlurmAccountReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	ClientMap *clientmap.ClientMap
+
+	slurmControl  slurmcontrol.SlurmControlInterface
+	eventRecorder record.EventRecorderLogger
+}
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmaccounts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmaccounts/finalizers,verbs=update
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *SlurmAccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, retErr error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Started syncing SlurmAccount", "request", req)
+
+	startTime := time.Now()
+	defer func() {
+		if retErr == nil {
+			if res.RequeueAfter > 0 {
+				logger.Info("Finished syncing SlurmAccount", "duration", time.Since(startTime), "result", res)
+			} else {
+				logger.Info("Finished syncing SlurmAccount", "duration", time.Since(startTime))
+			}
+		} else {
+			logger.Info("Finished syncing SlurmAccount", "duration", time.Since(startTime), "error", retErr)
+		}
+		// clean the duration store
+		_ = accountDurationStore.Pop(req.String())
+	}()
+
+	retErr = r.Sync(ctx, req)
+	res = reconcile.Result{
+		RequeueAfter: accountDurationStore.Pop(req.String()),
+	}
+	return res, retErr
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SlurmAccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(SlurmAccountControllerName).
+		For(&slinkyv1beta1.SlurmAccount{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxSlurmAccountConcurrentReconciles,
+		}).
+		Complete(r)
+}
+
+func NewSlurmAccountReconciler(c client.Client, cm *clientmap.ClientMap) *SlurmAccountReconciler {
+	s := c.Scheme()
+	es := corev1.EventSource{Component: SlurmAccountControllerName}
+	return &SlurmAccountReconciler{
+		Client: c,
+		Scheme: s,
+
+		ClientMap: cm,
+
+		slurmControl:  slurmcontrol.NewSlurmControl(cm),
+		eventRecorder: record.NewBroadcaster().NewRecorder(s, es),
+	}
+}
@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+type SlurmAccountSyncStep struct {
+	Name string
+	Sync func(ctx context.Context, account *slinkyv1beta1.SlurmAccount) error
+}
+
+// Sync implements control logic for synchronizing a SlurmAccount.
+func (r *SlurmAccountReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	account := &slinkyv1beta1.SlurmAccount{}
+	if err := r.Get(ctx, req.NamespacedName, account); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("SlurmAccount has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+
+	target, err := getControllerTarget(ctx, r.Client, account.Spec.ControllerRef)
+	if err != nil {
+		return err
+	}
+
+	if !account.DeletionTimestamp.IsZero() {
+		return r.finalize(ctx, account, target)
+	}
+
+	if target.ConfigErr == nil {
+		target.ConfigErr = validateLimits(&account.Spec.Limits)
+	}
+
+	observed := account.Status.Observed
+	result := slurmcontrol.SyncResult{}
+	syncSteps := []SlurmAccountSyncStep{
+		{
+			Name: "Finalizer",
+			Sync: func(ctx context.Context, account *slinkyv1beta1.SlurmAccount) error {
+				return addFinalizer(ctx, r.Client, account)
+			},
+		},
+		{
+			Name: "Account",
+			Sync: func(ctx context.Context, account *slinkyv1beta1.SlurmAccount) error {
+				if target.ConfigErr != nil {
+					return nil
+				}
+				var err error
+				observed, result, err = r.slurmControl.SyncAccount(ctx, target.Key, target.Cluster, account)
+				if err != nil {
+					return fmt.Errorf("failed to sync Slurm account (%s): %w", account.SlurmName(), err)
+				}
+				switch {
+				case result.Created:
+					r.eventRecorder.Eventf(account, corev1.EventTypeNormal, CreatedReason,
+						"Created Slurm account %s", account.SlurmName())
+				case result.Drifted:
+					r.eventRecorder.Eventf(account, corev1.EventTypeWarning, DriftCorrectedReason,
+						"Corrected Slurm account %s, which differed from the spec", account.SlurmName())
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, s := range syncSteps {
+		if err := s.Sync(ctx, account); err != nil {
+			e := fmt.Errorf("[%s]: %w", s.Name, err)
+			errors := []error{e}
+			if err := r.syncStatus(ctx, account, observed, result, target.ConfigErr, e); err != nil {
+				e := fmt.Errorf("[%s]: %w", s.Name, err)
+				errors = append(errors, e)
+			}
+			return utilerrors.NewAggregate(errors)
+		}
+	}
+
+	accountDurationStore.Push(objectutils.KeyFunc(account), resyncPeriod)
+
+	return r.syncStatus(ctx, account, observed, result, target.ConfigErr, nil)
+}
+
+// finalize deletes the Slurm account, unless retained, and removes the finalizer.
+func (r *SlurmAccountReconciler) finalize(
+	ctx context.Context,
+	account *slinkyv1beta1.SlurmAccount,
+	target *controllerTarget,
+) error {
+	if !controllerutil.ContainsFinalizer(account, slinkyv1beta1.FinalizerSlurmdb) {
+		return nil
+	}
+
+	if target.hasRecord(account.Status.Observed != nil) && !account.IsRetained() {
+		err := r.slurmControl.DeleteAccount(ctx, target.Key, account)
+		switch {
+		case err == nil:
+			r.eventRecorder.Eventf(account, corev1.EventTypeNormal, DeletedReason,
+				"Deleted Slurm account %s", account.SlurmName())
+		case waitForClient(account, err, time.Now()):
+			accountDurationStore.Push(objectutils.KeyFunc(account), noClientRequeue)
+			return nil
+		case errors.Is(err, slurmcontrol.ErrNoClient):
+			r.eventRecorder.Eventf(account, corev1.EventTypeWarning, AbandonedReason,
+				"Abandoned Slurm account %s, the Controller has no slurm client", account.SlurmName())
+		default:
+			return fmt.Errorf("failed to delete Slurm account (%s): %w", account.SlurmName(), err)
+		}
+	}
+
+	return removeFinalizer(ctx, r.Client, account)
+}
+
+// syncStatus handles determining and updating the status.
+func (r *SlurmAccountReconciler) syncStatus(
+	ctx context.Context,
+	account *slinkyv1beta1.SlurmAccount,
+	observed *slinkyv1beta1.SlurmAccountParameters,
+	result slurmcontrol.SyncResult,
+	configErr, syncErr error,
+) error {
+	logger := log.FromContext(ctx)
+
+	newStatus := &slinkyv1beta1.SlurmAccountStatus{
+		ObservedGeneration: account.Generation,
+		Observed:           observed,
+		LastDriftTime:      newLastDriftTime(account.Status.LastDriftTime, result.Drifted),
+		Conditions:         make([]metav1.Condition, len(account.Status.Conditions)),
+	}
+	copy(newStatus.Conditions, account.Status.Conditions)
+	ready := newReadyCondition(account.Generation,
+		fmt.Sprintf("Slurm account %s", account.SlurmName()), configErr, syncErr)
+	meta.SetStatusCondition(&newStatus.Conditions, ready)
+
+	if apiequality.Semantic.DeepEqual(account.Status, *newStatus) {
+		logger.V(2).Info("SlurmAccount Status has not changed, skipping status update",
+			"account", klog.KObj(account), "status", account.Status)
+		return nil
+	}
+
+	if err := r.updateStatus(ctx, account, newStatus); err != nil {
+		return fmt.Errorf("error updating SlurmAccount(%s) status: %w",
+			klog.KObj(account), err)
+	}
+
+	return nil
+}
+
+func (r *SlurmAccountReconciler) updateStatus(
+	ctx context.Context,
+	account *slinkyv1beta1.SlurmAccount,
+	newStatus *slinkyv1beta1.SlurmAccountStatus,
+) error {
+	logger := log.FromContext(ctx)
+	accountKey := objectutils.NamespacedName(account)
+
+	logger.V(1).Info("Pending SlurmAccount Status update",
+		"account", klog.KObj(account), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.SlurmAccount{}
+		if err := r.Get(ctx, accountKey, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
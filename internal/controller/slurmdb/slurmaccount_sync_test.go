@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+type fakeSlurmControl struct {
+	slurmcontrol.SlurmControlInterface
+	deleteErr error
+	deleted   []string
+}
+
+func (f *fakeSlurmControl) DeleteAccount(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	account *slinkyv1beta1.SlurmAccount,
+) error {
+	f.deleted = append(f.deleted, account.SlurmName())
+	return f.deleteErr
+}
+
+func TestSlurmAccountReconciler_finalize(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	newAccount := func(deletedFor time.Duration, observed bool) *slinkyv1beta1.SlurmAccount {
+		account := testutils.NewSlurmAccount("foo", controller)
+		account.Finalizers = []string{slinkyv1beta1.FinalizerSlurmdb}
+		account.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-deletedFor)}
+		if observed {
+			account.Status.Observed = &slinkyv1beta1.SlurmAccountParameters{}
+		}
+		return account
+	}
+	ready := &controllerTarget{Key: objectutils.NamespacedName(controller)}
+	tests := []struct {
+		name          string
+		account       *slinkyv1beta1.SlurmAccount
+		target        *controllerTarget
+		deleteErr     error
+		wantDeleted   bool
+		wantFinalizer bool
+		wantErr       bool
+	}{
+		{
+			name:          "Deleted",
+			account:       newAccount(0, true),
+			target:        ready,
+			wantDeleted:   true,
+			wantFinalizer: false,
+		},
+		{
+			name:    "Controller misconfigured",
+			account: newAccount(0, true),
+			target: &controllerTarget{
+				Key:       ready.Key,
+				ConfigErr: errors.New("accounting is not configured"),
+			},
+			wantDeleted:   false,
+			wantFinalizer: false,
+		},
+		{
+			name:          "Never observed",
+			account:       newAccount(0, false),
+			target:        ready,
+			wantDeleted:   false,
+			wantFinalizer: false,
+		},
+		{
+			name:          "No client, wait",
+			account:       newAccount(0, true),
+			target:        ready,
+			deleteErr:     slurmcontrol.ErrNoClient,
+			wantDeleted:   true,
+			wantFinalizer: true,
+		},
+		{
+			name:          "No client, timed out",
+			account:       newAccount(noClientTimeout, true),
+			target:        ready,
+			deleteErr:     slurmcontrol.ErrNoClient,
+			wantDeleted:   true,
+			wantFinalizer: false,
+		},
+		{
+			name:          "Delete failed",
+			account:       newAccount(noClientTimeout, true),
+			target:        ready,
+			deleteErr:     errors.New("failed"),
+			wantDeleted:   true,
+			wantFinalizer: true,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClient(tt.account)
+			slurmControl := &fakeSlurmControl{deleteErr: tt.deleteErr}
+			r := &SlurmAccountReconciler{
+				Client:        c,
+				Scheme:        c.Scheme(),
+				slurmControl:  slurmControl,
+				eventRecorder: record.NewBroadcaster().NewRecorder(c.Scheme(), corev1.EventSource{}),
+			}
+			if err := r.finalize(context.Background(), tt.account, tt.target); (err != nil) != tt.wantErr {
+				t.Errorf("finalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(slurmControl.deleted) > 0; got != tt.wantDeleted {
+				t.Errorf("finalize() deleted = %v, want %v", got, tt.wantDeleted)
+			}
+			// The fake client deletes the object once its last finalizer is removed.
+			err := c.Get(context.Background(), client.ObjectKeyFromObject(tt.account), &slinkyv1beta1.SlurmAccount{})
+			if gotFinalizer := !apierrors.IsNotFound(err); gotFinalizer != tt.wantFinalizer {
+				t.Errorf("finalize() finalizer = %v, want %v", gotFinalizer, tt.wantFinalizer)
+			}
+		})
+	}
+}
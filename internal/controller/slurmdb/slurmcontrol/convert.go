@@ -0,0 +1,502 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"cmp"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/tresutils"
+)
+
+// The generated slurmrestd types nest anonymous structs, these templates
+// allocate the ones that are set when building a request.
+const (
+	assocTemplate = `{"default":{},"max":{"jobs":{"per":{}},"tres":{"per":{}}}}`
+	userTemplate  = `{"default":{}}`
+	qosTemplate   = `{"limits":{"max":{"active_jobs":{},"jobs":{"active_jobs":{"per":{}},"per":{}},"tres":{"per":{}},"wall_clock":{"per":{}}}},"preempt":{}}`
+)
+
+func fromTemplate[T any](template string) T {
+	var out T
+	utilruntime.Must(json.Unmarshal([]byte(template), &out))
+	return out
+}
+
+// ignoredQOSFlags are reported by Slurm, but are not properties of the QOS.
+var ignoredQOSFlags = []string{
+	string(slurmapi.V0044QosFlagsADD),
+	string(slurmapi.V0044QosFlagsDELETED),
+	string(slurmapi.V0044QosFlagsNOTSET),
+	string(slurmapi.V0044QosFlagsREMOVE),
+}
+
+// assocKey identifies an association.
+type assocKey struct {
+	Account   string
+	User      string
+	Partition string
+	Cluster   string
+	Parent    string
+}
+
+func normalizeList(items []string) []string {
+	if len(items) == 0 {
+		return nil
+	}
+	out := slices.Clone(items)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+func truncateMinutes(d *metav1.Duration) *metav1.Duration {
+	if d == nil {
+		return nil
+	}
+	return &metav1.Duration{Duration: d.Truncate(time.Minute)}
+}
+
+func toNoVal(v *int32) *slurmapi.V0044Uint32NoValStruct {
+	if v == nil {
+		// Infinite clears the limit.
+		return &slurmapi.V0044Uint32NoValStruct{
+			Infinite: ptr.To(true),
+		}
+	}
+	return &slurmapi.V0044Uint32NoValStruct{
+		Set:    ptr.To(true),
+		Number: ptr.To(*v),
+	}
+}
+
+func fromNoVal(v *slurmapi.V0044Uint32NoValStruct) *int32 {
+	if v == nil || ptr.Deref(v.Infinite, false) || !ptr.Deref(v.Set, false) {
+		return nil
+	}
+	return ptr.To(ptr.Deref(v.Number, 0))
+}
+
+func toMinutes(d *metav1.Duration) *slurmapi.V0044Uint32NoValStruct {
+	if d == nil {
+		return toNoVal(nil)
+	}
+	return toNoVal(ptr.To(int32(d.Minutes())))
+}
+
+func fromMinutes(v *slurmapi.V0044Uint32NoValStruct) *metav1.Duration {
+	minutes := fromNoVal(v)
+	if minutes == nil {
+		return nil
+	}
+	return &metav1.Duration{Duration: time.Duration(*minutes) * time.Minute}
+}
+
+// toTresList returns the desired TRES, clearing the observed TRES that are
+// no longer desired.
+func toTresList(desired, observed string) *slurmapi.V0044TresList {
+	want, _ := tresutils.Parse(desired)
+	have, _ := tresutils.Parse(observed)
+	list := slurmapi.V0044TresList{}
+	for _, key := range slices.Sorted(maps.Keys(want)) {
+		list = append(list, newTres(key, want[key]))
+	}
+	for _, key := range slices.Sorted(maps.Keys(have)) {
+		if _, ok := want[key]; !ok {
+			list = append(list, newTres(key, -1))
+		}
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return &list
+}
+
+func newTres(key string, count int64) slurmapi.V0044Tres {
+	tresType, tresName := tresutils.Split(key)
+	tres := slurmapi.V0044Tres{
+		Type:  tresType,
+		Count: ptr.To(count),
+	}
+	if tresName != "" {
+		tres.Name = ptr.To(tresName)
+	}
+	return tres
+}
+
+func fromTresList(list *slurmapi.V0044TresList) string {
+	if list == nil {
+		return ""
+	}
+	tres := make(map[string]int64, len(*list))
+	for _, t := range *list {
+		count := ptr.Deref(t.Count, -1)
+		if count < 0 {
+			continue
+		}
+		tres[tresutils.Key(t.Type, ptr.Deref(t.Name, ""))] = count
+	}
+	return tresutils.Format(tres)
+}
+
+// normalizeLimits returns the limits in the form they are observed as.
+func normalizeLimits(limits slinkyv1beta1.AssociationLimits) slinkyv1beta1.AssociationLimits {
+	out := *limits.DeepCopy()
+	out.GrpTRES = tresutils.Normalize(out.GrpTRES)
+	out.MaxTRESPerJob = tresutils.Normalize(out.MaxTRESPerJob)
+	out.MaxWallDurationPerJob = truncateMinutes(out.MaxWallDurationPerJob)
+	out.QOS = normalizeList(out.QOS)
+	return out
+}
+
+// maskLimits ignores the observed limits that are left to Slurm when unset.
+func maskLimits(desired, observed *slinkyv1beta1.AssociationLimits) {
+	if desired.Fairshare == nil {
+		observed.Fairshare = nil
+	}
+	if len(desired.QOS) == 0 {
+		observed.QOS = nil
+	}
+	if desired.DefaultQOS == "" {
+		observed.DefaultQOS = ""
+	}
+}
+
+func assocLimits(assoc *slurmapi.V0044Assoc) slinkyv1beta1.AssociationLimits {
+	limits := slinkyv1beta1.AssociationLimits{
+		Fairshare: assoc.SharesRaw,
+		QOS:       normalizeList(ptr.Deref(assoc.Qos, nil)),
+	}
+	if assoc.Default != nil {
+		limits.DefaultQOS = ptr.Deref(assoc.Default.Qos, "")
+	}
+	if m := assoc.Max; m != nil {
+		if jobs := m.Jobs; jobs != nil {
+			limits.MaxJobs = fromNoVal(jobs.Active)
+			limits.MaxSubmitJobs = fromNoVal(jobs.Total)
+			if per := jobs.Per; per != nil {
+				limits.GrpJobs = fromNoVal(per.Count)
+				limits.GrpSubmitJobs = fromNoVal(per.Submitted)
+				limits.MaxWallDurationPerJob = fromMinutes(per.WallClock)
+			}
+		}
+		if tres := m.Tres; tres != nil {
+			limits.GrpTRES = fromTresList(tres.Total)
+			if tres.Per != nil {
+				limits.MaxTRESPerJob = fromTresList(tres.Per.Job)
+			}
+		}
+	}
+	return limits
+}
+
+// newAssoc returns the association request for the desired limits.
+func newAssoc(key assocKey, desired, observed *slinkyv1beta1.AssociationLimits) slurmapi.V0044Assoc {
+	if observed == nil {
+		observed = &slinkyv1beta1.AssociationLimits{}
+	}
+	assoc := fromTemplate[slurmapi.V0044Assoc](assocTemplate)
+	assoc.Account = ptr.To(key.Account)
+	assoc.User = key.User
+	assoc.Cluster = ptr.To(key.Cluster)
+	if key.Partition != "" {
+		assoc.Partition = ptr.To(key.Partition)
+	}
+	if key.Parent != "" {
+		assoc.ParentAccount = ptr.To(key.Parent)
+	}
+	assoc.SharesRaw = desired.Fairshare
+	if len(desired.QOS) > 0 {
+		assoc.Qos = ptr.To(slices.Clone(desired.QOS))
+	}
+	if desired.DefaultQOS != "" {
+		assoc.Default.Qos = ptr.To(desired.DefaultQOS)
+	} else {
+		assoc.Default = nil
+	}
+	assoc.Max.Jobs.Active = toNoVal(desired.MaxJobs)
+	assoc.Max.Jobs.Total = toNoVal(desired.MaxSubmitJobs)
+	assoc.Max.Jobs.Per.Count = toNoVal(desired.GrpJobs)
+	assoc.Max.Jobs.Per.Submitted = toNoVal(desired.GrpSubmitJobs)
+	assoc.Max.Jobs.Per.WallClock = toMinutes(desired.MaxWallDurationPerJob)
+	assoc.Max.Tres.Total = toTresList(desired.GrpTRES, observed.GrpTRES)
+	assoc.Max.Tres.Per.Job = toTresList(desired.MaxTRESPerJob, observed.MaxTRESPerJob)
+	return assoc
+}
+
+// desiredAccount returns the SlurmAccount parameters in the form they are observed as.
+func desiredAccount(account *slinkyv1beta1.SlurmAccount) slinkyv1beta1.SlurmAccountParameters {
+	name := account.SlurmName()
+	return slinkyv1beta1.SlurmAccountParameters{
+		Description:   cmp.Or(account.Spec.Description, name),
+		Organization:  cmp.Or(account.Spec.Organization, name),
+		ParentAccount: account.ParentAccount(),
+		Limits:        normalizeLimits(account.Spec.Limits),
+	}
+}
+
+// observedAccount returns the parameters of the Slurm account and its
+// association on the cluster.
+func observedAccount(account *slurmapi.V0044Account, assoc *slurmapi.V0044Assoc) *slinkyv1beta1.SlurmAccountParameters {
+	params := &slinkyv1beta1.SlurmAccountParameters{
+		Description:  account.Description,
+		Organization: account.Organization,
+	}
+	if assoc != nil {
+		params.ParentAccount = ptr.Deref(assoc.ParentAccount, "")
+		params.Limits = assocLimits(assoc)
+	}
+	return params
+}
+
+// accountDrifted reports if the observed account differs from the desired one.
+func accountDrifted(desired, observed *slinkyv1beta1.SlurmAccountParameters) bool {
+	masked := observed.DeepCopy()
+	// Slurm may store the organization in lowercase.
+	if strings.EqualFold(desired.Organization, masked.Organization) {
+		masked.Organization = desired.Organization
+	}
+	maskLimits(&desired.Limits, &masked.Limits)
+	return !apiequality.Semantic.DeepEqual(*desired, *masked)
+}
+
+func newAccount(name string, desired *slinkyv1beta1.SlurmAccountParameters) slurmapi.V0044Account {
+	return slurmapi.V0044Account{
+		Name:         name,
+		Description:  desired.Description,
+		Organization: desired.Organization,
+	}
+}
+
+func sortAssociations(assocs []slinkyv1beta1.SlurmUserAssociation) {
+	slices.SortFunc(assocs, func(a, b slinkyv1beta1.SlurmUserAssociation) int {
+		return cmp.Or(cmp.Compare(a.Account, b.Account), cmp.Compare(a.Partition, b.Partition))
+	})
+}
+
+func findAssociation(assocs []slinkyv1beta1.SlurmUserAssociation, account, partition string) *slinkyv1beta1.SlurmUserAssociation {
+	for i := range assocs {
+		if assocs[i].Account == account && assocs[i].Partition == partition {
+			return &assocs[i]
+		}
+	}
+	return nil
+}
+
+// desiredUser returns the SlurmUser parameters in the form they are observed as.
+func desiredUser(user *slinkyv1beta1.SlurmUser) slinkyv1beta1.SlurmUserParameters {
+	params := slinkyv1beta1.SlurmUserParameters{
+		DefaultAccount: user.DefaultAccount(),
+		AdminLevel:     user.AdminLevel(),
+	}
+	for _, assoc := range user.Spec.Associations {
+		params.Associations = append(params.Associations, slinkyv1beta1.SlurmUserAssociation{
+			Account:   assoc.Account,
+			Partition: assoc.Partition,
+			Limits:    normalizeLimits(assoc.Limits),
+		})
+	}
+	sortAssociations(params.Associations)
+	return params
+}
+
+// observedUser returns the parameters of the Slurm user and its associations
+// on the cluster.
+func observedUser(user *slurmapi.V0044User, assocs []slurmapi.V0044Assoc) *slinkyv1beta1.SlurmUserParameters {
+	params := &slinkyv1beta1.SlurmUserParameters{
+		AdminLevel: slinkyv1beta1.SlurmAdminLevelNone,
+	}
+	if user.Default != nil {
+		params.DefaultAccount = ptr.Deref(user.Default.Account, "")
+	}
+	for _, level := range ptr.Deref(user.AdministratorLevel, nil) {
+		switch level {
+		case slurmapi.V0044UserAdministratorLevelOperator:
+			params.AdminLevel = slinkyv1beta1.SlurmAdminLevelOperator
+		case slurmapi.V0044UserAdministratorLevelAdministrator:
+			params.AdminLevel = slinkyv1beta1.SlurmAdminLevelAdministrator
+		}
+	}
+	for i := range assocs {
+		params.Associations = append(params.Associations, slinkyv1beta1.SlurmUserAssociation{
+			Account:   ptr.Deref(assocs[i].Account, ""),
+			Partition: ptr.Deref(assocs[i].Partition, ""),
+			Limits:    assocLimits(&assocs[i]),
+		})
+	}
+	sortAssociations(params.Associations)
+	return params
+}
+
+// userDrifted reports if the observed user differs from the desired one.
+func userDrifted(desired, observed *slinkyv1beta1.SlurmUserParameters) bool {
+	masked := observed.DeepCopy()
+	if desired.DefaultAccount == "" {
+		masked.DefaultAccount = ""
+	}
+	for i := range masked.Associations {
+		assoc := &masked.Associations[i]
+		if want := findAssociation(desired.Associations, assoc.Account, assoc.Partition); want != nil {
+			maskLimits(&want.Limits, &assoc.Limits)
+		}
+	}
+	return !apiequality.Semantic.DeepEqual(*desired, *masked)
+}
+
+func newUser(name string, desired *slinkyv1beta1.SlurmUserParameters) slurmapi.V0044User {
+	user := fromTemplate[slurmapi.V0044User](userTemplate)
+	user.Name = name
+	user.AdministratorLevel = &[]slurmapi.V0044UserAdministratorLevel{
+		slurmapi.V0044UserAdministratorLevel(desired.AdminLevel),
+	}
+	if desired.DefaultAccount != "" {
+		user.Default.Account = ptr.To(desired.DefaultAccount)
+	} else {
+		user.Default = nil
+	}
+	return user
+}
+
+// newUserAddCond returns the request to create the user with its first association.
+func newUserAddCond(name, cluster string, desired *slinkyv1beta1.SlurmUserParameters) slurmapi.V0044OpenapiUsersAddCondResp {
+	assoc := desired.Associations[0]
+	body := slurmapi.V0044OpenapiUsersAddCondResp{
+		AssociationCondition: slurmapi.V0044UsersAddCond{
+			Users:    []string{name},
+			Accounts: ptr.To([]string{assoc.Account}),
+			Clusters: ptr.To([]string{cluster}),
+		},
+		User: slurmapi.V0044UserShort{
+			Defaultaccount: ptr.To(cmp.Or(desired.DefaultAccount, assoc.Account)),
+		},
+	}
+	if assoc.Partition != "" {
+		body.AssociationCondition.Partitions = ptr.To([]string{assoc.Partition})
+	}
+	return body
+}
+
+// desiredQOS returns the SlurmQOS parameters in the form they are observed as.
+func desiredQOS(qos *slinkyv1beta1.SlurmQOS) slinkyv1beta1.SlurmQOSParameters {
+	params := *qos.Spec.SlurmQOSParameters.DeepCopy()
+	params.Description = cmp.Or(params.Description, qos.SlurmName())
+	params.Priority = ptr.To(ptr.Deref(params.Priority, 0))
+	params.Flags = normalizeList(params.Flags)
+	params.PreemptMode = normalizeList(params.PreemptMode)
+	params.Preempt = normalizeList(params.Preempt)
+	params.GrpTRES = tresutils.Normalize(params.GrpTRES)
+	params.MaxTRESPerJob = tresutils.Normalize(params.MaxTRESPerJob)
+	params.MaxTRESPerNode = tresutils.Normalize(params.MaxTRESPerNode)
+	params.MaxTRESPerUser = tresutils.Normalize(params.MaxTRESPerUser)
+	params.MaxWallDurationPerJob = truncateMinutes(params.MaxWallDurationPerJob)
+	return params
+}
+
+// observedQOS returns the parameters of the Slurm QOS.
+func observedQOS(qos *slurmapi.V0044Qos) *slinkyv1beta1.SlurmQOSParameters {
+	params := &slinkyv1beta1.SlurmQOSParameters{
+		Description: ptr.Deref(qos.Description, ""),
+		Priority:    ptr.To(ptr.Deref(fromNoVal(qos.Priority), 0)),
+	}
+	flags := []string{}
+	for _, flag := range ptr.Deref(qos.Flags, nil) {
+		if !slices.Contains(ignoredQOSFlags, string(flag)) {
+			flags = append(flags, string(flag))
+		}
+	}
+	params.Flags = normalizeList(flags)
+	if preempt := qos.Preempt; preempt != nil {
+		params.Preempt = normalizeList(ptr.Deref(preempt.List, nil))
+		modes := []string{}
+		for _, mode := range ptr.Deref(preempt.Mode, nil) {
+			modes = append(modes, string(mode))
+		}
+		params.PreemptMode = normalizeList(modes)
+	}
+	if qos.Limits == nil || qos.Limits.Max == nil {
+		return params
+	}
+	m := qos.Limits.Max
+	if m.ActiveJobs != nil {
+		params.GrpJobs = fromNoVal(m.ActiveJobs.Count)
+	}
+	if jobs := m.Jobs; jobs != nil {
+		params.GrpSubmitJobs = fromNoVal(jobs.Count)
+		if jobs.ActiveJobs != nil && jobs.ActiveJobs.Per != nil {
+			params.MaxJobsPerUser = fromNoVal(jobs.ActiveJobs.Per.User)
+			params.MaxJobsPerAccount = fromNoVal(jobs.ActiveJobs.Per.Account)
+		}
+		if jobs.Per != nil {
+			params.MaxSubmitJobsPerUser = fromNoVal(jobs.Per.User)
+		}
+	}
+	if tres := m.Tres; tres != nil {
+		params.GrpTRES = fromTresList(tres.Total)
+		if tres.Per != nil {
+			params.MaxTRESPerJob = fromTresList(tres.Per.Job)
+			params.MaxTRESPerNode = fromTresList(tres.Per.Node)
+			params.MaxTRESPerUser = fromTresList(tres.Per.User)
+		}
+	}
+	if m.WallClock != nil && m.WallClock.Per != nil {
+		params.MaxWallDurationPerJob = fromMinutes(m.WallClock.Per.Job)
+	}
+	return params
+}
+
+// qosDrifted reports if the observed QOS differs from the desired one.
+func qosDrifted(desired, observed *slinkyv1beta1.SlurmQOSParameters) bool {
+	masked := observed.DeepCopy()
+	if len(desired.PreemptMode) == 0 {
+		masked.PreemptMode = nil
+	}
+	return !apiequality.Semantic.DeepEqual(*desired, *masked)
+}
+
+// newQOS returns the QOS request for the desired parameters.
+func newQOS(name string, desired, observed *slinkyv1beta1.SlurmQOSParameters) slurmapi.V0044Qos {
+	if observed == nil {
+		observed = &slinkyv1beta1.SlurmQOSParameters{}
+	}
+	qos := fromTemplate[slurmapi.V0044Qos](qosTemplate)
+	qos.Name = ptr.To(name)
+	qos.Description = ptr.To(desired.Description)
+	qos.Priority = toNoVal(desired.Priority)
+	flags := []slurmapi.V0044QosFlags{}
+	for _, flag := range desired.Flags {
+		flags = append(flags, slurmapi.V0044QosFlags(flag))
+	}
+	qos.Flags = &flags
+	qos.Preempt.List = ptr.To(slices.Clone(desired.Preempt))
+	if len(desired.PreemptMode) > 0 {
+		modes := []slurmapi.V0044QosPreemptMode{}
+		for _, mode := range desired.PreemptMode {
+			modes = append(modes, slurmapi.V0044QosPreemptMode(mode))
+		}
+		qos.Preempt.Mode = &modes
+	}
+	m := qos.Limits.Max
+	m.ActiveJobs.Count = toNoVal(desired.GrpJobs)
+	m.Jobs.Count = toNoVal(desired.GrpSubmitJobs)
+	m.Jobs.ActiveJobs.Per.User = toNoVal(desired.MaxJobsPerUser)
+	m.Jobs.ActiveJobs.Per.Account = toNoVal(desired.MaxJobsPerAccount)
+	m.Jobs.Per.User = toNoVal(desired.MaxSubmitJobsPerUser)
+	m.Tres.Total = toTresList(desired.GrpTRES, observed.GrpTRES)
+	m.Tres.Per.Job = toTresList(desired.MaxTRESPerJob, observed.MaxTRESPerJob)
+	m.Tres.Per.Node = toTresList(desired.MaxTRESPerNode, observed.MaxTRESPerNode)
+	m.Tres.Per.User = toTresList(desired.MaxTRESPerUser, observed.MaxTRESPerUser)
+	m.WallClock.Per.Job = toMinutes(desired.MaxWallDurationPerJob)
+	return qos
+}
@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_toTresList(t *testing.T) {
+	type args struct {
+		desired  string
+		observed string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *slurmapi.V0044TresList
+	}{
+		{
+			name: "Empty",
+			args: args{},
+			want: nil,
+		},
+		{
+			name: "Desired",
+			args: args{
+				desired: "cpu=4,gres/gpu=1",
+			},
+			want: &slurmapi.V0044TresList{
+				{Type: "cpu", Count: ptr.To[int64](4)},
+				{Type: "gres", Name: ptr.To("gpu"), Count: ptr.To[int64](1)},
+			},
+		},
+		{
+			name: "Clear observed",
+			args: args{
+				desired:  "cpu=4",
+				observed: "cpu=2,mem=1024",
+			},
+			want: &slurmapi.V0044TresList{
+				{Type: "cpu", Count: ptr.To[int64](4)},
+				{Type: "mem", Count: ptr.To[int64](-1)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toTresList(tt.args.desired, tt.args.observed)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("toTresList() (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_newAssoc(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired slinkyv1beta1.AssociationLimits
+	}{
+		{
+			name:    "Empty",
+			desired: slinkyv1beta1.AssociationLimits{},
+		},
+		{
+			name: "Limits",
+			desired: slinkyv1beta1.AssociationLimits{
+				Fairshare:             ptr.To[int32](10),
+				GrpJobs:               ptr.To[int32](100),
+				GrpSubmitJobs:         ptr.To[int32](200),
+				GrpTRES:               "mem=1G,cpu=64",
+				MaxJobs:               ptr.To[int32](10),
+				MaxSubmitJobs:         ptr.To[int32](20),
+				MaxTRESPerJob:         "gres/gpu=8",
+				MaxWallDurationPerJob: &metav1.Duration{Duration: 90 * time.Minute},
+				QOS:                   []string{"normal", "high"},
+				DefaultQOS:            "normal",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assoc := newAssoc(assocKey{Account: "foo", Cluster: "slurm"}, &tt.desired, nil)
+			want := normalizeLimits(tt.desired)
+			got := assocLimits(&assoc)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("assocLimits(newAssoc()) (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_accountDrifted(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	account := testutils.NewSlurmAccount("foo", controller)
+	account.Spec.Organization = "Physics"
+	account.Spec.Limits.GrpTRES = "mem=1G"
+	tests := []struct {
+		name     string
+		observed *slinkyv1beta1.SlurmAccountParameters
+		want     bool
+	}{
+		{
+			name: "In sync",
+			observed: &slinkyv1beta1.SlurmAccountParameters{
+				Description:   "foo",
+				Organization:  "physics",
+				ParentAccount: "root",
+				Limits: slinkyv1beta1.AssociationLimits{
+					Fairshare: ptr.To[int32](1),
+					GrpTRES:   "mem=1G",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Parent changed",
+			observed: &slinkyv1beta1.SlurmAccountParameters{
+				Description:   "foo",
+				Organization:  "physics",
+				ParentAccount: "bar",
+				Limits: slinkyv1beta1.AssociationLimits{
+					GrpTRES: "mem=1G",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Limit added",
+			observed: &slinkyv1beta1.SlurmAccountParameters{
+				Description:   "foo",
+				Organization:  "physics",
+				ParentAccount: "root",
+				Limits: slinkyv1beta1.AssociationLimits{
+					GrpTRES: "mem=1G",
+					MaxJobs: ptr.To[int32](1),
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := desiredAccount(account)
+			if got := accountDrifted(&desired, tt.observed); got != tt.want {
+				t.Errorf("accountDrifted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_userDrifted(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	user := testutils.NewSlurmUser("foo", controller, "physics", "chemistry")
+	tests := []struct {
+		name     string
+		observed *slinkyv1beta1.SlurmUserParameters
+		want     bool
+	}{
+		{
+			name: "In sync",
+			observed: &slinkyv1beta1.SlurmUserParameters{
+				DefaultAccount: "physics",
+				AdminLevel:     slinkyv1beta1.SlurmAdminLevelNone,
+				Associations: []slinkyv1beta1.SlurmUserAssociation{
+					{Account: "chemistry", Limits: slinkyv1beta1.AssociationLimits{QOS: []string{"normal"}}},
+					{Account: "physics"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Association removed",
+			observed: &slinkyv1beta1.SlurmUserParameters{
+				DefaultAccount: "physics",
+				AdminLevel:     slinkyv1beta1.SlurmAdminLevelNone,
+				Associations: []slinkyv1beta1.SlurmUserAssociation{
+					{Account: "physics"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Admin level changed",
+			observed: &slinkyv1beta1.SlurmUserParameters{
+				DefaultAccount: "physics",
+				AdminLevel:     slinkyv1beta1.SlurmAdminLevelOperator,
+				Associations: []slinkyv1beta1.SlurmUserAssociation{
+					{Account: "chemistry"},
+					{Account: "physics"},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := desiredUser(user)
+			if got := userDrifted(&desired, tt.observed); got != tt.want {
+				t.Errorf("userDrifted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newQOS(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	empty := testutils.NewSlurmQOS("foo", controller)
+	limited := testutils.NewSlurmQOS("bar", controller)
+	limited.Spec.SlurmQOSParameters = slinkyv1beta1.SlurmQOSParameters{
+		Description:           "Limited",
+		Priority:              ptr.To[int32](100),
+		Flags:                 []string{"DENY_LIMIT"},
+		PreemptMode:           []string{"REQUEUE"},
+		Preempt:               []string{"low"},
+		GrpJobs:               ptr.To[int32](10),
+		GrpSubmitJobs:         ptr.To[int32](20),
+		GrpTRES:               "cpu=128",
+		MaxJobsPerUser:        ptr.To[int32](2),
+		MaxJobsPerAccount:     ptr.To[int32](4),
+		MaxSubmitJobsPerUser:  ptr.To[int32](8),
+		MaxTRESPerJob:         "gres/gpu=8",
+		MaxTRESPerNode:        "mem=512G",
+		MaxTRESPerUser:        "cpu=64",
+		MaxWallDurationPerJob: &metav1.Duration{Duration: 24 * time.Hour},
+	}
+	tests := []struct {
+		name string
+		qos  *slinkyv1beta1.SlurmQOS
+	}{
+		{
+			name: "Empty",
+			qos:  empty,
+		},
+		{
+			name: "Limits",
+			qos:  limited,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := desiredQOS(tt.qos)
+			qos := newQOS(tt.qos.SlurmName(), &desired, nil)
+			got := observedQOS(&qos)
+			if qosDrifted(&desired, got) {
+				t.Errorf("observedQOS(newQOS()) drifted (-want,+got):\n%s", cmp.Diff(desired, *got))
+			}
+		})
+	}
+}
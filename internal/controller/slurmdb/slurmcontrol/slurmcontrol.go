@@ -0,0 +1,368 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+)
+
+// ErrNoClient is returned when there is no Slurm client for the Controller.
+var ErrNoClient = errors.New("no slurm client for controller")
+
+// SyncResult describes the changes made to a Slurm database record.
+type SyncResult struct {
+	// Created is true when the record did not exist.
+	Created bool
+	// Drifted is true when the record existed, but differed from the spec.
+	Drifted bool
+}
+
+type SlurmControlInterface interface {
+	// SyncAccount creates or corrects the Slurm account, and its association on the cluster.
+	// It returns the observed Slurm account.
+	SyncAccount(ctx context.Context, controllerKey types.NamespacedName, cluster string, account *slinkyv1beta1.SlurmAccount) (*slinkyv1beta1.SlurmAccountParameters, SyncResult, error)
+	// DeleteAccount deletes the Slurm account.
+	DeleteAccount(ctx context.Context, controllerKey types.NamespacedName, account *slinkyv1beta1.SlurmAccount) error
+	// SyncUser creates or corrects the Slurm user, and its associations on the cluster.
+	// It returns the observed Slurm user.
+	SyncUser(ctx context.Context, controllerKey types.NamespacedName, cluster string, user *slinkyv1beta1.SlurmUser) (*slinkyv1beta1.SlurmUserParameters, SyncResult, error)
+	// DeleteUser deletes the Slurm user.
+	DeleteUser(ctx context.Context, controllerKey types.NamespacedName, user *slinkyv1beta1.SlurmUser) error
+	// SyncQOS creates or corrects the Slurm QOS.
+	// It returns the observed Slurm QOS.
+	SyncQOS(ctx context.Context, controllerKey types.NamespacedName, qos *slinkyv1beta1.SlurmQOS) (*slinkyv1beta1.SlurmQOSParameters, SyncResult, error)
+	// DeleteQOS deletes the Slurm QOS.
+	DeleteQOS(ctx context.Context, controllerKey types.NamespacedName, qos *slinkyv1beta1.SlurmQOS) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+// readAccount returns the Slurm account and its association on the cluster,
+// or nil if the account does not exist.
+func readAccount(
+	ctx context.Context,
+	slurmClient slurmclient.Client,
+	httpClient *http.Client,
+	cluster, name string,
+) (*slinkyv1beta1.SlurmAccountParameters, error) {
+	account, err := getAccount(ctx, slurmClient, httpClient, name)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	assocs, err := getAssociations(ctx, slurmClient, httpClient, slurmapi.SlurmdbV0044GetAssociationsParams{
+		Account: ptr.To(name),
+		Cluster: ptr.To(cluster),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range assocs {
+		if assocs[i].User == "" && ptr.Deref(assocs[i].Partition, "") == "" {
+			return observedAccount(account, &assocs[i]), nil
+		}
+	}
+	return observedAccount(account, nil), nil
+}
+
+// SyncAccount implements SlurmControlInterface.
+func (r *realSlurmControl) SyncAccount(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	cluster string,
+	account *slinkyv1beta1.SlurmAccount,
+) (*slinkyv1beta1.SlurmAccountParameters, SyncResult, error) {
+	logger := log.FromContext(ctx)
+	result := SyncResult{}
+
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		return nil, result, ErrNoClient
+	}
+	httpClient := r.clientMap.GetHTTPClient(controllerKey)
+
+	name := account.SlurmName()
+	observed, err := readAccount(ctx, slurmClient, httpClient, cluster, name)
+	if err != nil {
+		return nil, result, err
+	}
+	desired := desiredAccount(account)
+	if observed != nil && !accountDrifted(&desired, observed) {
+		return observed, result, nil
+	}
+	result.Created = observed == nil
+	result.Drifted = observed != nil
+
+	logger.V(1).Info("Syncing Slurm account",
+		"account", name, "cluster", cluster, "created", result.Created)
+	if err := postAccount(ctx, slurmClient, httpClient, newAccount(name, &desired)); err != nil {
+		return observed, result, err
+	}
+	key := assocKey{
+		Account: name,
+		Cluster: cluster,
+		Parent:  desired.ParentAccount,
+	}
+	var observedLimits *slinkyv1beta1.AssociationLimits
+	if observed != nil {
+		observedLimits = &observed.Limits
+	}
+	assoc := newAssoc(key, &desired.Limits, observedLimits)
+	if err := postAssociations(ctx, slurmClient, httpClient, []slurmapi.V0044Assoc{assoc}); err != nil {
+		return observed, result, err
+	}
+
+	observed, err = readAccount(ctx, slurmClient, httpClient, cluster, name)
+	return observed, result, err
+}
+
+// DeleteAccount implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteAccount(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	account *slinkyv1beta1.SlurmAccount,
+) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		return ErrNoClient
+	}
+	httpClient := r.clientMap.GetHTTPClient(controllerKey)
+
+	name := account.SlurmName()
+	exists, err := getAccount(ctx, slurmClient, httpClient, name)
+	if err != nil || exists == nil {
+		return err
+	}
+
+	logger.V(1).Info("Deleting Slurm account", "account", name)
+	return deleteAccount(ctx, slurmClient, httpClient, name)
+}
+
+// readUser returns the Slurm user and its associations on the cluster, or nil
+// if the user does not exist.
+func readUser(
+	ctx context.Context,
+	slurmClient slurmclient.Client,
+	httpClient *http.Client,
+	cluster, name string,
+) (*slinkyv1beta1.SlurmUserParameters, error) {
+	user, err := getUser(ctx, slurmClient, httpClient, name)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	assocs, err := getAssociations(ctx, slurmClient, httpClient, slurmapi.SlurmdbV0044GetAssociationsParams{
+		User:    ptr.To(name),
+		Cluster: ptr.To(cluster),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return observedUser(user, assocs), nil
+}
+
+// SyncUser implements SlurmControlInterface.
+func (r *realSlurmControl) SyncUser(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	cluster string,
+	user *slinkyv1beta1.SlurmUser,
+) (*slinkyv1beta1.SlurmUserParameters, SyncResult, error) {
+	logger := log.FromContext(ctx)
+	result := SyncResult{}
+
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		return nil, result, ErrNoClient
+	}
+	httpClient := r.clientMap.GetHTTPClient(controllerKey)
+
+	name := user.SlurmName()
+	observed, err := readUser(ctx, slurmClient, httpClient, cluster, name)
+	if err != nil {
+		return nil, result, err
+	}
+	desired := desiredUser(user)
+	if observed != nil && !userDrifted(&desired, observed) {
+		return observed, result, nil
+	}
+	result.Created = observed == nil
+	result.Drifted = observed != nil
+
+	logger.V(1).Info("Syncing Slurm user",
+		"user", name, "cluster", cluster, "created", result.Created)
+	if observed == nil {
+		if len(desired.Associations) == 0 {
+			return nil, result, errors.New("a Slurm user cannot be created without an association")
+		}
+		if err := postUserAssociation(ctx, slurmClient, httpClient, newUserAddCond(name, cluster, &desired)); err != nil {
+			return nil, result, err
+		}
+		observed = &slinkyv1beta1.SlurmUserParameters{}
+	}
+
+	assocs := make([]slurmapi.V0044Assoc, 0, len(desired.Associations))
+	for _, want := range desired.Associations {
+		key := assocKey{
+			Account:   want.Account,
+			User:      name,
+			Partition: want.Partition,
+			Cluster:   cluster,
+		}
+		var observedLimits *slinkyv1beta1.AssociationLimits
+		if have := findAssociation(observed.Associations, want.Account, want.Partition); have != nil {
+			observedLimits = &have.Limits
+		}
+		assocs = append(assocs, newAssoc(key, &want.Limits, observedLimits))
+	}
+	if len(assocs) > 0 {
+		if err := postAssociations(ctx, slurmClient, httpClient, assocs); err != nil {
+			return observed, result, err
+		}
+	}
+	// The default account must be associated before it is set.
+	if err := postUser(ctx, slurmClient, httpClient, newUser(name, &desired)); err != nil {
+		return observed, result, err
+	}
+	for _, have := range observed.Associations {
+		if findAssociation(desired.Associations, have.Account, have.Partition) != nil {
+			continue
+		}
+		logger.V(1).Info("Deleting Slurm user association",
+			"user", name, "account", have.Account, "partition", have.Partition)
+		key := assocKey{
+			Account:   have.Account,
+			User:      name,
+			Partition: have.Partition,
+			Cluster:   cluster,
+		}
+		if err := deleteAssociation(ctx, slurmClient, httpClient, key); err != nil {
+			return observed, result, err
+		}
+	}
+
+	observed, err = readUser(ctx, slurmClient, httpClient, cluster, name)
+	return observed, result, err
+}
+
+// DeleteUser implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteUser(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	user *slinkyv1beta1.SlurmUser,
+) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		return ErrNoClient
+	}
+	httpClient := r.clientMap.GetHTTPClient(controllerKey)
+
+	name := user.SlurmName()
+	exists, err := getUser(ctx, slurmClient, httpClient, name)
+	if err != nil || exists == nil {
+		return err
+	}
+
+	logger.V(1).Info("Deleting Slurm user", "user", name)
+	return deleteUser(ctx, slurmClient, httpClient, name)
+}
+
+// readQOS returns the Slurm QOS, or nil if it does not exist.
+func readQOS(
+	ctx context.Context,
+	slurmClient slurmclient.Client,
+	httpClient *http.Client,
+	name string,
+) (*slinkyv1beta1.SlurmQOSParameters, error) {
+	qos, err := getQOS(ctx, slurmClient, httpClient, name)
+	if err != nil || qos == nil {
+		return nil, err
+	}
+	return observedQOS(qos), nil
+}
+
+// SyncQOS implements SlurmControlInterface.
+func (r *realSlurmControl) SyncQOS(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	qos *slinkyv1beta1.SlurmQOS,
+) (*slinkyv1beta1.SlurmQOSParameters, SyncResult, error) {
+	logger := log.FromContext(ctx)
+	result := SyncResult{}
+
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		return nil, result, ErrNoClient
+	}
+	httpClient := r.clientMap.GetHTTPClient(controllerKey)
+
+	name := qos.SlurmName()
+	observed, err := readQOS(ctx, slurmClient, httpClient, name)
+	if err != nil {
+		return nil, result, err
+	}
+	desired := desiredQOS(qos)
+	if observed != nil && !qosDrifted(&desired, observed) {
+		return observed, result, nil
+	}
+	result.Created = observed == nil
+	result.Drifted = observed != nil
+
+	logger.V(1).Info("Syncing Slurm QOS", "qos", name, "created", result.Created)
+	if err := postQOS(ctx, slurmClient, httpClient, newQOS(name, &desired, observed)); err != nil {
+		return observed, result, err
+	}
+
+	observed, err = readQOS(ctx, slurmClient, httpClient, name)
+	return observed, result, err
+}
+
+// DeleteQOS implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteQOS(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	qos *slinkyv1beta1.SlurmQOS,
+) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		return ErrNoClient
+	}
+	httpClient := r.clientMap.GetHTTPClient(controllerKey)
+
+	name := qos.SlurmName()
+	exists, err := getQOS(ctx, slurmClient, httpClient, name)
+	if err != nil || exists == nil {
+		return err
+	}
+
+	logger.V(1).Info("Deleting Slurm QOS", "qos", name)
+	return deleteQOS(ctx, slurmClient, httpClient, name)
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clusters *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clusters,
+	}
+}
@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var controllerKey = types.NamespacedName{
+	Namespace: corev1.NamespaceDefault,
+	Name:      "slurm",
+}
+
+func newSlurmClientMap(client slurmclient.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	cm.Add(controllerKey, client)
+	return cm
+}
+
+func Test_realSlurmControl_SyncAccount(t *testing.T) {
+	ctx := context.Background()
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	account := testutils.NewSlurmAccount("foo", controller)
+	inSync := &slurmapi.V0044Account{Name: "foo", Description: "foo", Organization: "foo"}
+	drifted := &slurmapi.V0044Account{Name: "foo", Description: "bar", Organization: "foo"}
+	rootAssoc := slurmapi.V0044Assoc{Account: ptr.To("foo"), ParentAccount: ptr.To("root")}
+	tests := []struct {
+		name      string
+		clientMap *clientmap.ClientMap
+		observed  *slurmapi.V0044Account
+		getErr    error
+		postErr   error
+		want      SyncResult
+		wantPost  bool
+		wantErr   bool
+	}{
+		{
+			name:      "No client",
+			clientMap: clientmap.NewClientMap(),
+			wantErr:   true,
+		},
+		{
+			name:      "Create",
+			clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			want:      SyncResult{Created: true},
+			wantPost:  true,
+		},
+		{
+			name:      "In sync",
+			clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			observed:  inSync,
+			want:      SyncResult{},
+			wantPost:  false,
+		},
+		{
+			name:      "Drifted",
+			clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			observed:  drifted,
+			want:      SyncResult{Drifted: true},
+			wantPost:  true,
+		},
+		{
+			name:      "Get error",
+			clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			getErr:    errors.New(http.StatusText(http.StatusInternalServerError)),
+			wantErr:   true,
+		},
+		{
+			name:      "Post error",
+			clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			postErr:   errors.New(http.StatusText(http.StatusInternalServerError)),
+			want:      SyncResult{Created: true},
+			wantPost:  true,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getAccountFn, postAccountFn := getAccount, postAccount
+			getAssociationsFn, postAssociationsFn := getAssociations, postAssociations
+			defer func() {
+				getAccount, postAccount = getAccountFn, postAccountFn
+				getAssociations, postAssociations = getAssociationsFn, postAssociationsFn
+			}()
+			getAccount = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ string) (*slurmapi.V0044Account, error) {
+				return tt.observed, tt.getErr
+			}
+			getAssociations = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ slurmapi.SlurmdbV0044GetAssociationsParams) ([]slurmapi.V0044Assoc, error) {
+				return []slurmapi.V0044Assoc{rootAssoc}, nil
+			}
+			posted := false
+			postAccount = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ slurmapi.V0044Account) error {
+				posted = true
+				return tt.postErr
+			}
+			postAssociations = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ []slurmapi.V0044Assoc) error {
+				return nil
+			}
+			r := &realSlurmControl{
+				clientMap: tt.clientMap,
+			}
+			_, got, err := r.SyncAccount(ctx, controllerKey, "slurm", account)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.SyncAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("realSlurmControl.SyncAccount() result = %v, want %v", got, tt.want)
+			}
+			if posted != tt.wantPost {
+				t.Errorf("realSlurmControl.SyncAccount() posted = %v, wantPost %v", posted, tt.wantPost)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_SyncUser(t *testing.T) {
+	ctx := context.Background()
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	user := testutils.NewSlurmUser("foo", controller, "physics")
+	observed := fromTemplate[slurmapi.V0044User](userTemplate)
+	observed.Name = "foo"
+	observed.Default.Account = ptr.To("physics")
+	tests := []struct {
+		name       string
+		observed   *slurmapi.V0044User
+		assocs     []slurmapi.V0044Assoc
+		want       SyncResult
+		wantAdd    bool
+		wantDelete []string
+	}{
+		{
+			name:    "Create",
+			want:    SyncResult{Created: true},
+			wantAdd: true,
+		},
+		{
+			name:     "In sync",
+			observed: &observed,
+			assocs: []slurmapi.V0044Assoc{
+				{Account: ptr.To("physics"), User: "foo"},
+			},
+			want: SyncResult{},
+		},
+		{
+			name:     "Stale association",
+			observed: &observed,
+			assocs: []slurmapi.V0044Assoc{
+				{Account: ptr.To("chemistry"), User: "foo"},
+				{Account: ptr.To("physics"), User: "foo"},
+			},
+			want:       SyncResult{Drifted: true},
+			wantDelete: []string{"chemistry"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getUserFn, postUserFn, postUserAssociationFn := getUser, postUser, postUserAssociation
+			getAssociationsFn, postAssociationsFn, deleteAssociationFn := getAssociations, postAssociations, deleteAssociation
+			defer func() {
+				getUser, postUser, postUserAssociation = getUserFn, postUserFn, postUserAssociationFn
+				getAssociations, postAssociations, deleteAssociation = getAssociationsFn, postAssociationsFn, deleteAssociationFn
+			}()
+			getUser = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ string) (*slurmapi.V0044User, error) {
+				return tt.observed, nil
+			}
+			getAssociations = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ slurmapi.SlurmdbV0044GetAssociationsParams) ([]slurmapi.V0044Assoc, error) {
+				return tt.assocs, nil
+			}
+			added := false
+			postUserAssociation = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ slurmapi.V0044OpenapiUsersAddCondResp) error {
+				added = true
+				return nil
+			}
+			postAssociations = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ []slurmapi.V0044Assoc) error {
+				return nil
+			}
+			postUser = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ slurmapi.V0044User) error {
+				return nil
+			}
+			deleted := []string{}
+			deleteAssociation = func(_ context.Context, _ slurmclient.Client, _ *http.Client, key assocKey) error {
+				deleted = append(deleted, key.Account)
+				return nil
+			}
+			r := &realSlurmControl{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			}
+			_, got, err := r.SyncUser(ctx, controllerKey, "slurm", user)
+			if err != nil {
+				t.Errorf("realSlurmControl.SyncUser() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("realSlurmControl.SyncUser() result = %v, want %v", got, tt.want)
+			}
+			if added != tt.wantAdd {
+				t.Errorf("realSlurmControl.SyncUser() added = %v, wantAdd %v", added, tt.wantAdd)
+			}
+			if len(deleted) != len(tt.wantDelete) {
+				t.Errorf("realSlurmControl.SyncUser() deleted = %v, wantDelete %v", deleted, tt.wantDelete)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_DeleteQOS(t *testing.T) {
+	ctx := context.Background()
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	qos := testutils.NewSlurmQOS("foo", controller)
+	tests := []struct {
+		name       string
+		clientMap  *clientmap.ClientMap
+		exists     bool
+		wantDelete bool
+		wantErr    bool
+	}{
+		{
+			name:      "No client",
+			clientMap: clientmap.NewClientMap(),
+			wantErr:   true,
+		},
+		{
+			name:       "Exists",
+			clientMap:  newSlurmClientMap(fake.NewFakeClient()),
+			exists:     true,
+			wantDelete: true,
+		},
+		{
+			name:       "Not found",
+			clientMap:  newSlurmClientMap(fake.NewFakeClient()),
+			exists:     false,
+			wantDelete: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getQOSFn, deleteQOSFn := getQOS, deleteQOS
+			defer func() { getQOS, deleteQOS = getQOSFn, deleteQOSFn }()
+			getQOS = func(_ context.Context, _ slurmclient.Client, _ *http.Client, name string) (*slurmapi.V0044Qos, error) {
+				if !tt.exists {
+					return nil, nil
+				}
+				return &slurmapi.V0044Qos{Name: ptr.To(name)}, nil
+			}
+			deleted := false
+			deleteQOS = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ string) error {
+				deleted = true
+				return nil
+			}
+			r := &realSlurmControl{
+				clientMap: tt.clientMap,
+			}
+			err := r.DeleteQOS(ctx, controllerKey, qos)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.DeleteQOS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if deleted != tt.wantDelete {
+				t.Errorf("realSlurmControl.DeleteQOS() deleted = %v, wantDelete %v", deleted, tt.wantDelete)
+			}
+		})
+	}
+}
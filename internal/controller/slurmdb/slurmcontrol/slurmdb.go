@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmapiclient "github.com/SlinkyProject/slurm-client/pkg/client/api/v0044"
+)
+
+// getAccount returns the account through slurmrestd, or nil if it does not exist.
+var getAccount = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, name string) (*slurmapi.V0044Account, error) {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.SlurmdbV0044GetAccountWithResponse(ctx, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case res.StatusCode() == http.StatusNotFound:
+		return nil, nil
+	case res.StatusCode() != http.StatusOK || res.JSON200 == nil:
+		return nil, errors.New(http.StatusText(res.StatusCode()))
+	case len(res.JSON200.Accounts) == 0:
+		return nil, nil
+	}
+	return &res.JSON200.Accounts[0], nil
+}
+
+// postAccount creates or updates the account through slurmrestd.
+var postAccount = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, account slurmapi.V0044Account) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	body := slurmapi.V0044OpenapiAccountsResp{
+		Accounts: []slurmapi.V0044Account{account},
+	}
+	res, err := client.SlurmdbV0044PostAccountsWithResponse(ctx, body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
+// deleteAccount deletes the account, and its associations, through slurmrestd.
+var deleteAccount = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, name string) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	res, err := client.SlurmdbV0044DeleteAccountWithResponse(ctx, name)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
+// getAssociations returns the associations matching the parameters through slurmrestd.
+var getAssociations = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, params slurmapi.SlurmdbV0044GetAssociationsParams) ([]slurmapi.V0044Assoc, error) {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.SlurmdbV0044GetAssociationsWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case res.StatusCode() == http.StatusNotFound:
+		return nil, nil
+	case res.StatusCode() != http.StatusOK || res.JSON200 == nil:
+		return nil, errors.New(http.StatusText(res.StatusCode()))
+	}
+	return res.JSON200.Associations, nil
+}
+
+// postAssociations creates or updates the associations through slurmrestd.
+var postAssociations = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, assocs []slurmapi.V0044Assoc) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	body := slurmapi.V0044OpenapiAssocsResp{
+		Associations: assocs,
+	}
+	res, err := client.SlurmdbV0044PostAssociationsWithResponse(ctx, body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
+// deleteAssociation deletes the association through slurmrestd.
+var deleteAssociation = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, key assocKey) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	params := &slurmapi.SlurmdbV0044DeleteAssociationParams{
+		Account: ptr.To(key.Account),
+		Cluster: ptr.To(key.Cluster),
+		User:    ptr.To(key.User),
+	}
+	if key.Partition != "" {
+		params.Partition = ptr.To(key.Partition)
+	}
+	res, err := client.SlurmdbV0044DeleteAssociationWithResponse(ctx, params)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
+// getUser returns the user through slurmrestd, or nil if it does not exist.
+var getUser = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, name string) (*slurmapi.V0044User, error) {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.SlurmdbV0044GetUserWithResponse(ctx, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case res.StatusCode() == http.StatusNotFound:
+		return nil, nil
+	case res.StatusCode() != http.StatusOK || res.JSON200 == nil:
+		return nil, errors.New(http.StatusText(res.StatusCode()))
+	case len(res.JSON200.Users) == 0:
+		return nil, nil
+	}
+	return &res.JSON200.Users[0], nil
+}
+
+// postUserAssociation creates the user with an association through slurmrestd.
+var postUserAssociation = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, body slurmapi.V0044OpenapiUsersAddCondResp) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	res, err := client.SlurmdbV0044PostUsersAssociationWithResponse(ctx, nil, body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
+// postUser updates the user through slurmrestd.
+var postUser = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, user slurmapi.V0044User) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	body := slurmapi.V0044OpenapiUsersResp{
+		Users: []slurmapi.V0044User{user},
+	}
+	res, err := client.SlurmdbV0044PostUsersWithResponse(ctx, body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
+// deleteUser deletes the user, and its associations, through slurmrestd.
+var deleteUser = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, name string) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	res, err := client.SlurmdbV0044DeleteUserWithResponse(ctx, name)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
+// getQOS returns the QOS through slurmrestd, or nil if it does not exist.
+var getQOS = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, name string) (*slurmapi.V0044Qos, error) {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.SlurmdbV0044GetSingleQosWithResponse(ctx, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case res.StatusCode() == http.StatusNotFound:
+		return nil, nil
+	case res.StatusCode() != http.StatusOK || res.JSON200 == nil:
+		return nil, errors.New(http.StatusText(res.StatusCode()))
+	case len(res.JSON200.Qos) == 0:
+		return nil, nil
+	}
+	return &res.JSON200.Qos[0], nil
+}
+
+// postQOS creates or updates the QOS through slurmrestd.
+var postQOS = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, qos slurmapi.V0044Qos) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	body := slurmapi.V0044OpenapiSlurmdbdQosResp{
+		Qos: []slurmapi.V0044Qos{qos},
+	}
+	res, err := client.SlurmdbV0044PostQosWithResponse(ctx, nil, body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
+// deleteQOS deletes the QOS through slurmrestd.
+var deleteQOS = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, name string) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	res, err := client.SlurmdbV0044DeleteSingleQosWithResponse(ctx, name)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
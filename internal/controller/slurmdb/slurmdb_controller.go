@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/tresutils"
+)
+
+const (
+	// CreatedReason is added to an event when the Slurm database record is created.
+	CreatedReason = "Created"
+	// DriftCorrectedReason is added to an event when the Slurm database record
+	// differed from the spec, and was corrected.
+	DriftCorrectedReason = "DriftCorrected"
+	// DeletedReason is added to an event when the Slurm database record is deleted.
+	DeletedReason = "Deleted"
+	// AbandonedReason is added to an event when the Slurm database record could
+	// not be deleted, because the Controller had no slurm client for too long.
+	AbandonedReason = "Abandoned"
+)
+
+const (
+	// noClientRequeue is how often the deletion of a Slurm database record is
+	// retried, while the Controller has no slurm client.
+	noClientRequeue = 10 * time.Second
+	// noClientTimeout bounds how long the deletion of a Slurm database record
+	// waits for the slurm client of the Controller, before the record is
+	// abandoned and the finalizer removed.
+	noClientTimeout = 10 * time.Minute
+)
+
+func init() {
+	flag.DurationVar(&resyncPeriod, "slurmdb-resync-period", resyncPeriod, "How often the Slurm database records are checked for drift.")
+}
+
+var (
+	// resyncPeriod is how often the Slurm database records are checked for drift.
+	resyncPeriod = 5 * time.Minute
+
+	// this is a short cut for any sub-functions to notify the reconcile how long to wait to requeue
+	accountDurationStore = durationstore.NewDurationStore(durationstore.Greater)
+	userDurationStore    = durationstore.NewDurationStore(durationstore.Greater)
+	qosDurationStore     = durationstore.NewDurationStore(durationstore.Greater)
+)
+
+// controllerTarget is the Controller whose accounting database holds the records.
+type controllerTarget struct {
+	// Key is the Controller key in the client map.
+	Key types.NamespacedName
+	// Cluster is the Slurm cluster name.
+	Cluster string
+	// ConfigErr is set when the Controller cannot be used.
+	ConfigErr error
+}
+
+// getControllerTarget resolves the Controller referenced by the object.
+func getControllerTarget(
+	ctx context.Context,
+	c client.Client,
+	ref slinkyv1beta1.ObjectReference,
+) (*controllerTarget, error) {
+	target := &controllerTarget{}
+	refResolver := refresolver.New(c)
+	controller, err := refResolver.GetController(ctx, ref)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			target.ConfigErr = fmt.Errorf("controller (%s) was not found", ref.NamespacedName())
+			return target, nil
+		}
+		return nil, err
+	}
+	target.Key = client.ObjectKeyFromObject(controller)
+	target.Cluster = controller.ClusterName()
+	if controller.Spec.AccountingRef.Name == "" {
+		target.ConfigErr = fmt.Errorf("controller (%s) does not have accounting", target.Key)
+	}
+	return target, nil
+}
+
+// Exists reports if the Controller was found.
+func (t *controllerTarget) Exists() bool {
+	return t.Key.Name != ""
+}
+
+// hasRecord reports if the Slurm database record may exist, and must be
+// deleted. Without a usable Controller, there is no Slurm database to delete
+// from; without an observed record, it was never created.
+func (t *controllerTarget) hasRecord(observed bool) bool {
+	return t.Exists() && t.ConfigErr == nil && observed
+}
+
+// waitForClient reports if the deletion of the Slurm database record should be
+// retried, because the Controller has no slurm client, and the object has not
+// been deleting for longer than noClientTimeout.
+func waitForClient(obj client.Object, err error, now time.Time) bool {
+	return errors.Is(err, slurmcontrol.ErrNoClient) &&
+		now.Sub(obj.GetDeletionTimestamp().Time) < noClientTimeout
+}
+
+// validateTRES returns an error when the TRES string cannot be parsed.
+func validateTRES(name, tres string) error {
+	if _, err := tresutils.Parse(tres); err != nil {
+		return fmt.Errorf("%s is not valid: %w", name, err)
+	}
+	return nil
+}
+
+// validateLimits returns an error when the TRES of the limits cannot be parsed.
+func validateLimits(limits *slinkyv1beta1.AssociationLimits) error {
+	return errors.Join(
+		validateTRES("grpTRES", limits.GrpTRES),
+		validateTRES("maxTRESPerJob", limits.MaxTRESPerJob),
+	)
+}
+
+// validateUser returns an error when the SlurmUser cannot be synced.
+func validateUser(user *slinkyv1beta1.SlurmUser) error {
+	if len(user.Spec.Associations) == 0 {
+		return errors.New("at least one association is required")
+	}
+	errs := []error{}
+	accounts := []string{}
+	for i := range user.Spec.Associations {
+		assoc := &user.Spec.Associations[i]
+		accounts = append(accounts, assoc.Account)
+		if err := validateLimits(&assoc.Limits); err != nil {
+			errs = append(errs, fmt.Errorf("associations[%d]: %w", i, err))
+		}
+	}
+	if !slices.Contains(accounts, user.DefaultAccount()) {
+		errs = append(errs, fmt.Errorf("default account (%s) is not associated", user.DefaultAccount()))
+	}
+	return errors.Join(errs...)
+}
+
+// validateQOS returns an error when the SlurmQOS cannot be synced.
+func validateQOS(qos *slinkyv1beta1.SlurmQOS) error {
+	return errors.Join(
+		validateTRES("grpTRES", qos.Spec.GrpTRES),
+		validateTRES("maxTRESPerJob", qos.Spec.MaxTRESPerJob),
+		validateTRES("maxTRESPerNode", qos.Spec.MaxTRESPerNode),
+		validateTRES("maxTRESPerUser", qos.Spec.MaxTRESPerUser),
+	)
+}
+
+// addFinalizer adds the slurmdb finalizer to the object.
+func addFinalizer(ctx context.Context, c client.Client, obj client.Object) error {
+	if controllerutil.ContainsFinalizer(obj, slinkyv1beta1.FinalizerSlurmdb) {
+		return nil
+	}
+	toUpdate := obj.DeepCopyObject().(client.Object)
+	controllerutil.AddFinalizer(toUpdate, slinkyv1beta1.FinalizerSlurmdb)
+	if err := c.Patch(ctx, toUpdate, client.MergeFrom(obj)); err != nil {
+		return err
+	}
+	obj.SetFinalizers(toUpdate.GetFinalizers())
+	return nil
+}
+
+// removeFinalizer removes the slurmdb finalizer from the object.
+func removeFinalizer(ctx context.Context, c client.Client, obj client.Object) error {
+	toUpdate := obj.DeepCopyObject().(client.Object)
+	controllerutil.RemoveFinalizer(toUpdate, slinkyv1beta1.FinalizerSlurmdb)
+	return c.Patch(ctx, toUpdate, client.MergeFrom(obj))
+}
+
+// newReadyCondition returns the Ready condition of the Slurm database record.
+func newReadyCondition(generation int64, record string, configErr, syncErr error) metav1.Condition {
+	ready := metav1.Condition{
+		Type:               slinkyv1beta1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             slinkyv1beta1.ReasonAsExpected,
+		Message:            fmt.Sprintf("%s is in sync", record),
+	}
+	switch {
+	case configErr != nil:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = slinkyv1beta1.ReasonInvalidConfig
+		ready.Message = configErr.Error()
+	case syncErr != nil:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = slinkyv1beta1.ReasonSyncFailed
+		ready.Message = syncErr.Error()
+	}
+	return ready
+}
+
+// newLastDriftTime returns when the Slurm database record last drifted.
+func newLastDriftTime(last *metav1.Time, drifted bool) *metav1.Time {
+	if !drifted {
+		return last
+	}
+	now := metav1.Now()
+	return &now
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_getControllerTarget(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	accounting := testutils.NewAccounting("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, corev1.SecretKeySelector{})
+	withAccounting := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, accounting)
+	withoutAccounting := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	ref := slinkyv1beta1.ObjectReference{
+		Namespace: corev1.NamespaceDefault,
+		Name:      "slurm",
+	}
+	tests := []struct {
+		name          string
+		client        client.Client
+		wantExists    bool
+		wantConfigErr bool
+	}{
+		{
+			name:          "Not found",
+			client:        fake.NewClientBuilder().Build(),
+			wantExists:    false,
+			wantConfigErr: true,
+		},
+		{
+			name:          "Without accounting",
+			client:        fake.NewClientBuilder().WithObjects(withoutAccounting).Build(),
+			wantExists:    true,
+			wantConfigErr: true,
+		},
+		{
+			name:          "With accounting",
+			client:        fake.NewClientBuilder().WithObjects(withAccounting).Build(),
+			wantExists:    true,
+			wantConfigErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getControllerTarget(context.Background(), tt.client, ref)
+			if err != nil {
+				t.Fatalf("getControllerTarget() error = %v", err)
+			}
+			if got.Exists() != tt.wantExists {
+				t.Errorf("getControllerTarget() Exists() = %v, want %v", got.Exists(), tt.wantExists)
+			}
+			if (got.ConfigErr != nil) != tt.wantConfigErr {
+				t.Errorf("getControllerTarget() ConfigErr = %v, wantConfigErr %v", got.ConfigErr, tt.wantConfigErr)
+			}
+		})
+	}
+}
+
+func Test_validateUser(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	noAssociations := testutils.NewSlurmUser("foo", controller)
+	badLimits := testutils.NewSlurmUser("foo", controller, "physics")
+	badLimits.Spec.Associations[0].Limits.GrpTRES = "cpu"
+	badDefault := testutils.NewSlurmUser("foo", controller, "physics")
+	badDefault.Spec.DefaultAccount = "chemistry"
+	valid := testutils.NewSlurmUser("foo", controller, "physics", "chemistry")
+	valid.Spec.DefaultAccount = "chemistry"
+	tests := []struct {
+		name    string
+		user    *slinkyv1beta1.SlurmUser
+		wantErr bool
+	}{
+		{
+			name:    "No associations",
+			user:    noAssociations,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid limits",
+			user:    badLimits,
+			wantErr: true,
+		},
+		{
+			name:    "Default account not associated",
+			user:    badDefault,
+			wantErr: true,
+		},
+		{
+			name:    "Valid",
+			user:    valid,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateUser(tt.user); (err != nil) != tt.wantErr {
+				t.Errorf("validateUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
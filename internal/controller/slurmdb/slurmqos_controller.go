@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+)
+
+const (
+	SlurmQOSControllerName = "slurmqos-controller"
+)
+
+func init() {
+	flag.IntVar(&maxSlurmQOSConcurrentReconciles, "slurmqos-workers", maxSlurmQOSConcurrentReconciles, "Max concurrent workers for SlurmQOS controller.")
+}
+
+var (
+	maxSlurmQOSConcurrentReconciles = 1
+)
+
+// SlurmQOSReconciler reconciles a SlurmQOS object
+type SlurmQOSReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	ClientMap *clientmap.ClientMap
+
+	slurmControl  slurmcontrol.SlurmControlInterface
+	eventRecorder record.EventRecorderLogger
+}
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmqoses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmqoses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmqoses/finalizers,verbs=update
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *SlurmQOSReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, retErr error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Started syncing SlurmQOS", "request", req)
+
+	startTime := time.Now()
+	defer func() {
+		if retErr == nil {
+			if res.RequeueAfter > 0 {
+				logger.Info("Finished syncing SlurmQOS", "duration", time.Since(startTime), "result", res)
+			} else {
+				logger.Info("Finished syncing SlurmQOS", "duration", time.Since(startTime))
+			}
+		} else {
+			logger.Info("Finished syncing SlurmQOS", "duration", time.Since(startTime), "error", retErr)
+		}
+		// clean the duration store
+		_ = qosDurationStore.Pop(req.String())
+	}()
+
+	retErr = r.Sync(ctx, req)
+	res = reconcile.Result{
+		RequeueAfter: qosDurationStore.Pop(req.String()),
+	}
+	return res, retErr
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SlurmQOSReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(SlurmQOSControllerName).
+		For(&slinkyv1beta1.SlurmQOS{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxSlurmQOSConcurrentReconciles,
+		}).
+		Complete(r)
+}
+
+func NewSlurmQOSReconciler(c client.Client, cm *clientmap.ClientMap) *SlurmQOSReconciler {
+	s := c.Scheme()
+	es := corev1.EventSource{Component: SlurmQOSControllerName}
+	return &SlurmQOSReconciler{
+		Client: c,
+		Scheme: s,
+
+		ClientMap: cm,
+
+		slurmControl:  slurmcontrol.NewSlurmControl(cm),
+		eventRecorder: record.NewBroadcaster().NewRecorder(s, es),
+	}
+}
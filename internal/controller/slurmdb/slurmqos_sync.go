@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+type SlurmQOSSyncStep struct {
+	Name string
+	Sync func(ctx context.Context, qos *slinkyv1beta1.SlurmQOS) error
+}
+
+// Sync implements control logic for synchronizing a SlurmQOS.
+func (r *SlurmQOSReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	qos := &slinkyv1beta1.SlurmQOS{}
+	if err := r.Get(ctx, req.NamespacedName, qos); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("SlurmQOS has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+
+	target, err := getControllerTarget(ctx, r.Client, qos.Spec.ControllerRef)
+	if err != nil {
+		return err
+	}
+
+	if !qos.DeletionTimestamp.IsZero() {
+		return r.finalize(ctx, qos, target)
+	}
+
+	if target.ConfigErr == nil {
+		target.ConfigErr = validateQOS(qos)
+	}
+
+	observed := qos.Status.Observed
+	result := slurmcontrol.SyncResult{}
+	syncSteps := []SlurmQOSSyncStep{
+		{
+			Name: "Finalizer",
+			Sync: func(ctx context.Context, qos *slinkyv1beta1.SlurmQOS) error {
+				return addFinalizer(ctx, r.Client, qos)
+			},
+		},
+		{
+			Name: "QOS",
+			Sync: func(ctx context.Context, qos *slinkyv1beta1.SlurmQOS) error {
+				if target.ConfigErr != nil {
+					return nil
+				}
+				var err error
+				observed, result, err = r.slurmControl.SyncQOS(ctx, target.Key, qos)
+				if err != nil {
+					return fmt.Errorf("failed to sync Slurm QOS (%s): %w", qos.SlurmName(), err)
+				}
+				switch {
+				case result.Created:
+					r.eventRecorder.Eventf(qos, corev1.EventTypeNormal, CreatedReason,
+						"Created Slurm QOS %s", qos.SlurmName())
+				case result.Drifted:
+					r.eventRecorder.Eventf(qos, corev1.EventTypeWarning, DriftCorrectedReason,
+						"Corrected Slurm QOS %s, which differed from the spec", qos.SlurmName())
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, s := range syncSteps {
+		if err := s.Sync(ctx, qos); err != nil {
+			e := fmt.Errorf("[%s]: %w", s.Name, err)
+			errors := []error{e}
+			if err := r.syncStatus(ctx, qos, observed, result, target.ConfigErr, e); err != nil {
+				e := fmt.Errorf("[%s]: %w", s.Name, err)
+				errors = append(errors, e)
+			}
+			return utilerrors.NewAggregate(errors)
+		}
+	}
+
+	qosDurationStore.Push(objectutils.KeyFunc(qos), resyncPeriod)
+
+	return r.syncStatus(ctx, qos, observed, result, target.ConfigErr, nil)
+}
+
+// finalize deletes the Slurm QOS, unless retained, and removes the finalizer.
+func (r *SlurmQOSReconciler) finalize(
+	ctx context.Context,
+	qos *slinkyv1beta1.SlurmQOS,
+	target *controllerTarget,
+) error {
+	if !controllerutil.ContainsFinalizer(qos, slinkyv1beta1.FinalizerSlurmdb) {
+		return nil
+	}
+
+	if target.hasRecord(qos.Status.Observed != nil) && !qos.IsRetained() {
+		err := r.slurmControl.DeleteQOS(ctx, target.Key, qos)
+		switch {
+		case err == nil:
+			r.eventRecorder.Eventf(qos, corev1.EventTypeNormal, DeletedReason,
+				"Deleted Slurm QOS %s", qos.SlurmName())
+		case waitForClient(qos, err, time.Now()):
+			qosDurationStore.Push(objectutils.KeyFunc(qos), noClientRequeue)
+			return nil
+		case errors.Is(err, slurmcontrol.ErrNoClient):
+			r.eventRecorder.Eventf(qos, corev1.EventTypeWarning, AbandonedReason,
+				"Abandoned Slurm QOS %s, the Controller has no slurm client", qos.SlurmName())
+		default:
+			return fmt.Errorf("failed to delete Slurm QOS (%s): %w", qos.SlurmName(), err)
+		}
+	}
+
+	return removeFinalizer(ctx, r.Client, qos)
+}
+
+// syncStatus handles determining and updating the status.
+func (r *SlurmQOSReconciler) syncStatus(
+	ctx context.Context,
+	qos *slinkyv1beta1.SlurmQOS,
+	observed *slinkyv1beta1.SlurmQOSParameters,
+	result slurmcontrol.SyncResult,
+	configErr, syncErr error,
+) error {
+	logger := log.FromContext(ctx)
+
+	newStatus := &slinkyv1beta1.SlurmQOSStatus{
+		ObservedGeneration: qos.Generation,
+		Observed:           observed,
+		LastDriftTime:      newLastDriftTime(qos.Status.LastDriftTime, result.Drifted),
+		Conditions:         make([]metav1.Condition, len(qos.Status.Conditions)),
+	}
+	copy(newStatus.Conditions, qos.Status.Conditions)
+	ready := newReadyCondition(qos.Generation,
+		fmt.Sprintf("Slurm QOS %s", qos.SlurmName()), configErr, syncErr)
+	meta.SetStatusCondition(&newStatus.Conditions, ready)
+
+	if apiequality.Semantic.DeepEqual(qos.Status, *newStatus) {
+		logger.V(2).Info("SlurmQOS Status has not changed, skipping status update",
+			"qos", klog.KObj(qos), "status", qos.Status)
+		return nil
+	}
+
+	if err := r.updateStatus(ctx, qos, newStatus); err != nil {
+		return fmt.Errorf("error updating SlurmQOS(%s) status: %w",
+			klog.KObj(qos), err)
+	}
+
+	return nil
+}
+
+func (r *SlurmQOSReconciler) updateStatus(
+	ctx context.Context,
+	qos *slinkyv1beta1.SlurmQOS,
+	newStatus *slinkyv1beta1.SlurmQOSStatus,
+) error {
+	logger := log.FromContext(ctx)
+	qosKey := objectutils.NamespacedName(qos)
+
+	logger.V(1).Info("Pending SlurmQOS Status update",
+		"qos", klog.KObj(qos), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.SlurmQOS{}
+		if err := r.Get(ctx, qosKey, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
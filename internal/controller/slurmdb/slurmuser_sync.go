@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+type SlurmUserSyncStep struct {
+	Name string
+	Sync func(ctx context.Context, user *slinkyv1beta1.SlurmUser) error
+}
+
+// Sync implements control logic for synchronizing a SlurmUser.
+func (r *SlurmUserReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	user := &slinkyv1beta1.SlurmUser{}
+	if err := r.Get(ctx, req.NamespacedName, user); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("SlurmUser has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+
+	target, err := getControllerTarget(ctx, r.Client, user.Spec.ControllerRef)
+	if err != nil {
+		return err
+	}
+
+	if !user.DeletionTimestamp.IsZero() {
+		return r.finalize(ctx, user, target)
+	}
+
+	if target.ConfigErr == nil {
+		target.ConfigErr = validateUser(user)
+	}
+
+	observed := user.Status.Observed
+	result := slurmcontrol.SyncResult{}
+	syncSteps := []SlurmUserSyncStep{
+		{
+			Name: "Finalizer",
+			Sync: func(ctx context.Context, user *slinkyv1beta1.SlurmUser) error {
+				return addFinalizer(ctx, r.Client, user)
+			},
+		},
+		{
+			Name: "User",
+			Sync: func(ctx context.Context, user *slinkyv1beta1.SlurmUser) error {
+				if target.ConfigErr != nil {
+					return nil
+				}
+				var err error
+				observed, result, err = r.slurmControl.SyncUser(ctx, target.Key, target.Cluster, user)
+				if err != nil {
+					return fmt.Errorf("failed to sync Slurm user (%s): %w", user.SlurmName(), err)
+				}
+				switch {
+				case result.Created:
+					r.eventRecorder.Eventf(user, corev1.EventTypeNormal, CreatedReason,
+						"Created Slurm user %s", user.SlurmName())
+				case result.Drifted:
+					r.eventRecorder.Eventf(user, corev1.EventTypeWarning, DriftCorrectedReason,
+						"Corrected Slurm user %s, which differed from the spec", user.SlurmName())
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, s := range syncSteps {
+		if err := s.Sync(ctx, user); err != nil {
+			e := fmt.Errorf("[%s]: %w", s.Name, err)
+			errors := []error{e}
+			if err := r.syncStatus(ctx, user, observed, result, target.ConfigErr, e); err != nil {
+				e := fmt.Errorf("[%s]: %w", s.Name, err)
+				errors = append(errors, e)
+			}
+			return utilerrors.NewAggregate(errors)
+		}
+	}
+
+	userDurationStore.Push(objectutils.KeyFunc(user), resyncPeriod)
+
+	return r.syncStatus(ctx, user, observed, result, target.ConfigErr, nil)
+}
+
+// finalize deletes the Slurm user, unless retained, and removes the finalizer.
+func (r *SlurmUserReconciler) finalize(
+	ctx context.Context,
+	user *slinkyv1beta1.SlurmUser,
+	target *controllerTarget,
+) error {
+	if !controllerutil.ContainsFinalizer(user, slinkyv1beta1.FinalizerSlurmdb) {
+		return nil
+	}
+
+	if target.hasRecord(user.Status.Observed != nil) && !user.IsRetained() {
+		err := r.slurmControl.DeleteUser(ctx, target.Key, user)
+		switch {
+		case err == nil:
+			r.eventRecorder.Eventf(user, corev1.EventTypeNormal, DeletedReason,
+				"Deleted Slurm user %s", user.SlurmName())
+		case waitForClient(user, err, time.Now()):
+			userDurationStore.Push(objectutils.KeyFunc(user), noClientRequeue)
+			return nil
+		case errors.Is(err, slurmcontrol.ErrNoClient):
+			r.eventRecorder.Eventf(user, corev1.EventTypeWarning, AbandonedReason,
+				"Abandoned Slurm user %s, the Controller has no slurm client", user.SlurmName())
+		default:
+			return fmt.Errorf("failed to delete Slurm user (%s): %w", user.SlurmName(), err)
+		}
+	}
+
+	return removeFinalizer(ctx, r.Client, user)
+}
+
+// syncStatus handles determining and updating the status.
+func (r *SlurmUserReconciler) syncStatus(
+	ctx context.Context,
+	user *slinkyv1beta1.SlurmUser,
+	observed *slinkyv1beta1.SlurmUserParameters,
+	result slurmcontrol.SyncResult,
+	configErr, syncErr error,
+) error {
+	logger := log.FromContext(ctx)
+
+	newStatus := &slinkyv1beta1.SlurmUserStatus{
+		ObservedGeneration: user.Generation,
+		Observed:           observed,
+		LastDriftTime:      newLastDriftTime(user.Status.LastDriftTime, result.Drifted),
+		Conditions:         make([]metav1.Condition, len(user.Status.Conditions)),
+	}
+	copy(newStatus.Conditions, user.Status.Conditions)
+	ready := newReadyCondition(user.Generation,
+		fmt.Sprintf("Slurm user %s", user.SlurmName()), configErr, syncErr)
+	meta.SetStatusCondition(&newStatus.Conditions, ready)
+
+	if apiequality.Semantic.DeepEqual(user.Status, *newStatus) {
+		logger.V(2).Info("SlurmUser Status has not changed, skipping status update",
+			"user", klog.KObj(user), "status", user.Status)
+		return nil
+	}
+
+	if err := r.updateStatus(ctx, user, newStatus); err != nil {
+		return fmt.Errorf("error updating SlurmUser(%s) status: %w",
+			klog.KObj(user), err)
+	}
+
+	return nil
+}
+
+func (r *SlurmUserReconciler) updateStatus(
+	ctx context.Context,
+	user *slinkyv1beta1.SlurmUser,
+	newStatus *slinkyv1beta1.SlurmUserStatus,
+) error {
+	logger := log.FromContext(ctx)
+	userKey := objectutils.NamespacedName(user)
+
+	logger.V(1).Info("Pending SlurmUser Status update",
+		"user", klog.KObj(user), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.SlurmUser{}
+		if err := r.Get(ctx, userKey, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/jobinfo"
+)
+
+// SetEventHandler sends an event for the SlurmJob of a Slurm job when the job
+// is added, changes state, or is removed from slurmctld.
+func SetEventHandler(client slurmclient.Client, eventCh chan event.GenericEvent) {
+	informer := client.GetInformer(slurmtypes.ObjectTypeV0044JobInfo)
+	informer.SetEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if evt, ok := jobEvent(obj); ok {
+				eventCh <- evt
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldJob, ok := oldObj.(*slurmtypes.V0044JobInfo)
+			if !ok {
+				return
+			}
+			newJob, ok := newObj.(*slurmtypes.V0044JobInfo)
+			if !ok {
+				return
+			}
+			if apiequality.Semantic.DeepEqual(newJob.JobState, oldJob.JobState) {
+				return
+			}
+			if evt, ok := jobEvent(newJob); ok {
+				eventCh <- evt
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if evt, ok := jobEvent(obj); ok {
+				eventCh <- evt
+			}
+		},
+	})
+}
+
+// jobEvent returns the event of the SlurmJob of the Slurm job, if it was
+// submitted by a SlurmJob.
+func jobEvent(obj interface{}) (event.GenericEvent, bool) {
+	job, ok := obj.(*slurmtypes.V0044JobInfo)
+	if !ok {
+		return event.GenericEvent{}, false
+	}
+	jobInfo := jobinfo.JobInfo{}
+	if err := jobinfo.ParseIntoJobInfo(job.Comment, &jobInfo); err != nil || jobInfo.SlurmJob == "" {
+		return event.GenericEvent{}, false
+	}
+	evt := event.GenericEvent{
+		Object: &slinkyv1beta1.SlurmJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: jobInfo.Namespace,
+				Name:      jobInfo.SlurmJob,
+			},
+		},
+	}
+	return evt, true
+}
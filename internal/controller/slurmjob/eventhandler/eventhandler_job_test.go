@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/jobinfo"
+)
+
+func Test_jobEvent(t *testing.T) {
+	newJob := func(comment *string) *slurmtypes.V0044JobInfo {
+		job := &slurmtypes.V0044JobInfo{}
+		job.JobId = ptr.To[int32](1)
+		job.Comment = comment
+		return job
+	}
+	tests := []struct {
+		name   string
+		obj    interface{}
+		want   types.NamespacedName
+		wantOk bool
+	}{
+		{
+			name: "SlurmJob",
+			obj:  newJob(ptr.To((&jobinfo.JobInfo{Namespace: corev1.NamespaceDefault, SlurmJob: "foo"}).ToString())),
+			want: types.NamespacedName{
+				Namespace: corev1.NamespaceDefault,
+				Name:      "foo",
+			},
+			wantOk: true,
+		},
+		{
+			name:   "No comment",
+			obj:    newJob(nil),
+			wantOk: false,
+		},
+		{
+			name:   "User comment",
+			obj:    newJob(ptr.To("my job")),
+			wantOk: false,
+		},
+		{
+			name:   "Not a job",
+			obj:    &slurmtypes.V0044Node{},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := jobEvent(tt.obj)
+			if ok != tt.wantOk {
+				t.Fatalf("jobEvent() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !ok {
+				return
+			}
+			if key := client.ObjectKeyFromObject(got.Object); key != tt.want {
+				t.Errorf("jobEvent() = %v, want %v", key, tt.want)
+			}
+		})
+	}
+}
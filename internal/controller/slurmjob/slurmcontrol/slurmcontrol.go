@@ -0,0 +1,297 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmapiclient "github.com/SlinkyProject/slurm-client/pkg/client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/jobinfo"
+)
+
+// ErrNoClient is returned when there is no Slurm client for the Controller.
+var ErrNoClient = errors.New("no slurm client for controller")
+
+// defaultPath is the PATH of the batch script, unless set by the SlurmJob.
+const defaultPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+// JobStatus is the observed state of a Slurm job, or of a job array as a whole.
+type JobStatus struct {
+	// State is the base state of the job (e.g. `RUNNING`).
+	State string
+	// StateReason is the reason for the state.
+	StateReason string
+	// ExitCode is the exit code of the batch script, once finished.
+	ExitCode *int32
+	// ArrayTasks are the array task counts, for a job array.
+	ArrayTasks *slinkyv1beta1.SlurmJobArrayTasks
+
+	SubmitTime *time.Time
+	StartTime  *time.Time
+	EndTime    *time.Time
+
+	// Finished is true when the job, or all array tasks, are done.
+	Finished bool
+	// Succeeded is true when the job, or all array tasks, completed successfully.
+	Succeeded bool
+}
+
+type SlurmControlInterface interface {
+	// SubmitJob submits the Slurm job of the SlurmJob, with the dependency
+	// string, and returns its job ID. When the token is set, the job is
+	// submitted as its user. If slurmctld already knows of a Slurm job of the
+	// SlurmJob, its job ID is returned instead of submitting another.
+	SubmitJob(ctx context.Context, controllerKey types.NamespacedName, job *slinkyv1beta1.SlurmJob, dependency, token string) (int32, error)
+	// GetJob returns the status of the Slurm job, or nil if slurmctld no longer knows of it.
+	GetJob(ctx context.Context, controllerKey types.NamespacedName, jobID int32) (*JobStatus, error)
+	// CancelJob cancels the Slurm job, and all of its array tasks, if not finished.
+	CancelJob(ctx context.Context, controllerKey types.NamespacedName, jobID int32) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+// submitJob submits the job through slurmrestd, authenticated by the token.
+var submitJob = func(ctx context.Context, server string, httpClient *http.Client, token string, req slurmapi.V0044JobSubmitReq) (int32, error) {
+	client, err := slurmapiclient.NewSlurmClient(server, token, httpClient)
+	if err != nil {
+		return 0, err
+	}
+	jobId, err := client.CreateJobInfo(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	if jobId == nil {
+		return 0, errors.New("slurmrestd did not return a job ID")
+	}
+	return *jobId, nil
+}
+
+// SubmitJob implements SlurmControlInterface.
+func (r *realSlurmControl) SubmitJob(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	job *slinkyv1beta1.SlurmJob,
+	dependency, token string,
+) (int32, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		return 0, ErrNoClient
+	}
+	if token == "" {
+		token = slurmClient.GetToken()
+	}
+	httpClient := r.clientMap.GetHTTPClient(controllerKey)
+
+	jobID, err := findSubmittedJob(ctx, slurmClient, job)
+	if err != nil {
+		return 0, err
+	}
+	if jobID != 0 {
+		logger.Info("Slurm job already submitted, adopting it",
+			"job", job.SlurmName(), "jobId", jobID)
+		return jobID, nil
+	}
+
+	req := NewJobSubmitReq(job, dependency)
+	logger.V(1).Info("Submitting Slurm job",
+		"job", job.SlurmName(), "dependency", dependency, "submitAs", job.Spec.SubmitAs)
+	return submitJob(ctx, slurmClient.GetServer(), httpClient, token, req)
+}
+
+// findSubmittedJob returns the job ID of the Slurm job whose comment carries the
+// UID of the SlurmJob, or zero if there is none. This guards against submitting
+// twice when the status holding the job ID was not yet observed.
+func findSubmittedJob(ctx context.Context, slurmClient slurmclient.Client, job *slinkyv1beta1.SlurmJob) (int32, error) {
+	if job.UID == "" {
+		return 0, nil
+	}
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList, &slurmclient.ListOptions{RefreshCache: true}); err != nil {
+		return 0, err
+	}
+	for _, item := range jobList.Items {
+		info := jobinfo.JobInfo{}
+		if err := jobinfo.ParseIntoJobInfo(item.Comment, &info); err != nil {
+			continue
+		}
+		if info.UID != string(job.UID) {
+			continue
+		}
+		if arrayJobID := getNoVal32(item.ArrayJobId); arrayJobID != 0 {
+			return arrayJobID, nil
+		}
+		return ptr.Deref(item.JobId, 0), nil
+	}
+	return 0, nil
+}
+
+// NewJobSubmitReq returns the submit request of the SlurmJob. The job comment
+// identifies the SlurmJob.
+func NewJobSubmitReq(job *slinkyv1beta1.SlurmJob, dependency string) slurmapi.V0044JobSubmitReq {
+	info := jobinfo.JobInfo{
+		Namespace: job.Namespace,
+		SlurmJob:  job.Name,
+		UID:       string(job.UID),
+	}
+	desc := &slurmapi.V0044JobDescMsg{
+		Name:                    ptr.To(job.SlurmName()),
+		Script:                  ptr.To(job.Spec.Script),
+		Comment:                 ptr.To(info.ToString()),
+		CurrentWorkingDirectory: ptr.To(job.WorkingDir()),
+		Environment:             ptr.To(newEnvironment(job.Spec.Env)),
+	}
+	if job.Spec.Partition != "" {
+		desc.Partition = ptr.To(job.Spec.Partition)
+	}
+	if job.Spec.Account != "" {
+		desc.Account = ptr.To(job.Spec.Account)
+	}
+	if job.Spec.QOS != "" {
+		desc.Qos = ptr.To(job.Spec.QOS)
+	}
+	if job.Spec.Array != "" {
+		desc.Array = ptr.To(job.Spec.Array)
+	}
+	if dependency != "" {
+		desc.Dependency = ptr.To(dependency)
+	}
+
+	res := job.Spec.Resources
+	if res.Nodes != nil {
+		desc.MinimumNodes = ptr.To(*res.Nodes)
+		desc.MaximumNodes = ptr.To(*res.Nodes)
+	}
+	desc.Tasks = res.Tasks
+	desc.CpusPerTask = res.CPUsPerTask
+	if res.MemoryPerNode != nil {
+		mebibytes := (res.MemoryPerNode.Value() + (1 << 20) - 1) >> 20
+		desc.MemoryPerNode = &slurmapi.V0044Uint64NoValStruct{
+			Set:    ptr.To(true),
+			Number: ptr.To(mebibytes),
+		}
+	}
+	if res.TimeLimit != nil {
+		minutes := int32((res.TimeLimit.Duration + time.Minute - 1) / time.Minute)
+		desc.TimeLimit = &slurmapi.V0044Uint32NoValStruct{
+			Set:    ptr.To(true),
+			Number: ptr.To(minutes),
+		}
+	}
+	if res.TRESPerNode != "" {
+		desc.TresPerNode = ptr.To(res.TRESPerNode)
+	}
+
+	return slurmapi.V0044JobSubmitReq{
+		Job: desc,
+	}
+}
+
+// newEnvironment returns the environment of the batch script, with a default PATH.
+func newEnvironment(env []corev1.EnvVar) []string {
+	out := make([]string, 0, len(env)+1)
+	hasPath := false
+	for _, e := range env {
+		if e.Name == "PATH" {
+			hasPath = true
+		}
+		out = append(out, fmt.Sprintf("%s=%s", e.Name, e.Value))
+	}
+	if !hasPath {
+		out = append(out, "PATH="+defaultPath)
+	}
+	return out
+}
+
+// listJobs returns the job records of the job ID, including its array tasks.
+func listJobs(ctx context.Context, slurmClient slurmclient.Client, jobID int32) ([]slurmtypes.V0044JobInfo, error) {
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList); err != nil {
+		return nil, err
+	}
+	jobs := []slurmtypes.V0044JobInfo{}
+	for _, job := range jobList.Items {
+		if ptr.Deref(job.JobId, 0) == jobID || getNoVal32(job.ArrayJobId) == jobID {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+// GetJob implements SlurmControlInterface.
+func (r *realSlurmControl) GetJob(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	jobID int32,
+) (*JobStatus, error) {
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		return nil, ErrNoClient
+	}
+
+	jobs, err := listJobs(ctx, slurmClient, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return newJobStatus(jobs), nil
+}
+
+// CancelJob implements SlurmControlInterface.
+func (r *realSlurmControl) CancelJob(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	jobID int32,
+) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do CancelJob()",
+			"controller", controllerKey)
+		return nil
+	}
+
+	jobs, err := listJobs(ctx, slurmClient, jobID)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 || newJobStatus(jobs).Finished {
+		return nil
+	}
+
+	logger.V(1).Info("Cancelling Slurm job", "jobId", jobID)
+	job := &slurmtypes.V0044JobInfo{}
+	job.JobId = ptr.To(jobID)
+	return slurmClient.Delete(ctx, job)
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clusters *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clusters,
+	}
+}
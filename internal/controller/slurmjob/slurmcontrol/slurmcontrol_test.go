@@ -0,0 +1,598 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var controllerKey = types.NamespacedName{
+	Namespace: corev1.NamespaceDefault,
+	Name:      "slurm",
+}
+
+func newSlurmClientMap(client slurmclient.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	cm.Add(controllerKey, client)
+	return cm
+}
+
+func newNoVal32(n int32) *slurmapi.V0044Uint32NoValStruct {
+	return &slurmapi.V0044Uint32NoValStruct{
+		Set:    ptr.To(true),
+		Number: ptr.To(n),
+	}
+}
+
+func newNoVal64(t time.Time) *slurmapi.V0044Uint64NoValStruct {
+	return &slurmapi.V0044Uint64NoValStruct{
+		Set:    ptr.To(true),
+		Number: ptr.To(t.Unix()),
+	}
+}
+
+func newJobInfo(jobID int32, state slurmapi.V0044JobInfoJobState, exitCode int32) slurmtypes.V0044JobInfo {
+	job := slurmtypes.V0044JobInfo{}
+	job.JobId = ptr.To(jobID)
+	job.JobState = ptr.To([]slurmapi.V0044JobInfoJobState{state})
+	job.ExitCode = &slurmapi.V0044ProcessExitCodeVerbose{
+		ReturnCode: newNoVal32(exitCode),
+	}
+	return job
+}
+
+func newArrayTask(arrayJobID, jobID, taskID int32, state slurmapi.V0044JobInfoJobState, exitCode int32) slurmtypes.V0044JobInfo {
+	job := newJobInfo(jobID, state, exitCode)
+	job.ArrayJobId = newNoVal32(arrayJobID)
+	job.ArrayTaskId = newNoVal32(taskID)
+	return job
+}
+
+func Test_NewJobSubmitReq(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	script := "#!/bin/bash\nsrun hostname\n"
+
+	simple := testutils.NewSlurmJob("foo", controller, script)
+
+	full := testutils.NewSlurmJob("foo", controller, script)
+	full.Spec.Name = "bar"
+	full.Spec.Partition = "debug"
+	full.Spec.Account = "physics"
+	full.Spec.QOS = "high"
+	full.Spec.Array = "0-3"
+	full.Spec.WorkingDir = "/home/user"
+	full.Spec.Env = []corev1.EnvVar{
+		{Name: "PATH", Value: "/opt/bin"},
+		{Name: "FOO", Value: "bar"},
+	}
+	full.Spec.Resources = slinkyv1beta1.SlurmJobResources{
+		Nodes:         ptr.To[int32](2),
+		Tasks:         ptr.To[int32](4),
+		CPUsPerTask:   ptr.To[int32](8),
+		MemoryPerNode: ptr.To(resource.MustParse("1500Ki")),
+		TimeLimit:     &metav1.Duration{Duration: 90 * time.Second},
+		TRESPerNode:   "gres/gpu:2",
+	}
+
+	tests := []struct {
+		name       string
+		job        *slinkyv1beta1.SlurmJob
+		dependency string
+		want       slurmapi.V0044JobDescMsg
+	}{
+		{
+			name: "Defaults",
+			job:  simple,
+			want: slurmapi.V0044JobDescMsg{
+				Name:                    ptr.To("foo"),
+				Script:                  ptr.To(script),
+				Comment:                 ptr.To(`{"namespace":"default","slurmJob":"foo"}`),
+				CurrentWorkingDirectory: ptr.To("/tmp"),
+				Environment:             ptr.To([]string{"PATH=" + defaultPath}),
+			},
+		},
+		{
+			name:       "All fields",
+			job:        full,
+			dependency: "afterok:1:2",
+			want: slurmapi.V0044JobDescMsg{
+				Name:                    ptr.To("bar"),
+				Script:                  ptr.To(script),
+				Comment:                 ptr.To(`{"namespace":"default","slurmJob":"foo"}`),
+				CurrentWorkingDirectory: ptr.To("/home/user"),
+				Environment:             ptr.To([]string{"PATH=/opt/bin", "FOO=bar"}),
+				Partition:               ptr.To("debug"),
+				Account:                 ptr.To("physics"),
+				Qos:                     ptr.To("high"),
+				Array:                   ptr.To("0-3"),
+				Dependency:              ptr.To("afterok:1:2"),
+				MinimumNodes:            ptr.To[int32](2),
+				MaximumNodes:            ptr.To[int32](2),
+				Tasks:                   ptr.To[int32](4),
+				CpusPerTask:             ptr.To[int32](8),
+				MemoryPerNode: &slurmapi.V0044Uint64NoValStruct{
+					Set:    ptr.To(true),
+					Number: ptr.To[int64](2),
+				},
+				TimeLimit:   newNoVal32(2),
+				TresPerNode: ptr.To("gres/gpu:2"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewJobSubmitReq(tt.job, tt.dependency)
+			if !reflect.DeepEqual(*got.Job, tt.want) {
+				t.Errorf("NewJobSubmitReq() = %+v, want %+v", *got.Job, tt.want)
+			}
+		})
+	}
+}
+
+func Test_countArrayTasks(t *testing.T) {
+	tests := []struct {
+		str  string
+		want int32
+	}{
+		{str: "", want: 1},
+		{str: "3", want: 1},
+		{str: "0-15", want: 16},
+		{str: "0-15%4", want: 16},
+		{str: "1,3,5-11:2", want: 6},
+		{str: "5-1", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			if got := countArrayTasks(tt.str); got != tt.want {
+				t.Errorf("countArrayTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code *slurmapi.V0044ProcessExitCodeVerbose
+		want *int32
+	}{
+		{
+			name: "Unset",
+			code: nil,
+			want: nil,
+		},
+		{
+			name: "Return code",
+			code: &slurmapi.V0044ProcessExitCodeVerbose{
+				ReturnCode: newNoVal32(2),
+			},
+			want: ptr.To[int32](2),
+		},
+		{
+			name: "Signal",
+			code: &slurmapi.V0044ProcessExitCodeVerbose{
+				ReturnCode: newNoVal32(0),
+				Signal: &struct {
+					Id   *slurmapi.V0044Uint16NoValStruct `json:"id,omitempty"`
+					Name *string                          `json:"name,omitempty"`
+				}{
+					Id: &slurmapi.V0044Uint16NoValStruct{Set: ptr.To(true), Number: ptr.To[int32](9)},
+				},
+			},
+			want: ptr.To[int32](137),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getExitCode(tt.code); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getExitCode() = %v, want %v", ptr.Deref(got, -1), ptr.Deref(tt.want, -1))
+			}
+		})
+	}
+}
+
+func Test_newJobStatus(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	pendingArray := newJobInfo(10, slurmapi.V0044JobInfoJobStatePENDING, 0)
+	pendingArray.ArrayJobId = newNoVal32(10)
+	pendingArray.ArrayTaskString = ptr.To("2-3")
+	pendingArray.StateReason = ptr.To("Resources")
+	completed := newJobInfo(1, slurmapi.V0044JobInfoJobStateCOMPLETED, 0)
+	completed.SubmitTime = newNoVal64(now.Add(-time.Hour))
+	completed.StartTime = newNoVal64(now.Add(-time.Minute))
+	completed.EndTime = newNoVal64(now)
+	completing := newJobInfo(1, slurmapi.V0044JobInfoJobStateCOMPLETED, 0)
+	completing.JobState = ptr.To([]slurmapi.V0044JobInfoJobState{
+		slurmapi.V0044JobInfoJobStateCOMPLETED,
+		slurmapi.V0044JobInfoJobStateCOMPLETING,
+	})
+
+	tests := []struct {
+		name string
+		jobs []slurmtypes.V0044JobInfo
+		want *JobStatus
+	}{
+		{
+			name: "Pending",
+			jobs: func() []slurmtypes.V0044JobInfo {
+				job := newJobInfo(1, slurmapi.V0044JobInfoJobStatePENDING, 0)
+				job.StateReason = ptr.To("Priority")
+				return []slurmtypes.V0044JobInfo{job}
+			}(),
+			want: &JobStatus{
+				State:       "PENDING",
+				StateReason: "Priority",
+			},
+		},
+		{
+			name: "Completed",
+			jobs: []slurmtypes.V0044JobInfo{completed},
+			want: &JobStatus{
+				State:      "COMPLETED",
+				ExitCode:   ptr.To[int32](0),
+				SubmitTime: ptr.To(now.Add(-time.Hour)),
+				StartTime:  ptr.To(now.Add(-time.Minute)),
+				EndTime:    ptr.To(now),
+				Finished:   true,
+				Succeeded:  true,
+			},
+		},
+		{
+			name: "Completing",
+			jobs: []slurmtypes.V0044JobInfo{completing},
+			want: &JobStatus{
+				State: "COMPLETED",
+			},
+		},
+		{
+			name: "Failed",
+			jobs: []slurmtypes.V0044JobInfo{newJobInfo(1, slurmapi.V0044JobInfoJobStateFAILED, 3)},
+			want: &JobStatus{
+				State:    "FAILED",
+				ExitCode: ptr.To[int32](3),
+				Finished: true,
+			},
+		},
+		{
+			name: "Array, running",
+			jobs: []slurmtypes.V0044JobInfo{
+				pendingArray,
+				newArrayTask(10, 11, 0, slurmapi.V0044JobInfoJobStateRUNNING, 0),
+				newArrayTask(10, 12, 1, slurmapi.V0044JobInfoJobStateCOMPLETED, 0),
+			},
+			want: &JobStatus{
+				State:    "RUNNING",
+				ExitCode: ptr.To[int32](0),
+				ArrayTasks: &slinkyv1beta1.SlurmJobArrayTasks{
+					Pending:   2,
+					Running:   1,
+					Succeeded: 1,
+				},
+			},
+		},
+		{
+			name: "Array, failed",
+			jobs: []slurmtypes.V0044JobInfo{
+				newArrayTask(10, 11, 0, slurmapi.V0044JobInfoJobStateCOMPLETED, 0),
+				newArrayTask(10, 12, 1, slurmapi.V0044JobInfoJobStateFAILED, 1),
+			},
+			want: &JobStatus{
+				State:    "FAILED",
+				ExitCode: ptr.To[int32](1),
+				ArrayTasks: &slinkyv1beta1.SlurmJobArrayTasks{
+					Succeeded: 1,
+					Failed:    1,
+				},
+				Finished: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newJobStatus(tt.jobs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newJobStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_SubmitJob(t *testing.T) {
+	ctx := context.Background()
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	job := testutils.NewSlurmJob("foo", controller, "#!/bin/bash\n")
+	submitted := newJobInfo(7, slurmapi.V0044JobInfoJobStatePENDING, 0)
+	submitted.Comment = ptr.To(`{"namespace":"default","slurmJob":"foo","uid":"1234"}`)
+	other := newJobInfo(8, slurmapi.V0044JobInfoJobStatePENDING, 0)
+	other.Comment = ptr.To(`{"namespace":"default","slurmJob":"foo","uid":"5678"}`)
+	type fields struct {
+		clientMap *clientmap.ClientMap
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		uid       types.UID
+		token     string
+		submitErr error
+		wantToken string
+		want      int32
+		wantErr   bool
+	}{
+		{
+			name: "No client",
+			fields: fields{
+				clientMap: clientmap.NewClientMap(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Client token",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			},
+			wantToken: "slurm-token",
+			want:      1,
+		},
+		{
+			name: "Submit as user",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			},
+			token:     "user-token",
+			wantToken: "user-token",
+			want:      1,
+		},
+		{
+			name: "Submit error",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			},
+			submitErr: errors.New(http.StatusText(http.StatusInternalServerError)),
+			wantToken: "slurm-token",
+			wantErr:   true,
+		},
+		{
+			name: "Already submitted",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewClientBuilder().WithLists(&slurmtypes.V0044JobInfoList{
+					Items: []slurmtypes.V0044JobInfo{other, submitted},
+				}).Build()),
+			},
+			uid:       "1234",
+			submitErr: errors.New("submitted twice"),
+			want:      7,
+		},
+		{
+			name: "Submitted by another SlurmJob",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewClientBuilder().WithLists(&slurmtypes.V0044JobInfoList{
+					Items: []slurmtypes.V0044JobInfo{other},
+				}).Build()),
+			},
+			uid:       "1234",
+			wantToken: "slurm-token",
+			want:      1,
+		},
+		{
+			name: "List error",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, list object.ObjectList, opts ...slurmclient.ListOption) error {
+						return errors.New(http.StatusText(http.StatusInternalServerError))
+					},
+				}).Build()),
+			},
+			uid:       "1234",
+			submitErr: errors.New("submitted without lookup"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submitJobFn := submitJob
+			defer func() { submitJob = submitJobFn }()
+			submitJob = func(_ context.Context, _ string, _ *http.Client, token string, req slurmapi.V0044JobSubmitReq) (int32, error) {
+				if token != tt.wantToken {
+					t.Errorf("submitJob() token = %v, want %v", token, tt.wantToken)
+				}
+				if tt.submitErr != nil {
+					return 0, tt.submitErr
+				}
+				return 1, nil
+			}
+			r := &realSlurmControl{
+				clientMap: tt.fields.clientMap,
+			}
+			job := job.DeepCopy()
+			job.UID = tt.uid
+			got, err := r.SubmitJob(ctx, controllerKey, job, "", tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.SubmitJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("realSlurmControl.SubmitJob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_GetJob(t *testing.T) {
+	ctx := context.Background()
+	jobList := &slurmtypes.V0044JobInfoList{
+		Items: []slurmtypes.V0044JobInfo{
+			newJobInfo(1, slurmapi.V0044JobInfoJobStateRUNNING, 0),
+			newJobInfo(2, slurmapi.V0044JobInfoJobStateCOMPLETED, 0),
+		},
+	}
+	type fields struct {
+		clientMap *clientmap.ClientMap
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		jobID   int32
+		want    string
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name: "No client",
+			fields: fields{
+				clientMap: clientmap.NewClientMap(),
+			},
+			jobID:   1,
+			wantNil: true,
+			wantErr: true,
+		},
+		{
+			name: "Found",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewClientBuilder().WithLists(jobList).Build()),
+			},
+			jobID: 2,
+			want:  "COMPLETED",
+		},
+		{
+			name: "Not found",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewClientBuilder().WithLists(jobList).Build()),
+			},
+			jobID:   3,
+			wantNil: true,
+		},
+		{
+			name: "List error",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, list object.ObjectList, opts ...slurmclient.ListOption) error {
+						return errors.New(http.StatusText(http.StatusInternalServerError))
+					},
+				}).Build()),
+			},
+			jobID:   1,
+			wantNil: true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &realSlurmControl{
+				clientMap: tt.fields.clientMap,
+			}
+			got, err := r.GetJob(ctx, controllerKey, tt.jobID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.GetJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantNil {
+				t.Fatalf("realSlurmControl.GetJob() = %v, wantNil %v", got, tt.wantNil)
+			}
+			if got != nil && got.State != tt.want {
+				t.Errorf("realSlurmControl.GetJob() State = %v, want %v", got.State, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_CancelJob(t *testing.T) {
+	ctx := context.Background()
+	jobList := &slurmtypes.V0044JobInfoList{
+		Items: []slurmtypes.V0044JobInfo{
+			newJobInfo(1, slurmapi.V0044JobInfoJobStateRUNNING, 0),
+			newJobInfo(2, slurmapi.V0044JobInfoJobStateCOMPLETED, 0),
+		},
+	}
+	tests := []struct {
+		name       string
+		clientMap  func(deleted *[]int32) *clientmap.ClientMap
+		jobID      int32
+		wantDelete []int32
+		wantErr    bool
+	}{
+		{
+			name: "No client",
+			clientMap: func(_ *[]int32) *clientmap.ClientMap {
+				return clientmap.NewClientMap()
+			},
+			jobID: 1,
+		},
+		{
+			name:  "Running",
+			jobID: 1,
+			clientMap: func(deleted *[]int32) *clientmap.ClientMap {
+				return newSlurmClientMap(fake.NewClientBuilder().WithLists(jobList).WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, obj object.Object, opts ...slurmclient.DeleteOption) error {
+						*deleted = append(*deleted, ptr.Deref(obj.(*slurmtypes.V0044JobInfo).JobId, 0))
+						return nil
+					},
+				}).Build())
+			},
+			wantDelete: []int32{1},
+		},
+		{
+			name:  "Finished",
+			jobID: 2,
+			clientMap: func(deleted *[]int32) *clientmap.ClientMap {
+				return newSlurmClientMap(fake.NewClientBuilder().WithLists(jobList).WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, obj object.Object, opts ...slurmclient.DeleteOption) error {
+						*deleted = append(*deleted, ptr.Deref(obj.(*slurmtypes.V0044JobInfo).JobId, 0))
+						return nil
+					},
+				}).Build())
+			},
+		},
+		{
+			name:  "Delete error",
+			jobID: 1,
+			clientMap: func(deleted *[]int32) *clientmap.ClientMap {
+				return newSlurmClientMap(fake.NewClientBuilder().WithLists(jobList).WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, obj object.Object, opts ...slurmclient.DeleteOption) error {
+						*deleted = append(*deleted, ptr.Deref(obj.(*slurmtypes.V0044JobInfo).JobId, 0))
+						return errors.New(http.StatusText(http.StatusInternalServerError))
+					},
+				}).Build())
+			},
+			wantDelete: []int32{1},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := []int32{}
+			r := &realSlurmControl{
+				clientMap: tt.clientMap(&deleted),
+			}
+			err := r.CancelJob(ctx, controllerKey, tt.jobID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.CancelJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(deleted, tt.wantDelete) {
+				t.Errorf("realSlurmControl.CancelJob() deleted = %v, want %v", deleted, tt.wantDelete)
+			}
+		})
+	}
+}
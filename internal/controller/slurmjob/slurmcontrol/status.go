@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// baseStates are the Slurm job base states; the other states are flags.
+var baseStates = []slurmapi.V0044JobInfoJobState{
+	slurmapi.V0044JobInfoJobStatePENDING,
+	slurmapi.V0044JobInfoJobStateRUNNING,
+	slurmapi.V0044JobInfoJobStateSUSPENDED,
+	slurmapi.V0044JobInfoJobStateCOMPLETED,
+	slurmapi.V0044JobInfoJobStateCANCELLED,
+	slurmapi.V0044JobInfoJobStateFAILED,
+	slurmapi.V0044JobInfoJobStateTIMEOUT,
+	slurmapi.V0044JobInfoJobStateNODEFAIL,
+	slurmapi.V0044JobInfoJobStatePREEMPTED,
+	slurmapi.V0044JobInfoJobStateBOOTFAIL,
+	slurmapi.V0044JobInfoJobStateDEADLINE,
+	slurmapi.V0044JobInfoJobStateOUTOFMEMORY,
+}
+
+// baseState returns the base state of the job record.
+func baseState(job *slurmtypes.V0044JobInfo) slurmapi.V0044JobInfoJobState {
+	states := job.GetStateAsSet()
+	for _, state := range baseStates {
+		if states.Has(state) {
+			return state
+		}
+	}
+	return slurmapi.V0044JobInfoJobStatePENDING
+}
+
+// isActive reports if the job record has not finished.
+func isActive(job *slurmtypes.V0044JobInfo) bool {
+	switch baseState(job) {
+	case slurmapi.V0044JobInfoJobStatePENDING,
+		slurmapi.V0044JobInfoJobStateRUNNING,
+		slurmapi.V0044JobInfoJobStateSUSPENDED:
+		return true
+	}
+	// The job is still cleaning up (e.g. epilog).
+	return job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStateCOMPLETING)
+}
+
+// newJobStatus returns the status of the job from its records. A job array
+// has a record for its pending tasks, and a record for each started task.
+func newJobStatus(jobs []slurmtypes.V0044JobInfo) *JobStatus {
+	status := &JobStatus{}
+	tasks := &slinkyv1beta1.SlurmJobArrayTasks{}
+	isArray := false
+
+	for i := range jobs {
+		job := &jobs[i]
+		state := baseState(job)
+		count := int32(1)
+		if job.ArrayJobId != nil && getNoVal32(job.ArrayJobId) != 0 {
+			isArray = true
+			if job.ArrayTaskId == nil || !ptr.Deref(job.ArrayTaskId.Set, false) {
+				count = countArrayTasks(ptr.Deref(job.ArrayTaskString, ""))
+			}
+		}
+
+		switch {
+		case state == slurmapi.V0044JobInfoJobStatePENDING:
+			tasks.Pending += count
+			if status.StateReason == "" {
+				status.StateReason = ptr.Deref(job.StateReason, "")
+			}
+		case isActive(job):
+			tasks.Running += count
+		case state == slurmapi.V0044JobInfoJobStateCOMPLETED:
+			tasks.Succeeded += count
+		default:
+			tasks.Failed += count
+		}
+
+		if code := getExitCode(job.ExitCode); code != nil && !isActive(job) {
+			if status.ExitCode == nil || *code > *status.ExitCode {
+				status.ExitCode = code
+			}
+		}
+		status.SubmitTime = minTime(status.SubmitTime, getTime(job.SubmitTime))
+		status.StartTime = minTime(status.StartTime, getTime(job.StartTime))
+		status.EndTime = maxTime(status.EndTime, getTime(job.EndTime))
+
+		if !isArray && i == 0 {
+			status.State = string(state)
+			status.StateReason = ptr.Deref(job.StateReason, "")
+		}
+	}
+
+	status.Finished = tasks.Pending == 0 && tasks.Running == 0
+	status.Succeeded = status.Finished && tasks.Failed == 0
+	if !status.Finished {
+		status.EndTime = nil
+	}
+
+	if isArray {
+		status.ArrayTasks = tasks
+		switch {
+		case tasks.Running > 0:
+			status.State = string(slurmapi.V0044JobInfoJobStateRUNNING)
+		case tasks.Pending > 0:
+			status.State = string(slurmapi.V0044JobInfoJobStatePENDING)
+		case tasks.Failed > 0:
+			status.State = string(slurmapi.V0044JobInfoJobStateFAILED)
+		default:
+			status.State = string(slurmapi.V0044JobInfoJobStateCOMPLETED)
+		}
+		if status.State != string(slurmapi.V0044JobInfoJobStatePENDING) {
+			status.StateReason = ""
+		}
+	}
+
+	return status
+}
+
+// countArrayTasks returns the number of tasks of an array task string
+// (e.g. `1,3,5-11:2%4`).
+func countArrayTasks(str string) int32 {
+	str, _, _ = strings.Cut(str, "%")
+	if str == "" {
+		return 1
+	}
+	count := int32(0)
+	for part := range strings.SplitSeq(str, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, ":")
+		step := 1
+		if hasStep {
+			if n, err := strconv.Atoi(stepStr); err == nil && n > 0 {
+				step = n
+			}
+		}
+		startStr, endStr, isRange := strings.Cut(rng, "-")
+		if !isRange {
+			count++
+			continue
+		}
+		start, err1 := strconv.Atoi(startStr)
+		end, err2 := strconv.Atoi(endStr)
+		if err1 != nil || err2 != nil || end < start {
+			count++
+			continue
+		}
+		count += int32((end-start)/step + 1)
+	}
+	return count
+}
+
+// getExitCode returns the exit code of the batch script, as a shell would
+// report it (i.e. 128+N when killed by signal N).
+func getExitCode(code *slurmapi.V0044ProcessExitCodeVerbose) *int32 {
+	if code == nil {
+		return nil
+	}
+	if code.Signal != nil && code.Signal.Id != nil && ptr.Deref(code.Signal.Id.Set, false) {
+		if signal := int32(ptr.Deref(code.Signal.Id.Number, 0)); signal > 0 {
+			return ptr.To(128 + signal)
+		}
+	}
+	if code.ReturnCode == nil || !ptr.Deref(code.ReturnCode.Set, false) {
+		return nil
+	}
+	return ptr.To(ptr.Deref(code.ReturnCode.Number, 0))
+}
+
+func getNoVal32(val *slurmapi.V0044Uint32NoValStruct) int32 {
+	if val == nil || !ptr.Deref(val.Set, false) {
+		return 0
+	}
+	return ptr.Deref(val.Number, 0)
+}
+
+// getTime returns the time of a Slurm timestamp, or nil if it is unset.
+func getTime(val *slurmapi.V0044Uint64NoValStruct) *time.Time {
+	if val == nil || !ptr.Deref(val.Set, false) || ptr.Deref(val.Number, 0) <= 0 {
+		return nil
+	}
+	return ptr.To(time.Unix(*val.Number, 0))
+}
+
+func minTime(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.Before(*a)) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.After(*a)) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjob
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmjob/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+const (
+	ControllerName = "slurmjob-controller"
+
+	// SubmittedReason is added to an event when the Slurm job is submitted.
+	SubmittedReason = "Submitted"
+	// FinishedReason is added to an event when the Slurm job finished.
+	FinishedReason = "Finished"
+	// CancelledReason is added to an event when the Slurm job is cancelled.
+	CancelledReason = "Cancelled"
+
+	// activeResyncPeriod is how often the Slurm job state is refreshed while
+	// the job is active, in case an informer event was missed.
+	activeResyncPeriod = 1 * time.Minute
+	// waitingResyncPeriod is how often a SlurmJob is retried while it waits
+	// for its dependencies, or for the slurm client of the Controller.
+	waitingResyncPeriod = 10 * time.Second
+	// jobNotFoundGracePeriod is how long after submission a missing Slurm job
+	// is tolerated, while the slurm client cache catches up.
+	jobNotFoundGracePeriod = 2 * time.Minute
+	// submitTokenLifetime is the lifetime of the JWT used to submit as a user.
+	submitTokenLifetime = 5 * time.Minute
+)
+
+func init() {
+	flag.IntVar(&maxConcurrentReconciles, "slurmjob-workers", maxConcurrentReconciles, "Max concurrent workers for SlurmJob controller.")
+}
+
+var (
+	maxConcurrentReconciles = 1
+
+	// this is a short cut for any sub-functions to notify the reconcile how long to wait to requeue
+	durationStore = durationstore.NewDurationStore(durationstore.Less)
+)
+
+// SlurmJobReconciler reconciles a SlurmJob object
+type SlurmJobReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	ClientMap *clientmap.ClientMap
+	EventCh   chan event.GenericEvent
+
+	slurmControl  slurmcontrol.SlurmControlInterface
+	refResolver   *refresolver.RefResolver
+	eventRecorder record.EventRecorderLogger
+}
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmjobs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmjobs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *SlurmJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, retErr error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Started syncing SlurmJob", "request", req)
+
+	startTime := time.Now()
+	defer func() {
+		if retErr == nil {
+			if res.RequeueAfter > 0 {
+				logger.Info("Finished syncing SlurmJob", "duration", time.Since(startTime), "result", res)
+			} else {
+				logger.Info("Finished syncing SlurmJob", "duration", time.Since(startTime))
+			}
+		} else {
+			logger.Info("Finished syncing SlurmJob", "duration", time.Since(startTime), "error", retErr)
+		}
+		// clean the duration store
+		_ = durationStore.Pop(req.String())
+	}()
+
+	retErr = r.Sync(ctx, req)
+	res = reconcile.Result{
+		RequeueAfter: durationStore.Pop(req.String()),
+	}
+	return res, retErr
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SlurmJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&slinkyv1beta1.SlurmJob{}).
+		WatchesRawSource(source.Channel(r.EventCh, &handler.EnqueueRequestForObject{})).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+		}).
+		Complete(r)
+}
+
+func NewReconciler(c client.Client, cm *clientmap.ClientMap, ec chan event.GenericEvent) *SlurmJobReconciler {
+	s := c.Scheme()
+	es := corev1.EventSource{Component: ControllerName}
+	if cm == nil {
+		panic("ClientMap cannot be nil")
+	}
+	if ec == nil {
+		panic("EventCh cannot be nil")
+	}
+	return &SlurmJobReconciler{
+		Client: c,
+		Scheme: s,
+
+		ClientMap: cm,
+		EventCh:   ec,
+
+		slurmControl:  slurmcontrol.NewSlurmControl(cm),
+		refResolver:   refresolver.New(c),
+		eventRecorder: record.NewBroadcaster().NewRecorder(s, es),
+	}
+}
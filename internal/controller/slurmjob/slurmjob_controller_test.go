@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjob
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("SlurmJob Controller", func() {
+	Context("When reconciling a SlurmJob", func() {
+		var name = testutils.GenerateResourceName(5)
+		var job *slinkyv1beta1.SlurmJob
+
+		BeforeEach(func() {
+			controller := testutils.NewController(name, corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			job = testutils.NewSlurmJob(name, controller, "#!/bin/bash\nsrun hostname\n")
+			job.Spec.Dependencies = []slinkyv1beta1.SlurmJobDependency{
+				{Type: slinkyv1beta1.SlurmJobDependencyAfterOK, SlurmJob: name + "-missing"},
+			}
+			Expect(k8sClient.Create(ctx, job.DeepCopy())).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, job)
+		})
+
+		It("Should successfully wait for its dependencies", func(ctx SpecContext) {
+			By("Creating SlurmJob CR")
+			createdJob := &slinkyv1beta1.SlurmJob{}
+			jobKey := client.ObjectKeyFromObject(job)
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, jobKey, createdJob)).To(Succeed())
+				g.Expect(controllerutil.ContainsFinalizer(createdJob, slinkyv1beta1.FinalizerSlurmJob)).To(BeTrue())
+				cond := meta.FindStatusCondition(createdJob.Status.Conditions, slinkyv1beta1.ConditionSubmitted)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Reason).To(Equal(slinkyv1beta1.ReasonWaitingForDeps))
+			}, testutils.Timeout, testutils.Interval).Should(Succeed())
+		}, SpecTimeout(testutils.Timeout))
+	})
+})
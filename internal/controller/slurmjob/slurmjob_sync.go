@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmjob/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+type SyncStep struct {
+	Name string
+	Sync func(ctx context.Context, job *slinkyv1beta1.SlurmJob) error
+}
+
+// syncState is what the sync steps observed, for the status.
+type syncState struct {
+	// Submitted is true when the Slurm job was submitted during this sync.
+	Submitted bool
+	// WaitingFor are the SlurmJobs, depended on, which were not yet submitted.
+	WaitingFor []string
+}
+
+// Sync implements control logic for synchronizing a SlurmJob.
+func (r *SlurmJobReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	job := &slinkyv1beta1.SlurmJob{}
+	if err := r.Get(ctx, req.NamespacedName, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("SlurmJob has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+
+	if !job.DeletionTimestamp.IsZero() {
+		return r.finalize(ctx, job)
+	}
+
+	state := &syncState{}
+	syncSteps := []SyncStep{
+		{
+			Name: "Finalizer",
+			Sync: func(ctx context.Context, job *slinkyv1beta1.SlurmJob) error {
+				if controllerutil.ContainsFinalizer(job, slinkyv1beta1.FinalizerSlurmJob) {
+					return nil
+				}
+				toUpdate := job.DeepCopy()
+				controllerutil.AddFinalizer(toUpdate, slinkyv1beta1.FinalizerSlurmJob)
+				if err := r.Patch(ctx, toUpdate, client.MergeFrom(job)); err != nil {
+					return err
+				}
+				job.Finalizers = toUpdate.Finalizers
+				return nil
+			},
+		},
+		{
+			Name: "Submit",
+			Sync: func(ctx context.Context, job *slinkyv1beta1.SlurmJob) error {
+				if job.IsSubmitted() {
+					return nil
+				}
+				return r.submit(ctx, job, state)
+			},
+		},
+	}
+
+	for _, s := range syncSteps {
+		if err := s.Sync(ctx, job); err != nil {
+			e := fmt.Errorf("[%s]: %w", s.Name, err)
+			errors := []error{e}
+			if err := r.syncStatus(ctx, job, state, e); err != nil {
+				e := fmt.Errorf("[%s]: %w", s.Name, err)
+				errors = append(errors, e)
+			}
+			return utilerrors.NewAggregate(errors)
+		}
+	}
+
+	if err := r.syncStatus(ctx, job, state, nil); err != nil {
+		return err
+	}
+
+	return r.syncTTL(ctx, job, time.Now())
+}
+
+// submit submits the Slurm job, once all of its dependencies were submitted.
+func (r *SlurmJobReconciler) submit(
+	ctx context.Context,
+	job *slinkyv1beta1.SlurmJob,
+	state *syncState,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(job)
+
+	dependency, waitingFor, err := r.getDependency(ctx, job)
+	if err != nil {
+		return err
+	}
+	if len(waitingFor) > 0 {
+		logger.V(1).Info("SlurmJob is waiting for its dependencies to be submitted",
+			"waitingFor", waitingFor)
+		state.WaitingFor = waitingFor
+		durationStore.Push(key, waitingResyncPeriod)
+		return nil
+	}
+
+	controller, err := r.refResolver.GetController(ctx, job.Spec.ControllerRef)
+	if err != nil {
+		return fmt.Errorf("failed to get controller (%s): %w", job.Spec.ControllerRef.NamespacedName(), err)
+	}
+
+	token := ""
+	if job.Spec.SubmitAs != "" {
+		if !controller.IsUserGranted(job.Namespace, job.Spec.SubmitAs) {
+			return fmt.Errorf("controller (%s) does not grant user (%s) to namespace (%s)",
+				objectutils.KeyFunc(controller), job.Spec.SubmitAs, job.Namespace)
+		}
+		signingKey, err := r.refResolver.GetJwtSigningKey(ctx, controller)
+		if err != nil {
+			return fmt.Errorf("failed to get JWT signing key: %w", err)
+		}
+		token, err = slurmjwt.NewTokenWithKey(signingKey).
+			WithUsername(job.Spec.SubmitAs).
+			WithLifetime(submitTokenLifetime).
+			NewSignedToken()
+		if err != nil {
+			return fmt.Errorf("failed to sign token for user (%s): %w", job.Spec.SubmitAs, err)
+		}
+	}
+
+	jobID, err := r.slurmControl.SubmitJob(ctx, controller.Key(), job, dependency, token)
+	if err != nil {
+		if errors.Is(err, slurmcontrol.ErrNoClient) {
+			logger.V(1).Info("No slurm client for controller yet, retrying later",
+				"controller", controller.Key())
+			durationStore.Push(key, waitingResyncPeriod)
+			return nil
+		}
+		return fmt.Errorf("failed to submit Slurm job (%s): %w", job.SlurmName(), err)
+	}
+
+	// Record the job ID at once, so the job is never submitted twice.
+	job.Status.JobID = jobID
+	state.Submitted = true
+	if err := r.updateStatus(ctx, job, &job.Status); err != nil {
+		return fmt.Errorf("failed to record Slurm job ID (%d): %w", jobID, err)
+	}
+	r.eventRecorder.Eventf(job, corev1.EventTypeNormal, SubmittedReason,
+		"Submitted Slurm job %d", jobID)
+	return nil
+}
+
+// getDependency returns the Slurm dependency string of the SlurmJob
+// (e.g. `afterok:123:456,afterany:789`), and the names of the SlurmJobs
+// depended on that were not yet submitted.
+func (r *SlurmJobReconciler) getDependency(
+	ctx context.Context,
+	job *slinkyv1beta1.SlurmJob,
+) (string, []string, error) {
+	waitingFor := []string{}
+	order := []slinkyv1beta1.SlurmJobDependencyType{}
+	jobIDs := map[slinkyv1beta1.SlurmJobDependencyType][]string{}
+
+	for _, dep := range job.Spec.Dependencies {
+		other := &slinkyv1beta1.SlurmJob{}
+		otherKey := types.NamespacedName{Namespace: job.Namespace, Name: dep.SlurmJob}
+		if err := r.Get(ctx, otherKey, other); err != nil {
+			if apierrors.IsNotFound(err) {
+				waitingFor = append(waitingFor, dep.SlurmJob)
+				continue
+			}
+			return "", nil, err
+		}
+		if !other.Spec.ControllerRef.IsMatch(job.Spec.ControllerRef.NamespacedName()) {
+			return "", nil, fmt.Errorf("dependency (%s) is for a different Controller", dep.SlurmJob)
+		}
+		if !other.IsSubmitted() {
+			waitingFor = append(waitingFor, dep.SlurmJob)
+			continue
+		}
+		depType := dep.Type
+		if depType == "" {
+			depType = slinkyv1beta1.SlurmJobDependencyAfterOK
+		}
+		if _, ok := jobIDs[depType]; !ok {
+			order = append(order, depType)
+		}
+		jobIDs[depType] = append(jobIDs[depType], fmt.Sprint(other.Status.JobID))
+	}
+
+	parts := make([]string, 0, len(order))
+	for _, depType := range order {
+		parts = append(parts, string(depType)+":"+strings.Join(jobIDs[depType], ":"))
+	}
+	return strings.Join(parts, ","), waitingFor, nil
+}
+
+// finalize cancels the Slurm job, if still active, and removes the finalizer.
+func (r *SlurmJobReconciler) finalize(ctx context.Context, job *slinkyv1beta1.SlurmJob) error {
+	if !controllerutil.ContainsFinalizer(job, slinkyv1beta1.FinalizerSlurmJob) {
+		return nil
+	}
+
+	if job.IsSubmitted() && !job.IsFinished() {
+		controllerKey := job.Spec.ControllerRef.NamespacedName()
+		if err := r.slurmControl.CancelJob(ctx, controllerKey, job.Status.JobID); err != nil {
+			return fmt.Errorf("failed to cancel Slurm job (%d): %w", job.Status.JobID, err)
+		}
+		r.eventRecorder.Eventf(job, corev1.EventTypeNormal, CancelledReason,
+			"Cancelled Slurm job %d", job.Status.JobID)
+	}
+
+	toUpdate := job.DeepCopy()
+	controllerutil.RemoveFinalizer(toUpdate, slinkyv1beta1.FinalizerSlurmJob)
+	return r.Patch(ctx, toUpdate, client.MergeFrom(job))
+}
+
+// syncTTL deletes the SlurmJob once its TTL after finishing has passed,
+// otherwise requeues for when it does.
+func (r *SlurmJobReconciler) syncTTL(ctx context.Context, job *slinkyv1beta1.SlurmJob, now time.Time) error {
+	logger := log.FromContext(ctx)
+
+	expiresAt, ok := job.ExpiresAt()
+	if !ok {
+		return nil
+	}
+	if now.Before(expiresAt) {
+		durationStore.Push(objectutils.KeyFunc(job), expiresAt.Sub(now))
+		return nil
+	}
+
+	logger.Info("Deleting finished SlurmJob, its TTL has passed", "expiredAt", expiresAt)
+	if err := r.Delete(ctx, job); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
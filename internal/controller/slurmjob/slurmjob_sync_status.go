@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmjob/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+// syncStatus handles determining and updating the status.
+func (r *SlurmJobReconciler) syncStatus(
+	ctx context.Context,
+	job *slinkyv1beta1.SlurmJob,
+	state *syncState,
+	syncErr error,
+) error {
+	logger := log.FromContext(ctx)
+	now := time.Now()
+
+	var jobStatus *slurmcontrol.JobStatus
+	if job.IsSubmitted() && !job.IsFinished() {
+		var err error
+		jobStatus, err = r.slurmControl.GetJob(ctx, job.Spec.ControllerRef.NamespacedName(), job.Status.JobID)
+		switch {
+		case errors.Is(err, slurmcontrol.ErrNoClient):
+			// Keep the last observation until the client is back.
+			logger.V(1).Info("No slurm client for controller, skipping status update",
+				"controller", job.Spec.ControllerRef.NamespacedName())
+			durationStore.Push(objectutils.KeyFunc(job), waitingResyncPeriod)
+			return nil
+		case err != nil:
+			return err
+		}
+	}
+
+	newStatus := newSlurmJobStatus(job, state, jobStatus, syncErr, now)
+	if job.IsSubmitted() && !isFinished(newStatus) {
+		durationStore.Push(objectutils.KeyFunc(job), activeResyncPeriod)
+	}
+
+	if apiequality.Semantic.DeepEqual(job.Status, *newStatus) {
+		logger.V(2).Info("SlurmJob Status has not changed, skipping status update",
+			"job", klog.KObj(job), "status", job.Status)
+		return nil
+	}
+
+	if !job.IsFinished() && isFinished(newStatus) {
+		eventType := corev1.EventTypeNormal
+		if meta.IsStatusConditionTrue(newStatus.Conditions, slinkyv1beta1.ConditionFailed) {
+			eventType = corev1.EventTypeWarning
+		}
+		r.eventRecorder.Eventf(job, eventType, FinishedReason,
+			"Slurm job %d finished: %s", newStatus.JobID, newStatus.State)
+	}
+
+	if err := r.updateStatus(ctx, job, newStatus); err != nil {
+		return fmt.Errorf("error updating SlurmJob(%s) status: %w",
+			klog.KObj(job), err)
+	}
+
+	return nil
+}
+
+// newSlurmJobStatus returns the status of the SlurmJob from the Slurm job
+// status, which is nil when the job is not known to slurmctld.
+func newSlurmJobStatus(
+	job *slinkyv1beta1.SlurmJob,
+	state *syncState,
+	jobStatus *slurmcontrol.JobStatus,
+	syncErr error,
+	now time.Time,
+) *slinkyv1beta1.SlurmJobStatus {
+	newStatus := job.Status.DeepCopy()
+	newStatus.ObservedGeneration = job.Generation
+
+	if jobStatus != nil {
+		newStatus.State = jobStatus.State
+		newStatus.StateReason = jobStatus.StateReason
+		newStatus.ExitCode = jobStatus.ExitCode
+		newStatus.ArrayTasks = jobStatus.ArrayTasks
+		newStatus.SubmitTime = toMetaTime(jobStatus.SubmitTime)
+		newStatus.StartTime = toMetaTime(jobStatus.StartTime)
+		newStatus.CompletionTime = toMetaTime(jobStatus.EndTime)
+	}
+
+	setConditions(job, newStatus, state, jobStatus, syncErr, now)
+
+	if isFinished(newStatus) && newStatus.CompletionTime == nil {
+		newStatus.CompletionTime = ptr.To(metav1.NewTime(now))
+	}
+
+	return newStatus
+}
+
+// setConditions sets the Submitted, Complete, and Failed conditions on the status.
+func setConditions(
+	job *slinkyv1beta1.SlurmJob,
+	status *slinkyv1beta1.SlurmJobStatus,
+	state *syncState,
+	jobStatus *slurmcontrol.JobStatus,
+	syncErr error,
+	now time.Time,
+) {
+	generation := job.Generation
+
+	submitted := metav1.Condition{
+		Type:               slinkyv1beta1.ConditionSubmitted,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             slinkyv1beta1.ReasonAsExpected,
+		Message:            fmt.Sprintf("Submitted as Slurm job %d", status.JobID),
+	}
+	switch {
+	case status.JobID != 0:
+	case syncErr != nil:
+		submitted.Status = metav1.ConditionFalse
+		submitted.Reason = slinkyv1beta1.ReasonSyncFailed
+		submitted.Message = syncErr.Error()
+	case len(state.WaitingFor) > 0:
+		submitted.Status = metav1.ConditionFalse
+		submitted.Reason = slinkyv1beta1.ReasonWaitingForDeps
+		submitted.Message = fmt.Sprintf("Waiting for SlurmJobs to be submitted: %s",
+			strings.Join(state.WaitingFor, ", "))
+	default:
+		submitted.Status = metav1.ConditionFalse
+		submitted.Reason = slinkyv1beta1.ReasonNotStarted
+		submitted.Message = "Slurm job has not been submitted"
+	}
+	meta.SetStatusCondition(&status.Conditions, submitted)
+
+	if status.JobID == 0 || job.IsFinished() {
+		// Preserve the last observation.
+		return
+	}
+
+	switch {
+	case jobStatus != nil && jobStatus.Finished:
+		complete := metav1.Condition{
+			Type:               slinkyv1beta1.ConditionComplete,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             slinkyv1beta1.ReasonAsExpected,
+			Message:            fmt.Sprintf("Slurm job %d completed", status.JobID),
+		}
+		failed := metav1.Condition{
+			Type:               slinkyv1beta1.ConditionFailed,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             slinkyv1beta1.ReasonAsExpected,
+			Message:            fmt.Sprintf("Slurm job %d completed", status.JobID),
+		}
+		if !jobStatus.Succeeded {
+			complete.Status = metav1.ConditionFalse
+			failed.Status = metav1.ConditionTrue
+			failed.Reason = jobStatus.State
+			failed.Message = fmt.Sprintf("Slurm job %d finished as %s", status.JobID, jobStatus.State)
+			if jobStatus.StateReason != "" {
+				failed.Message += ": " + jobStatus.StateReason
+			}
+		}
+		meta.SetStatusCondition(&status.Conditions, complete)
+		meta.SetStatusCondition(&status.Conditions, failed)
+	case jobStatus == nil && !state.Submitted && syncErr == nil && isLost(status, now):
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               slinkyv1beta1.ConditionFailed,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             slinkyv1beta1.ReasonJobNotFound,
+			Message:            fmt.Sprintf("Slurm job %d is not known to slurmctld", status.JobID),
+		})
+	}
+}
+
+// isLost reports if the Slurm job has been missing for longer than the grace
+// period after its submission.
+func isLost(status *slinkyv1beta1.SlurmJobStatus, now time.Time) bool {
+	submitted := meta.FindStatusCondition(status.Conditions, slinkyv1beta1.ConditionSubmitted)
+	if submitted == nil || submitted.Status != metav1.ConditionTrue {
+		return false
+	}
+	return now.Sub(submitted.LastTransitionTime.Time) >= jobNotFoundGracePeriod
+}
+
+// isFinished reports if the status has the Complete or Failed condition.
+func isFinished(status *slinkyv1beta1.SlurmJobStatus) bool {
+	return meta.IsStatusConditionTrue(status.Conditions, slinkyv1beta1.ConditionComplete) ||
+		meta.IsStatusConditionTrue(status.Conditions, slinkyv1beta1.ConditionFailed)
+}
+
+func toMetaTime(t *time.Time) *metav1.Time {
+	if t == nil {
+		return nil
+	}
+	return ptr.To(metav1.NewTime(*t))
+}
+
+func (r *SlurmJobReconciler) updateStatus(
+	ctx context.Context,
+	job *slinkyv1beta1.SlurmJob,
+	newStatus *slinkyv1beta1.SlurmJobStatus,
+) error {
+	logger := log.FromContext(ctx)
+	jobKey := objectutils.NamespacedName(job)
+
+	logger.V(1).Info("Pending SlurmJob Status update",
+		"job", klog.KObj(job), "newStatus", newStatus)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.SlurmJob{}
+		if err := r.Get(ctx, jobKey, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+	if err != nil {
+		return err
+	}
+	job.Status = *newStatus
+	return nil
+}
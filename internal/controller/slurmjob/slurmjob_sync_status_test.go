@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjob
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmjob/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_newSlurmJobStatus(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	submittedSince := func(since time.Time) *slinkyv1beta1.SlurmJob {
+		job := newSubmittedJob("foo", controller, 10)
+		job.Status.Conditions = []metav1.Condition{
+			{
+				Type:               slinkyv1beta1.ConditionSubmitted,
+				Status:             metav1.ConditionTrue,
+				Reason:             slinkyv1beta1.ReasonAsExpected,
+				LastTransitionTime: metav1.NewTime(since),
+			},
+		}
+		return job
+	}
+	type args struct {
+		job       *slinkyv1beta1.SlurmJob
+		state     *syncState
+		jobStatus *slurmcontrol.JobStatus
+		syncErr   error
+	}
+	tests := []struct {
+		name             string
+		args             args
+		wantSubmitted    string
+		wantComplete     metav1.ConditionStatus
+		wantFailed       metav1.ConditionStatus
+		wantFailedReason string
+		wantCompletion   bool
+	}{
+		{
+			name: "Waiting for dependencies",
+			args: args{
+				job:   testutils.NewSlurmJob("foo", controller, "#!/bin/bash\n"),
+				state: &syncState{WaitingFor: []string{"bar"}},
+			},
+			wantSubmitted: slinkyv1beta1.ReasonWaitingForDeps,
+		},
+		{
+			name: "Submit failed",
+			args: args{
+				job:     testutils.NewSlurmJob("foo", controller, "#!/bin/bash\n"),
+				state:   &syncState{},
+				syncErr: errors.New("error"),
+			},
+			wantSubmitted: slinkyv1beta1.ReasonSyncFailed,
+		},
+		{
+			name: "Running",
+			args: args{
+				job:       submittedSince(now),
+				state:     &syncState{},
+				jobStatus: &slurmcontrol.JobStatus{State: "RUNNING"},
+			},
+			wantSubmitted: slinkyv1beta1.ReasonAsExpected,
+		},
+		{
+			name: "Completed",
+			args: args{
+				job:   submittedSince(now),
+				state: &syncState{},
+				jobStatus: &slurmcontrol.JobStatus{
+					State:     "COMPLETED",
+					ExitCode:  ptr.To[int32](0),
+					EndTime:   ptr.To(now),
+					Finished:  true,
+					Succeeded: true,
+				},
+			},
+			wantSubmitted:  slinkyv1beta1.ReasonAsExpected,
+			wantComplete:   metav1.ConditionTrue,
+			wantFailed:     metav1.ConditionFalse,
+			wantCompletion: true,
+		},
+		{
+			name: "Failed",
+			args: args{
+				job:   submittedSince(now),
+				state: &syncState{},
+				jobStatus: &slurmcontrol.JobStatus{
+					State:    "TIMEOUT",
+					ExitCode: ptr.To[int32](0),
+					EndTime:  ptr.To(now),
+					Finished: true,
+				},
+			},
+			wantSubmitted:    slinkyv1beta1.ReasonAsExpected,
+			wantComplete:     metav1.ConditionFalse,
+			wantFailed:       metav1.ConditionTrue,
+			wantFailedReason: "TIMEOUT",
+			wantCompletion:   true,
+		},
+		{
+			name: "Not found, within grace period",
+			args: args{
+				job:   submittedSince(now),
+				state: &syncState{},
+			},
+			wantSubmitted: slinkyv1beta1.ReasonAsExpected,
+		},
+		{
+			name: "Not found",
+			args: args{
+				job:   submittedSince(now.Add(-time.Hour)),
+				state: &syncState{},
+			},
+			wantSubmitted:    slinkyv1beta1.ReasonAsExpected,
+			wantFailed:       metav1.ConditionTrue,
+			wantFailedReason: slinkyv1beta1.ReasonJobNotFound,
+			wantCompletion:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newSlurmJobStatus(tt.args.job, tt.args.state, tt.args.jobStatus, tt.args.syncErr, now)
+
+			submitted := meta.FindStatusCondition(got.Conditions, slinkyv1beta1.ConditionSubmitted)
+			if submitted == nil || submitted.Reason != tt.wantSubmitted {
+				t.Errorf("newSlurmJobStatus() Submitted = %v, want reason %v", submitted, tt.wantSubmitted)
+			}
+			checkCondition := func(condType string, want metav1.ConditionStatus, wantReason string) {
+				cond := meta.FindStatusCondition(got.Conditions, condType)
+				switch {
+				case want == "" && cond != nil:
+					t.Errorf("newSlurmJobStatus() %s = %v, want none", condType, cond)
+				case want == "":
+				case cond == nil || cond.Status != want:
+					t.Errorf("newSlurmJobStatus() %s = %v, want %v", condType, cond, want)
+				case wantReason != "" && cond.Reason != wantReason:
+					t.Errorf("newSlurmJobStatus() %s reason = %v, want %v", condType, cond.Reason, wantReason)
+				}
+			}
+			checkCondition(slinkyv1beta1.ConditionComplete, tt.wantComplete, "")
+			checkCondition(slinkyv1beta1.ConditionFailed, tt.wantFailed, tt.wantFailedReason)
+			if (got.CompletionTime != nil) != tt.wantCompletion {
+				t.Errorf("newSlurmJobStatus() CompletionTime = %v, wantCompletion %v", got.CompletionTime, tt.wantCompletion)
+			}
+		})
+	}
+}
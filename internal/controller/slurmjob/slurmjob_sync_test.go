@@ -0,0 +1,287 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjob
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmjob/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+// fakeSlurmControl records the submitted Slurm jobs.
+type fakeSlurmControl struct {
+	jobID      int32
+	dependency string
+	token      string
+	jobStatus  *slurmcontrol.JobStatus
+	cancelled  []int32
+}
+
+func (f *fakeSlurmControl) SubmitJob(_ context.Context, _ types.NamespacedName, _ *slinkyv1beta1.SlurmJob, dependency, token string) (int32, error) {
+	f.dependency = dependency
+	f.token = token
+	return f.jobID, nil
+}
+
+func (f *fakeSlurmControl) GetJob(_ context.Context, _ types.NamespacedName, _ int32) (*slurmcontrol.JobStatus, error) {
+	return f.jobStatus, nil
+}
+
+func (f *fakeSlurmControl) CancelJob(_ context.Context, _ types.NamespacedName, jobID int32) error {
+	f.cancelled = append(f.cancelled, jobID)
+	return nil
+}
+
+var _ slurmcontrol.SlurmControlInterface = &fakeSlurmControl{}
+
+func newReconciler(c client.Client, slurmControl slurmcontrol.SlurmControlInterface) *SlurmJobReconciler {
+	r := NewReconciler(c, clientmap.NewClientMap(), make(chan event.GenericEvent, 1))
+	r.slurmControl = slurmControl
+	return r
+}
+
+func newSubmittedJob(name string, controller *slinkyv1beta1.Controller, jobID int32) *slinkyv1beta1.SlurmJob {
+	job := testutils.NewSlurmJob(name, controller, "#!/bin/bash\n")
+	job.Status.JobID = jobID
+	return job
+}
+
+func TestSlurmJobReconciler_getDependency(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	other := testutils.NewController("other", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	a := newSubmittedJob("a", controller, 1)
+	b := newSubmittedJob("b", controller, 2)
+	c := newSubmittedJob("c", controller, 3)
+	pending := newSubmittedJob("pending", controller, 0)
+	foreign := newSubmittedJob("foreign", other, 4)
+
+	newJob := func(deps ...slinkyv1beta1.SlurmJobDependency) *slinkyv1beta1.SlurmJob {
+		job := testutils.NewSlurmJob("job", controller, "#!/bin/bash\n")
+		job.Spec.Dependencies = deps
+		return job
+	}
+	tests := []struct {
+		name           string
+		job            *slinkyv1beta1.SlurmJob
+		want           string
+		wantWaitingFor []string
+		wantErr        bool
+	}{
+		{
+			name:           "No dependencies",
+			job:            newJob(),
+			want:           "",
+			wantWaitingFor: []string{},
+		},
+		{
+			name: "Grouped by type",
+			job: newJob(
+				slinkyv1beta1.SlurmJobDependency{Type: slinkyv1beta1.SlurmJobDependencyAfterOK, SlurmJob: "a"},
+				slinkyv1beta1.SlurmJobDependency{Type: slinkyv1beta1.SlurmJobDependencyAfterAny, SlurmJob: "b"},
+				slinkyv1beta1.SlurmJobDependency{SlurmJob: "c"},
+			),
+			want:           "afterok:1:3,afterany:2",
+			wantWaitingFor: []string{},
+		},
+		{
+			name: "Waiting",
+			job: newJob(
+				slinkyv1beta1.SlurmJobDependency{SlurmJob: "a"},
+				slinkyv1beta1.SlurmJobDependency{SlurmJob: "pending"},
+				slinkyv1beta1.SlurmJobDependency{SlurmJob: "missing"},
+			),
+			want:           "afterok:1",
+			wantWaitingFor: []string{"pending", "missing"},
+		},
+		{
+			name: "Different Controller",
+			job: newJob(
+				slinkyv1beta1.SlurmJobDependency{SlurmJob: "foreign"},
+			),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClient(a, b, c, pending, foreign)
+			r := newReconciler(c, &fakeSlurmControl{})
+			got, waitingFor, err := r.getDependency(context.TODO(), tt.job)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SlurmJobReconciler.getDependency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SlurmJobReconciler.getDependency() = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(waitingFor, tt.wantWaitingFor) {
+				t.Errorf("SlurmJobReconciler.getDependency() waitingFor = %v, want %v", waitingFor, tt.wantWaitingFor)
+			}
+		})
+	}
+}
+
+func TestSlurmJobReconciler_Sync(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+
+	t.Run("Submit", func(t *testing.T) {
+		job := testutils.NewSlurmJob("foo", controller, "#!/bin/bash\n")
+		c := fake.NewClientBuilder().
+			WithObjects(controller, job).
+			WithStatusSubresource(job).
+			Build()
+		slurmControl := &fakeSlurmControl{
+			jobID:     10,
+			jobStatus: &slurmcontrol.JobStatus{State: "PENDING", StateReason: "Priority"},
+		}
+		r := newReconciler(c, slurmControl)
+		req := reconcile.Request{NamespacedName: job.Key()}
+		if err := r.Sync(context.TODO(), req); err != nil {
+			t.Fatalf("SlurmJobReconciler.Sync() error = %v", err)
+		}
+		_ = durationStore.Pop(req.String())
+
+		got := &slinkyv1beta1.SlurmJob{}
+		if err := c.Get(context.TODO(), job.Key(), got); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Status.JobID != 10 {
+			t.Errorf("Status.JobID = %v, want %v", got.Status.JobID, 10)
+		}
+		if got.Status.State != "PENDING" {
+			t.Errorf("Status.State = %v, want %v", got.Status.State, "PENDING")
+		}
+		if !slices.Contains(got.Finalizers, slinkyv1beta1.FinalizerSlurmJob) {
+			t.Errorf("Finalizers = %v, want %v", got.Finalizers, slinkyv1beta1.FinalizerSlurmJob)
+		}
+	})
+
+	t.Run("SubmitAs not granted", func(t *testing.T) {
+		job := testutils.NewSlurmJob("foo", controller, "#!/bin/bash\n")
+		job.Spec.SubmitAs = "root"
+		c := fake.NewClientBuilder().
+			WithObjects(controller, job).
+			WithStatusSubresource(job).
+			Build()
+		slurmControl := &fakeSlurmControl{jobID: 10}
+		r := newReconciler(c, slurmControl)
+		req := reconcile.Request{NamespacedName: job.Key()}
+		if err := r.Sync(context.TODO(), req); err == nil {
+			t.Fatalf("SlurmJobReconciler.Sync() error = nil, want error")
+		}
+		_ = durationStore.Pop(req.String())
+
+		got := &slinkyv1beta1.SlurmJob{}
+		if err := c.Get(context.TODO(), job.Key(), got); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Status.JobID != 0 {
+			t.Errorf("Status.JobID = %v, want %v", got.Status.JobID, 0)
+		}
+	})
+
+	t.Run("Cancel on delete", func(t *testing.T) {
+		job := newSubmittedJob("foo", controller, 10)
+		job.Finalizers = []string{slinkyv1beta1.FinalizerSlurmJob}
+		job.DeletionTimestamp = ptr.To(metav1.Now())
+		c := fake.NewClientBuilder().
+			WithObjects(controller, job).
+			WithStatusSubresource(job).
+			Build()
+		slurmControl := &fakeSlurmControl{}
+		r := newReconciler(c, slurmControl)
+		if err := r.Sync(context.TODO(), reconcile.Request{NamespacedName: job.Key()}); err != nil {
+			t.Fatalf("SlurmJobReconciler.Sync() error = %v", err)
+		}
+		if !slices.Equal(slurmControl.cancelled, []int32{10}) {
+			t.Errorf("cancelled = %v, want %v", slurmControl.cancelled, []int32{10})
+		}
+		if err := c.Get(context.TODO(), job.Key(), &slinkyv1beta1.SlurmJob{}); !apierrors.IsNotFound(err) {
+			t.Errorf("Get() error = %v, want NotFound", err)
+		}
+	})
+}
+
+func TestSlurmJobReconciler_syncTTL(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	now := time.Now()
+	newFinishedJob := func(ttl *int32, completed time.Time) *slinkyv1beta1.SlurmJob {
+		job := newSubmittedJob("foo", controller, 10)
+		job.Spec.TTLSecondsAfterFinished = ttl
+		job.Status.CompletionTime = ptr.To(metav1.NewTime(completed))
+		job.Status.Conditions = []metav1.Condition{
+			{Type: slinkyv1beta1.ConditionComplete, Status: metav1.ConditionTrue},
+		}
+		return job
+	}
+	tests := []struct {
+		name        string
+		job         *slinkyv1beta1.SlurmJob
+		wantDeleted bool
+		wantRequeue bool
+	}{
+		{
+			name:        "No TTL",
+			job:         newFinishedJob(nil, now.Add(-time.Hour)),
+			wantDeleted: false,
+			wantRequeue: false,
+		},
+		{
+			name:        "Not finished",
+			job:         newSubmittedJob("foo", controller, 10),
+			wantDeleted: false,
+			wantRequeue: false,
+		},
+		{
+			name:        "Not expired",
+			job:         newFinishedJob(ptr.To[int32](600), now.Add(-time.Minute)),
+			wantDeleted: false,
+			wantRequeue: true,
+		},
+		{
+			name:        "Expired",
+			job:         newFinishedJob(ptr.To[int32](60), now.Add(-time.Hour)),
+			wantDeleted: true,
+			wantRequeue: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClient(tt.job)
+			r := newReconciler(c, &fakeSlurmControl{})
+			key := reconcile.Request{NamespacedName: tt.job.Key()}.String()
+			defer durationStore.Pop(key)
+
+			if err := r.syncTTL(context.TODO(), tt.job, now); err != nil {
+				t.Fatalf("SlurmJobReconciler.syncTTL() error = %v", err)
+			}
+			err := c.Get(context.TODO(), tt.job.Key(), &slinkyv1beta1.SlurmJob{})
+			if deleted := apierrors.IsNotFound(err); deleted != tt.wantDeleted {
+				t.Errorf("SlurmJobReconciler.syncTTL() deleted = %v, wantDeleted %v", deleted, tt.wantDeleted)
+			}
+			if requeue := durationStore.Peek(key) > 0; requeue != tt.wantRequeue {
+				t.Errorf("SlurmJobReconciler.syncTTL() requeue = %v, wantRequeue %v", requeue, tt.wantRequeue)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"slices"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+func NewNodeSetEventHandler(reader client.Reader) *NodeSetEventHandler {
+	return &NodeSetEventHandler{
+		Reader: reader,
+	}
+}
+
+var _ handler.EventHandler = &NodeSetEventHandler{}
+
+type NodeSetEventHandler struct {
+	client.Reader
+}
+
+func (e *NodeSetEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *NodeSetEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.ObjectNew, q)
+}
+
+func (e *NodeSetEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *NodeSetEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+func (e *NodeSetEventHandler) enqueueRequest(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	logger := log.FromContext(ctx)
+
+	nodeset, ok := obj.(*slinkyv1beta1.NodeSet)
+	if !ok {
+		return
+	}
+
+	list := &slinkyv1beta1.SlurmMaintenanceList{}
+	if err := e.List(ctx, list, client.InNamespace(nodeset.Namespace)); err != nil {
+		logger.Error(err, "failed to list SlurmMaintenances")
+		return
+	}
+
+	for _, item := range list.Items {
+		// Pods selected by label may belong to any NodeSet.
+		if item.Spec.PodSelector == nil &&
+			!slices.Contains(item.Spec.NodeSets, nodeset.Name) &&
+			!slices.Contains(item.Status.NodeSets, nodeset.Name) {
+			continue
+		}
+		objectutils.EnqueueRequest(q, &item)
+	}
+}
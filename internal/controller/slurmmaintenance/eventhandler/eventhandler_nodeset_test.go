@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_NodeSetEventHandler_Create(t *testing.T) {
+	slurmKeyRef := testutils.NewSlurmKeyRef("foo")
+	jwtHs256KeyRef := testutils.NewJwtHs256KeyRef("foo")
+	controller := testutils.NewController("slurm", slurmKeyRef, jwtHs256KeyRef, nil)
+	nodesetA := testutils.NewNodeset("slurmA", controller, 2)
+	start := time.Now().Add(time.Hour)
+	byName := testutils.NewSlurmMaintenance("by-name", []string{"slurmA"}, start, time.Hour)
+	other := testutils.NewSlurmMaintenance("other", []string{"slurmB"}, start, time.Hour)
+	bySelector := testutils.NewSlurmMaintenance("by-selector", nil, start, time.Hour)
+	bySelector.Spec.PodSelector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"foo": "bar"},
+	}
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.CreateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Empty",
+			fields: fields{
+				Reader: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{},
+				q:   newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "No SlurmMaintenance",
+			fields: fields{
+				Reader: fake.NewFakeClient(nodesetA),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: nodesetA,
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "By name and selector",
+			fields: fields{
+				Reader: fake.NewFakeClient(nodesetA, byName, other, bySelector),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: nodesetA,
+				},
+				q: newQueue(),
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewNodeSetEventHandler(tt.fields.Reader)
+			e.Create(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("NodeSetEventHandler.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/puttsk/hostlist"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmapiclient "github.com/SlinkyProject/slurm-client/pkg/client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+)
+
+// ErrNoClient is returned when there is no Slurm client for the Controller.
+var ErrNoClient = errors.New("no slurm client for controller")
+
+// NodeActivity describes the workload of the Slurm nodes under maintenance.
+type NodeActivity struct {
+	// QuiescedNodes are drained and have no running jobs.
+	QuiescedNodes []string
+	// RunningJobs are the IDs of the jobs running on any of the nodes.
+	RunningJobs []int32
+}
+
+type SlurmControlInterface interface {
+	// SyncReservation creates the maintenance reservation on the Slurm nodes,
+	// or updates it when update is true.
+	SyncReservation(ctx context.Context, controllerKey types.NamespacedName, maintenance *slinkyv1beta1.SlurmMaintenance, nodes []string, update bool) error
+	// DeleteReservation deletes the maintenance reservation.
+	DeleteReservation(ctx context.Context, controllerKey types.NamespacedName, maintenance *slinkyv1beta1.SlurmMaintenance) error
+	// GetNodeActivity returns which Slurm nodes are quiesced and which jobs are still running on them.
+	GetNodeActivity(ctx context.Context, controllerKey types.NamespacedName, nodes []string) (NodeActivity, error)
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+// getReservation reports if the reservation exists through slurmrestd.
+var getReservation = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, name string) (bool, error) {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return false, err
+	}
+	res, err := client.SlurmV0044GetReservationWithResponse(ctx, name, nil)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case res.StatusCode() == http.StatusNotFound:
+		return false, nil
+	case res.StatusCode() != http.StatusOK || res.JSON200 == nil:
+		return false, errors.New(http.StatusText(res.StatusCode()))
+	}
+	return len(res.JSON200.Reservations) > 0, nil
+}
+
+// postReservation creates or updates the reservation through slurmrestd.
+var postReservation = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, desc slurmapi.V0044ReservationDescMsg) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	res, err := client.SlurmV0044PostReservationWithResponse(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
+// deleteReservation deletes the reservation through slurmrestd.
+var deleteReservation = func(ctx context.Context, slurmClient slurmclient.Client, httpClient *http.Client, name string) error {
+	client, err := slurmapiclient.NewSlurmClient(slurmClient.GetServer(), slurmClient.GetToken(), httpClient)
+	if err != nil {
+		return err
+	}
+	res, err := client.SlurmV0044DeleteReservationWithResponse(ctx, name)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() != http.StatusOK {
+		return errors.New(http.StatusText(res.StatusCode()))
+	}
+	return nil
+}
+
+// SyncReservation implements SlurmControlInterface.
+func (r *realSlurmControl) SyncReservation(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	maintenance *slinkyv1beta1.SlurmMaintenance,
+	nodes []string,
+	update bool,
+) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		return ErrNoClient
+	}
+	httpClient := r.clientMap.GetHTTPClient(controllerKey)
+
+	name := maintenance.ReservationName()
+	exists, err := getReservation(ctx, slurmClient, httpClient, name)
+	if err != nil {
+		return err
+	}
+	if exists && !update {
+		return nil
+	}
+
+	desc := newReservationDesc(maintenance, nodes, exists, time.Now())
+	logger.V(1).Info("Syncing Slurm maintenance reservation",
+		"reservation", name, "nodes", nodes, "exists", exists)
+	return postReservation(ctx, slurmClient, httpClient, desc)
+}
+
+// newReservationDesc returns the reservation request for the maintenance window.
+// The start time of a reservation cannot be in the past, so it is only set
+// when the reservation is created or the window has not started yet.
+func newReservationDesc(
+	maintenance *slinkyv1beta1.SlurmMaintenance,
+	nodes []string,
+	exists bool,
+	now time.Time,
+) slurmapi.V0044ReservationDescMsg {
+	desc := slurmapi.V0044ReservationDescMsg{
+		Name:     ptr.To(maintenance.ReservationName()),
+		NodeList: ptr.To(slices.Clone(nodes)),
+		Users:    ptr.To(slices.Clone(maintenance.Users())),
+		Flags: ptr.To([]slurmapi.V0044ReservationDescMsgFlags{
+			slurmapi.V0044ReservationDescMsgFlagsMAINT,
+			slurmapi.V0044ReservationDescMsgFlagsIGNOREJOBS,
+		}),
+		EndTime: &slurmapi.V0044Uint64NoValStruct{
+			Set:    ptr.To(true),
+			Number: ptr.To(maintenance.EndTime().Unix()),
+		},
+	}
+	if !exists || maintenance.IsUpcoming(now) {
+		startTime := maintenance.Spec.StartTime.Time
+		if startTime.Before(now) {
+			startTime = now
+		}
+		desc.StartTime = &slurmapi.V0044Uint64NoValStruct{
+			Set:    ptr.To(true),
+			Number: ptr.To(startTime.Unix()),
+		}
+	}
+	return desc
+}
+
+// DeleteReservation implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteReservation(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	maintenance *slinkyv1beta1.SlurmMaintenance,
+) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do DeleteReservation()",
+			"controller", controllerKey)
+		return nil
+	}
+	httpClient := r.clientMap.GetHTTPClient(controllerKey)
+
+	name := maintenance.ReservationName()
+	exists, err := getReservation(ctx, slurmClient, httpClient, name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	logger.V(1).Info("Deleting Slurm maintenance reservation", "reservation", name)
+	return deleteReservation(ctx, slurmClient, httpClient, name)
+}
+
+// GetNodeActivity implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodeActivity(
+	ctx context.Context,
+	controllerKey types.NamespacedName,
+	nodes []string,
+) (NodeActivity, error) {
+	logger := log.FromContext(ctx)
+	activity := NodeActivity{}
+
+	slurmClient := r.clientMap.Get(controllerKey)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do GetNodeActivity()",
+			"controller", controllerKey)
+		return activity, nil
+	}
+
+	nodeSet := set.New(nodes...)
+
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList, &slurmclient.ListOptions{RefreshCache: true}); err != nil {
+		return activity, err
+	}
+	busyNodes := set.New[string]()
+	for _, job := range jobList.Items {
+		if !job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStateRUNNING) {
+			continue
+		}
+		jobNodes, err := hostlist.Expand(ptr.Deref(job.Nodes, ""))
+		if err != nil {
+			logger.Error(err, "failed to expand job node hostlist",
+				"job", ptr.Deref(job.JobId, 0))
+			return activity, err
+		}
+		if !nodeSet.HasAny(jobNodes...) {
+			continue
+		}
+		busyNodes.Insert(jobNodes...)
+		activity.RunningJobs = append(activity.RunningJobs, ptr.Deref(job.JobId, 0))
+	}
+	slices.Sort(activity.RunningJobs)
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList, &slurmclient.ListOptions{RefreshCache: true}); err != nil {
+		return activity, err
+	}
+	for _, node := range nodeList.Items {
+		name := ptr.Deref(node.Name, "")
+		if !nodeSet.Has(name) || busyNodes.Has(name) {
+			continue
+		}
+		// Drained is when a node has the DRAIN flag and is not doing any work (e.g. job step, prolog, epilog).
+		state := node.GetStateAsSet()
+		isBusy := state.HasAny(slurmapi.V0044NodeStateALLOCATED, slurmapi.V0044NodeStateMIXED, slurmapi.V0044NodeStateCOMPLETING)
+		isDrain := state.Has(slurmapi.V0044NodeStateDRAIN) && !state.Has(slurmapi.V0044NodeStateUNDRAIN)
+		if isDrain && !isBusy {
+			activity.QuiescedNodes = append(activity.QuiescedNodes, name)
+		}
+	}
+	slices.Sort(activity.QuiescedNodes)
+
+	return activity, nil
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clusters *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clusters,
+	}
+}
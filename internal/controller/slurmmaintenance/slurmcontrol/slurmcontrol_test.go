@@ -0,0 +1,495 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var controllerKey = types.NamespacedName{
+	Namespace: corev1.NamespaceDefault,
+	Name:      "slurm",
+}
+
+func newSlurmClientMap(client slurmclient.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	cm.Add(controllerKey, client)
+	return cm
+}
+
+func newNoValStruct(t time.Time) *slurmapi.V0044Uint64NoValStruct {
+	return &slurmapi.V0044Uint64NoValStruct{
+		Set:    ptr.To(true),
+		Number: ptr.To(t.Unix()),
+	}
+}
+
+func Test_newReservationDesc(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	upcoming := testutils.NewSlurmMaintenance("foo", []string{"bar"}, now.Add(time.Hour), time.Hour)
+	active := testutils.NewSlurmMaintenance("foo", []string{"bar"}, now.Add(-time.Hour), 2*time.Hour)
+	active.Spec.Users = []string{"admin"}
+	flags := &[]slurmapi.V0044ReservationDescMsgFlags{
+		slurmapi.V0044ReservationDescMsgFlagsMAINT,
+		slurmapi.V0044ReservationDescMsgFlagsIGNOREJOBS,
+	}
+	type args struct {
+		nodes  []string
+		exists bool
+	}
+	tests := []struct {
+		name        string
+		maintenance *slinkyv1beta1.SlurmMaintenance
+		args        args
+		want        slurmapi.V0044ReservationDescMsg
+	}{
+		{
+			name:        "Upcoming, new",
+			maintenance: upcoming,
+			args: args{
+				nodes:  []string{"bar-0", "bar-1"},
+				exists: false,
+			},
+			want: slurmapi.V0044ReservationDescMsg{
+				Name:      ptr.To("slinky_default_foo"),
+				NodeList:  ptr.To([]string{"bar-0", "bar-1"}),
+				Users:     ptr.To([]string{"root"}),
+				Flags:     flags,
+				StartTime: newNoValStruct(now.Add(time.Hour)),
+				EndTime:   newNoValStruct(now.Add(2 * time.Hour)),
+			},
+		},
+		{
+			name:        "Upcoming, exists",
+			maintenance: upcoming,
+			args: args{
+				nodes:  []string{"bar-0"},
+				exists: true,
+			},
+			want: slurmapi.V0044ReservationDescMsg{
+				Name:      ptr.To("slinky_default_foo"),
+				NodeList:  ptr.To([]string{"bar-0"}),
+				Users:     ptr.To([]string{"root"}),
+				Flags:     flags,
+				StartTime: newNoValStruct(now.Add(time.Hour)),
+				EndTime:   newNoValStruct(now.Add(2 * time.Hour)),
+			},
+		},
+		{
+			name:        "Active, new",
+			maintenance: active,
+			args: args{
+				nodes:  []string{"bar-0"},
+				exists: false,
+			},
+			want: slurmapi.V0044ReservationDescMsg{
+				Name:      ptr.To("slinky_default_foo"),
+				NodeList:  ptr.To([]string{"bar-0"}),
+				Users:     ptr.To([]string{"admin"}),
+				Flags:     flags,
+				StartTime: newNoValStruct(now),
+				EndTime:   newNoValStruct(now.Add(time.Hour)),
+			},
+		},
+		{
+			name:        "Active, exists",
+			maintenance: active,
+			args: args{
+				nodes:  []string{"bar-0"},
+				exists: true,
+			},
+			want: slurmapi.V0044ReservationDescMsg{
+				Name:     ptr.To("slinky_default_foo"),
+				NodeList: ptr.To([]string{"bar-0"}),
+				Users:    ptr.To([]string{"admin"}),
+				Flags:    flags,
+				EndTime:  newNoValStruct(now.Add(time.Hour)),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newReservationDesc(tt.maintenance, tt.args.nodes, tt.args.exists, now)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newReservationDesc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getReservation(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name:       "Exists",
+			statusCode: http.StatusOK,
+			body:       `{"reservations":[{"name":"maintenance"}]}`,
+			want:       true,
+		},
+		{
+			name:       "Empty",
+			statusCode: http.StatusOK,
+			body:       `{"reservations":[]}`,
+			want:       false,
+		},
+		{
+			name:       "Not found",
+			statusCode: http.StatusNotFound,
+			body:       `{}`,
+			want:       false,
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{}`,
+			wantErr:    true,
+		},
+		{
+			name:       "Unavailable",
+			statusCode: http.StatusServiceUnavailable,
+			body:       `{}`,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			slurmClient := fake.NewFakeClient()
+			slurmClient.SetServer(server.URL)
+
+			got, err := getReservation(context.Background(), slurmClient, server.Client(), "maintenance")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getReservation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getReservation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_SyncReservation(t *testing.T) {
+	ctx := context.Background()
+	maintenance := testutils.NewSlurmMaintenance("foo", []string{"bar"}, time.Now().Add(time.Hour), time.Hour)
+	type fields struct {
+		clientMap *clientmap.ClientMap
+	}
+	type args struct {
+		nodes  []string
+		update bool
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		exists   bool
+		getErr   error
+		postErr  error
+		wantPost bool
+		wantErr  bool
+	}{
+		{
+			name: "No client",
+			fields: fields{
+				clientMap: clientmap.NewClientMap(),
+			},
+			args:     args{nodes: []string{"bar-0"}},
+			wantPost: false,
+			wantErr:  true,
+		},
+		{
+			name: "Create",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			},
+			args:     args{nodes: []string{"bar-0"}},
+			exists:   false,
+			wantPost: true,
+			wantErr:  false,
+		},
+		{
+			name: "Exists, no update",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			},
+			args:     args{nodes: []string{"bar-0"}},
+			exists:   true,
+			wantPost: false,
+			wantErr:  false,
+		},
+		{
+			name: "Exists, update",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			},
+			args:     args{nodes: []string{"bar-0"}, update: true},
+			exists:   true,
+			wantPost: true,
+			wantErr:  false,
+		},
+		{
+			name: "Get error",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			},
+			args:     args{nodes: []string{"bar-0"}},
+			getErr:   errors.New(http.StatusText(http.StatusInternalServerError)),
+			wantPost: false,
+			wantErr:  true,
+		},
+		{
+			name: "Post error",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			},
+			args:     args{nodes: []string{"bar-0"}},
+			postErr:  errors.New(http.StatusText(http.StatusInternalServerError)),
+			wantPost: true,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getReservationFn, postReservationFn := getReservation, postReservation
+			defer func() { getReservation, postReservation = getReservationFn, postReservationFn }()
+			getReservation = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ string) (bool, error) {
+				return tt.exists, tt.getErr
+			}
+			posted := false
+			postReservation = func(_ context.Context, _ slurmclient.Client, _ *http.Client, desc slurmapi.V0044ReservationDescMsg) error {
+				posted = true
+				if got := ptr.Deref(desc.NodeList, nil); !reflect.DeepEqual(got, tt.args.nodes) {
+					t.Errorf("postReservation() nodes = %v, want %v", got, tt.args.nodes)
+				}
+				return tt.postErr
+			}
+			r := &realSlurmControl{
+				clientMap: tt.fields.clientMap,
+			}
+			err := r.SyncReservation(ctx, controllerKey, maintenance, tt.args.nodes, tt.args.update)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.SyncReservation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if posted != tt.wantPost {
+				t.Errorf("realSlurmControl.SyncReservation() posted = %v, wantPost %v", posted, tt.wantPost)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_DeleteReservation(t *testing.T) {
+	ctx := context.Background()
+	maintenance := testutils.NewSlurmMaintenance("foo", []string{"bar"}, time.Now(), time.Hour)
+	type fields struct {
+		clientMap *clientmap.ClientMap
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		exists     bool
+		deleteErr  error
+		wantDelete bool
+		wantErr    bool
+	}{
+		{
+			name: "No client",
+			fields: fields{
+				clientMap: clientmap.NewClientMap(),
+			},
+			wantDelete: false,
+			wantErr:    false,
+		},
+		{
+			name: "Not found",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			},
+			exists:     false,
+			wantDelete: false,
+			wantErr:    false,
+		},
+		{
+			name: "Delete",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			},
+			exists:     true,
+			wantDelete: true,
+			wantErr:    false,
+		},
+		{
+			name: "Delete error",
+			fields: fields{
+				clientMap: newSlurmClientMap(fake.NewFakeClient()),
+			},
+			exists:     true,
+			deleteErr:  errors.New(http.StatusText(http.StatusInternalServerError)),
+			wantDelete: true,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getReservationFn, deleteReservationFn := getReservation, deleteReservation
+			defer func() { getReservation, deleteReservation = getReservationFn, deleteReservationFn }()
+			getReservation = func(_ context.Context, _ slurmclient.Client, _ *http.Client, _ string) (bool, error) {
+				return tt.exists, nil
+			}
+			deleted := false
+			deleteReservation = func(_ context.Context, _ slurmclient.Client, _ *http.Client, name string) error {
+				deleted = true
+				if name != maintenance.ReservationName() {
+					t.Errorf("deleteReservation() name = %v, want %v", name, maintenance.ReservationName())
+				}
+				return tt.deleteErr
+			}
+			r := &realSlurmControl{
+				clientMap: tt.fields.clientMap,
+			}
+			if err := r.DeleteReservation(ctx, controllerKey, maintenance); (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.DeleteReservation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if deleted != tt.wantDelete {
+				t.Errorf("realSlurmControl.DeleteReservation() deleted = %v, wantDelete %v", deleted, tt.wantDelete)
+			}
+		})
+	}
+}
+
+func newNode(name string, states ...slurmapi.V0044NodeState) slurmtypes.V0044Node {
+	return slurmtypes.V0044Node{
+		V0044Node: slurmapi.V0044Node{
+			Name:  ptr.To(name),
+			State: ptr.To(states),
+		},
+	}
+}
+
+func newJob(jobId int32, state slurmapi.V0044JobInfoJobState, nodes string) slurmtypes.V0044JobInfo {
+	return slurmtypes.V0044JobInfo{
+		V0044JobInfo: slurmapi.V0044JobInfo{
+			JobId:    ptr.To(jobId),
+			JobState: ptr.To([]slurmapi.V0044JobInfoJobState{state}),
+			Nodes:    ptr.To(nodes),
+		},
+	}
+}
+
+func Test_realSlurmControl_GetNodeActivity(t *testing.T) {
+	ctx := context.Background()
+	type fields struct {
+		clientMap *clientmap.ClientMap
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		nodes   []string
+		want    NodeActivity
+		wantErr bool
+	}{
+		{
+			name: "No client",
+			fields: fields{
+				clientMap: clientmap.NewClientMap(),
+			},
+			nodes:   []string{"bar-0"},
+			want:    NodeActivity{},
+			wantErr: false,
+		},
+		{
+			name: "Quiesced and busy nodes",
+			fields: func() fields {
+				nodeList := &slurmtypes.V0044NodeList{
+					Items: []slurmtypes.V0044Node{
+						newNode("bar-0", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDRAIN),
+						newNode("bar-1", slurmapi.V0044NodeStateMIXED, slurmapi.V0044NodeStateDRAIN),
+						newNode("bar-2", slurmapi.V0044NodeStateIDLE),
+						newNode("bar-3", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDRAIN),
+						newNode("other-0", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDRAIN),
+					},
+				}
+				jobList := &slurmtypes.V0044JobInfoList{
+					Items: []slurmtypes.V0044JobInfo{
+						newJob(3, slurmapi.V0044JobInfoJobStateRUNNING, "bar-1"),
+						newJob(2, slurmapi.V0044JobInfoJobStateRUNNING, "bar-3,other-0"),
+						newJob(1, slurmapi.V0044JobInfoJobStateCOMPLETED, "bar-0"),
+						newJob(4, slurmapi.V0044JobInfoJobStateRUNNING, "other-0"),
+					},
+				}
+				sclient := fake.NewClientBuilder().WithLists(nodeList, jobList).Build()
+				return fields{
+					clientMap: newSlurmClientMap(sclient),
+				}
+			}(),
+			nodes: []string{"bar-0", "bar-1", "bar-2", "bar-3"},
+			want: NodeActivity{
+				QuiescedNodes: []string{"bar-0"},
+				RunningJobs:   []int32{2, 3},
+			},
+			wantErr: false,
+		},
+		{
+			name: "List error",
+			fields: func() fields {
+				sclient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, list object.ObjectList, opts ...slurmclient.ListOption) error {
+						return errors.New(http.StatusText(http.StatusInternalServerError))
+					},
+				}).Build()
+				return fields{
+					clientMap: newSlurmClientMap(sclient),
+				}
+			}(),
+			nodes:   []string{"bar-0"},
+			want:    NodeActivity{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &realSlurmControl{
+				clientMap: tt.fields.clientMap,
+			}
+			got, err := r.GetNodeActivity(ctx, controllerKey, tt.nodes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.GetNodeActivity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("realSlurmControl.GetNodeActivity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
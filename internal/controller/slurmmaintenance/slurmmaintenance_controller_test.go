@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmmaintenance
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("SlurmMaintenance Controller", func() {
+	Context("When reconciling a SlurmMaintenance", func() {
+		var name = testutils.GenerateResourceName(5)
+		var maintenance *slinkyv1beta1.SlurmMaintenance
+
+		BeforeEach(func() {
+			maintenance = testutils.NewSlurmMaintenance(name, []string{name}, time.Now().Add(time.Hour), time.Hour)
+			Expect(k8sClient.Create(ctx, maintenance.DeepCopy())).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, maintenance)
+		})
+
+		It("Should successfully report an invalid selection", func(ctx SpecContext) {
+			By("Creating SlurmMaintenance CR")
+			createdMaintenance := &slinkyv1beta1.SlurmMaintenance{}
+			maintenanceKey := client.ObjectKeyFromObject(maintenance)
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, maintenanceKey, createdMaintenance)).To(Succeed())
+				g.Expect(controllerutil.ContainsFinalizer(createdMaintenance, slinkyv1beta1.FinalizerSlurmMaintenance)).To(BeTrue())
+				g.Expect(createdMaintenance.Status.Phase).To(Equal(slinkyv1beta1.SlurmMaintenancePending))
+			}, testutils.Timeout, testutils.Interval).Should(Succeed())
+		}, SpecTimeout(testutils.Timeout))
+	})
+})
@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmmaintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+type SyncStep struct {
+	Name string
+	Sync func(ctx context.Context, maintenance *slinkyv1beta1.SlurmMaintenance) error
+}
+
+// maintenanceTargets are the NodeSet pods selected by a SlurmMaintenance.
+type maintenanceTargets struct {
+	// ControllerKey is the Controller of the selected NodeSets.
+	ControllerKey types.NamespacedName
+	// NodeSets are the names of the NodeSets with selected pods.
+	NodeSets []string
+	// Nodes are the Slurm node names of the selected pods.
+	Nodes []string
+	// ConfigErr is set when the selection cannot be reserved.
+	ConfigErr error
+}
+
+// Sync implements control logic for synchronizing a SlurmMaintenance.
+func (r *SlurmMaintenanceReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	maintenance := &slinkyv1beta1.SlurmMaintenance{}
+	if err := r.Get(ctx, req.NamespacedName, maintenance); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("SlurmMaintenance has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+
+	targets, err := r.getMaintenanceTargets(ctx, maintenance)
+	if err != nil {
+		return err
+	}
+
+	if !maintenance.DeletionTimestamp.IsZero() {
+		return r.finalize(ctx, maintenance, targets)
+	}
+
+	syncSteps := []SyncStep{
+		{
+			Name: "Finalizer",
+			Sync: func(ctx context.Context, maintenance *slinkyv1beta1.SlurmMaintenance) error {
+				if controllerutil.ContainsFinalizer(maintenance, slinkyv1beta1.FinalizerSlurmMaintenance) {
+					return nil
+				}
+				toUpdate := maintenance.DeepCopy()
+				controllerutil.AddFinalizer(toUpdate, slinkyv1beta1.FinalizerSlurmMaintenance)
+				if err := r.Patch(ctx, toUpdate, client.MergeFrom(maintenance)); err != nil {
+					return err
+				}
+				maintenance.Finalizers = toUpdate.Finalizers
+				return nil
+			},
+		},
+		{
+			Name: "Reservation",
+			Sync: func(ctx context.Context, maintenance *slinkyv1beta1.SlurmMaintenance) error {
+				now := time.Now()
+				if targets.ConfigErr != nil || !now.Before(maintenance.EndTime()) {
+					return nil
+				}
+				update := maintenance.Status.ObservedGeneration != maintenance.Generation ||
+					!slices.Equal(maintenance.Status.Nodes, targets.Nodes)
+				if err := r.slurmControl.SyncReservation(ctx, targets.ControllerKey, maintenance, targets.Nodes, update); err != nil {
+					return fmt.Errorf("failed to sync reservation (%s): %w", maintenance.ReservationName(), err)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, s := range syncSteps {
+		if err := s.Sync(ctx, maintenance); err != nil {
+			e := fmt.Errorf("[%s]: %w", s.Name, err)
+			errors := []error{e}
+			if err := r.syncStatus(ctx, maintenance, targets, e); err != nil {
+				e := fmt.Errorf("[%s]: %w", s.Name, err)
+				errors = append(errors, e)
+			}
+			return utilerrors.NewAggregate(errors)
+		}
+	}
+
+	r.requeueAtNextEvent(maintenance, time.Now())
+
+	return r.syncStatus(ctx, maintenance, targets, nil)
+}
+
+// finalize deletes the Slurm reservation and removes the finalizer.
+func (r *SlurmMaintenanceReconciler) finalize(
+	ctx context.Context,
+	maintenance *slinkyv1beta1.SlurmMaintenance,
+	targets *maintenanceTargets,
+) error {
+	if !controllerutil.ContainsFinalizer(maintenance, slinkyv1beta1.FinalizerSlurmMaintenance) {
+		return nil
+	}
+
+	// The selected NodeSets may be gone, so prefer the Controller which the
+	// reservation was created on.
+	controllerKey := maintenance.Status.ControllerRef.NamespacedName()
+	if controllerKey.Name == "" {
+		controllerKey = targets.ControllerKey
+	}
+	if maintenance.Status.ReservationName != "" && controllerKey.Name != "" {
+		if err := r.slurmControl.DeleteReservation(ctx, controllerKey, maintenance); err != nil {
+			return fmt.Errorf("failed to delete reservation (%s): %w", maintenance.Status.ReservationName, err)
+		}
+	}
+
+	toUpdate := maintenance.DeepCopy()
+	controllerutil.RemoveFinalizer(toUpdate, slinkyv1beta1.FinalizerSlurmMaintenance)
+	return r.Patch(ctx, toUpdate, client.MergeFrom(maintenance))
+}
+
+// requeueAtNextEvent requeues when the maintenance window starts, and
+// periodically while it is in progress to refresh the node activity.
+func (r *SlurmMaintenanceReconciler) requeueAtNextEvent(
+	maintenance *slinkyv1beta1.SlurmMaintenance,
+	now time.Time,
+) {
+	key := objectutils.KeyFunc(maintenance)
+	switch {
+	case maintenance.IsUpcoming(now):
+		durationStore.Push(key, maintenance.Spec.StartTime.Sub(now))
+	case maintenance.IsActive(now):
+		durationStore.Push(key, min(activeResyncPeriod, maintenance.EndTime().Sub(now)))
+	}
+}
+
+// getMaintenanceTargets resolves the NodeSet pods selected by the SlurmMaintenance.
+func (r *SlurmMaintenanceReconciler) getMaintenanceTargets(
+	ctx context.Context,
+	maintenance *slinkyv1beta1.SlurmMaintenance,
+) (*maintenanceTargets, error) {
+	targets := &maintenanceTargets{}
+
+	nodesetList := &slinkyv1beta1.NodeSetList{}
+	if err := r.List(ctx, nodesetList, client.InNamespace(maintenance.Namespace)); err != nil {
+		return nil, err
+	}
+	nodesets := make(map[types.UID]*slinkyv1beta1.NodeSet, len(nodesetList.Items))
+	for i := range nodesetList.Items {
+		nodesets[nodesetList.Items[i].UID] = &nodesetList.Items[i]
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(maintenance.Namespace)); err != nil {
+		return nil, err
+	}
+
+	nodesetNames := sets.New[string]()
+	nodes := sets.New[string]()
+	controllers := sets.New[types.NamespacedName]()
+	for _, nodeset := range nodesets {
+		if slices.Contains(maintenance.Spec.NodeSets, nodeset.Name) {
+			nodesetNames.Insert(nodeset.Name)
+			controllers.Insert(nodeset.Spec.ControllerRef.NamespacedName())
+		}
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		owner := metav1.GetControllerOf(pod)
+		if owner == nil || owner.Kind != slinkyv1beta1.NodeSetKind {
+			continue
+		}
+		nodeset, ok := nodesets[owner.UID]
+		if !ok || !maintenance.SelectsNodeSetPod(nodeset.Name, pod.Labels) {
+			continue
+		}
+		nodesetNames.Insert(nodeset.Name)
+		nodes.Insert(nodesetutils.GetNodeName(pod))
+		controllers.Insert(nodeset.Spec.ControllerRef.NamespacedName())
+	}
+
+	targets.NodeSets = sets.List(nodesetNames)
+	targets.Nodes = sets.List(nodes)
+
+	switch controllers.Len() {
+	case 0:
+		targets.ConfigErr = errors.New("no NodeSet pods are selected")
+	case 1:
+		targets.ControllerKey = controllers.UnsortedList()[0]
+		if len(targets.Nodes) == 0 {
+			targets.ConfigErr = errors.New("no NodeSet pods are selected")
+		}
+	default:
+		names := []string{}
+		for key := range controllers {
+			names = append(names, key.String())
+		}
+		slices.Sort(names)
+		targets.ConfigErr = fmt.Errorf("selected NodeSets reference multiple Controllers: %s", strings.Join(names, ", "))
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmmaintenance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+// syncStatus handles determining and updating the status.
+func (r *SlurmMaintenanceReconciler) syncStatus(
+	ctx context.Context,
+	maintenance *slinkyv1beta1.SlurmMaintenance,
+	targets *maintenanceTargets,
+	syncErr error,
+) error {
+	logger := log.FromContext(ctx)
+	now := time.Now()
+
+	newStatus := &slinkyv1beta1.SlurmMaintenanceStatus{
+		ObservedGeneration: maintenance.Generation,
+		ReservationName:    maintenance.Status.ReservationName,
+		ControllerRef:      maintenance.Status.ControllerRef,
+		NodeSets:           targets.NodeSets,
+		Nodes:              targets.Nodes,
+		Conditions:         make([]metav1.Condition, len(maintenance.Status.Conditions)),
+	}
+	copy(newStatus.Conditions, maintenance.Status.Conditions)
+	switch {
+	case syncErr != nil:
+		// Keep what was last reserved, so the reservation update is retried.
+		newStatus.ObservedGeneration = maintenance.Status.ObservedGeneration
+		newStatus.Nodes = maintenance.Status.Nodes
+	case targets.ConfigErr == nil:
+		newStatus.ReservationName = maintenance.ReservationName()
+		newStatus.ControllerRef = slinkyv1beta1.ObjectReference{
+			Namespace: targets.ControllerKey.Namespace,
+			Name:      targets.ControllerKey.Name,
+		}
+	}
+
+	newStatus.Phase = newPhase(maintenance, newStatus.ReservationName, now)
+	if newStatus.Phase == slinkyv1beta1.SlurmMaintenanceInProgress && targets.ConfigErr == nil {
+		activity, err := r.slurmControl.GetNodeActivity(ctx, targets.ControllerKey, targets.Nodes)
+		if err != nil {
+			return err
+		}
+		newStatus.QuiescedNodes = activity.QuiescedNodes
+		newStatus.RunningJobs = activity.RunningJobs
+	}
+
+	setConditions(newStatus, maintenance.Generation, targets.ConfigErr, syncErr)
+
+	if apiequality.Semantic.DeepEqual(maintenance.Status, *newStatus) {
+		logger.V(2).Info("SlurmMaintenance Status has not changed, skipping status update",
+			"maintenance", klog.KObj(maintenance), "status", maintenance.Status)
+		return nil
+	}
+
+	if err := r.updateStatus(ctx, maintenance, newStatus); err != nil {
+		return fmt.Errorf("error updating SlurmMaintenance(%s) status: %w",
+			klog.KObj(maintenance), err)
+	}
+
+	return nil
+}
+
+// newPhase returns the phase of the maintenance window at the given time.
+func newPhase(
+	maintenance *slinkyv1beta1.SlurmMaintenance,
+	reservationName string,
+	now time.Time,
+) slinkyv1beta1.SlurmMaintenancePhase {
+	switch {
+	case !now.Before(maintenance.EndTime()):
+		return slinkyv1beta1.SlurmMaintenanceCompleted
+	case reservationName == "":
+		return slinkyv1beta1.SlurmMaintenancePending
+	case maintenance.IsUpcoming(now):
+		return slinkyv1beta1.SlurmMaintenanceScheduled
+	default:
+		return slinkyv1beta1.SlurmMaintenanceInProgress
+	}
+}
+
+// setConditions sets the Ready and Quiesced conditions on the status.
+func setConditions(
+	status *slinkyv1beta1.SlurmMaintenanceStatus,
+	generation int64,
+	configErr, syncErr error,
+) {
+	ready := metav1.Condition{
+		Type:               slinkyv1beta1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             slinkyv1beta1.ReasonAsExpected,
+		Message:            fmt.Sprintf("Reservation %s is in sync", status.ReservationName),
+	}
+	switch {
+	case configErr != nil:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = slinkyv1beta1.ReasonInvalidConfig
+		ready.Message = configErr.Error()
+	case syncErr != nil:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = slinkyv1beta1.ReasonSyncFailed
+		ready.Message = syncErr.Error()
+	case status.Phase == slinkyv1beta1.SlurmMaintenanceCompleted:
+		ready.Message = "Maintenance window has ended"
+	}
+	meta.SetStatusCondition(&status.Conditions, ready)
+
+	quiesced := metav1.Condition{
+		Type:               slinkyv1beta1.ConditionQuiesced,
+		ObservedGeneration: generation,
+	}
+	switch status.Phase {
+	case slinkyv1beta1.SlurmMaintenanceInProgress:
+		if len(status.RunningJobs) == 0 && len(status.QuiescedNodes) == len(status.Nodes) {
+			quiesced.Status = metav1.ConditionTrue
+			quiesced.Reason = slinkyv1beta1.ReasonAsExpected
+			quiesced.Message = "All nodes are drained"
+		} else {
+			quiesced.Status = metav1.ConditionFalse
+			quiesced.Reason = slinkyv1beta1.ReasonJobsRunning
+			quiesced.Message = fmt.Sprintf("%d of %d nodes are drained, %d jobs are running",
+				len(status.QuiescedNodes), len(status.Nodes), len(status.RunningJobs))
+		}
+	case slinkyv1beta1.SlurmMaintenanceCompleted:
+		// Preserve the last observation.
+		return
+	default:
+		quiesced.Status = metav1.ConditionFalse
+		quiesced.Reason = slinkyv1beta1.ReasonNotStarted
+		quiesced.Message = "Maintenance window has not started"
+	}
+	meta.SetStatusCondition(&status.Conditions, quiesced)
+}
+
+func (r *SlurmMaintenanceReconciler) updateStatus(
+	ctx context.Context,
+	maintenance *slinkyv1beta1.SlurmMaintenance,
+	newStatus *slinkyv1beta1.SlurmMaintenanceStatus,
+) error {
+	logger := log.FromContext(ctx)
+	maintenanceKey := objectutils.NamespacedName(maintenance)
+
+	logger.V(1).Info("Pending SlurmMaintenance Status update",
+		"maintenance", klog.KObj(maintenance), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.SlurmMaintenance{}
+		if err := r.Get(ctx, maintenanceKey, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
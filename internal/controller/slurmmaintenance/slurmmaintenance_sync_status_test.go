@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmmaintenance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_newPhase(t *testing.T) {
+	now := time.Now()
+	type args struct {
+		maintenance     *slinkyv1beta1.SlurmMaintenance
+		reservationName string
+	}
+	tests := []struct {
+		name string
+		args args
+		want slinkyv1beta1.SlurmMaintenancePhase
+	}{
+		{
+			name: "Pending",
+			args: args{
+				maintenance:     testutils.NewSlurmMaintenance("foo", nil, now.Add(time.Hour), time.Hour),
+				reservationName: "",
+			},
+			want: slinkyv1beta1.SlurmMaintenancePending,
+		},
+		{
+			name: "Scheduled",
+			args: args{
+				maintenance:     testutils.NewSlurmMaintenance("foo", nil, now.Add(time.Hour), time.Hour),
+				reservationName: "slinky_default_foo",
+			},
+			want: slinkyv1beta1.SlurmMaintenanceScheduled,
+		},
+		{
+			name: "InProgress",
+			args: args{
+				maintenance:     testutils.NewSlurmMaintenance("foo", nil, now.Add(-time.Minute), time.Hour),
+				reservationName: "slinky_default_foo",
+			},
+			want: slinkyv1beta1.SlurmMaintenanceInProgress,
+		},
+		{
+			name: "Completed",
+			args: args{
+				maintenance:     testutils.NewSlurmMaintenance("foo", nil, now.Add(-2*time.Hour), time.Hour),
+				reservationName: "",
+			},
+			want: slinkyv1beta1.SlurmMaintenanceCompleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newPhase(tt.args.maintenance, tt.args.reservationName, now); got != tt.want {
+				t.Errorf("newPhase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setConditions(t *testing.T) {
+	type args struct {
+		status    *slinkyv1beta1.SlurmMaintenanceStatus
+		configErr error
+		syncErr   error
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantReady      string
+		wantQuiesced   metav1.ConditionStatus
+		wantQuiescedBy string
+	}{
+		{
+			name: "Scheduled",
+			args: args{
+				status: &slinkyv1beta1.SlurmMaintenanceStatus{
+					Phase: slinkyv1beta1.SlurmMaintenanceScheduled,
+					Nodes: []string{"foo-0"},
+				},
+			},
+			wantReady:      slinkyv1beta1.ReasonAsExpected,
+			wantQuiesced:   metav1.ConditionFalse,
+			wantQuiescedBy: slinkyv1beta1.ReasonNotStarted,
+		},
+		{
+			name: "Invalid config",
+			args: args{
+				status: &slinkyv1beta1.SlurmMaintenanceStatus{
+					Phase: slinkyv1beta1.SlurmMaintenancePending,
+				},
+				configErr: errors.New("no NodeSet pods are selected"),
+			},
+			wantReady:      slinkyv1beta1.ReasonInvalidConfig,
+			wantQuiesced:   metav1.ConditionFalse,
+			wantQuiescedBy: slinkyv1beta1.ReasonNotStarted,
+		},
+		{
+			name: "Sync failed",
+			args: args{
+				status: &slinkyv1beta1.SlurmMaintenanceStatus{
+					Phase: slinkyv1beta1.SlurmMaintenancePending,
+				},
+				syncErr: errors.New("failed"),
+			},
+			wantReady:      slinkyv1beta1.ReasonSyncFailed,
+			wantQuiesced:   metav1.ConditionFalse,
+			wantQuiescedBy: slinkyv1beta1.ReasonNotStarted,
+		},
+		{
+			name: "Jobs running",
+			args: args{
+				status: &slinkyv1beta1.SlurmMaintenanceStatus{
+					Phase:         slinkyv1beta1.SlurmMaintenanceInProgress,
+					Nodes:         []string{"foo-0", "foo-1"},
+					QuiescedNodes: []string{"foo-0"},
+					RunningJobs:   []int32{1},
+				},
+			},
+			wantReady:      slinkyv1beta1.ReasonAsExpected,
+			wantQuiesced:   metav1.ConditionFalse,
+			wantQuiescedBy: slinkyv1beta1.ReasonJobsRunning,
+		},
+		{
+			name: "Quiesced",
+			args: args{
+				status: &slinkyv1beta1.SlurmMaintenanceStatus{
+					Phase:         slinkyv1beta1.SlurmMaintenanceInProgress,
+					Nodes:         []string{"foo-0", "foo-1"},
+					QuiescedNodes: []string{"foo-0", "foo-1"},
+				},
+			},
+			wantReady:      slinkyv1beta1.ReasonAsExpected,
+			wantQuiesced:   metav1.ConditionTrue,
+			wantQuiescedBy: slinkyv1beta1.ReasonAsExpected,
+		},
+		{
+			name: "Completed",
+			args: args{
+				status: &slinkyv1beta1.SlurmMaintenanceStatus{
+					Phase: slinkyv1beta1.SlurmMaintenanceCompleted,
+					Conditions: []metav1.Condition{
+						{
+							Type:   slinkyv1beta1.ConditionQuiesced,
+							Status: metav1.ConditionTrue,
+							Reason: slinkyv1beta1.ReasonAsExpected,
+						},
+					},
+				},
+			},
+			wantReady:      slinkyv1beta1.ReasonAsExpected,
+			wantQuiesced:   metav1.ConditionTrue,
+			wantQuiescedBy: slinkyv1beta1.ReasonAsExpected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConditions(tt.args.status, 1, tt.args.configErr, tt.args.syncErr)
+			ready := meta.FindStatusCondition(tt.args.status.Conditions, slinkyv1beta1.ConditionReady)
+			if ready == nil || ready.Reason != tt.wantReady {
+				t.Errorf("setConditions() Ready = %v, want reason %v", ready, tt.wantReady)
+			}
+			quiesced := meta.FindStatusCondition(tt.args.status.Conditions, slinkyv1beta1.ConditionQuiesced)
+			if quiesced == nil || quiesced.Status != tt.wantQuiesced || quiesced.Reason != tt.wantQuiescedBy {
+				t.Errorf("setConditions() Quiesced = %v, want %v/%v", quiesced, tt.wantQuiesced, tt.wantQuiescedBy)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmmaintenance
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmmaintenance/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newNodeSet(name, controllerName string) *slinkyv1beta1.NodeSet {
+	return &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      name,
+			UID:       types.UID(name),
+		},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			ControllerRef: slinkyv1beta1.ObjectReference{
+				Namespace: corev1.NamespaceDefault,
+				Name:      controllerName,
+			},
+			Replicas: ptr.To[int32](1),
+		},
+	}
+}
+
+func newNodeSetPod(nodeset *slinkyv1beta1.NodeSet, ordinal int, labels map[string]string) *corev1.Pod {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodeset.Spec.ControllerRef.Name,
+		},
+	}
+	pod := nodesetutils.NewNodeSetPod(nodeset, controller, ordinal, "")
+	for k, v := range labels {
+		pod.Labels[k] = v
+	}
+	return pod
+}
+
+func TestSlurmMaintenanceReconciler_getMaintenanceTargets(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	now := time.Now()
+	foo := newNodeSet("foo", "slurm")
+	bar := newNodeSet("bar", "slurm")
+	baz := newNodeSet("baz", "other")
+	type fields struct {
+		Client client.Client
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		maintenance   *slinkyv1beta1.SlurmMaintenance
+		wantNodeSets  []string
+		wantNodes     []string
+		wantConfigErr bool
+	}{
+		{
+			name: "NodeSets",
+			fields: fields{
+				Client: fake.NewFakeClient(foo, bar,
+					newNodeSetPod(foo, 0, nil), newNodeSetPod(foo, 1, nil), newNodeSetPod(bar, 0, nil)),
+			},
+			maintenance:   testutils.NewSlurmMaintenance("maint", []string{"foo"}, now, time.Hour),
+			wantNodeSets:  []string{"foo"},
+			wantNodes:     []string{"foo-0", "foo-1"},
+			wantConfigErr: false,
+		},
+		{
+			name: "Pod selector",
+			fields: fields{
+				Client: fake.NewFakeClient(foo, bar,
+					newNodeSetPod(foo, 0, map[string]string{"maint": "true"}), newNodeSetPod(foo, 1, nil),
+					newNodeSetPod(bar, 0, map[string]string{"maint": "true"})),
+			},
+			maintenance: func() *slinkyv1beta1.SlurmMaintenance {
+				maintenance := testutils.NewSlurmMaintenance("maint", nil, now, time.Hour)
+				maintenance.Spec.PodSelector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{"maint": "true"},
+				}
+				return maintenance
+			}(),
+			wantNodeSets:  []string{"bar", "foo"},
+			wantNodes:     []string{"bar-0", "foo-0"},
+			wantConfigErr: false,
+		},
+		{
+			name: "No pods",
+			fields: fields{
+				Client: fake.NewFakeClient(foo),
+			},
+			maintenance:   testutils.NewSlurmMaintenance("maint", []string{"foo"}, now, time.Hour),
+			wantNodeSets:  []string{"foo"},
+			wantNodes:     []string{},
+			wantConfigErr: true,
+		},
+		{
+			name: "No NodeSets",
+			fields: fields{
+				Client: fake.NewFakeClient(),
+			},
+			maintenance:   testutils.NewSlurmMaintenance("maint", []string{"foo"}, now, time.Hour),
+			wantNodeSets:  []string{},
+			wantNodes:     []string{},
+			wantConfigErr: true,
+		},
+		{
+			name: "Multiple Controllers",
+			fields: fields{
+				Client: fake.NewFakeClient(foo, baz, newNodeSetPod(foo, 0, nil), newNodeSetPod(baz, 0, nil)),
+			},
+			maintenance:   testutils.NewSlurmMaintenance("maint", []string{"foo", "baz"}, now, time.Hour),
+			wantNodeSets:  []string{"baz", "foo"},
+			wantNodes:     []string{"baz-0", "foo-0"},
+			wantConfigErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReconciler(tt.fields.Client, clientmap.NewClientMap())
+			got, err := r.getMaintenanceTargets(context.TODO(), tt.maintenance)
+			if err != nil {
+				t.Fatalf("SlurmMaintenanceReconciler.getMaintenanceTargets() error = %v", err)
+			}
+			if (got.ConfigErr != nil) != tt.wantConfigErr {
+				t.Errorf("SlurmMaintenanceReconciler.getMaintenanceTargets() ConfigErr = %v, wantConfigErr %v", got.ConfigErr, tt.wantConfigErr)
+			}
+			if !slices.Equal(got.NodeSets, tt.wantNodeSets) {
+				t.Errorf("SlurmMaintenanceReconciler.getMaintenanceTargets() NodeSets = %v, want %v", got.NodeSets, tt.wantNodeSets)
+			}
+			if !slices.Equal(got.Nodes, tt.wantNodes) {
+				t.Errorf("SlurmMaintenanceReconciler.getMaintenanceTargets() Nodes = %v, want %v", got.Nodes, tt.wantNodes)
+			}
+		})
+	}
+}
+
+// fakeSlurmControl records the reservations deleted through it.
+type fakeSlurmControl struct {
+	slurmcontrol.SlurmControlInterface
+	deleted []types.NamespacedName
+}
+
+func (f *fakeSlurmControl) DeleteReservation(_ context.Context, controllerKey types.NamespacedName, _ *slinkyv1beta1.SlurmMaintenance) error {
+	f.deleted = append(f.deleted, controllerKey)
+	return nil
+}
+
+func TestSlurmMaintenanceReconciler_finalize(t *testing.T) {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	now := time.Now()
+	slurmKey := types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: "slurm"}
+	tests := []struct {
+		name          string
+		status        slinkyv1beta1.SlurmMaintenanceStatus
+		targets       *maintenanceTargets
+		wantDeleteFor []types.NamespacedName
+	}{
+		{
+			name: "NodeSets deleted",
+			status: slinkyv1beta1.SlurmMaintenanceStatus{
+				ReservationName: "maint",
+				ControllerRef:   slinkyv1beta1.ObjectReference{Namespace: slurmKey.Namespace, Name: slurmKey.Name},
+			},
+			targets:       &maintenanceTargets{},
+			wantDeleteFor: []types.NamespacedName{slurmKey},
+		},
+		{
+			name: "No Controller recorded",
+			status: slinkyv1beta1.SlurmMaintenanceStatus{
+				ReservationName: "maint",
+			},
+			targets:       &maintenanceTargets{ControllerKey: slurmKey},
+			wantDeleteFor: []types.NamespacedName{slurmKey},
+		},
+		{
+			name:          "No reservation",
+			status:        slinkyv1beta1.SlurmMaintenanceStatus{},
+			targets:       &maintenanceTargets{ControllerKey: slurmKey},
+			wantDeleteFor: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maintenance := testutils.NewSlurmMaintenance("maint", []string{"foo"}, now, time.Hour)
+			maintenance.Finalizers = []string{slinkyv1beta1.FinalizerSlurmMaintenance}
+			maintenance.Status = tt.status
+			k8sclient := fake.NewFakeClient(maintenance.DeepCopy())
+			slurmControl := &fakeSlurmControl{}
+			r := NewReconciler(k8sclient, clientmap.NewClientMap())
+			r.slurmControl = slurmControl
+			if err := r.finalize(context.TODO(), maintenance, tt.targets); err != nil {
+				t.Fatalf("SlurmMaintenanceReconciler.finalize() error = %v", err)
+			}
+			if !slices.Equal(slurmControl.deleted, tt.wantDeleteFor) {
+				t.Errorf("SlurmMaintenanceReconciler.finalize() deleted = %v, want %v", slurmControl.deleted, tt.wantDeleteFor)
+			}
+			got := &slinkyv1beta1.SlurmMaintenance{}
+			if err := k8sclient.Get(context.TODO(), client.ObjectKeyFromObject(maintenance), got); err != nil {
+				t.Fatalf("failed to get SlurmMaintenance: %v", err)
+			}
+			if len(got.Finalizers) != 0 {
+				t.Errorf("SlurmMaintenanceReconciler.finalize() finalizers = %v, want none", got.Finalizers)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func Test_ControllerEventHandler_Update(t *testing.T) {
+	slurmKeyRef := testutils.NewSlurmKeyRef("foo")
+	jwtHs256KeyRef := testutils.NewJwtHs256KeyRef("foo")
+	jwtHs256KeySecret := testutils.NewJwtHs256KeySecret(jwtHs256KeyRef)
+	controller := testutils.NewController("slurm", slurmKeyRef, jwtHs256KeyRef, nil)
+	other := testutils.NewController("other", slurmKeyRef, jwtHs256KeyRef, nil)
+	newToken := func(name string, controller *slinkyv1beta1.Controller) *slinkyv1beta1.Token {
+		token := testutils.NewToken(name, jwtHs256KeySecret)
+		if controller != nil {
+			token.Spec.JwtHs256KeyRef = slinkyv1beta1.JwtSecretKeySelector{}
+			token.Spec.ControllerRef = testutils.NewObjectRef(controller)
+		}
+		return token
+	}
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Tokens of the Controller",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					controller,
+					newToken("a", controller),
+					newToken("b", controller),
+					newToken("c", other),
+					newToken("d", nil),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: controller,
+					ObjectNew: controller,
+				},
+				q: newQueue(),
+			},
+			want: 2,
+		},
+		{
+			name: "Not a Controller",
+			fields: fields{
+				Reader: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newToken("a", controller),
+					ObjectNew: newToken("a", controller),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewControllerEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			if got := tt.args.q.Len(); got != tt.want {
+				t.Errorf("ControllerEventHandler.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
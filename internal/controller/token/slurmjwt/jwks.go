@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// JWK is a public JSON Web Key.
+// Ref: https://datatracker.ietf.org/doc/html/rfc7517
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+
+	// RSA public key parameters.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC public key parameters.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set, as read from the Slurm `jwks` file.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_jwks
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWK returns the public JSON Web Key of the signing key.
+func NewJWK(key *SigningKey) (JWK, error) {
+	jwk := JWK{
+		Algorithm: key.Method.Alg(),
+		KeyID:     key.KeyID,
+		Use:       "sig",
+	}
+	switch k := key.Key.(type) {
+	case *rsa.PrivateKey:
+		jwk.KeyType = "RSA"
+		jwk.N = encodeBase64URL(k.N.Bytes())
+		jwk.E = encodeBase64URL(big.NewInt(int64(k.E)).Bytes())
+	case *ecdsa.PrivateKey:
+		publicKey, err := k.PublicKey.ECDH()
+		if err != nil {
+			return JWK{}, fmt.Errorf("failed to convert EC public key (%s): %w", key.KeyID, err)
+		}
+		// The uncompressed point is 0x04 || X || Y.
+		point := publicKey.Bytes()
+		size := (len(point) - 1) / 2
+		jwk.KeyType = "EC"
+		jwk.Curve = k.Curve.Params().Name
+		jwk.X = encodeBase64URL(point[1 : 1+size])
+		jwk.Y = encodeBase64URL(point[1+size:])
+	default:
+		return JWK{}, fmt.Errorf("key (%s) of algorithm %s cannot be published", key.KeyID, key.Method.Alg())
+	}
+	return jwk, nil
+}
+
+// NewJWKS returns the JSON Web Key Set of the signing keys.
+func NewJWKS(keys []*SigningKey) ([]byte, error) {
+	jwks := JWKS{
+		Keys: make([]JWK, 0, len(keys)),
+	}
+	for _, key := range keys {
+		jwk, err := NewJWK(key)
+		if err != nil {
+			return nil, err
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	out, err := json.Marshal(jwks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JWKS: %w", err)
+	}
+	return out, nil
+}
+
+func encodeBase64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
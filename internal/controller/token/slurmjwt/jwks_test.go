@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjwt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+)
+
+func TestNewJWKS(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []*SigningKey
+		wantKeys []JWK
+		wantErr  bool
+	}{
+		{
+			name:     "Empty",
+			keys:     nil,
+			wantKeys: []JWK{},
+		},
+		{
+			name: "RS256 and ES256",
+			keys: []*SigningKey{
+				newSigningKey("rsa", AlgorithmRS256),
+				newSigningKey("ec", AlgorithmES256),
+			},
+			wantKeys: []JWK{
+				{KeyType: "RSA", Algorithm: AlgorithmRS256, KeyID: "rsa", Use: "sig"},
+				{KeyType: "EC", Algorithm: AlgorithmES256, KeyID: "ec", Use: "sig", Curve: "P-256"},
+			},
+		},
+		{
+			name: "HS256",
+			keys: []*SigningKey{
+				NewHS256SigningKey(crypto.NewSigningKey()),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewJWKS(tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewJWKS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			jwks := JWKS{}
+			if err := json.Unmarshal(got, &jwks); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if len(jwks.Keys) != len(tt.wantKeys) {
+				t.Fatalf("NewJWKS() keys = %v, want %v", jwks.Keys, tt.wantKeys)
+			}
+			for i, want := range tt.wantKeys {
+				got := jwks.Keys[i]
+				if got.KeyType != want.KeyType || got.Algorithm != want.Algorithm ||
+					got.KeyID != want.KeyID || got.Use != want.Use || got.Curve != want.Curve {
+					t.Errorf("NewJWKS() key[%d] = %+v, want %+v", i, got, want)
+				}
+				switch got.KeyType {
+				case "RSA":
+					if got.N == "" || got.E != "AQAB" {
+						t.Errorf("NewJWKS() key[%d] has invalid RSA parameters: %+v", i, got)
+					}
+				case "EC":
+					if len(got.X) != 43 || len(got.Y) != 43 {
+						t.Errorf("NewJWKS() key[%d] has invalid EC parameters: %+v", i, got)
+					}
+				}
+			}
+		})
+	}
+}
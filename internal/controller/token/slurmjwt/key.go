@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+
+	// minRsaKeyBits is the smallest RSA key accepted for signing.
+	minRsaKeyBits = 2048
+)
+
+// SigningKey is a key which signs Slurm JWTs.
+type SigningKey struct {
+	// KeyID is the `kid` of the key, which is empty for the HS256 key.
+	KeyID string
+
+	// Method is the signing method of the key.
+	Method jwt.SigningMethod
+
+	// Key is the HS256 secret ([]byte), or the RS256 (*rsa.PrivateKey) or
+	// ES256 (*ecdsa.PrivateKey) private key.
+	Key any
+}
+
+// NewHS256SigningKey returns the signing key of the Slurm `jwt_key`.
+func NewHS256SigningKey(key []byte) *SigningKey {
+	return &SigningKey{
+		Method: jwt.SigningMethodHS256,
+		Key:    key,
+	}
+}
+
+// ParseSigningKey returns the signing key from a PEM encoded private key
+// (PKCS #1, SEC 1, or PKCS #8) for the algorithm.
+func ParseSigningKey(keyID, algorithm string, keyPEM []byte) (*SigningKey, error) {
+	if keyID == "" {
+		return nil, errors.New("key ID must not be empty")
+	}
+	switch algorithm {
+	case AlgorithmRS256:
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse RSA private key (%s): %w", keyID, err)
+		}
+		if bits := key.N.BitLen(); bits < minRsaKeyBits {
+			return nil, fmt.Errorf("RSA private key (%s) has %d bits, at least %d are required", keyID, bits, minRsaKeyBits)
+		}
+		return &SigningKey{KeyID: keyID, Method: jwt.SigningMethodRS256, Key: key}, nil
+	case AlgorithmES256:
+		key, err := jwt.ParseECPrivateKeyFromPEM(keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse EC private key (%s): %w", keyID, err)
+		}
+		if key.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("EC private key (%s) must use the P-256 curve", keyID)
+		}
+		return &SigningKey{KeyID: keyID, Method: jwt.SigningMethodES256, Key: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported JWT signing algorithm: %s", algorithm)
+	}
+}
+
+// VerifyKey returns the key which verifies the JWTs signed by the key.
+func (k *SigningKey) VerifyKey() any {
+	switch key := k.Key.(type) {
+	case *rsa.PrivateKey:
+		return &key.PublicKey
+	case *ecdsa.PrivateKey:
+		return &key.PublicKey
+	default:
+		return k.Key
+	}
+}
+
+// FindSigningKey returns the key with the key ID, or nil.
+func FindSigningKey(keys []*SigningKey, keyID string) *SigningKey {
+	for _, key := range keys {
+		if key.KeyID == keyID {
+			return key
+		}
+	}
+	return nil
+}
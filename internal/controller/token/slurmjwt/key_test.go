@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func newRsaKeyPEM(bits int) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func newEcKeyPEM(curve elliptic.Curve) []byte {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func newSigningKey(keyID, algorithm string) *SigningKey {
+	keyPEM := newRsaKeyPEM(2048)
+	if algorithm == AlgorithmES256 {
+		keyPEM = newEcKeyPEM(elliptic.P256())
+	}
+	key, err := ParseSigningKey(keyID, algorithm, keyPEM)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func TestParseSigningKey(t *testing.T) {
+	rsaKey := newRsaKeyPEM(2048)
+	ecKey := newEcKeyPEM(elliptic.P256())
+	type args struct {
+		keyID     string
+		algorithm string
+		keyPEM    []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantAlg string
+		wantErr bool
+	}{
+		{
+			name:    "RS256",
+			args:    args{keyID: "a", algorithm: AlgorithmRS256, keyPEM: rsaKey},
+			wantAlg: AlgorithmRS256,
+		},
+		{
+			name:    "ES256",
+			args:    args{keyID: "a", algorithm: AlgorithmES256, keyPEM: ecKey},
+			wantAlg: AlgorithmES256,
+		},
+		{
+			name:    "Empty key ID",
+			args:    args{keyID: "", algorithm: AlgorithmRS256, keyPEM: rsaKey},
+			wantErr: true,
+		},
+		{
+			name:    "Algorithm mismatch",
+			args:    args{keyID: "a", algorithm: AlgorithmRS256, keyPEM: ecKey},
+			wantErr: true,
+		},
+		{
+			name:    "Short RSA key",
+			args:    args{keyID: "a", algorithm: AlgorithmRS256, keyPEM: newRsaKeyPEM(1024)},
+			wantErr: true,
+		},
+		{
+			name:    "Wrong curve",
+			args:    args{keyID: "a", algorithm: AlgorithmES256, keyPEM: newEcKeyPEM(elliptic.P384())},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported algorithm",
+			args:    args{keyID: "a", algorithm: AlgorithmHS256, keyPEM: rsaKey},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSigningKey(tt.args.keyID, tt.args.algorithm, tt.args.keyPEM)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSigningKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Method.Alg() != tt.wantAlg {
+				t.Errorf("ParseSigningKey() alg = %v, want %v", got.Method.Alg(), tt.wantAlg)
+			}
+			if got.KeyID != tt.args.keyID {
+				t.Errorf("ParseSigningKey() kid = %v, want %v", got.KeyID, tt.args.keyID)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package slurmjwt
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -20,16 +21,20 @@ const (
 )
 
 type Token struct {
-	signingKey []byte
-	method     jwt.SigningMethod
+	signingKey *SigningKey
 	username   string
 	lifetime   time.Duration
 }
 
 func NewToken(signingKey []byte) *Token {
+	return NewTokenWithKey(NewHS256SigningKey(signingKey))
+}
+
+// NewTokenWithKey returns a token signed by the key, whose key ID is set as
+// the `kid` header.
+func NewTokenWithKey(signingKey *SigningKey) *Token {
 	return &Token{
 		signingKey: signingKey,
-		method:     jwt.SigningMethodHS256,
 		username:   "slurm",
 		lifetime:   infinite * time.Second,
 	}
@@ -65,9 +70,12 @@ func (t *Token) NewSignedToken() (string, error) {
 		SlurmUsername: t.username,
 	}
 
-	token := jwt.NewWithClaims(t.method, claims)
+	token := jwt.NewWithClaims(t.signingKey.Method, claims)
+	if t.signingKey.KeyID != "" {
+		token.Header["kid"] = t.signingKey.KeyID
+	}
 
-	tokenString, err := token.SignedString(t.signingKey)
+	tokenString, err := token.SignedString(t.signingKey.Key)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -89,6 +97,46 @@ func ParseTokenClaims(tokenString string, signingKey []byte) (jwt.MapClaims, err
 	return claims, nil
 }
 
+// ParseTokenClaimsWithKeys parses the JWT claims, verified by the key of its
+// `kid` header. The claims of an expired JWT are returned with the error,
+// which wraps jwt.ErrTokenExpired.
+func ParseTokenClaimsWithKeys(tokenString string, signingKeys []*SigningKey) (jwt.MapClaims, error) {
+	signingKeyFunc := func(token *jwt.Token) (any, error) {
+		keyID, _ := token.Header["kid"].(string)
+		signingKey := FindSigningKey(signingKeys, keyID)
+		if signingKey == nil {
+			return nil, fmt.Errorf("unknown key ID: %q", keyID)
+		}
+		if token.Method.Alg() != signingKey.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method for key ID (%q): %s", keyID, token.Method.Alg())
+		}
+		return signingKey.VerifyKey(), nil
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, signingKeyFunc)
+	if err != nil {
+		// The signature is verified before the expiration.
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return claims, fmt.Errorf("failed to parse JWT claims: %w", err)
+		}
+		return nil, fmt.Errorf("failed to parse JWT claims: %w", err)
+	}
+
+	return claims, nil
+}
+
+// ParseKeyID returns the `kid` header of the JWT, without verifying it. The
+// key ID of a JWT signed by the HS256 key is empty.
+func ParseKeyID(tokenString string) (string, error) {
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return "", fmt.Errorf("failed to parse JWT: %w", err)
+	}
+	keyID, _ := token.Header["kid"].(string)
+	return keyID, nil
+}
+
 func VerifyToken(tokenString string, signingKey []byte) (bool, error) {
 	signingKeyFunc := func(token *jwt.Token) (any, error) {
 		return signingKey, nil
@@ -53,7 +53,7 @@ func TestToken_NewSignedToken(t *testing.T) {
 				t.Errorf("Token.NewSignedToken() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			ok, err := VerifyToken(got, tr.signingKey)
+			ok, err := VerifyToken(got, tr.signingKey.Key.([]byte))
 			if (err != nil) != tt.wantErr {
 				t.Errorf("VerifyToken() = %v", err)
 				return
@@ -153,3 +153,131 @@ func TestVerifyToken(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTokenClaimsWithKeys(t *testing.T) {
+	hs256Key := NewHS256SigningKey(crypto.NewSigningKey())
+	rs256Key := newSigningKey("a", AlgorithmRS256)
+	es256Key := newSigningKey("b", AlgorithmES256)
+	newSignedTokenWithKey := func(signingKey *SigningKey) string {
+		tokenString, err := NewTokenWithKey(signingKey).NewSignedToken()
+		if err != nil {
+			panic(err)
+		}
+		return tokenString
+	}
+	type args struct {
+		tokenString string
+		signingKeys []*SigningKey
+	}
+	expiredToken, err := NewTokenWithKey(rs256Key).WithLifetime(0).NewSignedToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantClaims bool
+		wantErr    bool
+	}{
+		{
+			name: "HS256",
+			args: args{
+				tokenString: newSignedTokenWithKey(hs256Key),
+				signingKeys: []*SigningKey{hs256Key, rs256Key},
+			},
+			wantClaims: true,
+		},
+		{
+			name: "RS256",
+			args: args{
+				tokenString: newSignedTokenWithKey(rs256Key),
+				signingKeys: []*SigningKey{hs256Key, rs256Key, es256Key},
+			},
+			wantClaims: true,
+		},
+		{
+			name: "ES256",
+			args: args{
+				tokenString: newSignedTokenWithKey(es256Key),
+				signingKeys: []*SigningKey{rs256Key, es256Key},
+			},
+			wantClaims: true,
+		},
+		{
+			name: "Unknown key ID",
+			args: args{
+				tokenString: newSignedTokenWithKey(rs256Key),
+				signingKeys: []*SigningKey{hs256Key, es256Key},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Different key with the same key ID",
+			args: args{
+				tokenString: newSignedTokenWithKey(rs256Key),
+				signingKeys: []*SigningKey{newSigningKey("a", AlgorithmRS256)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Expired",
+			args: args{
+				tokenString: expiredToken,
+				signingKeys: []*SigningKey{rs256Key},
+			},
+			wantClaims: true,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTokenClaimsWithKeys(tt.args.tokenString, tt.args.signingKeys)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTokenClaimsWithKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got != nil) != tt.wantClaims {
+				t.Errorf("ParseTokenClaimsWithKeys() = %v, wantClaims %v", got, tt.wantClaims)
+			}
+		})
+	}
+}
+
+func TestParseKeyID(t *testing.T) {
+	rs256Token, err := NewTokenWithKey(newSigningKey("a", AlgorithmRS256)).NewSignedToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		tokenString string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "HS256",
+			tokenString: newSignedToken(crypto.NewSigningKey()),
+			want:        "",
+		},
+		{
+			name:        "RS256",
+			tokenString: rs256Token,
+			want:        "a",
+		},
+		{
+			name:        "Invalid",
+			tokenString: "foo",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseKeyID(tt.tokenString)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKeyID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseKeyID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
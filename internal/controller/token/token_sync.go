@@ -16,6 +16,7 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/metrics"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
 )
 
@@ -32,6 +33,7 @@ func (r *TokenReconciler) Sync(ctx context.Context, req reconcile.Request) error
 	if err := r.Get(ctx, req.NamespacedName, token); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("Token has been deleted", "request", req)
+			metrics.DeleteToken(req.Namespace, req.Name)
 			return nil
 		}
 		return err
@@ -51,6 +53,48 @@ func (r *TokenReconciler) Sync(ctx context.Context, req reconcile.Request) error
 				return nil
 			},
 		},
+		{
+			Name: "Resign",
+			Sync: func(ctx context.Context, token *slinkyv1beta1.Token) error {
+				if !token.HasControllerRef() {
+					return nil
+				}
+
+				authToken, err := r.refResolver.GetSecretKeyRef(ctx, token.SecretRef(), token.Namespace)
+				if err != nil {
+					return err
+				}
+				_, signingKey, err := r.getJwtKeys(ctx, token)
+				if err != nil {
+					return err
+				}
+
+				keyID, err := slurmjwt.ParseKeyID(string(authToken))
+				if err != nil {
+					logger.V(1).Error(err, "failed to parse Slurm auth token key ID")
+				}
+				if err == nil && keyID == signingKey.KeyID {
+					return nil
+				}
+
+				logger.Info("Token is not signed by the signing key, signing it again",
+					"keyID", keyID, "signingKeyID", signingKey.KeyID)
+				object, err := r.builder.BuildTokenSecret(token)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+				// An immutable Secret can only be replaced.
+				if !token.Spec.Refresh {
+					if err := objectutils.DeleteObject(r.Client, ctx, object); err != nil {
+						return fmt.Errorf("failed to delete object (%s): %w", klog.KObj(object), err)
+					}
+				}
+				if err := objectutils.SyncObject(r.Client, ctx, object, true); err != nil {
+					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+				}
+				return nil
+			},
+		},
 		{
 			Name: "Refresh",
 			Sync: func(ctx context.Context, token *slinkyv1beta1.Token) error {
@@ -62,13 +106,12 @@ func (r *TokenReconciler) Sync(ctx context.Context, req reconcile.Request) error
 				if err != nil {
 					return err
 				}
-				jwtHs256Ref := token.JwtHs256Ref()
-				signingKey, err := r.refResolver.GetSecretKeyRef(ctx, &jwtHs256Ref.SecretKeySelector, jwtHs256Ref.Namespace)
+				jwtKeys, _, err := r.getJwtKeys(ctx, token)
 				if err != nil {
 					return err
 				}
 
-				authTokenClaims, err := slurmjwt.ParseTokenClaims(string(authToken), signingKey)
+				authTokenClaims, err := slurmjwt.ParseTokenClaimsWithKeys(string(authToken), jwtKeys)
 				if err != nil {
 					logger.V(1).Error(err, "failed to parse Slurm auth token claims")
 				}
@@ -83,10 +126,7 @@ func (r *TokenReconciler) Sync(ctx context.Context, req reconcile.Request) error
 					expirationTime = time.Time(exp.Time)
 				}
 
-				key := token.Key().String()
-				durationStore.Push(key, 30*time.Second)
-
-				refreshTime := expirationTime.Add(-token.Lifetime() * 1 / 5)
+				refreshTime := token.RefreshTime(expirationTime)
 				if now.Before(refreshTime) {
 					logger.V(2).Info("token is not near expiration time yet, skipping...", "expirationTime", expirationTime)
 					return nil
@@ -107,6 +147,7 @@ func (r *TokenReconciler) Sync(ctx context.Context, req reconcile.Request) error
 
 	for _, s := range syncSteps {
 		if err := s.Sync(ctx, token); err != nil {
+			metrics.TokenRefreshFailures.WithLabelValues(token.Namespace, token.Name, s.Name).Inc()
 			e := fmt.Errorf("[%s]: %w", s.Name, err)
 			errors := []error{e}
 			if err := r.syncStatus(ctx, token); err != nil {
@@ -119,3 +160,34 @@ func (r *TokenReconciler) Sync(ctx context.Context, req reconcile.Request) error
 
 	return r.syncStatus(ctx, token)
 }
+
+// getJwtKeys returns the keys which verify the JWT of the Token, and the key
+// which signs it.
+func (r *TokenReconciler) getJwtKeys(
+	ctx context.Context,
+	token *slinkyv1beta1.Token,
+) ([]*slurmjwt.SigningKey, *slurmjwt.SigningKey, error) {
+	if !token.HasControllerRef() {
+		jwtHs256Ref := token.JwtHs256Ref()
+		key, err := r.refResolver.GetSecretKeyRef(ctx, &jwtHs256Ref.SecretKeySelector, jwtHs256Ref.Namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		signingKey := slurmjwt.NewHS256SigningKey(key)
+		return []*slurmjwt.SigningKey{signingKey}, signingKey, nil
+	}
+
+	controller, err := r.refResolver.GetController(ctx, token.Spec.ControllerRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get controller (%s): %w", token.Spec.ControllerRef.NamespacedName(), err)
+	}
+	keys, err := r.refResolver.GetJwtKeys(ctx, controller)
+	if err != nil {
+		return nil, nil, err
+	}
+	signingKey := slurmjwt.FindSigningKey(keys, controller.JwtSigningKeyID())
+	if signingKey == nil {
+		return nil, nil, fmt.Errorf("JWT signing key (%s) is not one of the keys", controller.JwtSigningKeyID())
+	}
+	return keys, signingKey, nil
+}
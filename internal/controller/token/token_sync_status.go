@@ -5,10 +5,14 @@ package token
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	jwt "github.com/golang-jwt/jwt/v5"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
@@ -18,7 +22,6 @@ import (
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
-	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 )
 
 // syncStatus handles determining and updating the status.
@@ -28,47 +31,133 @@ func (r *TokenReconciler) syncStatus(
 ) error {
 	logger := log.FromContext(ctx)
 
-	authToken, err := r.refResolver.GetSecretKeyRef(ctx, token.SecretRef(), token.Namespace)
-	if err != nil {
-		return err
+	now := time.Now()
+	newStatus, statusErr := r.getStatus(ctx, token, now)
+
+	// Requeue when the JWT is refreshed, or expires.
+	if requeueAt := getRequeueTime(newStatus, now); !requeueAt.IsZero() {
+		durationStore.Push(token.Key().String(), requeueAt.Sub(now))
+	}
+
+	if apiequality.Semantic.DeepEqual(token.Status, *newStatus) {
+		logger.V(2).Info("Token Status has not changed, skipping status update",
+			"token", klog.KObj(token), "status", token.Status)
+		return statusErr
+	}
+
+	if err := r.updateStatus(ctx, token, newStatus); err != nil {
+		return fmt.Errorf("error updating Token(%s) status: %w",
+			klog.KObj(token), err)
+	}
+
+	return statusErr
+}
+
+// getStatus returns the status of the JWT in the Secret of the Token, and the
+// error which prevented it from being determined, if any.
+func (r *TokenReconciler) getStatus(
+	ctx context.Context,
+	token *slinkyv1beta1.Token,
+	now time.Time,
+) (*slinkyv1beta1.TokenStatus, error) {
+	newStatus := &slinkyv1beta1.TokenStatus{
+		Conditions: make([]metav1.Condition, len(token.Status.Conditions)),
+	}
+	copy(newStatus.Conditions, token.Status.Conditions)
+
+	ready := metav1.Condition{
+		Type:               slinkyv1beta1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: token.Generation,
+		Reason:             slinkyv1beta1.ReasonAsExpected,
+		Message:            "JWT is valid",
 	}
-	jwtHs256Ref := token.JwtHs256Ref()
-	signingKey, err := r.refResolver.GetSecretKeyRef(ctx, &jwtHs256Ref.SecretKeySelector, jwtHs256Ref.Namespace)
+	signingKeyMissing := metav1.Condition{
+		Type:               slinkyv1beta1.ConditionSigningKeyMissing,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: token.Generation,
+		Reason:             slinkyv1beta1.ReasonAsExpected,
+		Message:            "Signing key was resolved",
+	}
+	expired := metav1.Condition{
+		Type:               slinkyv1beta1.ConditionExpired,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: token.Generation,
+		Reason:             slinkyv1beta1.ReasonAsExpected,
+		Message:            "JWT has not expired",
+	}
+	defer func() {
+		meta.SetStatusCondition(&newStatus.Conditions, ready)
+		meta.SetStatusCondition(&newStatus.Conditions, signingKeyMissing)
+		meta.SetStatusCondition(&newStatus.Conditions, expired)
+	}()
+
+	jwtKeys, _, err := r.getJwtKeys(ctx, token)
 	if err != nil {
-		return err
+		signingKeyMissing.Status = metav1.ConditionTrue
+		signingKeyMissing.Reason = slinkyv1beta1.ReasonSigningKeyNotFound
+		signingKeyMissing.Message = err.Error()
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = slinkyv1beta1.ReasonSigningKeyNotFound
+		ready.Message = err.Error()
+		return newStatus, err
 	}
 
-	authTokenClaims, err := slurmjwt.ParseTokenClaims(string(authToken), signingKey)
+	authToken, err := r.refResolver.GetSecretKeyRef(ctx, token.SecretRef(), token.Namespace)
 	if err != nil {
-		return fmt.Errorf("failed to parse Slurm auth token: %w", err)
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = slinkyv1beta1.ReasonInvalidToken
+		ready.Message = err.Error()
+		return newStatus, err
+	}
+	newStatus.KeyID, _ = slurmjwt.ParseKeyID(string(authToken))
+
+	authTokenClaims, err := slurmjwt.ParseTokenClaimsWithKeys(string(authToken), jwtKeys)
+	if err != nil && !errors.Is(err, jwt.ErrTokenExpired) {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = slinkyv1beta1.ReasonInvalidToken
+		ready.Message = err.Error()
+		return newStatus, fmt.Errorf("failed to parse Slurm auth token: %w", err)
 	}
 	iat, err := authTokenClaims.GetIssuedAt()
 	if err != nil {
-		return fmt.Errorf("failed to get issued at time: %w", err)
+		return newStatus, fmt.Errorf("failed to get issued at time: %w", err)
+	}
+	exp, err := authTokenClaims.GetExpirationTime()
+	if err != nil {
+		return newStatus, fmt.Errorf("failed to get expiration time: %w", err)
 	}
 
-	var issuedAt *metav1.Time
 	if iat != nil {
-		issuedAt = ptr.To(metav1.NewTime(iat.Time))
+		newStatus.IssuedAt = ptr.To(metav1.NewTime(iat.Time))
 	}
-
-	newStatus := &slinkyv1beta1.TokenStatus{
-		IssuedAt:   issuedAt,
-		Conditions: structutils.MergeList(token.Status.Conditions),
+	if exp != nil {
+		newStatus.ExpiresAt = ptr.To(metav1.NewTime(exp.Time))
+		if token.Spec.Refresh {
+			newStatus.NextRefreshAt = ptr.To(metav1.NewTime(token.RefreshTime(exp.Time)))
+		}
+		if !now.Before(exp.Time) {
+			expired.Status = metav1.ConditionTrue
+			expired.Reason = slinkyv1beta1.ReasonExpired
+			expired.Message = fmt.Sprintf("JWT expired at %s", exp.Format(time.RFC3339))
+			ready.Status = metav1.ConditionFalse
+			ready.Reason = slinkyv1beta1.ReasonExpired
+			ready.Message = expired.Message
+		}
 	}
 
-	if apiequality.Semantic.DeepEqual(token.Status, newStatus) {
-		logger.V(2).Info("Token Status has not changed, skipping status update",
-			"token", klog.KObj(token), "status", token.Status)
-		return nil
-	}
+	return newStatus, nil
+}
 
-	if err := r.updateStatus(ctx, token, newStatus); err != nil {
-		return fmt.Errorf("error updating Token(%s) status: %w",
-			klog.KObj(token), err)
+// getRequeueTime returns the next time when the status changes: when the JWT
+// is refreshed, or else when it expires.
+func getRequeueTime(status *slinkyv1beta1.TokenStatus, now time.Time) time.Time {
+	for _, t := range []*metav1.Time{status.NextRefreshAt, status.ExpiresAt} {
+		if t != nil && now.Before(t.Time) {
+			return t.Time
+		}
 	}
-
-	return nil
+	return time.Time{}
 }
 
 func (r *TokenReconciler) updateStatus(
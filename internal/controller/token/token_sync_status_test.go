@@ -6,10 +6,18 @@ package token
 import (
 	"context"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
 func TestTokenReconciler_syncStatus(t *testing.T) {
@@ -64,3 +72,174 @@ func TestTokenReconciler_updateStatus(t *testing.T) {
 		})
 	}
 }
+
+func newTokenSecret(token *slinkyv1beta1.Token, jwtHs256KeySecret *corev1.Secret, lifetime time.Duration) *corev1.Secret {
+	authToken, err := slurmjwt.NewToken(jwtHs256KeySecret.Data[token.JwtHs256Ref().Key]).
+		WithUsername(token.Username()).
+		WithLifetime(lifetime).
+		NewSignedToken()
+	if err != nil {
+		panic(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      token.SecretKey().Name,
+			Namespace: token.SecretKey().Namespace,
+		},
+		Data: map[string][]byte{
+			token.SecretRef().Key: []byte(authToken),
+		},
+	}
+}
+
+func TestTokenReconciler_getStatus(t *testing.T) {
+	jwtHs256KeySecret := testutils.NewJwtHs256KeySecret(testutils.NewJwtHs256KeyRef("slurm"))
+	token := testutils.NewToken("slurm", jwtHs256KeySecret)
+	token.Spec.Lifetime = &metav1.Duration{Duration: time.Hour}
+	refreshToken := token.DeepCopy()
+	refreshToken.Spec.Refresh = true
+
+	otherKeySecret := jwtHs256KeySecret.DeepCopy()
+	otherKeySecret.Data[token.JwtHs256Ref().Key] = []byte("other")
+
+	tests := []struct {
+		name              string
+		client            client.Client
+		token             *slinkyv1beta1.Token
+		wantErr           bool
+		wantReady         metav1.ConditionStatus
+		wantReason        string
+		wantExpired       metav1.ConditionStatus
+		wantKeyMissing    metav1.ConditionStatus
+		wantExpiresAt     bool
+		wantNextRefreshAt bool
+	}{
+		{
+			name: "Valid",
+			client: fake.NewClientBuilder().
+				WithObjects(jwtHs256KeySecret, newTokenSecret(token, jwtHs256KeySecret, time.Hour)).
+				Build(),
+			token:          token,
+			wantReady:      metav1.ConditionTrue,
+			wantReason:     slinkyv1beta1.ReasonAsExpected,
+			wantExpired:    metav1.ConditionFalse,
+			wantKeyMissing: metav1.ConditionFalse,
+			wantExpiresAt:  true,
+		},
+		{
+			name: "Valid, with refresh",
+			client: fake.NewClientBuilder().
+				WithObjects(jwtHs256KeySecret, newTokenSecret(refreshToken, jwtHs256KeySecret, time.Hour)).
+				Build(),
+			token:             refreshToken,
+			wantReady:         metav1.ConditionTrue,
+			wantReason:        slinkyv1beta1.ReasonAsExpected,
+			wantExpired:       metav1.ConditionFalse,
+			wantKeyMissing:    metav1.ConditionFalse,
+			wantExpiresAt:     true,
+			wantNextRefreshAt: true,
+		},
+		{
+			name: "Expired",
+			client: fake.NewClientBuilder().
+				WithObjects(jwtHs256KeySecret, newTokenSecret(token, jwtHs256KeySecret, -time.Minute)).
+				Build(),
+			token:          token,
+			wantReady:      metav1.ConditionFalse,
+			wantReason:     slinkyv1beta1.ReasonExpired,
+			wantExpired:    metav1.ConditionTrue,
+			wantKeyMissing: metav1.ConditionFalse,
+			wantExpiresAt:  true,
+		},
+		{
+			name: "Signed by another key",
+			client: fake.NewClientBuilder().
+				WithObjects(jwtHs256KeySecret, newTokenSecret(token, otherKeySecret, time.Hour)).
+				Build(),
+			token:          token,
+			wantErr:        true,
+			wantReady:      metav1.ConditionFalse,
+			wantReason:     slinkyv1beta1.ReasonInvalidToken,
+			wantExpired:    metav1.ConditionFalse,
+			wantKeyMissing: metav1.ConditionFalse,
+		},
+		{
+			name:           "Signing key missing",
+			client:         fake.NewClientBuilder().Build(),
+			token:          token,
+			wantErr:        true,
+			wantReady:      metav1.ConditionFalse,
+			wantReason:     slinkyv1beta1.ReasonSigningKeyNotFound,
+			wantExpired:    metav1.ConditionFalse,
+			wantKeyMissing: metav1.ConditionTrue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReconciler(tt.client)
+			got, err := r.getStatus(context.TODO(), tt.token, time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TokenReconciler.getStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			ready := meta.FindStatusCondition(got.Conditions, slinkyv1beta1.ConditionReady)
+			if ready == nil || ready.Status != tt.wantReady || ready.Reason != tt.wantReason {
+				t.Errorf("Ready = %v, want %v (%v)", ready, tt.wantReady, tt.wantReason)
+			}
+			if !meta.IsStatusConditionPresentAndEqual(got.Conditions, slinkyv1beta1.ConditionExpired, tt.wantExpired) {
+				t.Errorf("Expired = %v, want %v", got.Conditions, tt.wantExpired)
+			}
+			if !meta.IsStatusConditionPresentAndEqual(got.Conditions, slinkyv1beta1.ConditionSigningKeyMissing, tt.wantKeyMissing) {
+				t.Errorf("SigningKeyMissing = %v, want %v", got.Conditions, tt.wantKeyMissing)
+			}
+			if (got.ExpiresAt != nil) != tt.wantExpiresAt {
+				t.Errorf("ExpiresAt = %v, want %v", got.ExpiresAt, tt.wantExpiresAt)
+			}
+			if (got.NextRefreshAt != nil) != tt.wantNextRefreshAt {
+				t.Errorf("NextRefreshAt = %v, want %v", got.NextRefreshAt, tt.wantNextRefreshAt)
+			}
+			if got.NextRefreshAt != nil && !got.NextRefreshAt.Before(got.ExpiresAt) {
+				t.Errorf("NextRefreshAt = %v, want before %v", got.NextRefreshAt, got.ExpiresAt)
+			}
+		})
+	}
+}
+
+func Test_getRequeueTime(t *testing.T) {
+	now := time.Now()
+	past := metav1.NewTime(now.Add(-time.Minute))
+	refresh := metav1.NewTime(now.Add(time.Minute))
+	expires := metav1.NewTime(now.Add(time.Hour))
+	tests := []struct {
+		name   string
+		status *slinkyv1beta1.TokenStatus
+		want   time.Time
+	}{
+		{
+			name:   "Empty",
+			status: &slinkyv1beta1.TokenStatus{},
+			want:   time.Time{},
+		},
+		{
+			name:   "Refresh",
+			status: &slinkyv1beta1.TokenStatus{NextRefreshAt: ptr.To(refresh), ExpiresAt: ptr.To(expires)},
+			want:   refresh.Time,
+		},
+		{
+			name:   "Refresh is past",
+			status: &slinkyv1beta1.TokenStatus{NextRefreshAt: ptr.To(past), ExpiresAt: ptr.To(expires)},
+			want:   expires.Time,
+		},
+		{
+			name:   "Expired",
+			status: &slinkyv1beta1.TokenStatus{ExpiresAt: ptr.To(past)},
+			want:   time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getRequeueTime(tt.status, now); !got.Equal(tt.want) {
+				t.Errorf("getRequeueTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics defines the Prometheus metrics of the slurm-operator, which
+// are served with the controller-runtime metrics.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "slurm_operator"
+
+	LabelController = "controller"
+	LabelNamespace  = "namespace"
+	LabelNodeSet    = "nodeset"
+	LabelToken      = "token"
+	LabelStep       = "step"
+	LabelObject     = "object"
+	LabelMethod     = "method"
+	LabelCode       = "code"
+	LabelState      = "state"
+	LabelAction     = "action"
+)
+
+const (
+	TaintActionAdd    = "add"
+	TaintActionRemove = "remove"
+)
+
+var (
+	SlurmrestdRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "slurmrestd",
+			Name:      "requests_total",
+			Help:      "Number of slurmrestd requests, by Controller, object type, method, and status code.",
+		},
+		[]string{LabelController, LabelObject, LabelMethod, LabelCode},
+	)
+	SlurmrestdRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "slurmrestd",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of slurmrestd requests, by Controller and object type.",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+		},
+		[]string{LabelController, LabelObject},
+	)
+	SlurmrestdRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "slurmrestd",
+			Name:      "request_errors_total",
+			Help:      "Number of slurmrestd requests which failed or returned a server error, by Controller and object type.",
+		},
+		[]string{LabelController, LabelObject},
+	)
+
+	SlurmClients = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "slurm_client",
+			Help:      "Whether the slurm client of the Controller is in the client map.",
+		},
+		[]string{LabelController},
+	)
+
+	NodeSetSlurmNodes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "nodeset",
+			Name:      "slurm_nodes",
+			Help:      "Number of Slurm nodes of the NodeSet, by Slurm base and flag state.",
+		},
+		[]string{LabelNamespace, LabelNodeSet, LabelState},
+	)
+	NodeSetReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "nodeset",
+			Name:      "replicas",
+			Help:      "Number of NodeSet pods.",
+		},
+		[]string{LabelNamespace, LabelNodeSet},
+	)
+	NodeSetUpdatedReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "nodeset",
+			Name:      "updated_replicas",
+			Help:      "Number of NodeSet pods at the update revision, the progress of a rolling update.",
+		},
+		[]string{LabelNamespace, LabelNodeSet},
+	)
+	NodeSetPodDrainDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "nodeset",
+			Name:      "pod_drain_duration_seconds",
+			Help:      "Time NodeSet pods spent draining before termination.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+		},
+		[]string{LabelNamespace, LabelNodeSet},
+	)
+	TaintSyncActions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "nodeset",
+			Name:      "taint_sync_actions_total",
+			Help:      "Number of Kubernetes node taints added or removed for NodeSet pods.",
+		},
+		[]string{LabelAction},
+	)
+
+	TokenRefreshFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "token",
+			Name:      "refresh_failures_total",
+			Help:      "Number of Token syncs which failed to issue or refresh the token, by sync step.",
+		},
+		[]string{LabelNamespace, LabelToken, LabelStep},
+	)
+	TokenExchangeRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "token_exchange",
+			Name:      "requests_total",
+			Help:      "Number of ServiceAccount token exchange requests, by status code.",
+		},
+		[]string{LabelCode},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		SlurmrestdRequests,
+		SlurmrestdRequestDuration,
+		SlurmrestdRequestErrors,
+		SlurmClients,
+		NodeSetSlurmNodes,
+		NodeSetReplicas,
+		NodeSetUpdatedReplicas,
+		NodeSetPodDrainDuration,
+		TaintSyncActions,
+		TokenRefreshFailures,
+		TokenExchangeRequests,
+	)
+}
+
+// DeleteNodeSet removes the metrics of a deleted NodeSet.
+func DeleteNodeSet(namespace, name string) {
+	labels := prometheus.Labels{LabelNamespace: namespace, LabelNodeSet: name}
+	NodeSetSlurmNodes.DeletePartialMatch(labels)
+	NodeSetReplicas.DeletePartialMatch(labels)
+	NodeSetUpdatedReplicas.DeletePartialMatch(labels)
+	NodeSetPodDrainDuration.DeletePartialMatch(labels)
+}
+
+// DeleteToken removes the metrics of a deleted Token.
+func DeleteToken(namespace, name string) {
+	TokenRefreshFailures.DeletePartialMatch(prometheus.Labels{LabelNamespace: namespace, LabelToken: name})
+}
+
+// slurmrestdTransport is an http.RoundTripper which records the slurmrestd
+// requests of a Controller.
+type slurmrestdTransport struct {
+	controller string
+	transport  http.RoundTripper
+}
+
+// NewSlurmrestdTransport returns an http.RoundTripper which records the
+// slurmrestd requests of the Controller.
+func NewSlurmrestdTransport(controller string, transport http.RoundTripper) http.RoundTripper {
+	return &slurmrestdTransport{
+		controller: controller,
+		transport:  transport,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *slurmrestdTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	object := ObjectFromPath(req.URL.Path)
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	SlurmrestdRequestDuration.WithLabelValues(t.controller, object).Observe(time.Since(start).Seconds())
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	SlurmrestdRequests.WithLabelValues(t.controller, object, req.Method, code).Inc()
+	if err != nil || resp.StatusCode >= http.StatusInternalServerError {
+		SlurmrestdRequestErrors.WithLabelValues(t.controller, object).Inc()
+	}
+	return resp, err
+}
+
+// ObjectFromPath returns the object type of a slurmrestd request path
+// (e.g. `/slurm/v0044/node/foo` is `node`).
+func ObjectFromPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	// Paths are `/<plugin>/<version>/<object>/...`
+	if len(parts) < 3 || parts[2] == "" {
+		return "unknown"
+	}
+	return parts[2]
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObjectFromPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "Slurm object",
+			path: "/slurm/v0044/nodes",
+			want: "nodes",
+		},
+		{
+			name: "Slurm object, with name",
+			path: "/slurm/v0044/node/slurm-node-0",
+			want: "node",
+		},
+		{
+			name: "Slurmdb object",
+			path: "/slurmdb/v0044/accounts/",
+			want: "accounts",
+		},
+		{
+			name: "Unknown",
+			path: "/openapi",
+			want: "unknown",
+		},
+		{
+			name: "Empty",
+			path: "",
+			want: "unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ObjectFromPath(tt.path); got != tt.want {
+				t.Errorf("ObjectFromPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestSlurmrestdTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller string
+		transport  http.RoundTripper
+		wantCode   string
+		wantErrors float64
+	}{
+		{
+			name:       "Success",
+			controller: "slurm/success",
+			transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+			}),
+			wantCode:   "200",
+			wantErrors: 0,
+		},
+		{
+			name:       "Server error",
+			controller: "slurm/server-error",
+			transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+			}),
+			wantCode:   "503",
+			wantErrors: 1,
+		},
+		{
+			name:       "Transport error",
+			controller: "slurm/transport-error",
+			transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			}),
+			wantCode:   "error",
+			wantErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := NewSlurmrestdTransport(tt.controller, tt.transport)
+			req := httptest.NewRequest(http.MethodGet, "http://slurm-restapi:6820/slurm/v0044/nodes", nil)
+			resp, _ := transport.RoundTrip(req)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			if got := testutil.ToFloat64(SlurmrestdRequests.WithLabelValues(tt.controller, "nodes", http.MethodGet, tt.wantCode)); got != 1 {
+				t.Errorf("SlurmrestdRequests = %v, want %v", got, 1)
+			}
+			if got := testutil.ToFloat64(SlurmrestdRequestErrors.WithLabelValues(tt.controller, "nodes")); got != tt.wantErrors {
+				t.Errorf("SlurmrestdRequestErrors = %v, want %v", got, tt.wantErrors)
+			}
+			if got := testutil.CollectAndCount(SlurmrestdRequestDuration); got == 0 {
+				t.Errorf("SlurmrestdRequestDuration was not observed")
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tokenexchange exchanges the tokens of Kubernetes ServiceAccounts for
+// short-lived Slurm JWTs, as granted by TokenPolicies.
+package tokenexchange
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/metrics"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/mathutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+const (
+	// Path is the path of the exchange endpoint.
+	Path = "/token"
+
+	// DefaultAudience is the audience which the ServiceAccount token must be
+	// issued for.
+	DefaultAudience = "slurm-operator"
+
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+
+	maxRequestBytes = 1 << 20
+)
+
+var log = ctrl.Log.WithName("token-exchange")
+
+// ExchangeRequest is the optional body of an exchange request. It selects the
+// Controller and the username when several TokenPolicies grant a JWT to the
+// ServiceAccount.
+type ExchangeRequest struct {
+	// ControllerRef is a reference to the Controller whose signing key signs
+	// the JWT. The namespace defaults to the namespace of the ServiceAccount.
+	ControllerRef slinkyv1beta1.ObjectReference `json:"controllerRef,omitzero"`
+
+	// Username is the username whom the JWT is issued for.
+	Username string `json:"username,omitempty"`
+
+	// Lifetime of the JWT, which is capped by the maximum lifetime of the
+	// TokenPolicy. Defaults to the maximum lifetime.
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+}
+
+// ExchangeResponse is the body of a successful exchange.
+type ExchangeResponse struct {
+	// Token is the Slurm JWT.
+	Token string `json:"token"`
+
+	// Username is the username whom the JWT is issued for.
+	Username string `json:"username"`
+
+	// ExpirationTimestamp is the time when the JWT expires.
+	ExpirationTimestamp metav1.Time `json:"expirationTimestamp"`
+}
+
+// grant is the JWT which TokenPolicies grant to a ServiceAccount.
+type grant struct {
+	controller  types.NamespacedName
+	username    string
+	maxLifetime time.Duration
+}
+
+// exchangeError is an error with the HTTP status code of the response.
+type exchangeError struct {
+	code int
+	err  error
+}
+
+func (e *exchangeError) Error() string {
+	return e.err.Error()
+}
+
+func newExchangeError(code int, format string, args ...any) error {
+	return &exchangeError{code: code, err: fmt.Errorf(format, args...)}
+}
+
+// Handler serves the exchange endpoint. A pod presents the token of its
+// ServiceAccount as a bearer token, which is validated with the TokenReview
+// API, and receives a Slurm JWT signed by the signing key of the Controller.
+type Handler struct {
+	client.Client
+
+	refResolver *refresolver.RefResolver
+	audiences   []string
+}
+
+func NewHandler(client client.Client, audiences []string) *Handler {
+	return &Handler{
+		Client:      client,
+		refResolver: refresolver.New(client),
+		audiences:   audiences,
+	}
+}
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=tokenpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	code := http.StatusOK
+	defer func() {
+		metrics.TokenExchangeRequests.WithLabelValues(strconv.Itoa(code)).Inc()
+	}()
+
+	response, err := h.serve(r)
+	if err != nil {
+		code = http.StatusInternalServerError
+		if e := (*exchangeError)(nil); errors.As(err, &e) {
+			code = e.code
+		}
+		if code == http.StatusInternalServerError {
+			log.Error(err, "failed to exchange token")
+		} else {
+			log.V(1).Info("denied token exchange", "code", code, "reason", err.Error())
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Error(err, "failed to write response")
+	}
+}
+
+func (h *Handler) serve(r *http.Request) (*ExchangeResponse, error) {
+	ctx := r.Context()
+
+	if r.Method != http.MethodPost {
+		return nil, newExchangeError(http.StatusMethodNotAllowed, "method %s is not allowed", r.Method)
+	}
+	bearerToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || bearerToken == "" {
+		return nil, newExchangeError(http.StatusUnauthorized, "missing bearer token")
+	}
+
+	request := &ExchangeRequest{}
+	body := io.LimitReader(r.Body, maxRequestBytes)
+	if err := json.NewDecoder(body).Decode(request); err != nil && !errors.Is(err, io.EOF) {
+		return nil, newExchangeError(http.StatusBadRequest, "invalid request: %v", err)
+	}
+	if request.Lifetime != nil && request.Lifetime.Duration <= 0 {
+		return nil, newExchangeError(http.StatusBadRequest, "invalid request: lifetime must be positive")
+	}
+
+	serviceAccount, err := h.authenticate(ctx, bearerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	grant, err := h.getGrant(ctx, serviceAccount, request)
+	if err != nil {
+		return nil, err
+	}
+
+	lifetime := grant.maxLifetime
+	if request.Lifetime != nil {
+		lifetime = mathutils.Clamp(request.Lifetime.Duration, 0, grant.maxLifetime)
+	}
+
+	response, err := h.sign(ctx, grant, lifetime)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Issued JWT", "serviceAccount", serviceAccount, "controller", grant.controller,
+		"username", grant.username, "expirationTimestamp", response.ExpirationTimestamp)
+	return response, nil
+}
+
+// authenticate validates the token with the TokenReview API, and returns the
+// ServiceAccount of the token.
+func (h *Handler) authenticate(ctx context.Context, token string) (types.NamespacedName, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: h.audiences,
+		},
+	}
+	if err := h.Create(ctx, review); err != nil {
+		return types.NamespacedName{}, fmt.Errorf("failed to create TokenReview: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return types.NamespacedName{}, newExchangeError(http.StatusUnauthorized, "token is not authenticated: %s", review.Status.Error)
+	}
+
+	serviceAccount, ok := parseServiceAccountUsername(review.Status.User.Username)
+	if !ok {
+		return types.NamespacedName{}, newExchangeError(http.StatusUnauthorized, "token is not of a ServiceAccount")
+	}
+	return serviceAccount, nil
+}
+
+// parseServiceAccountUsername returns the ServiceAccount of the username
+// (e.g. `system:serviceaccount:<namespace>:<name>`).
+func parseServiceAccountUsername(username string) (types.NamespacedName, bool) {
+	rest, ok := strings.CutPrefix(username, serviceAccountUsernamePrefix)
+	if !ok {
+		return types.NamespacedName{}, false
+	}
+	namespace, name, ok := strings.Cut(rest, ":")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, ":") {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, true
+}
+
+// getGrant returns the JWT which the TokenPolicies grant to the
+// ServiceAccount. TokenPolicies which grant the same JWT are merged, with the
+// longest maximum lifetime.
+func (h *Handler) getGrant(ctx context.Context, serviceAccount types.NamespacedName, request *ExchangeRequest) (*grant, error) {
+	controllerRef := request.ControllerRef
+	if controllerRef.Name != "" && controllerRef.Namespace == "" {
+		controllerRef.Namespace = serviceAccount.Namespace
+	}
+
+	list := &slinkyv1beta1.TokenPolicyList{}
+	if err := h.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list TokenPolicies: %w", err)
+	}
+
+	grants := []*grant{}
+	for i := range list.Items {
+		policy := &list.Items[i]
+		if !policy.MatchesServiceAccount(serviceAccount) {
+			continue
+		}
+		if controllerRef.Name != "" && !controllerRef.IsMatch(policy.ControllerKey()) {
+			continue
+		}
+		if request.Username != "" && request.Username != policy.Spec.Username {
+			continue
+		}
+		if ok, err := h.isPermitted(ctx, policy); err != nil {
+			return nil, err
+		} else if !ok {
+			log.V(1).Info("Controller does not permit TokenPolicy", "tokenPolicy", policy.Key(), "controller", policy.ControllerKey())
+			continue
+		}
+
+		idx := -1
+		for j, g := range grants {
+			if g.controller == policy.ControllerKey() && g.username == policy.Spec.Username {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			grants = append(grants, &grant{
+				controller:  policy.ControllerKey(),
+				username:    policy.Spec.Username,
+				maxLifetime: policy.MaxLifetime(),
+			})
+			continue
+		}
+		grants[idx].maxLifetime = max(grants[idx].maxLifetime, policy.MaxLifetime())
+	}
+
+	switch len(grants) {
+	case 0:
+		return nil, newExchangeError(http.StatusForbidden, "no TokenPolicy grants a JWT to ServiceAccount (%s)", serviceAccount)
+	case 1:
+		return grants[0], nil
+	default:
+		return nil, newExchangeError(http.StatusConflict,
+			"%d TokenPolicies grant a JWT to ServiceAccount (%s), the request must select the controllerRef and username", len(grants), serviceAccount)
+	}
+}
+
+// isPermitted reports if the Controller of the policy permits it to issue its
+// JWTs. A missing Controller permits nothing.
+func (h *Handler) isPermitted(ctx context.Context, policy *slinkyv1beta1.TokenPolicy) (bool, error) {
+	if policy.ControllerKey().Namespace == policy.Namespace {
+		return true, nil
+	}
+	controller := &slinkyv1beta1.Controller{}
+	if err := h.Get(ctx, policy.ControllerKey(), controller); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get controller (%s): %w", policy.ControllerKey(), err)
+	}
+	return policy.IsPermittedBy(controller), nil
+}
+
+// sign returns the JWT of the grant, signed by the signing key of the
+// Controller.
+func (h *Handler) sign(ctx context.Context, grant *grant, lifetime time.Duration) (*ExchangeResponse, error) {
+	controller, err := h.refResolver.GetController(ctx, slinkyv1beta1.ObjectReference{
+		Namespace: grant.controller.Namespace,
+		Name:      grant.controller.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get controller (%s): %w", grant.controller, err)
+	}
+	signingKey, err := h.refResolver.GetJwtSigningKey(ctx, controller)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := slurmjwt.NewTokenWithKey(signingKey).
+		WithUsername(grant.username).
+		WithLifetime(lifetime).
+		NewSignedToken()
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := slurmjwt.ParseTokenClaimsWithKeys(token, []*slurmjwt.SigningKey{signingKey})
+	if err != nil {
+		return nil, err
+	}
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return nil, fmt.Errorf("failed to get expiration time: %w", err)
+	}
+
+	return &ExchangeResponse{
+		Token:               token,
+		Username:            grant.username,
+		ExpirationTimestamp: metav1.NewTime(exp.Time),
+	}, nil
+}
@@ -0,0 +1,299 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenexchange
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func init() {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+}
+
+// newTokenReviewClient returns a client whose TokenReviews authenticate the
+// tokens as the users.
+func newTokenReviewClient(users map[string]string, objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().
+		WithObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authenticationv1.TokenReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				username, ok := users[review.Spec.Token]
+				if !ok {
+					review.Status.Error = "invalid bearer token"
+					return nil
+				}
+				review.Status.Authenticated = true
+				review.Status.User.Username = username
+				return nil
+			},
+		}).
+		Build()
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	jwtHs256KeyRef := testutils.NewJwtHs256KeyRef("slurm")
+	jwtHs256KeySecret := testutils.NewJwtHs256KeySecret(jwtHs256KeyRef)
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), jwtHs256KeyRef, nil)
+
+	ciPolicy := testutils.NewTokenPolicy("ci", controller, "ci-runner", "ci")
+	ciPolicy.Spec.MaxLifetime = &metav1.Duration{Duration: 10 * time.Minute}
+	sharedPolicy := testutils.NewTokenPolicy("shared", controller, "alice", "shared")
+	sharedPolicy2 := testutils.NewTokenPolicy("shared2", controller, "bob", "shared")
+	crossPolicy := testutils.NewTokenPolicy("cross", controller, "root", "ci")
+	crossPolicy.Namespace = "other"
+	grantedPolicy := testutils.NewTokenPolicy("granted", controller, "ci-runner", "ci")
+	grantedPolicy.Namespace = "granted"
+	controller.Spec.UserGrants = []slinkyv1beta1.UserGrant{
+		{Namespace: "granted", Usernames: []string{"ci-runner"}},
+	}
+
+	users := map[string]string{
+		"ci":      "system:serviceaccount:default:ci",
+		"none":    "system:serviceaccount:none:ci",
+		"other":   "system:serviceaccount:other:ci",
+		"granted": "system:serviceaccount:granted:ci",
+		"shared":  "system:serviceaccount:default:shared",
+		"user":    "alice",
+	}
+	objs := []client.Object{jwtHs256KeySecret, controller, ciPolicy, sharedPolicy, sharedPolicy2, crossPolicy, grantedPolicy}
+
+	tests := []struct {
+		name         string
+		method       string
+		token        string
+		body         string
+		wantCode     int
+		wantUsername string
+		wantLifetime time.Duration
+	}{
+		{
+			name:     "Method not allowed",
+			method:   http.MethodGet,
+			token:    "ci",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "Missing bearer token",
+			method:   http.MethodPost,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "Not authenticated",
+			method:   http.MethodPost,
+			token:    "invalid",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "Not a ServiceAccount",
+			method:   http.MethodPost,
+			token:    "user",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "Invalid request",
+			method:   http.MethodPost,
+			token:    "ci",
+			body:     `{"lifetime": "-1m"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "No TokenPolicy",
+			method:   http.MethodPost,
+			token:    "none",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "Controller of another namespace, not granted",
+			method:   http.MethodPost,
+			token:    "other",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:         "Controller of another namespace, granted",
+			method:       http.MethodPost,
+			token:        "granted",
+			wantCode:     http.StatusOK,
+			wantUsername: "ci-runner",
+			wantLifetime: 15 * time.Minute,
+		},
+		{
+			name:     "Other Controller",
+			method:   http.MethodPost,
+			token:    "ci",
+			body:     `{"controllerRef": {"name": "other"}}`,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:         "Max lifetime",
+			method:       http.MethodPost,
+			token:        "ci",
+			wantCode:     http.StatusOK,
+			wantUsername: "ci-runner",
+			wantLifetime: 10 * time.Minute,
+		},
+		{
+			name:         "Requested lifetime",
+			method:       http.MethodPost,
+			token:        "ci",
+			body:         `{"controllerRef": {"name": "slurm"}, "lifetime": "1m"}`,
+			wantCode:     http.StatusOK,
+			wantUsername: "ci-runner",
+			wantLifetime: time.Minute,
+		},
+		{
+			name:         "Requested lifetime is capped",
+			method:       http.MethodPost,
+			token:        "ci",
+			body:         `{"lifetime": "1h"}`,
+			wantCode:     http.StatusOK,
+			wantUsername: "ci-runner",
+			wantLifetime: 10 * time.Minute,
+		},
+		{
+			name:     "Several TokenPolicies",
+			method:   http.MethodPost,
+			token:    "shared",
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:         "Several TokenPolicies, with username",
+			method:       http.MethodPost,
+			token:        "shared",
+			body:         `{"username": "bob"}`,
+			wantCode:     http.StatusOK,
+			wantUsername: "bob",
+			wantLifetime: 15 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(newTokenReviewClient(users, objs...), []string{DefaultAudience})
+
+			req := httptest.NewRequest(tt.method, Path, strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			now := time.Now()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("Handler.ServeHTTP() code = %v, want %v: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			response := &ExchangeResponse{}
+			if err := json.Unmarshal(rec.Body.Bytes(), response); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if response.Username != tt.wantUsername {
+				t.Errorf("response.Username = %v, want %v", response.Username, tt.wantUsername)
+			}
+			claims, err := slurmjwt.ParseTokenClaims(response.Token, jwtHs256KeySecret.Data[jwtHs256KeyRef.Key])
+			if err != nil {
+				t.Fatalf("slurmjwt.ParseTokenClaims() error = %v", err)
+			}
+			if claims["sun"] != tt.wantUsername {
+				t.Errorf("claims[sun] = %v, want %v", claims["sun"], tt.wantUsername)
+			}
+			exp, err := claims.GetExpirationTime()
+			if err != nil {
+				t.Fatalf("claims.GetExpirationTime() error = %v", err)
+			}
+			if !exp.Time.Equal(response.ExpirationTimestamp.Time) {
+				t.Errorf("response.ExpirationTimestamp = %v, want %v", response.ExpirationTimestamp, exp.Time)
+			}
+			if lifetime := exp.Sub(now); lifetime > tt.wantLifetime || lifetime < tt.wantLifetime-time.Minute {
+				t.Errorf("lifetime = %v, want %v", lifetime, tt.wantLifetime)
+			}
+		})
+	}
+}
+
+func Test_parseServiceAccountUsername(t *testing.T) {
+	tests := []struct {
+		username string
+		want     types.NamespacedName
+		wantOk   bool
+	}{
+		{
+			username: "system:serviceaccount:default:ci",
+			want:     types.NamespacedName{Namespace: "default", Name: "ci"},
+			wantOk:   true,
+		},
+		{
+			username: "alice",
+		},
+		{
+			username: "system:serviceaccount:default",
+		},
+		{
+			username: "system:serviceaccount::ci",
+		},
+		{
+			username: "system:serviceaccount:default:ci:extra",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.username, func(t *testing.T) {
+			got, ok := parseServiceAccountUsername(tt.username)
+			if ok != tt.wantOk {
+				t.Fatalf("parseServiceAccountUsername() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Errorf("parseServiceAccountUsername() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenPolicy_MatchesServiceAccount(t *testing.T) {
+	controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+	policy := testutils.NewTokenPolicy("ci", controller, "ci-runner", "ci")
+	policy.Spec.ServiceAccounts = append(policy.Spec.ServiceAccounts, slinkyv1beta1.ServiceAccountSelector{
+		Namespace: "builds",
+		Name:      slinkyv1beta1.ServiceAccountSelectorAll,
+	})
+	tests := []struct {
+		serviceAccount types.NamespacedName
+		want           bool
+	}{
+		{serviceAccount: types.NamespacedName{Namespace: "default", Name: "ci"}, want: true},
+		{serviceAccount: types.NamespacedName{Namespace: "default", Name: "other"}, want: false},
+		{serviceAccount: types.NamespacedName{Namespace: "builds", Name: "any"}, want: true},
+		{serviceAccount: types.NamespacedName{Namespace: "other", Name: "ci"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.serviceAccount.String(), func(t *testing.T) {
+			if got := policy.MatchesServiceAccount(tt.serviceAccount); got != tt.want {
+				t.Errorf("TokenPolicy.MatchesServiceAccount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// CertificateValidity is how long generated certificates are valid for.
+	CertificateValidity = 10 * 365 * 24 * time.Hour
+)
+
+// CertificateAuthority is a self-signed CA which issues certificates.
+type CertificateAuthority struct {
+	cert       *x509.Certificate
+	privateKey *ecdsa.PrivateKey
+}
+
+// Certificate is a certificate and its private key, in PEM format.
+type Certificate struct {
+	Cert []byte
+	Key  []byte
+}
+
+// NewCertificateAuthority returns a new self-signed CA.
+func NewCertificateAuthority(commonName string) (*CertificateAuthority, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+	template, err := newCertificateTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, privateKey.Public(), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	o := &CertificateAuthority{
+		cert:       cert,
+		privateKey: privateKey,
+	}
+	return o, nil
+}
+
+// Certificate returns the CA certificate in PEM format.
+func (o *CertificateAuthority) Certificate() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: o.cert.Raw})
+}
+
+// NewServerCertificate returns a certificate for a server reachable by the DNS names.
+func (o *CertificateAuthority) NewServerCertificate(commonName string, dnsNames ...string) (*Certificate, error) {
+	template, err := newCertificateTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	template.DNSNames = dnsNames
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	return o.issue(template)
+}
+
+// NewClientCertificate returns a certificate for a client.
+func (o *CertificateAuthority) NewClientCertificate(commonName string) (*Certificate, error) {
+	template, err := newCertificateTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	return o.issue(template)
+}
+
+// issue signs a certificate from the template with the CA.
+func (o *CertificateAuthority) issue(template *x509.Certificate) (*Certificate, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate key: %w", err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, o.cert, privateKey.Public(), o.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	key, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal certificate key: %w", err)
+	}
+
+	out := &Certificate{
+		Cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		Key:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}),
+	}
+	return out, nil
+}
+
+// newCertificateTemplate returns a certificate template with a random serial number.
+func newCertificateTemplate(commonName string) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName: commonName,
+		},
+		NotBefore: now.Add(-5 * time.Minute),
+		NotAfter:  now.Add(CertificateValidity),
+	}
+	return template, nil
+}
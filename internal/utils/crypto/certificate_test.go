@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package crypto
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestCertificateAuthority(t *testing.T) {
+	ca, err := NewCertificateAuthority("slurm-ca")
+	if err != nil {
+		t.Fatalf("NewCertificateAuthority() error = %v", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(ca.Certificate()) {
+		t.Fatalf("CertificateAuthority.Certificate() is not a PEM certificate")
+	}
+
+	server, err := ca.NewServerCertificate("slurm-restapi", "slurm-restapi", "slurm-restapi.slurm")
+	if err != nil {
+		t.Fatalf("CertificateAuthority.NewServerCertificate() error = %v", err)
+	}
+	client, err := ca.NewClientCertificate("slurm-operator")
+	if err != nil {
+		t.Fatalf("CertificateAuthority.NewClientCertificate() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		cert    *Certificate
+		opts    x509.VerifyOptions
+		wantErr bool
+	}{
+		{
+			name: "Server",
+			cert: server,
+			opts: x509.VerifyOptions{
+				Roots:     roots,
+				DNSName:   "slurm-restapi.slurm",
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			},
+		},
+		{
+			name: "Server, wrong name",
+			cert: server,
+			opts: x509.VerifyOptions{
+				Roots:     roots,
+				DNSName:   "slurm-controller.slurm",
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Client",
+			cert: client,
+			opts: x509.VerifyOptions{
+				Roots:     roots,
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			},
+		},
+		{
+			name: "Client, as server",
+			cert: client,
+			opts: x509.VerifyOptions{
+				Roots:     roots,
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tls.X509KeyPair(tt.cert.Cert, tt.cert.Key); err != nil {
+				t.Fatalf("tls.X509KeyPair() error = %v", err)
+			}
+			block, _ := pem.Decode(tt.cert.Cert)
+			if block == nil {
+				t.Fatalf("Certificate.Cert is not PEM encoded")
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				t.Fatalf("x509.ParseCertificate() error = %v", err)
+			}
+			if _, err := cert.Verify(tt.opts); (err != nil) != tt.wantErr {
+				t.Errorf("Certificate.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
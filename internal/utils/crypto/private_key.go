@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+const (
+	DefaultRsaPrivateKeyLength = 2048
+)
+
+// NewPrivateKeyPEM returns a new RSA (2048 bits) or ECDSA (P-256) private key,
+// PKCS #8 encoded in PEM format.
+func NewPrivateKeyPEM(keyType KeyPairType) ([]byte, error) {
+	var privateKey crypto.PrivateKey
+	var err error
+	switch keyType {
+	case KeyPairRsa:
+		privateKey, err = rsa.GenerateKey(rand.Reader, DefaultRsaPrivateKeyLength)
+	case KeyPairEcdsa:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported private key type: %v", keyType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s private key: %w", keyType, err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s private key: %w", keyType, err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
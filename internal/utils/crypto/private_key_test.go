@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestNewPrivateKeyPEM(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyType KeyPairType
+		wantErr bool
+	}{
+		{
+			name:    "RSA",
+			keyType: KeyPairRsa,
+		},
+		{
+			name:    "ECDSA",
+			keyType: KeyPairEcdsa,
+		},
+		{
+			name:    "Unsupported",
+			keyType: KeyPairEd25519,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPrivateKeyPEM(tt.keyType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPrivateKeyPEM() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			block, _ := pem.Decode(got)
+			if block == nil {
+				t.Fatalf("NewPrivateKeyPEM() is not PEM encoded")
+			}
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				t.Fatalf("x509.ParsePKCS8PrivateKey() error = %v", err)
+			}
+			switch k := key.(type) {
+			case *rsa.PrivateKey:
+				if tt.keyType != KeyPairRsa || k.N.BitLen() != DefaultRsaPrivateKeyLength {
+					t.Errorf("NewPrivateKeyPEM() = RSA %d bits, want %v", k.N.BitLen(), tt.keyType)
+				}
+			case *ecdsa.PrivateKey:
+				if tt.keyType != KeyPairEcdsa {
+					t.Errorf("NewPrivateKeyPEM() = ECDSA, want %v", tt.keyType)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package jobinfo
+
+import (
+	"encoding/json"
+
+	"k8s.io/utils/ptr"
+)
+
+// JobInfo identifies the SlurmJob of a Slurm job, stored in the job comment.
+type JobInfo struct {
+	Namespace string `json:"namespace"`
+	SlurmJob  string `json:"slurmJob"`
+	UID       string `json:"uid,omitempty"`
+}
+
+func (jobInfo *JobInfo) ToString() string {
+	b, _ := json.Marshal(jobInfo)
+	return string(b)
+}
+
+func ParseIntoJobInfo(str *string, out *JobInfo) error {
+	data := ptr.Deref(str, "")
+	return json.Unmarshal([]byte(data), &out)
+}
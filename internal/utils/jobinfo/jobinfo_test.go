@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package jobinfo
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseIntoJobInfo(t *testing.T) {
+	type args struct {
+		str *string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    JobInfo
+		wantErr bool
+	}{
+		{
+			name: "Round trip",
+			args: args{
+				str: ptr.To((&JobInfo{Namespace: corev1.NamespaceDefault, SlurmJob: "foo"}).ToString()),
+			},
+			want: JobInfo{Namespace: corev1.NamespaceDefault, SlurmJob: "foo"},
+		},
+		{
+			name: "Nil",
+			args: args{
+				str: nil,
+			},
+			want:    JobInfo{},
+			wantErr: true,
+		},
+		{
+			name: "Not JSON",
+			args: args{
+				str: ptr.To("user comment"),
+			},
+			want:    JobInfo{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := JobInfo{}
+			if err := ParseIntoJobInfo(tt.args.str, &got); (err != nil) != tt.wantErr {
+				t.Errorf("ParseIntoJobInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseIntoJobInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
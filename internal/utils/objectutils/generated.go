@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package objectutils
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// SyncGeneratedSecret creates the generated Secret when it does not exist.
+// Generated Secrets are immutable, so an existing one is never updated;
+// instead, the owner adopts it, or releases it when it is retained on delete.
+// Secrets which were not generated are left alone.
+func SyncGeneratedSecret(c client.Client, ctx context.Context, newObj *corev1.Secret, owner client.Object) error {
+	logger := log.FromContext(ctx)
+
+	key := client.ObjectKeyFromObject(newObj)
+	oldObj := &corev1.Secret{}
+	if err := c.Get(ctx, key, oldObj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error getting %s: %w", key, err)
+		}
+		logger.Info("Generating Secret", "secret", key)
+		if err := c.Create(ctx, newObj); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("error creating %s: %w", key, err)
+		}
+		return nil
+	}
+
+	if !metav1.HasAnnotation(oldObj.ObjectMeta, slinkyv1beta1.AnnotationGeneratedKeys) {
+		logger.V(1).Info(fmt.Sprintf("%s was not generated. Skipping...", key))
+		return nil
+	}
+	if !oldObj.GetDeletionTimestamp().IsZero() {
+		logger.V(1).Info(fmt.Sprintf("%s is being deleted. Skipping...", key))
+		return nil
+	}
+
+	isOwner, err := controllerutil.HasOwnerReference(oldObj.OwnerReferences, owner, c.Scheme())
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(oldObj.DeepCopy())
+	switch {
+	case IsRetainedOnDelete(oldObj) && isOwner:
+		if err := controllerutil.RemoveOwnerReference(owner, oldObj, c.Scheme()); err != nil {
+			return err
+		}
+	case !IsRetainedOnDelete(oldObj) && !isOwner:
+		if err := controllerutil.SetOwnerReference(owner, oldObj, c.Scheme()); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	if err := c.Patch(ctx, oldObj, patch); err != nil {
+		return fmt.Errorf("error patching %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// IsRetainedOnDelete reports if the object is kept when its owners are
+// deleted.
+func IsRetainedOnDelete(obj metav1.Object) bool {
+	return obj.GetAnnotations()[slinkyv1beta1.AnnotationRetainOnDelete] == "true"
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package objectutils
+
+import (
+	"context"
+	"testing"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestSyncGeneratedSecret(t *testing.T) {
+	owner := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+			UID:       "slurm",
+		},
+	}
+	otherOwner := &slinkyv1beta1.Accounting{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+			UID:       "accounting",
+		},
+	}
+	newSecret := func(data string, annotations map[string]string, owners ...client.Object) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   corev1.NamespaceDefault,
+				Name:        "slurm-auth-slurm",
+				Annotations: annotations,
+			},
+			Data: map[string][]byte{
+				"slurm.key": []byte(data),
+			},
+			Immutable: ptr.To(true),
+		}
+		for _, o := range owners {
+			if err := controllerutil.SetOwnerReference(o, secret, scheme.Scheme); err != nil {
+				panic(err)
+			}
+		}
+		return secret
+	}
+	generated := map[string]string{slinkyv1beta1.AnnotationGeneratedKeys: "slurm.key"}
+	retained := map[string]string{slinkyv1beta1.AnnotationGeneratedKeys: "slurm.key", slinkyv1beta1.AnnotationRetainOnDelete: "true"}
+	tests := []struct {
+		name      string
+		existing  *corev1.Secret
+		wantData  string
+		wantOwner bool
+	}{
+		{
+			name:      "Create",
+			wantData:  "new",
+			wantOwner: true,
+		},
+		{
+			name:      "Not generated",
+			existing:  newSecret("old", nil),
+			wantData:  "old",
+			wantOwner: false,
+		},
+		{
+			name:      "Adopt",
+			existing:  newSecret("old", generated, otherOwner),
+			wantData:  "old",
+			wantOwner: true,
+		},
+		{
+			name:      "Retain",
+			existing:  newSecret("old", retained, owner, otherOwner),
+			wantData:  "old",
+			wantOwner: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder()
+			if tt.existing != nil {
+				builder = builder.WithObjects(tt.existing)
+			}
+			c := builder.Build()
+			if err := SyncGeneratedSecret(c, context.TODO(), newSecret("new", generated, owner), owner); err != nil {
+				t.Fatalf("SyncGeneratedSecret() error = %v", err)
+			}
+			got := &corev1.Secret{}
+			if err := c.Get(context.TODO(), client.ObjectKey{Namespace: corev1.NamespaceDefault, Name: "slurm-auth-slurm"}, got); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if string(got.Data["slurm.key"]) != tt.wantData {
+				t.Errorf("Data = %s, want %s", got.Data["slurm.key"], tt.wantData)
+			}
+			isOwner, err := controllerutil.HasOwnerReference(got.OwnerReferences, owner, scheme.Scheme)
+			if err != nil {
+				t.Fatalf("HasOwnerReference() error = %v", err)
+			}
+			if isOwner != tt.wantOwner {
+				t.Errorf("HasOwnerReference() = %v, want %v", isOwner, tt.wantOwner)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
 )
 
@@ -107,6 +108,66 @@ func (r *RefResolver) GetControllersForAccounting(ctx context.Context, accountin
 	return out, nil
 }
 
+func (r *RefResolver) GetTokensForController(ctx context.Context, controller *slinkyv1beta1.Controller) (*slinkyv1beta1.TokenList, error) {
+	list := &slinkyv1beta1.TokenList{}
+	if err := r.reader.List(ctx, list); err != nil {
+		return nil, err
+	}
+
+	out := &slinkyv1beta1.TokenList{}
+	for _, item := range list.Items {
+		if item.HasControllerRef() && item.Spec.ControllerRef.IsMatch(objectutils.NamespacedName(controller)) {
+			out.Items = append(out.Items, item)
+		}
+	}
+
+	return out, nil
+}
+
+// GetJwtKeys returns the JWT keys of the Controller, the HS256 key first.
+func (r *RefResolver) GetJwtKeys(ctx context.Context, controller *slinkyv1beta1.Controller) ([]*slurmjwt.SigningKey, error) {
+	jwtHs256Key, err := r.GetSecretKeyRef(ctx, controller.AuthJwtHs256Ref(), controller.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JWT HS256 key: %w", err)
+	}
+	out := []*slurmjwt.SigningKey{slurmjwt.NewHS256SigningKey(jwtHs256Key)}
+
+	if controller.Spec.JwtKeys == nil {
+		return out, nil
+	}
+	for _, key := range controller.Spec.JwtKeys.Keys {
+		keyPEM, err := r.GetSecretKeyRef(ctx, &key.PrivateKeyRef, controller.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get JWT key (%s): %w", key.KeyID, err)
+		}
+		algorithm := key.Algorithm
+		if algorithm == "" {
+			algorithm = slinkyv1beta1.JwtKeyAlgorithmRS256
+		}
+		signingKey, err := slurmjwt.ParseSigningKey(key.KeyID, string(algorithm), keyPEM)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, signingKey)
+	}
+
+	return out, nil
+}
+
+// GetJwtSigningKey returns the key which signs the JWTs of the Controller.
+func (r *RefResolver) GetJwtSigningKey(ctx context.Context, controller *slinkyv1beta1.Controller) (*slurmjwt.SigningKey, error) {
+	keys, err := r.GetJwtKeys(ctx, controller)
+	if err != nil {
+		return nil, err
+	}
+	keyID := controller.JwtSigningKeyID()
+	signingKey := slurmjwt.FindSigningKey(keys, keyID)
+	if signingKey == nil {
+		return nil, fmt.Errorf("JWT signing key (%s) is not one of the keys", keyID)
+	}
+	return signingKey, nil
+}
+
 func (r *RefResolver) GetSecretKeyRef(ctx context.Context, selector *corev1.SecretKeySelector, namespace string) ([]byte, error) {
 	secret := &corev1.Secret{}
 	key := types.NamespacedName{
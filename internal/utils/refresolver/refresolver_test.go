@@ -9,6 +9,7 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -616,3 +617,91 @@ func TestRefResolver_GetSecretKeyRef(t *testing.T) {
 		})
 	}
 }
+
+func TestRefResolver_GetJwtSigningKey(t *testing.T) {
+	slurmKeyRef := testutils.NewSlurmKeyRef("slurm")
+	jwtHs256KeyRef := testutils.NewJwtHs256KeyRef("slurm")
+	jwtKeyRef := testutils.NewJwtKeyRef("slurm")
+	newController := func(jwtKeys *slinkyv1beta1.JwtKeys) *slinkyv1beta1.Controller {
+		controller := testutils.NewController("slurm", slurmKeyRef, jwtHs256KeyRef, nil)
+		controller.Spec.JwtKeys = jwtKeys
+		return controller
+	}
+	reader := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(testutils.NewJwtHs256KeySecret(jwtHs256KeyRef), testutils.NewJwtKeySecret(jwtKeyRef)).
+		Build()
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		wantKeyID  string
+		wantAlg    string
+		wantErr    bool
+	}{
+		{
+			name:       "HS256",
+			controller: newController(nil),
+			wantKeyID:  "",
+			wantAlg:    "HS256",
+		},
+		{
+			name: "RS256",
+			controller: newController(&slinkyv1beta1.JwtKeys{
+				SigningKeyID: "a",
+				Keys: []slinkyv1beta1.JwtKey{
+					{KeyID: "a", PrivateKeyRef: jwtKeyRef},
+				},
+			}),
+			wantKeyID: "a",
+			wantAlg:   "RS256",
+		},
+		{
+			name: "Unknown signing key",
+			controller: newController(&slinkyv1beta1.JwtKeys{
+				SigningKeyID: "b",
+				Keys: []slinkyv1beta1.JwtKey{
+					{KeyID: "a", PrivateKeyRef: jwtKeyRef},
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Algorithm mismatch",
+			controller: newController(&slinkyv1beta1.JwtKeys{
+				SigningKeyID: "a",
+				Keys: []slinkyv1beta1.JwtKey{
+					{KeyID: "a", Algorithm: slinkyv1beta1.JwtKeyAlgorithmES256, PrivateKeyRef: jwtKeyRef},
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Missing private key",
+			controller: newController(&slinkyv1beta1.JwtKeys{
+				SigningKeyID: "a",
+				Keys: []slinkyv1beta1.JwtKey{
+					{KeyID: "a", PrivateKeyRef: testutils.NewJwtKeyRef("missing")},
+				},
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(reader)
+			got, err := r.GetJwtSigningKey(context.TODO(), tt.controller)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RefResolver.GetJwtSigningKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.KeyID != tt.wantKeyID {
+				t.Errorf("RefResolver.GetJwtSigningKey() kid = %v, want %v", got.KeyID, tt.wantKeyID)
+			}
+			if got.Method.Alg() != tt.wantAlg {
+				t.Errorf("RefResolver.GetJwtSigningKey() alg = %v, want %v", got.Method.Alg(), tt.wantAlg)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package statusutils
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+// NewGeneratedKeysStatus returns the status of the Secrets of the key refs
+// which were generated by the operator. Secrets which do not exist yet, or
+// were not generated, are omitted.
+func NewGeneratedKeysStatus(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	refs []*corev1.SecretKeySelector,
+) ([]slinkyv1beta1.GeneratedKeyStatus, error) {
+	out := []slinkyv1beta1.GeneratedKeyStatus{}
+	for _, ref := range refs {
+		if slices.ContainsFunc(out, func(status slinkyv1beta1.GeneratedKeyStatus) bool {
+			return status.Name == ref.Name
+		}) {
+			continue
+		}
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+		if err := reader.Get(ctx, key, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !metav1.HasAnnotation(secret.ObjectMeta, slinkyv1beta1.AnnotationGeneratedKeys) {
+			continue
+		}
+		out = append(out, slinkyv1beta1.GeneratedKeyStatus{
+			Name:        secret.Name,
+			Keys:        strings.Split(secret.Annotations[slinkyv1beta1.AnnotationGeneratedKeys], ","),
+			GeneratedAt: secret.CreationTimestamp,
+			Retained:    objectutils.IsRetainedOnDelete(secret),
+		})
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package statusutils
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func TestNewGeneratedKeysStatus(t *testing.T) {
+	newRef := func(name, key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		}
+	}
+	newSecret := func(name string, annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   corev1.NamespaceDefault,
+				Name:        name,
+				Annotations: annotations,
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithObjects(
+		newSecret("generated", map[string]string{
+			slinkyv1beta1.AnnotationGeneratedKeys: "jwt_hs256.key,slurm.key",
+		}),
+		newSecret("retained", map[string]string{
+			slinkyv1beta1.AnnotationGeneratedKeys:  "slurm.key",
+			slinkyv1beta1.AnnotationRetainOnDelete: "true",
+		}),
+		newSecret("user", nil),
+	).Build()
+	tests := []struct {
+		name string
+		refs []*corev1.SecretKeySelector
+		want []slinkyv1beta1.GeneratedKeyStatus
+	}{
+		{
+			name: "No refs",
+			want: nil,
+		},
+		{
+			name: "Missing or not generated",
+			refs: []*corev1.SecretKeySelector{newRef("missing", "slurm.key"), newRef("user", "slurm.key")},
+			want: nil,
+		},
+		{
+			name: "Generated",
+			refs: []*corev1.SecretKeySelector{
+				newRef("generated", "slurm.key"),
+				newRef("generated", "jwt_hs256.key"),
+				newRef("retained", "slurm.key"),
+			},
+			want: []slinkyv1beta1.GeneratedKeyStatus{
+				{Name: "generated", Keys: []string{"jwt_hs256.key", "slurm.key"}},
+				{Name: "retained", Keys: []string{"slurm.key"}, Retained: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGeneratedKeysStatus(context.TODO(), c, corev1.NamespaceDefault, tt.refs)
+			if err != nil {
+				t.Fatalf("NewGeneratedKeysStatus() error = %v", err)
+			}
+			for i := range got {
+				got[i].GeneratedAt = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("NewGeneratedKeysStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
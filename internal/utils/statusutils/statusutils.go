@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package statusutils
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// WorkloadStatus is the rollout status of an owned StatefulSet or Deployment.
+type WorkloadStatus struct {
+	// Kind of the workload, used in messages.
+	Kind string
+	// Whether the workload exists.
+	Found bool
+	// Whether the workload controller has observed the latest workload spec.
+	Observed bool
+
+	Desired   int32
+	Ready     int32
+	Updated   int32
+	Available int32
+}
+
+// IsReady reports if all desired replicas are ready and available.
+func (s WorkloadStatus) IsReady() bool {
+	return s.Found && s.Observed &&
+		s.Ready >= s.Desired && s.Available >= s.Desired
+}
+
+// IsProgressing reports if the workload is still rolling out.
+func (s WorkloadStatus) IsProgressing() bool {
+	return s.Found && (!s.Observed || s.Updated < s.Desired || s.Ready < s.Desired)
+}
+
+// NewStatefulSetStatus returns the WorkloadStatus of the StatefulSet.
+// A nil StatefulSet is reported as not found.
+func NewStatefulSetStatus(sts *appsv1.StatefulSet) WorkloadStatus {
+	status := WorkloadStatus{Kind: "StatefulSet"}
+	if sts == nil {
+		return status
+	}
+	status.Found = true
+	status.Observed = sts.Status.ObservedGeneration >= sts.Generation
+	status.Desired = ptr.Deref(sts.Spec.Replicas, 1)
+	status.Ready = sts.Status.ReadyReplicas
+	status.Updated = sts.Status.UpdatedReplicas
+	status.Available = sts.Status.AvailableReplicas
+	return status
+}
+
+// NewDeploymentStatus returns the WorkloadStatus of the Deployment.
+// A nil Deployment is reported as not found.
+func NewDeploymentStatus(deployment *appsv1.Deployment) WorkloadStatus {
+	status := WorkloadStatus{Kind: "Deployment"}
+	if deployment == nil {
+		return status
+	}
+	status.Found = true
+	status.Observed = deployment.Status.ObservedGeneration >= deployment.Generation
+	status.Desired = ptr.Deref(deployment.Spec.Replicas, 1)
+	status.Ready = deployment.Status.ReadyReplicas
+	status.Updated = deployment.Status.UpdatedReplicas
+	status.Available = deployment.Status.AvailableReplicas
+	return status
+}
+
+// Observations are the inputs from which standard conditions are computed.
+type Observations struct {
+	// Generation of the object being reconciled.
+	Generation int64
+
+	// Workload is the status of the owned workload, nil if there is none (e.g.
+	// an external slurmctld).
+	Workload *WorkloadStatus
+
+	// SyncErr is the error of the failed SyncStep, if any.
+	SyncErr error
+
+	// ConfigErr is the error resolving referenced objects (e.g. Secrets), if any.
+	ConfigErr error
+
+	// Pinged reports whether the Slurm daemon was pinged through the slurm
+	// client. If not, PingErr is ignored.
+	Pinged bool
+	// PingErr is the error pinging the Slurm daemon, if any.
+	PingErr error
+}
+
+// NewConditions computes the standard conditions from the observations.
+// Conditions of other types in existing are preserved, and transition times
+// are only updated when the condition status changes.
+func NewConditions(existing []metav1.Condition, obs Observations) []metav1.Condition {
+	conditions := make([]metav1.Condition, len(existing))
+	copy(conditions, existing)
+
+	for _, cond := range []metav1.Condition{
+		newConfigValidCondition(obs),
+		newProgressingCondition(obs),
+		newDegradedCondition(obs),
+		newReadyCondition(obs),
+	} {
+		cond.ObservedGeneration = obs.Generation
+		meta.SetStatusCondition(&conditions, cond)
+	}
+
+	return conditions
+}
+
+func newConfigValidCondition(obs Observations) metav1.Condition {
+	if obs.ConfigErr != nil {
+		return metav1.Condition{
+			Type:    slinkyv1beta1.ConditionConfigValid,
+			Status:  metav1.ConditionFalse,
+			Reason:  slinkyv1beta1.ReasonInvalidConfig,
+			Message: obs.ConfigErr.Error(),
+		}
+	}
+	return metav1.Condition{
+		Type:   slinkyv1beta1.ConditionConfigValid,
+		Status: metav1.ConditionTrue,
+		Reason: slinkyv1beta1.ReasonAsExpected,
+	}
+}
+
+func newProgressingCondition(obs Observations) metav1.Condition {
+	if w := obs.Workload; w != nil && w.IsProgressing() {
+		return metav1.Condition{
+			Type:    slinkyv1beta1.ConditionProgressing,
+			Status:  metav1.ConditionTrue,
+			Reason:  slinkyv1beta1.ReasonRollingOut,
+			Message: workloadMessage(w),
+		}
+	}
+	return metav1.Condition{
+		Type:   slinkyv1beta1.ConditionProgressing,
+		Status: metav1.ConditionFalse,
+		Reason: slinkyv1beta1.ReasonAsExpected,
+	}
+}
+
+func newDegradedCondition(obs Observations) metav1.Condition {
+	switch {
+	case obs.SyncErr != nil:
+		return metav1.Condition{
+			Type:    slinkyv1beta1.ConditionDegraded,
+			Status:  metav1.ConditionTrue,
+			Reason:  slinkyv1beta1.ReasonSyncFailed,
+			Message: obs.SyncErr.Error(),
+		}
+	case obs.Pinged && obs.PingErr != nil && (obs.Workload == nil || !obs.Workload.IsProgressing()):
+		return metav1.Condition{
+			Type:    slinkyv1beta1.ConditionDegraded,
+			Status:  metav1.ConditionTrue,
+			Reason:  slinkyv1beta1.ReasonPingFailed,
+			Message: obs.PingErr.Error(),
+		}
+	}
+	return metav1.Condition{
+		Type:   slinkyv1beta1.ConditionDegraded,
+		Status: metav1.ConditionFalse,
+		Reason: slinkyv1beta1.ReasonAsExpected,
+	}
+}
+
+func newReadyCondition(obs Observations) metav1.Condition {
+	notReady := func(reason, message string) metav1.Condition {
+		return metav1.Condition{
+			Type:    slinkyv1beta1.ConditionReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		}
+	}
+	switch w := obs.Workload; {
+	case obs.ConfigErr != nil:
+		return notReady(slinkyv1beta1.ReasonInvalidConfig, obs.ConfigErr.Error())
+	case w != nil && !w.Found:
+		return notReady(slinkyv1beta1.ReasonWorkloadNotFound, fmt.Sprintf("%s not found", w.Kind))
+	case w != nil && !w.IsReady():
+		return notReady(slinkyv1beta1.ReasonPodsNotReady, workloadMessage(w))
+	case obs.Pinged && obs.PingErr != nil:
+		return notReady(slinkyv1beta1.ReasonPingFailed, obs.PingErr.Error())
+	}
+	return metav1.Condition{
+		Type:   slinkyv1beta1.ConditionReady,
+		Status: metav1.ConditionTrue,
+		Reason: slinkyv1beta1.ReasonAsExpected,
+	}
+}
+
+func workloadMessage(w *WorkloadStatus) string {
+	return fmt.Sprintf("%s: %d/%d ready, %d/%d updated",
+		w.Kind, w.Ready, w.Desired, w.Updated, w.Desired)
+}
+
+// IsReady reports if the Ready condition is true.
+func IsReady(conditions []metav1.Condition) bool {
+	return meta.IsStatusConditionTrue(conditions, slinkyv1beta1.ConditionReady)
+}
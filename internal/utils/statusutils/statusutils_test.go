@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package statusutils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func TestNewStatefulSetStatus(t *testing.T) {
+	tests := []struct {
+		name            string
+		sts             *appsv1.StatefulSet
+		want            WorkloadStatus
+		wantReady       bool
+		wantProgressing bool
+	}{
+		{
+			name: "Not found",
+			sts:  nil,
+			want: WorkloadStatus{Kind: "StatefulSet"},
+		},
+		{
+			name: "Ready",
+			sts: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](1)},
+				Status: appsv1.StatefulSetStatus{
+					ObservedGeneration: 2,
+					ReadyReplicas:      1,
+					UpdatedReplicas:    1,
+					AvailableReplicas:  1,
+				},
+			},
+			want: WorkloadStatus{
+				Kind: "StatefulSet", Found: true, Observed: true,
+				Desired: 1, Ready: 1, Updated: 1, Available: 1,
+			},
+			wantReady: true,
+		},
+		{
+			name: "Not observed",
+			sts: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](1)},
+				Status: appsv1.StatefulSetStatus{
+					ObservedGeneration: 2,
+					ReadyReplicas:      1,
+					UpdatedReplicas:    1,
+					AvailableReplicas:  1,
+				},
+			},
+			want: WorkloadStatus{
+				Kind: "StatefulSet", Found: true, Observed: false,
+				Desired: 1, Ready: 1, Updated: 1, Available: 1,
+			},
+			wantProgressing: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewStatefulSetStatus(tt.sts)
+			if got != tt.want {
+				t.Errorf("NewStatefulSetStatus() = %v, want %v", got, tt.want)
+			}
+			if got.IsReady() != tt.wantReady {
+				t.Errorf("IsReady() = %v, want %v", got.IsReady(), tt.wantReady)
+			}
+			if got.IsProgressing() != tt.wantProgressing {
+				t.Errorf("IsProgressing() = %v, want %v", got.IsProgressing(), tt.wantProgressing)
+			}
+		})
+	}
+}
+
+func TestNewDeploymentStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		want       WorkloadStatus
+	}{
+		{
+			name:       "Not found",
+			deployment: nil,
+			want:       WorkloadStatus{Kind: "Deployment"},
+		},
+		{
+			name: "Rolling out",
+			deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+				Status: appsv1.DeploymentStatus{
+					ReadyReplicas:     2,
+					UpdatedReplicas:   1,
+					AvailableReplicas: 2,
+				},
+			},
+			want: WorkloadStatus{
+				Kind: "Deployment", Found: true, Observed: true,
+				Desired: 2, Ready: 2, Updated: 1, Available: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewDeploymentStatus(tt.deployment); got != tt.want {
+				t.Errorf("NewDeploymentStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewConditions(t *testing.T) {
+	ready := &WorkloadStatus{Kind: "StatefulSet", Found: true, Observed: true, Desired: 1, Ready: 1, Updated: 1, Available: 1}
+	notReady := &WorkloadStatus{Kind: "StatefulSet", Found: true, Observed: true, Desired: 1, Updated: 1}
+	notFound := &WorkloadStatus{Kind: "StatefulSet"}
+	type want struct {
+		ready       metav1.ConditionStatus
+		readyReason string
+		progressing metav1.ConditionStatus
+		degraded    metav1.ConditionStatus
+		configValid metav1.ConditionStatus
+	}
+	tests := []struct {
+		name string
+		obs  Observations
+		want want
+	}{
+		{
+			name: "Ready",
+			obs:  Observations{Workload: ready, Pinged: true},
+			want: want{
+				ready:       metav1.ConditionTrue,
+				readyReason: slinkyv1beta1.ReasonAsExpected,
+				progressing: metav1.ConditionFalse,
+				degraded:    metav1.ConditionFalse,
+				configValid: metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "External, not pinged",
+			obs:  Observations{},
+			want: want{
+				ready:       metav1.ConditionTrue,
+				readyReason: slinkyv1beta1.ReasonAsExpected,
+				progressing: metav1.ConditionFalse,
+				degraded:    metav1.ConditionFalse,
+				configValid: metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "Pods not ready",
+			obs:  Observations{Workload: notReady},
+			want: want{
+				ready:       metav1.ConditionFalse,
+				readyReason: slinkyv1beta1.ReasonPodsNotReady,
+				progressing: metav1.ConditionTrue,
+				degraded:    metav1.ConditionFalse,
+				configValid: metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "Workload not found",
+			obs:  Observations{Workload: notFound, SyncErr: errors.New("[StatefulSet]: failed")},
+			want: want{
+				ready:       metav1.ConditionFalse,
+				readyReason: slinkyv1beta1.ReasonWorkloadNotFound,
+				progressing: metav1.ConditionFalse,
+				degraded:    metav1.ConditionTrue,
+				configValid: metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "Ping failed",
+			obs:  Observations{Workload: ready, Pinged: true, PingErr: errors.New("no slurmctld is responding")},
+			want: want{
+				ready:       metav1.ConditionFalse,
+				readyReason: slinkyv1beta1.ReasonPingFailed,
+				progressing: metav1.ConditionFalse,
+				degraded:    metav1.ConditionTrue,
+				configValid: metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "Ping ignored",
+			obs:  Observations{Workload: ready, Pinged: false, PingErr: errors.New("ignored")},
+			want: want{
+				ready:       metav1.ConditionTrue,
+				readyReason: slinkyv1beta1.ReasonAsExpected,
+				progressing: metav1.ConditionFalse,
+				degraded:    metav1.ConditionFalse,
+				configValid: metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "Invalid config",
+			obs:  Observations{Workload: ready, ConfigErr: errors.New("secret not found")},
+			want: want{
+				ready:       metav1.ConditionFalse,
+				readyReason: slinkyv1beta1.ReasonInvalidConfig,
+				progressing: metav1.ConditionFalse,
+				degraded:    metav1.ConditionFalse,
+				configValid: metav1.ConditionFalse,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.obs.Generation = 4
+			got := NewConditions(nil, tt.obs)
+			check := func(condType string, wantStatus metav1.ConditionStatus) *metav1.Condition {
+				cond := meta.FindStatusCondition(got, condType)
+				if cond == nil {
+					t.Fatalf("NewConditions() missing condition %q", condType)
+				}
+				if cond.Status != wantStatus {
+					t.Errorf("NewConditions() %s = %v, want %v", condType, cond.Status, wantStatus)
+				}
+				if cond.ObservedGeneration != tt.obs.Generation {
+					t.Errorf("NewConditions() %s.ObservedGeneration = %v, want %v", condType, cond.ObservedGeneration, tt.obs.Generation)
+				}
+				return cond
+			}
+			if cond := check(slinkyv1beta1.ConditionReady, tt.want.ready); cond.Reason != tt.want.readyReason {
+				t.Errorf("NewConditions() Ready.Reason = %v, want %v", cond.Reason, tt.want.readyReason)
+			}
+			check(slinkyv1beta1.ConditionProgressing, tt.want.progressing)
+			check(slinkyv1beta1.ConditionDegraded, tt.want.degraded)
+			check(slinkyv1beta1.ConditionConfigValid, tt.want.configValid)
+			if IsReady(got) != (tt.want.ready == metav1.ConditionTrue) {
+				t.Errorf("IsReady() = %v, want %v", IsReady(got), tt.want.ready)
+			}
+		})
+	}
+}
+
+func TestNewConditions_Preserve(t *testing.T) {
+	transitionTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	existing := []metav1.Condition{
+		{
+			Type:               slinkyv1beta1.ConditionReady,
+			Status:             metav1.ConditionTrue,
+			Reason:             slinkyv1beta1.ReasonAsExpected,
+			LastTransitionTime: transitionTime,
+		},
+		{
+			Type:               "Custom",
+			Status:             metav1.ConditionTrue,
+			Reason:             "Custom",
+			LastTransitionTime: transitionTime,
+		},
+	}
+	got := NewConditions(existing, Observations{})
+	if len(got) != 5 {
+		t.Errorf("NewConditions() len = %v, want %v", len(got), 5)
+	}
+	if cond := meta.FindStatusCondition(got, "Custom"); cond == nil {
+		t.Errorf("NewConditions() dropped existing condition")
+	}
+	cond := meta.FindStatusCondition(got, slinkyv1beta1.ConditionReady)
+	if !cond.LastTransitionTime.Equal(&transitionTime) {
+		t.Errorf("NewConditions() Ready.LastTransitionTime = %v, want %v", cond.LastTransitionTime, transitionTime)
+	}
+	if existing[0].ObservedGeneration != 0 {
+		t.Errorf("NewConditions() mutated existing conditions")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
 )
 
 const Timeout = 30 * time.Second
@@ -39,8 +40,8 @@ func NewController(name string, slurmKeyRef, jwtHs256KeyRef corev1.SecretKeySele
 			Namespace: corev1.NamespaceDefault,
 		},
 		Spec: slinkyv1beta1.ControllerSpec{
-			SlurmKeyRef:    slurmKeyRef,
-			JwtHs256KeyRef: jwtHs256KeyRef,
+			SlurmKeyRef:    slinkyv1beta1.GeneratedSecretKeySelector{SecretKeySelector: slurmKeyRef},
+			JwtHs256KeyRef: slinkyv1beta1.GeneratedSecretKeySelector{SecretKeySelector: jwtHs256KeyRef},
 			AccountingRef:  accountingRef,
 			Slurmctld: slinkyv1beta1.ContainerWrapper{
 				Container: corev1.Container{
@@ -103,6 +104,31 @@ func NewJwtHs256KeySecret(ref corev1.SecretKeySelector) *corev1.Secret {
 	}
 }
 
+func NewJwtKeyRef(name string) corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: name + "-jwtkey",
+		},
+		Key: "jwt.key",
+	}
+}
+
+func NewJwtKeySecret(ref corev1.SecretKeySelector) *corev1.Secret {
+	key, err := crypto.NewPrivateKeyPEM(crypto.KeyPairRsa)
+	if err != nil {
+		panic(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ref.Name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Data: map[string][]byte{
+			ref.Key: key,
+		},
+	}
+}
+
 func NewAccounting(name string, slurmKeyRef, jwtHs256KeyRef corev1.SecretKeySelector, passwordRef corev1.SecretKeySelector) *slinkyv1beta1.Accounting {
 	return &slinkyv1beta1.Accounting{
 		TypeMeta: metav1.TypeMeta{
@@ -114,8 +140,8 @@ func NewAccounting(name string, slurmKeyRef, jwtHs256KeyRef corev1.SecretKeySele
 			Namespace: corev1.NamespaceDefault,
 		},
 		Spec: slinkyv1beta1.AccountingSpec{
-			SlurmKeyRef:    slurmKeyRef,
-			JwtHs256KeyRef: jwtHs256KeyRef,
+			SlurmKeyRef:    slinkyv1beta1.GeneratedSecretKeySelector{SecretKeySelector: slurmKeyRef},
+			JwtHs256KeyRef: slinkyv1beta1.GeneratedSecretKeySelector{SecretKeySelector: jwtHs256KeyRef},
 			StorageConfig: slinkyv1beta1.StorageConfig{
 				Host:           "mariadb",
 				PasswordKeyRef: passwordRef,
@@ -271,3 +297,134 @@ func NewToken(name string, jwtHs256KeySecret *corev1.Secret) *slinkyv1beta1.Toke
 		},
 	}
 }
+
+func NewSlurmMaintenance(name string, nodesets []string, startTime time.Time, duration time.Duration) *slinkyv1beta1.SlurmMaintenance {
+	return &slinkyv1beta1.SlurmMaintenance{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.SlurmMaintenanceAPIVersion,
+			Kind:       slinkyv1beta1.SlurmMaintenanceKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.SlurmMaintenanceSpec{
+			NodeSets:  nodesets,
+			StartTime: metav1.NewTime(startTime),
+			Duration:  metav1.Duration{Duration: duration},
+		},
+	}
+}
+
+func NewSlurmAccount(name string, controller *slinkyv1beta1.Controller) *slinkyv1beta1.SlurmAccount {
+	return &slinkyv1beta1.SlurmAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.SlurmAccountAPIVersion,
+			Kind:       slinkyv1beta1.SlurmAccountKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.SlurmAccountSpec{
+			ControllerRef: slinkyv1beta1.ObjectReference{
+				Namespace: controller.Namespace,
+				Name:      controller.Name,
+			},
+		},
+	}
+}
+
+func NewSlurmUser(name string, controller *slinkyv1beta1.Controller, accounts ...string) *slinkyv1beta1.SlurmUser {
+	associations := make([]slinkyv1beta1.SlurmUserAssociation, 0, len(accounts))
+	for _, account := range accounts {
+		associations = append(associations, slinkyv1beta1.SlurmUserAssociation{
+			Account: account,
+		})
+	}
+	return &slinkyv1beta1.SlurmUser{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.SlurmUserAPIVersion,
+			Kind:       slinkyv1beta1.SlurmUserKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.SlurmUserSpec{
+			ControllerRef: slinkyv1beta1.ObjectReference{
+				Namespace: controller.Namespace,
+				Name:      controller.Name,
+			},
+			SlurmUserParameters: slinkyv1beta1.SlurmUserParameters{
+				Associations: associations,
+			},
+		},
+	}
+}
+
+func NewSlurmQOS(name string, controller *slinkyv1beta1.Controller) *slinkyv1beta1.SlurmQOS {
+	return &slinkyv1beta1.SlurmQOS{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.SlurmQOSAPIVersion,
+			Kind:       slinkyv1beta1.SlurmQOSKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.SlurmQOSSpec{
+			ControllerRef: slinkyv1beta1.ObjectReference{
+				Namespace: controller.Namespace,
+				Name:      controller.Name,
+			},
+		},
+	}
+}
+
+func NewSlurmJob(name string, controller *slinkyv1beta1.Controller, script string) *slinkyv1beta1.SlurmJob {
+	return &slinkyv1beta1.SlurmJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.SlurmJobAPIVersion,
+			Kind:       slinkyv1beta1.SlurmJobKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.SlurmJobSpec{
+			ControllerRef: slinkyv1beta1.ObjectReference{
+				Namespace: controller.Namespace,
+				Name:      controller.Name,
+			},
+			Script: script,
+		},
+	}
+}
+
+func NewTokenPolicy(name string, controller *slinkyv1beta1.Controller, username string, serviceAccounts ...string) *slinkyv1beta1.TokenPolicy {
+	selectors := make([]slinkyv1beta1.ServiceAccountSelector, 0, len(serviceAccounts))
+	for _, serviceAccount := range serviceAccounts {
+		selectors = append(selectors, slinkyv1beta1.ServiceAccountSelector{
+			Name: serviceAccount,
+		})
+	}
+	return &slinkyv1beta1.TokenPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.TokenPolicyAPIVersion,
+			Kind:       slinkyv1beta1.TokenPolicyKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.TokenPolicySpec{
+			ControllerRef: slinkyv1beta1.ObjectReference{
+				Namespace: controller.Namespace,
+				Name:      controller.Name,
+			},
+			ServiceAccounts: selectors,
+			Username:        username,
+		},
+	}
+}
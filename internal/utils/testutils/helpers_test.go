@@ -205,6 +205,61 @@ func TestNewJwtHs256KeySecret(t *testing.T) {
 	}
 }
 
+func TestNewJwtKeyRef(t *testing.T) {
+	type args struct {
+		name string
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "smoke",
+			args: args{
+				name: "foo",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewJwtKeyRef(tt.args.name)
+			if !strings.Contains(got.Name, tt.args.name) {
+				t.Error("name does not match")
+			}
+		})
+	}
+}
+
+func TestNewJwtKeySecret(t *testing.T) {
+	type args struct {
+		ref corev1.SecretKeySelector
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "smoke",
+			args: args{
+				ref: NewJwtKeyRef("foo"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewJwtKeySecret(tt.args.ref)
+			switch {
+			case got == nil:
+				t.Error("returned object was nil")
+			case !strings.Contains(NewObjectRef(got).Name, tt.args.ref.Name):
+				t.Error("name does not match")
+			case len(got.Data[tt.args.ref.Key]) == 0:
+				t.Error("private key is empty")
+			}
+		})
+	}
+}
+
 func TestNewAccounting(t *testing.T) {
 	type args struct {
 		name           string
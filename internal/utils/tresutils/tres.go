@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package tresutils
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// TresMem is the TRES type counted in megabytes.
+	TresMem = "mem"
+)
+
+// memUnits are the multipliers, relative to megabytes, of the memory suffixes.
+var memUnits = map[byte]int64{
+	'M': 1,
+	'G': 1 << 10,
+	'T': 1 << 20,
+	'P': 1 << 30,
+}
+
+// Key returns the canonical TRES key (e.g. `cpu`, `gres/gpu`).
+func Key(tresType, tresName string) string {
+	tresType = strings.ToLower(tresType)
+	if tresName == "" {
+		return tresType
+	}
+	return tresType + "/" + tresName
+}
+
+// Split returns the TRES type and name of the canonical TRES key.
+func Split(key string) (string, string) {
+	tresType, tresName, _ := strings.Cut(key, "/")
+	return tresType, tresName
+}
+
+// Parse parses a Slurm TRES string (e.g. `cpu=100,mem=200G,gres/gpu=8`) into
+// counts by canonical TRES key. Memory is counted in megabytes, and accepts
+// the M, G, T, and P suffixes.
+func Parse(str string) (map[string]int64, error) {
+	out := make(map[string]int64)
+	if strings.TrimSpace(str) == "" {
+		return out, nil
+	}
+	for item := range strings.SplitSeq(str, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("invalid TRES %q: expected <type>=<count>", item)
+		}
+		tresType, tresName := Split(key)
+		key = Key(tresType, tresName)
+		if _, ok := out[key]; ok {
+			return nil, fmt.Errorf("duplicate TRES %q", key)
+		}
+		count, err := parseCount(tresType, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TRES %q: %w", item, err)
+		}
+		out[key] = count
+	}
+	return out, nil
+}
+
+func parseCount(tresType, value string) (int64, error) {
+	multiplier := int64(1)
+	if strings.EqualFold(tresType, TresMem) {
+		if m, ok := memUnits[strings.ToUpper(value[len(value)-1:])[0]]; ok {
+			multiplier = m
+			value = value[:len(value)-1]
+		}
+	}
+	count, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if count < 0 {
+		return 0, fmt.Errorf("count must not be negative")
+	}
+	return count * multiplier, nil
+}
+
+// Format renders the counts by canonical TRES key as a Slurm TRES string,
+// sorted by key. Memory is rendered with the largest exact suffix.
+func Format(tres map[string]int64) string {
+	items := make([]string, 0, len(tres))
+	for _, key := range slices.Sorted(maps.Keys(tres)) {
+		value := strconv.FormatInt(tres[key], 10)
+		if key == TresMem {
+			value = formatMem(tres[key])
+		}
+		items = append(items, key+"="+value)
+	}
+	return strings.Join(items, ",")
+}
+
+func formatMem(mb int64) string {
+	for _, suffix := range []byte{'P', 'T', 'G'} {
+		m := memUnits[suffix]
+		if mb != 0 && mb%m == 0 {
+			return strconv.FormatInt(mb/m, 10) + string(suffix)
+		}
+	}
+	return strconv.FormatInt(mb, 10) + "M"
+}
+
+// Normalize returns the TRES string in canonical form, or the input when it
+// cannot be parsed.
+func Normalize(str string) string {
+	tres, err := Parse(str)
+	if err != nil {
+		return str
+	}
+	return Format(tres)
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package tresutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		want    map[string]int64
+		wantErr bool
+	}{
+		{
+			name: "Empty",
+			str:  "",
+			want: map[string]int64{},
+		},
+		{
+			name: "Counts",
+			str:  "cpu=100,node=4,gres/gpu=8",
+			want: map[string]int64{"cpu": 100, "node": 4, "gres/gpu": 8},
+		},
+		{
+			name: "Memory",
+			str:  "mem=2G, MEM/ignored=1",
+			want: map[string]int64{"mem": 2048, "mem/ignored": 1},
+		},
+		{
+			name: "Memory, no suffix",
+			str:  "mem=512",
+			want: map[string]int64{"mem": 512},
+		},
+		{
+			name:    "Duplicate",
+			str:     "cpu=1,CPU=2",
+			wantErr: true,
+		},
+		{
+			name:    "Missing count",
+			str:     "cpu",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid count",
+			str:     "cpu=1G",
+			wantErr: true,
+		},
+		{
+			name:    "Negative count",
+			str:     "cpu=-1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		tres map[string]int64
+		want string
+	}{
+		{
+			name: "Empty",
+			tres: nil,
+			want: "",
+		},
+		{
+			name: "Sorted",
+			tres: map[string]int64{"node": 4, "gres/gpu": 8, "cpu": 100},
+			want: "cpu=100,gres/gpu=8,node=4",
+		},
+		{
+			name: "Memory",
+			tres: map[string]int64{"mem": 204800},
+			want: "mem=200G",
+		},
+		{
+			name: "Memory, megabytes",
+			tres: map[string]int64{"mem": 1500},
+			want: "mem=1500M",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Format(tt.tres); got != tt.want {
+				t.Errorf("Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		want string
+	}{
+		{
+			name: "Canonical",
+			str:  "gres/gpu=8, CPU=100,mem=1024M",
+			want: "cpu=100,gres/gpu=8,mem=1G",
+		},
+		{
+			name: "Invalid",
+			str:  "cpu",
+			want: "cpu",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.str); got != tt.want {
+				t.Errorf("Normalize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package webhook
 
 import (
 	"context"
+	"errors"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -50,11 +51,15 @@ func (r *AccountingSetWebhook) ValidateCreate(ctx context.Context, obj runtime.O
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *AccountingSetWebhook) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
 	newAccounting := newObj.(*slinkyv1beta1.Accounting)
-	_ = oldObj.(*slinkyv1beta1.Accounting)
+	oldAccounting := oldObj.(*slinkyv1beta1.Accounting)
 	accountinglog.Info("validate update", "newAccounting", klog.KObj(newAccounting))
 
 	warns, errs := validateAccounting(newAccounting)
 
+	if newAccounting.Spec.Auth.AuthType() != oldAccounting.Spec.Auth.AuthType() {
+		errs = append(errs, errors.New("cannot change Auth.Type after deployment"))
+	}
+
 	return warns, utilerrors.NewAggregate(errs)
 }
 
@@ -70,5 +75,16 @@ func validateAccounting(obj *slinkyv1beta1.Accounting) (admission.Warnings, []er
 	var warns admission.Warnings
 	var errs []error
 
+	authWarns, authErrs := validateAuth("Accounting.Spec", obj.Spec.Auth, obj.Spec.SlurmKeyRef)
+	warns = append(warns, authWarns...)
+	errs = append(errs, authErrs...)
+
+	keyWarns, keyErrs := validateGeneratedKeyRef("Accounting.Spec.SlurmKeyRef", obj.Spec.SlurmKeyRef, obj.Spec.External)
+	warns = append(warns, keyWarns...)
+	errs = append(errs, keyErrs...)
+	keyWarns, keyErrs = validateGeneratedKeyRef("Accounting.Spec.JwtHs256KeyRef", obj.Spec.JwtHs256KeyRef, obj.Spec.External)
+	warns = append(warns, keyWarns...)
+	errs = append(errs, keyErrs...)
+
 	return warns, errs
 }
@@ -5,16 +5,54 @@ package webhook
 
 import (
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
 var _ = Describe("Accounting Webhook", func() {
 	Context("When creating Accounting under Validating Webhook", func() {
 		It("Should deny if a required field is empty", func() {
-			// TODO(user): Add your logic here
+			accounting := testutils.NewAccounting("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), testutils.NewPasswordRef("slurm"))
+			accounting.Spec.SlurmKeyRef.SecretKeySelector = corev1.SecretKeySelector{}
+			accounting.Spec.SlurmKeyRef.Generate = true
+			_, errs := validateAccounting(accounting)
+			Expect(errs).To(HaveLen(2))
 		})
 
 		It("Should admit if all required fields are provided", func() {
-			// TODO(user): Add your logic here
+			accounting := testutils.NewAccounting("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), testutils.NewPasswordRef("slurm"))
+			accounting.Spec.SlurmKeyRef.Generate = true
+			accounting.Spec.JwtHs256KeyRef.Generate = true
+			warns, errs := validateAccounting(accounting)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(BeEmpty())
+
+			accounting.Spec.External = true
+			warns, errs = validateAccounting(accounting)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(HaveLen(2))
+		})
+
+		It("Should deny auth/munge without a munged image", func() {
+			accounting := testutils.NewAccounting("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), testutils.NewPasswordRef("slurm"))
+			accounting.Spec.SlurmKeyRef = slinkyv1beta1.GeneratedSecretKeySelector{}
+			accounting.Spec.JwtHs256KeyRef.Generate = true
+			accounting.Spec.Auth = &slinkyv1beta1.SlurmAuth{
+				Type: slinkyv1beta1.SlurmAuthTypeMunge,
+				MungeKeyRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "munge"},
+					Key:                  "munge.key",
+				},
+			}
+			_, errs := validateAccounting(accounting)
+			Expect(errs).To(HaveLen(1))
+
+			accounting.Spec.Auth.Munged.Image = "munged"
+			_, errs = validateAccounting(accounting)
+			Expect(errs).To(BeEmpty())
 		})
 	})
 })
@@ -9,12 +9,16 @@ import (
 	"fmt"
 	"regexp"
 	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -88,18 +92,28 @@ func (r *ControllerWebhook) ValidateUpdate(ctx context.Context, oldObj runtime.O
 	if newController.ClusterName() != oldController.ClusterName() {
 		errs = append(errs, errors.New("cannot change ClusterName after deployment"))
 	}
-	if !apiequality.Semantic.DeepEqual(newController.Spec.SlurmKeyRef.LocalObjectReference, oldController.Spec.SlurmKeyRef.LocalObjectReference) {
-		errs = append(errs, errors.New("cannot change SlurmKeyRef after deployment"))
+	if !apiequality.Semantic.DeepEqual(newController.Spec.SlurmKeyRef.LocalObjectReference, oldController.Spec.SlurmKeyRef.LocalObjectReference) &&
+		!isSlurmKeyRotationFinished(newController, oldController) {
+		errs = append(errs, errors.New("cannot change SlurmKeyRef after deployment, rotate it with SlurmKeyRotation instead"))
 	}
 	if !apiequality.Semantic.DeepEqual(newController.Spec.JwtHs256KeyRef.LocalObjectReference, oldController.Spec.JwtHs256KeyRef.LocalObjectReference) {
 		errs = append(errs, errors.New("cannot change JwtHs256KeyRef after deployment"))
 	}
+	if newController.Spec.Auth.AuthType() != oldController.Spec.Auth.AuthType() {
+		errs = append(errs, errors.New("cannot change Auth.Type after deployment"))
+	}
+
+	errs = append(errs, validateJwtKeysUpdate(newController, oldController)...)
+	errs = append(errs, r.validateSlurmKeyRotationUpdate(ctx, newController, oldController)...)
 
 	// We use volumeClaimTemplates to handle the controller savestate PVC.
 	// StatefulSet does not allow update of that field.
 	if newController.Spec.Persistence.Enabled != oldController.Spec.Persistence.Enabled {
 		errs = append(errs, errors.New("cannot change persistence.enabled after deployment"))
 	}
+	if (newController.Spec.Persistence.ExistingClaim == "") != (oldController.Spec.Persistence.ExistingClaim == "") {
+		errs = append(errs, errors.New("cannot switch between persistence.existingClaim and a volume claim template after deployment"))
+	}
 
 	return warns, utilerrors.NewAggregate(errs)
 }
@@ -160,5 +174,395 @@ func (r *ControllerWebhook) validateController(ctx context.Context, obj *slinkyv
 		}
 	}
 
+	if !obj.Spec.External && obj.IsHighlyAvailable() {
+		errs = append(errs, r.validateStateSave(ctx, obj)...)
+	}
+
+	partitionWarns, partitionErrs := r.validatePartitions(ctx, obj)
+	warns = append(warns, partitionWarns...)
+	errs = append(errs, partitionErrs...)
+
+	slurmConfigWarns, slurmConfigErrs := validateSlurmConfig(obj)
+	warns = append(warns, slurmConfigWarns...)
+	errs = append(errs, slurmConfigErrs...)
+
+	topologyWarns, topologyErrs := validateTopology(obj)
+	warns = append(warns, topologyWarns...)
+	errs = append(errs, topologyErrs...)
+
+	errs = append(errs, validateJwtKeys(obj)...)
+
+	rotationWarns, rotationErrs := validateSlurmKeyRotation(obj)
+	warns = append(warns, rotationWarns...)
+	errs = append(errs, rotationErrs...)
+
+	authWarns, authErrs := validateAuth("Controller.Spec", obj.Spec.Auth, obj.Spec.SlurmKeyRef)
+	warns = append(warns, authWarns...)
+	errs = append(errs, authErrs...)
+	errs = append(errs, r.validateAccountingAuth(ctx, obj)...)
+
+	keyWarns, keyErrs := validateGeneratedKeyRef("Controller.Spec.SlurmKeyRef", obj.Spec.SlurmKeyRef, obj.Spec.External)
+	warns = append(warns, keyWarns...)
+	errs = append(errs, keyErrs...)
+	keyWarns, keyErrs = validateGeneratedKeyRef("Controller.Spec.JwtHs256KeyRef", obj.Spec.JwtHs256KeyRef, obj.Spec.External)
+	warns = append(warns, keyWarns...)
+	errs = append(errs, keyErrs...)
+
+	return warns, errs
+}
+
+// validateAuth checks that `auth/munge` references a MUNGE key and a munged
+// image, and warns that the `auth/slurm` key is unused.
+func validateAuth(field string, auth *slinkyv1beta1.SlurmAuth, slurmKeyRef slinkyv1beta1.GeneratedSecretKeySelector) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if !auth.IsMunge() {
+		return warns, errs
+	}
+	if auth.MungeKeyRef.Name == "" || auth.MungeKeyRef.Key == "" {
+		errs = append(errs, fmt.Errorf("`%s.Auth.MungeKeyRef` must set the name and key with `auth/munge`", field))
+	}
+	if auth.Munged.Image == "" {
+		errs = append(errs, fmt.Errorf("`%s.Auth.Munged.Image` must be set with `auth/munge`", field))
+	}
+	if slurmKeyRef.Name != "" {
+		warns = append(warns, fmt.Sprintf("`%s.SlurmKeyRef` is unused with `auth/munge`", field))
+	}
+
+	return warns, errs
+}
+
+// validateAccountingAuth checks that the Accounting of the Controller has the
+// same Slurm authentication, and with `auth/munge` the same MUNGE key Secret.
+func (r *ControllerWebhook) validateAccountingAuth(ctx context.Context, obj *slinkyv1beta1.Controller) []error {
+	var errs []error
+
+	if obj.Spec.AccountingRef.Name == "" {
+		return errs
+	}
+	accounting := &slinkyv1beta1.Accounting{}
+	if err := r.Get(ctx, obj.Spec.AccountingRef.NamespacedName(), accounting); err != nil {
+		if apierrors.IsNotFound(err) {
+			return errs
+		}
+		return []error{err}
+	}
+	if accounting.Spec.Auth.AuthType() != obj.Spec.Auth.AuthType() {
+		errs = append(errs, fmt.Errorf("`Controller.Spec.Auth.Type` (%s) must match the `Accounting.Spec.Auth.Type` (%s) of Accounting (%s)",
+			obj.Spec.Auth.AuthType(), accounting.Spec.Auth.AuthType(), klog.KObj(accounting)))
+		return errs
+	}
+	if !obj.Spec.Auth.IsMunge() {
+		return errs
+	}
+	mungeRef, accountingMungeRef := obj.AuthMungeRef(), accounting.AuthMungeRef()
+	if obj.Namespace != accounting.Namespace ||
+		mungeRef.Name != accountingMungeRef.Name || mungeRef.Key != accountingMungeRef.Key {
+		errs = append(errs, fmt.Errorf("`Controller.Spec.Auth.MungeKeyRef` (%s/%s) must match the `Accounting.Spec.Auth.MungeKeyRef` (%s/%s) of Accounting (%s)",
+			klog.KRef(obj.Namespace, mungeRef.Name), mungeRef.Key,
+			klog.KRef(accounting.Namespace, accountingMungeRef.Name), accountingMungeRef.Key, klog.KObj(accounting)))
+	}
+
+	return errs
+}
+
+// validateGeneratedKeyRef checks that a generated key ref names the Secret and
+// its key, which the operator cannot choose.
+func validateGeneratedKeyRef(field string, ref slinkyv1beta1.GeneratedSecretKeySelector, external bool) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if !ref.Generate {
+		return warns, errs
+	}
+	if ref.Name == "" {
+		errs = append(errs, fmt.Errorf("`%s.Name` must be set to generate the key", field))
+	}
+	if ref.Key == "" {
+		errs = append(errs, fmt.Errorf("`%s.Key` must be set to generate the key", field))
+	}
+	if external {
+		warns = append(warns, fmt.Sprintf("`%s.Generate` is ignored when external", field))
+	}
+
+	return warns, errs
+}
+
+// validateJwtKeys checks that the key IDs are unique and that the signing key
+// is one of the keys.
+func validateJwtKeys(obj *slinkyv1beta1.Controller) []error {
+	var errs []error
+
+	jwtKeys := obj.Spec.JwtKeys
+	if jwtKeys == nil {
+		return errs
+	}
+
+	seen := make(map[string]bool, len(jwtKeys.Keys))
+	for _, key := range jwtKeys.Keys {
+		if seen[key.KeyID] {
+			errs = append(errs, fmt.Errorf("`Controller.Spec.JwtKeys.Keys` must have unique key IDs. Got: %v", key.KeyID))
+		}
+		seen[key.KeyID] = true
+	}
+	if !seen[jwtKeys.SigningKeyID] {
+		errs = append(errs, fmt.Errorf("`Controller.Spec.JwtKeys.SigningKeyID` must be one of the keys. Got: %v", jwtKeys.SigningKeyID))
+	}
+
+	return errs
+}
+
+// validateJwtKeysUpdate checks that a key is only removed once no JWT is
+// signed by it anymore, as reported by the status of the Controller.
+func validateJwtKeysUpdate(newObj, oldObj *slinkyv1beta1.Controller) []error {
+	var errs []error
+
+	if oldObj.Spec.JwtKeys == nil {
+		return errs
+	}
+
+	newKeyIDs := newObj.JwtKeyIDs()
+	var retirable []string
+	if oldObj.Status.JwtKeys != nil && len(oldObj.Status.JwtKeys.PendingTokens) == 0 {
+		retirable = oldObj.Status.JwtKeys.RetirableKeyIDs
+	}
+	for _, keyID := range oldObj.JwtKeyIDs() {
+		if slices.Contains(newKeyIDs, keyID) {
+			continue
+		}
+		if keyID == oldObj.JwtSigningKeyID() {
+			errs = append(errs, fmt.Errorf("`Controller.Spec.JwtKeys.Keys` cannot remove the signing key, set another signing key first. Got: %v", keyID))
+		} else if !slices.Contains(retirable, keyID) {
+			errs = append(errs, fmt.Errorf("`Controller.Spec.JwtKeys.Keys` cannot remove a key until all JWTs are signed again, see the JwtKeysRotated condition. Got: %v", keyID))
+		}
+	}
+
+	return errs
+}
+
+// validateSlurmKeyRotation checks that the new key is set, and differs from
+// the current key.
+func validateSlurmKeyRotation(obj *slinkyv1beta1.Controller) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	rotation := obj.Spec.SlurmKeyRotation
+	if rotation == nil {
+		return warns, errs
+	}
+	if rotation.NewSlurmKeyRef.Name == "" || rotation.NewSlurmKeyRef.Key == "" {
+		errs = append(errs, errors.New("`Controller.Spec.SlurmKeyRotation.NewSlurmKeyRef` must set the name and key"))
+	}
+	if apiequality.Semantic.DeepEqual(rotation.NewSlurmKeyRef, obj.Spec.SlurmKeyRef.SecretKeySelector) {
+		errs = append(errs, errors.New("`Controller.Spec.SlurmKeyRotation.NewSlurmKeyRef` must differ from `Controller.Spec.SlurmKeyRef`"))
+	}
+	if obj.IsMungeAuth() {
+		errs = append(errs, errors.New("`Controller.Spec.SlurmKeyRotation` requires `auth/slurm`"))
+	}
+	if obj.Spec.External {
+		warns = append(warns, "`Controller.Spec.SlurmKeyRotation` is ignored when external")
+	}
+
 	return warns, errs
 }
+
+// isSlurmKeyRotationFinished reports if the update removes a completed
+// rotation, and makes its new key the current key.
+func isSlurmKeyRotationFinished(newObj, oldObj *slinkyv1beta1.Controller) bool {
+	rotation := oldObj.Spec.SlurmKeyRotation
+	if rotation == nil || newObj.Spec.SlurmKeyRotation != nil {
+		return false
+	}
+	if oldObj.Status.KeyRotation == nil || oldObj.Status.KeyRotation.Phase != slinkyv1beta1.KeyRotationPhaseComplete {
+		return false
+	}
+	return apiequality.Semantic.DeepEqual(newObj.Spec.SlurmKeyRef.SecretKeySelector, rotation.NewSlurmKeyRef)
+}
+
+// validateSlurmKeyRotationUpdate checks that the new key is not changed
+// during a rotation, and that a rotation is only removed once it rolled back,
+// or once it completed along with making the new key the current key.
+func (r *ControllerWebhook) validateSlurmKeyRotationUpdate(ctx context.Context, newObj, oldObj *slinkyv1beta1.Controller) []error {
+	var errs []error
+
+	rotation := oldObj.Spec.SlurmKeyRotation
+	if rotation == nil {
+		return errs
+	}
+	var phase slinkyv1beta1.KeyRotationPhase
+	if oldObj.Status.KeyRotation != nil {
+		phase = oldObj.Status.KeyRotation.Phase
+	}
+
+	if newObj.Spec.SlurmKeyRotation != nil {
+		if !apiequality.Semantic.DeepEqual(newObj.Spec.SlurmKeyRotation.NewSlurmKeyRef, rotation.NewSlurmKeyRef) {
+			errs = append(errs, errors.New("`Controller.Spec.SlurmKeyRotation.NewSlurmKeyRef` cannot change during a rotation, roll back and remove the rotation first"))
+		}
+		return errs
+	}
+
+	switch phase {
+	case "", slinkyv1beta1.KeyRotationPhaseRolledBack:
+	case slinkyv1beta1.KeyRotationPhaseComplete:
+		if !isSlurmKeyRotationFinished(newObj, oldObj) {
+			errs = append(errs, errors.New("`Controller.Spec.SlurmKeyRef` must be set to `Controller.Spec.SlurmKeyRotation.NewSlurmKeyRef` when removing a completed rotation"))
+			break
+		}
+		errs = append(errs, r.validateAccountingSlurmKeyRef(ctx, newObj)...)
+	default:
+		errs = append(errs, fmt.Errorf("`Controller.Spec.SlurmKeyRotation` cannot be removed during the %s phase, roll back first", phase))
+	}
+
+	return errs
+}
+
+// validateAccountingSlurmKeyRef checks that the Accounting of the Controller
+// has the same `auth/slurm` key.
+func (r *ControllerWebhook) validateAccountingSlurmKeyRef(ctx context.Context, obj *slinkyv1beta1.Controller) []error {
+	var errs []error
+
+	if obj.Spec.AccountingRef.Name == "" {
+		return errs
+	}
+	accounting := &slinkyv1beta1.Accounting{}
+	if err := r.Get(ctx, obj.Spec.AccountingRef.NamespacedName(), accounting); err != nil {
+		if apierrors.IsNotFound(err) {
+			return errs
+		}
+		return []error{err}
+	}
+	if !apiequality.Semantic.DeepEqual(accounting.Spec.SlurmKeyRef.SecretKeySelector, obj.Spec.SlurmKeyRef.SecretKeySelector) {
+		errs = append(errs, fmt.Errorf("`Accounting.Spec.SlurmKeyRef` of Accounting (%s) must be set to the new key first",
+			klog.KObj(accounting)))
+	}
+
+	return errs
+}
+
+// validateTopology checks that the topology label keys are valid, and that
+// the block sizes are only used with `topology/block`.
+func validateTopology(obj *slinkyv1beta1.Controller) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	topology := obj.Spec.Topology
+	if topology == nil {
+		return warns, errs
+	}
+
+	seen := make(map[string]bool, len(topology.LabelKeys))
+	for _, key := range topology.LabelKeys {
+		if msgs := validation.IsQualifiedName(key); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("`Controller.Spec.Topology.LabelKeys` is not valid. Got: %v. %s",
+				key, strings.Join(msgs, "; ")))
+		}
+		if seen[key] {
+			errs = append(errs, fmt.Errorf("`Controller.Spec.Topology.LabelKeys` must be unique. Got: %v", key))
+		}
+		seen[key] = true
+	}
+	for _, size := range topology.BlockSizes {
+		if size < 1 {
+			errs = append(errs, fmt.Errorf("`Controller.Spec.Topology.BlockSizes` must be positive. Got: %v", size))
+		}
+	}
+	if len(topology.BlockSizes) > 0 && topology.TopologyPlugin() != slinkyv1beta1.TopologyPluginBlock {
+		warns = append(warns, fmt.Sprintf("`Controller.Spec.Topology.BlockSizes` is ignored with %s", topology.TopologyPlugin()))
+	}
+
+	return warns, errs
+}
+
+// validatePartitions checks that the partitions do not collide with the
+// partition of a NodeSet of the Controller.
+func (r *ControllerWebhook) validatePartitions(ctx context.Context, obj *slinkyv1beta1.Controller) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if len(obj.Spec.Partitions) == 0 {
+		return warns, errs
+	}
+
+	nodesetList := &slinkyv1beta1.NodeSetList{}
+	if err := r.List(ctx, nodesetList); err != nil {
+		return warns, []error{err}
+	}
+	nodesets := []*slinkyv1beta1.NodeSet{}
+	for i := range nodesetList.Items {
+		nodeset := &nodesetList.Items[i]
+		if nodeset.Spec.ControllerRef.IsMatch(client.ObjectKeyFromObject(obj)) {
+			nodesets = append(nodesets, nodeset)
+		}
+	}
+
+	for _, partition := range obj.Spec.Partitions {
+		if partition.NodeSetSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(partition.NodeSetSelector); err != nil {
+				errs = append(errs, fmt.Errorf("partition (%s) has an invalid nodeSetSelector: %w", partition.Name, err))
+			}
+		}
+		for _, name := range partition.NodeSets {
+			if !slices.ContainsFunc(nodesets, func(nodeset *slinkyv1beta1.NodeSet) bool { return nodeset.Name == name }) {
+				warns = append(warns, fmt.Sprintf("partition (%s) references a NodeSet which does not exist for this Controller: %s", partition.Name, name))
+			}
+		}
+		for _, nodeset := range nodesets {
+			if nodeset.Spec.Partition.Enabled && nodeset.SlurmName() == partition.Name {
+				errs = append(errs, fmt.Errorf("partition (%s) collides with the partition of NodeSet (%s), disable `NodeSet.Spec.Partition` or rename the partition",
+					partition.Name, klog.KObj(nodeset)))
+			}
+		}
+	}
+
+	return warns, errs
+}
+
+// validateSlurmConfig checks the SlurmConfig parameters, which must not be
+// reserved for slurm-operator use nor be set more than once.
+func validateSlurmConfig(obj *slinkyv1beta1.Controller) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	for _, key := range obj.SlurmConfigKeys() {
+		if slinkyv1beta1.IsReservedSlurmConfigKey(key) {
+			errs = append(errs, fmt.Errorf("the slurmConfig parameter is reserved for slurm-operator use: %s", key))
+		}
+	}
+	if duplicates := obj.SlurmConfigDuplicates(); len(duplicates) > 0 {
+		errs = append(errs, fmt.Errorf("the slurmConfig parameters are case-insensitive, found duplicates: %s",
+			strings.Join(duplicates, ", ")))
+	}
+	if conflicts := obj.SlurmConfigConflicts(); len(conflicts) > 0 {
+		warns = append(warns, fmt.Sprintf("the slurmConfig parameters are also set in extraConf, which takes precedence: %s",
+			strings.Join(conflicts, ", ")))
+	}
+
+	return warns, errs
+}
+
+// validateStateSave checks that the StateSaveLocation is shared by all
+// slurmctld replicas, which is required for backup controllers to take over.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_StateSaveLocation
+func (r *ControllerWebhook) validateStateSave(ctx context.Context, obj *slinkyv1beta1.Controller) []error {
+	persistence := obj.Spec.Persistence
+	if !persistence.Enabled || persistence.ExistingClaim == "" {
+		return []error{fmt.Errorf("replicas (%d) > 1 requires persistence.existingClaim, a ReadWriteMany PersistentVolumeClaim shared by all replicas",
+			obj.Replicas())}
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	pvcKey := types.NamespacedName{
+		Name:      persistence.ExistingClaim,
+		Namespace: obj.Namespace,
+	}
+	if err := r.Get(ctx, pvcKey, pvc); err != nil {
+		return []error{err}
+	}
+	if !slices.Contains(pvc.Spec.AccessModes, corev1.ReadWriteMany) {
+		return []error{fmt.Errorf("replicas (%d) > 1 requires persistence.existingClaim to have the %s access mode: %s",
+			obj.Replicas(), corev1.ReadWriteMany, persistence.ExistingClaim)}
+	}
+
+	return nil
+}
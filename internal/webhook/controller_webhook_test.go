@@ -4,7 +4,19 @@
 package webhook
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
 var _ = Describe("Controller Webhook", func() {
@@ -17,4 +29,363 @@ var _ = Describe("Controller Webhook", func() {
 			// TODO(user): Add your logic here
 		})
 	})
+
+	Context("When creating Controller with backup controllers", func() {
+		newClaim := func(name string, accessMode corev1.PersistentVolumeAccessMode) *corev1.PersistentVolumeClaim {
+			return &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: corev1.NamespaceDefault,
+					Name:      name,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{accessMode},
+				},
+			}
+		}
+		webhook := &ControllerWebhook{
+			Client: fake.NewFakeClient(
+				newClaim("shared", corev1.ReadWriteMany),
+				newClaim("local", corev1.ReadWriteOnce),
+			),
+		}
+
+		It("Should deny without a shared StateSaveLocation", func() {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			controller.Namespace = corev1.NamespaceDefault
+			controller.Spec.Replicas = ptr.To[int32](2)
+			controller.Spec.Persistence.Enabled = true
+			Expect(webhook.validateStateSave(context.TODO(), controller)).To(HaveLen(1))
+
+			controller.Spec.Persistence.ExistingClaim = "local"
+			Expect(webhook.validateStateSave(context.TODO(), controller)).To(HaveLen(1))
+
+			controller.Spec.Persistence.ExistingClaim = "missing"
+			Expect(webhook.validateStateSave(context.TODO(), controller)).To(HaveLen(1))
+		})
+
+		It("Should admit with a shared StateSaveLocation", func() {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			controller.Namespace = corev1.NamespaceDefault
+			controller.Spec.Replicas = ptr.To[int32](2)
+			controller.Spec.Persistence.Enabled = true
+			controller.Spec.Persistence.ExistingClaim = "shared"
+			Expect(webhook.validateStateSave(context.TODO(), controller)).To(BeEmpty())
+		})
+	})
+
+	Context("When creating Controller with slurmConfig", func() {
+		It("Should deny reserved or duplicate parameters", func() {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			controller.Spec.SlurmConfig = map[string]slinkyv1beta1.SlurmConfigValue{
+				"SlurmctldHost": {"foo"},
+			}
+			_, errs := validateSlurmConfig(controller)
+			Expect(errs).To(HaveLen(1))
+
+			controller.Spec.SlurmConfig = map[string]slinkyv1beta1.SlurmConfigValue{
+				"MinJobAge": {"2"},
+				"minjobage": {"4"},
+			}
+			_, errs = validateSlurmConfig(controller)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should admit and warn about parameters also in extraConf", func() {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			controller.Spec.SlurmConfig = map[string]slinkyv1beta1.SlurmConfigValue{
+				"MinJobAge":  {"2"},
+				"DebugFlags": {"Backfill", "Gres"},
+			}
+			warns, errs := validateSlurmConfig(controller)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(BeEmpty())
+
+			controller.Spec.ExtraConf = "# MinJobAge=1\nminjobage=4"
+			warns, errs = validateSlurmConfig(controller)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(HaveLen(1))
+		})
+	})
+
+	Context("When creating Controller with partitions", func() {
+		utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+		controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+		nodeset := testutils.NewNodeset("gpu", controller, 1)
+		nodeset.Spec.Partition.Enabled = true
+		webhook := &ControllerWebhook{
+			Client: fake.NewClientBuilder().WithObjects(nodeset).Build(),
+		}
+
+		It("Should deny a partition colliding with a NodeSet partition", func() {
+			controller := controller.DeepCopy()
+			controller.Spec.Partitions = []slinkyv1beta1.ControllerPartition{
+				{Name: "gpu", NodeSets: []string{"gpu"}},
+			}
+			_, errs := webhook.validatePartitions(context.TODO(), controller)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should admit and warn about unknown NodeSets", func() {
+			controller := controller.DeepCopy()
+			controller.Spec.Partitions = []slinkyv1beta1.ControllerPartition{
+				{Name: "all", NodeSets: []string{"gpu"}},
+			}
+			warns, errs := webhook.validatePartitions(context.TODO(), controller)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(BeEmpty())
+
+			controller.Spec.Partitions[0].NodeSets = append(controller.Spec.Partitions[0].NodeSets, "cpu")
+			warns, errs = webhook.validatePartitions(context.TODO(), controller)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(HaveLen(1))
+		})
+	})
+
+	Context("When creating Controller with topology", func() {
+		It("Should deny invalid or duplicate label keys", func() {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			controller.Spec.Topology = &slinkyv1beta1.ControllerTopology{
+				LabelKeys: []string{"topology.kubernetes.io/zone", "topology.kubernetes.io/zone", "bad key"},
+			}
+			_, errs := validateTopology(controller)
+			Expect(errs).To(HaveLen(2))
+		})
+
+		It("Should admit and warn about block sizes without topology/block", func() {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			controller.Spec.Topology = &slinkyv1beta1.ControllerTopology{
+				Plugin:     slinkyv1beta1.TopologyPluginBlock,
+				LabelKeys:  []string{"example.com/block"},
+				BlockSizes: []int32{4, 16},
+			}
+			warns, errs := validateTopology(controller)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(BeEmpty())
+
+			controller.Spec.Topology.Plugin = slinkyv1beta1.TopologyPluginTree
+			warns, errs = validateTopology(controller)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(HaveLen(1))
+		})
+	})
+
+	Context("When creating Controller with JWT keys", func() {
+		newJwtKeys := func(signingKeyID string, keyIDs ...string) *slinkyv1beta1.JwtKeys {
+			jwtKeys := &slinkyv1beta1.JwtKeys{
+				SigningKeyID: signingKeyID,
+			}
+			for _, keyID := range keyIDs {
+				jwtKeys.Keys = append(jwtKeys.Keys, slinkyv1beta1.JwtKey{
+					KeyID:         keyID,
+					Algorithm:     slinkyv1beta1.JwtKeyAlgorithmRS256,
+					PrivateKeyRef: testutils.NewJwtKeyRef(keyID),
+				})
+			}
+			return jwtKeys
+		}
+
+		It("Should deny duplicate key IDs or an unknown signing key", func() {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			controller.Spec.JwtKeys = newJwtKeys("a", "a", "b")
+			Expect(validateJwtKeys(controller)).To(BeEmpty())
+
+			controller.Spec.JwtKeys = newJwtKeys("c", "a", "a")
+			Expect(validateJwtKeys(controller)).To(HaveLen(2))
+		})
+
+		It("Should only allow removing retirable keys", func() {
+			oldController := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			oldController.Spec.JwtKeys = newJwtKeys("b", "a", "b")
+			oldController.Status.JwtKeys = &slinkyv1beta1.JwtKeysStatus{
+				SigningKeyID:    "b",
+				PublishedKeyIDs: []string{"a", "b"},
+				PendingTokens:   []string{"default/token"},
+			}
+			newController := oldController.DeepCopy()
+			newController.Spec.JwtKeys = newJwtKeys("b", "b")
+			Expect(validateJwtKeysUpdate(newController, oldController)).To(HaveLen(1))
+
+			oldController.Status.JwtKeys.PendingTokens = nil
+			oldController.Status.JwtKeys.RetirableKeyIDs = []string{"a"}
+			Expect(validateJwtKeysUpdate(newController, oldController)).To(BeEmpty())
+
+			newController.Spec.JwtKeys = newJwtKeys("a", "a")
+			Expect(validateJwtKeysUpdate(newController, oldController)).To(HaveLen(1))
+		})
+	})
+
+	Context("When rotating the Controller auth/slurm key", func() {
+		utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+		accounting := testutils.NewAccounting("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), testutils.NewPasswordRef("slurm"))
+		newRotatingController := func(phase slinkyv1beta1.KeyRotationPhase) *slinkyv1beta1.Controller {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), accounting)
+			controller.Spec.SlurmKeyRotation = &slinkyv1beta1.SlurmKeyRotation{
+				NewSlurmKeyRef: testutils.NewSlurmKeyRef("slurm-new"),
+			}
+			if phase != "" {
+				controller.Status.KeyRotation = &slinkyv1beta1.KeyRotationStatus{Phase: phase}
+			}
+			return controller
+		}
+
+		It("Should deny a new key which is the current key", func() {
+			controller := newRotatingController("")
+			_, errs := validateSlurmKeyRotation(controller)
+			Expect(errs).To(BeEmpty())
+
+			controller.Spec.SlurmKeyRotation.NewSlurmKeyRef = controller.Spec.SlurmKeyRef.SecretKeySelector
+			_, errs = validateSlurmKeyRotation(controller)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should deny changing the new key during a rotation", func() {
+			webhook := &ControllerWebhook{Client: fake.NewFakeClient()}
+			oldController := newRotatingController(slinkyv1beta1.KeyRotationPhaseStaging)
+			newController := oldController.DeepCopy()
+			newController.Spec.SlurmKeyRotation.NewSlurmKeyRef = testutils.NewSlurmKeyRef("slurm-other")
+			Expect(webhook.validateSlurmKeyRotationUpdate(context.TODO(), newController, oldController)).To(HaveLen(1))
+		})
+
+		It("Should only allow removing a finished rotation", func() {
+			webhook := &ControllerWebhook{Client: fake.NewFakeClient()}
+			oldController := newRotatingController(slinkyv1beta1.KeyRotationPhaseSwitching)
+			newController := oldController.DeepCopy()
+			newController.Spec.SlurmKeyRotation = nil
+			Expect(webhook.validateSlurmKeyRotationUpdate(context.TODO(), newController, oldController)).To(HaveLen(1))
+
+			oldController = newRotatingController(slinkyv1beta1.KeyRotationPhaseRolledBack)
+			Expect(webhook.validateSlurmKeyRotationUpdate(context.TODO(), newController, oldController)).To(BeEmpty())
+		})
+
+		It("Should require the new key and the Accounting key when removing a completed rotation", func() {
+			oldController := newRotatingController(slinkyv1beta1.KeyRotationPhaseComplete)
+			newController := oldController.DeepCopy()
+			newController.Spec.SlurmKeyRotation = nil
+			webhook := &ControllerWebhook{Client: fake.NewClientBuilder().WithObjects(accounting).Build()}
+			Expect(webhook.validateSlurmKeyRotationUpdate(context.TODO(), newController, oldController)).To(HaveLen(1))
+			Expect(isSlurmKeyRotationFinished(newController, oldController)).To(BeFalse())
+
+			newController.Spec.SlurmKeyRef.SecretKeySelector = oldController.Spec.SlurmKeyRotation.NewSlurmKeyRef
+			Expect(isSlurmKeyRotationFinished(newController, oldController)).To(BeTrue())
+			Expect(webhook.validateSlurmKeyRotationUpdate(context.TODO(), newController, oldController)).To(HaveLen(1))
+
+			rotated := accounting.DeepCopy()
+			rotated.Spec.SlurmKeyRef.SecretKeySelector = oldController.Spec.SlurmKeyRotation.NewSlurmKeyRef
+			webhook = &ControllerWebhook{Client: fake.NewClientBuilder().WithObjects(rotated).Build()}
+			Expect(webhook.validateSlurmKeyRotationUpdate(context.TODO(), newController, oldController)).To(BeEmpty())
+		})
+	})
+
+	Context("When creating Controller with generated keys", func() {
+		It("Should deny a generated key without a Secret name or key", func() {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			controller.Spec.JwtHs256KeyRef.Key = ""
+			controller.Spec.JwtHs256KeyRef.Generate = true
+			_, errs := validateGeneratedKeyRef("Controller.Spec.JwtHs256KeyRef", controller.Spec.JwtHs256KeyRef, controller.Spec.External)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should admit a generated key", func() {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			controller.Spec.SlurmKeyRef.Generate = true
+			warns, errs := validateGeneratedKeyRef("Controller.Spec.SlurmKeyRef", controller.Spec.SlurmKeyRef, controller.Spec.External)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(BeEmpty())
+		})
+	})
+
+	Context("When creating Controller with auth/munge", func() {
+		utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+		mungeAuth := &slinkyv1beta1.SlurmAuth{
+			Type: slinkyv1beta1.SlurmAuthTypeMunge,
+			MungeKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "munge"},
+				Key:                  "munge.key",
+			},
+			Munged: slinkyv1beta1.ContainerWrapper{
+				Container: corev1.Container{Image: "munged"},
+			},
+		}
+
+		It("Should deny auth/munge without a MUNGE key", func() {
+			auth := mungeAuth.DeepCopy()
+			_, errs := validateAuth("Controller.Spec", auth, slinkyv1beta1.GeneratedSecretKeySelector{})
+			Expect(errs).To(BeEmpty())
+
+			auth.MungeKeyRef.Key = ""
+			_, errs = validateAuth("Controller.Spec", auth, slinkyv1beta1.GeneratedSecretKeySelector{})
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should deny auth/munge without a munged image", func() {
+			auth := mungeAuth.DeepCopy()
+			auth.Munged.Image = ""
+			_, errs := validateAuth("Controller.Spec", auth, slinkyv1beta1.GeneratedSecretKeySelector{})
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should warn about an unused auth/slurm key", func() {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			warns, errs := validateAuth("Controller.Spec", controller.Spec.Auth, controller.Spec.SlurmKeyRef)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(BeEmpty())
+
+			warns, errs = validateAuth("Controller.Spec", mungeAuth, controller.Spec.SlurmKeyRef)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(HaveLen(1))
+		})
+
+		It("Should deny a rotation of the auth/slurm key", func() {
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			controller.Spec.Auth = mungeAuth
+			controller.Spec.SlurmKeyRotation = &slinkyv1beta1.SlurmKeyRotation{
+				NewSlurmKeyRef: testutils.NewSlurmKeyRef("slurm-new"),
+			}
+			_, errs := validateSlurmKeyRotation(controller)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should deny an Accounting of another auth type", func() {
+			accounting := testutils.NewAccounting("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), testutils.NewPasswordRef("slurm"))
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), accounting)
+			controller.Spec.Auth = mungeAuth
+			webhook := &ControllerWebhook{Client: fake.NewClientBuilder().WithObjects(accounting).Build()}
+			Expect(webhook.validateAccountingAuth(context.TODO(), controller)).To(HaveLen(1))
+
+			mungeAccounting := accounting.DeepCopy()
+			mungeAccounting.Spec.Auth = mungeAuth
+			webhook = &ControllerWebhook{Client: fake.NewClientBuilder().WithObjects(mungeAccounting).Build()}
+			Expect(webhook.validateAccountingAuth(context.TODO(), controller)).To(BeEmpty())
+		})
+
+		It("Should deny an Accounting of another MUNGE key", func() {
+			accounting := testutils.NewAccounting("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), testutils.NewPasswordRef("slurm"))
+			accounting.Spec.Auth = mungeAuth.DeepCopy()
+			controller := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), accounting)
+			controller.Spec.Auth = mungeAuth.DeepCopy()
+			controller.Spec.Auth.MungeKeyRef.Name = "other-munge"
+			webhook := &ControllerWebhook{Client: fake.NewClientBuilder().WithObjects(accounting).Build()}
+			Expect(webhook.validateAccountingAuth(context.TODO(), controller)).To(HaveLen(1))
+
+			controller.Spec.Auth = mungeAuth.DeepCopy()
+			controller.Spec.Auth.MungeKeyRef.Key = "other.key"
+			Expect(webhook.validateAccountingAuth(context.TODO(), controller)).To(HaveLen(1))
+
+			otherNamespace := accounting.DeepCopy()
+			otherNamespace.Namespace = "other"
+			controller.Spec.Auth = mungeAuth.DeepCopy()
+			controller.Spec.AccountingRef.Namespace = otherNamespace.Namespace
+			webhook = &ControllerWebhook{Client: fake.NewClientBuilder().WithObjects(otherNamespace).Build()}
+			Expect(webhook.validateAccountingAuth(context.TODO(), controller)).To(HaveLen(1))
+		})
+
+		It("Should deny changing the auth type", func() {
+			webhook := &ControllerWebhook{Client: fake.NewFakeClient()}
+			oldController := testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtHs256KeyRef("slurm"), nil)
+			newController := oldController.DeepCopy()
+			newController.Spec.Auth = mungeAuth
+			_, err := webhook.ValidateUpdate(context.TODO(), oldController, newController)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
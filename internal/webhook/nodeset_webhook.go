@@ -6,11 +6,18 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -20,7 +27,9 @@ import (
 
 // TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 
-type NodeSetWebhook struct{}
+type NodeSetWebhook struct {
+	client.Client
+}
 
 // log is for logging in this package.
 var nodesetlog = logf.Log.WithName("nodeset-resource")
@@ -43,6 +52,7 @@ func (r *NodeSetWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 	nodesetlog.Info("validate create", "nodeset", klog.KObj(nodeset))
 
 	warns, errs := validateNodeSet(nodeset)
+	errs = append(errs, r.validatePartition(ctx, nodeset)...)
 
 	return warns, utilerrors.NewAggregate(errs)
 }
@@ -54,6 +64,7 @@ func (r *NodeSetWebhook) ValidateUpdate(ctx context.Context, oldObj runtime.Obje
 	nodesetlog.Info("validate update", "newNodeSet", klog.KObj(newNodeSet))
 
 	warns, errs := validateNodeSet(newNodeSet)
+	errs = append(errs, r.validatePartition(ctx, newNodeSet)...)
 
 	return warns, utilerrors.NewAggregate(errs)
 }
@@ -66,6 +77,29 @@ func (r *NodeSetWebhook) ValidateDelete(ctx context.Context, obj runtime.Object)
 	return nil, nil
 }
 
+// validatePartition checks that the partition does not collide with a
+// partition of the Controller.
+func (r *NodeSetWebhook) validatePartition(ctx context.Context, obj *slinkyv1beta1.NodeSet) []error {
+	var errs []error
+
+	if !obj.Spec.Partition.Enabled || obj.Spec.ControllerRef.Name == "" {
+		return errs
+	}
+	controller := &slinkyv1beta1.Controller{}
+	if err := r.Get(ctx, obj.Spec.ControllerRef.NamespacedName(), controller); err != nil {
+		if apierrors.IsNotFound(err) {
+			return errs
+		}
+		return []error{err}
+	}
+	if slices.Contains(controller.PartitionNames(), obj.SlurmName()) {
+		errs = append(errs, fmt.Errorf("`NodeSet.Spec.Partition` (%s) collides with a partition of Controller (%s), disable `NodeSet.Spec.Partition` or rename the partition",
+			obj.SlurmName(), klog.KObj(controller)))
+	}
+
+	return errs
+}
+
 func validateNodeSet(obj *slinkyv1beta1.NodeSet) (admission.Warnings, []error) {
 	var warns admission.Warnings
 	var errs []error
@@ -75,9 +109,34 @@ func validateNodeSet(obj *slinkyv1beta1.NodeSet) (admission.Warnings, []error) {
 		// valid
 	case slinkyv1beta1.OnDeleteNodeSetStrategyType:
 		// valid
+	case slinkyv1beta1.OpportunisticNodeSetStrategyType:
+		// valid
 	default:
-		errs = append(errs, fmt.Errorf("`NodeSet.Spec.UpdateStrategy.Type` is not valid. Got: %v. Expected of: %s; %s",
-			obj.Spec.UpdateStrategy.Type, slinkyv1beta1.RollingUpdateNodeSetStrategyType, slinkyv1beta1.OnDeleteNodeSetStrategyType))
+		errs = append(errs, fmt.Errorf("`NodeSet.Spec.UpdateStrategy.Type` is not valid. Got: %v. Expected of: %s; %s; %s",
+			obj.Spec.UpdateStrategy.Type, slinkyv1beta1.RollingUpdateNodeSetStrategyType, slinkyv1beta1.OnDeleteNodeSetStrategyType,
+			slinkyv1beta1.OpportunisticNodeSetStrategyType))
+	}
+
+	if deadline, ok := obj.Spec.UpdateStrategy.Opportunistic.UpdateDeadline(); ok && deadline < 0 {
+		errs = append(errs, fmt.Errorf("`NodeSet.Spec.UpdateStrategy.Opportunistic.Deadline` is not valid. Got: %v. Expected a non-negative duration",
+			deadline))
+	}
+
+	if canary := obj.Spec.UpdateStrategy.RollingUpdate.CanaryConfig(); canary != nil {
+		if obj.Spec.UpdateStrategy.Type != slinkyv1beta1.RollingUpdateNodeSetStrategyType {
+			warns = append(warns, fmt.Sprintf("`NodeSet.Spec.UpdateStrategy.RollingUpdate.Canary` is ignored without `NodeSet.Spec.UpdateStrategy.Type` of %s",
+				slinkyv1beta1.RollingUpdateNodeSetStrategyType))
+		}
+		if canary.Replicas != nil {
+			if _, err := intstr.GetScaledValueFromIntOrPercent(canary.Replicas, 100, true); err != nil {
+				errs = append(errs, fmt.Errorf("`NodeSet.Spec.UpdateStrategy.RollingUpdate.Canary.Replicas` is not valid. Got: %v. Expected a number or percentage",
+					canary.Replicas.String()))
+			}
+		}
+		if canary.SoakPeriod != nil && canary.SoakPeriod.Duration < 0 {
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.UpdateStrategy.RollingUpdate.Canary.SoakPeriod` is not valid. Got: %v. Expected a non-negative duration",
+				canary.SoakPeriod.Duration))
+		}
 	}
 
 	if obj.Spec.PersistentVolumeClaimRetentionPolicy != nil {
@@ -101,5 +160,136 @@ func validateNodeSet(obj *slinkyv1beta1.NodeSet) (admission.Warnings, []error) {
 		}
 	}
 
+	if autoscaling := obj.Spec.Autoscaling; autoscaling != nil && autoscaling.Enabled {
+		minReplicas := ptr.Deref(autoscaling.MinReplicas, 0)
+		if minReplicas > autoscaling.MaxReplicas {
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.Autoscaling.MinReplicas` is not valid. Got: %v. Expected less than or equal to `NodeSet.Spec.Autoscaling.MaxReplicas` (%v)",
+				minReplicas, autoscaling.MaxReplicas))
+		}
+		if !obj.Spec.Partition.Enabled {
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.Autoscaling` requires `NodeSet.Spec.Partition.Enabled`, pending jobs are matched by partition"))
+		}
+		if autoscaling.ScaleUpStabilizationWindow != nil && autoscaling.ScaleUpStabilizationWindow.Duration < 0 {
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.Autoscaling.ScaleUpStabilizationWindow` is not valid. Got: %v. Expected a non-negative duration",
+				autoscaling.ScaleUpStabilizationWindow.Duration))
+		}
+		if autoscaling.ScaleDownStabilizationWindow != nil && autoscaling.ScaleDownStabilizationWindow.Duration < 0 {
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.Autoscaling.ScaleDownStabilizationWindow` is not valid. Got: %v. Expected a non-negative duration",
+				autoscaling.ScaleDownStabilizationWindow.Duration))
+		}
+		replicas := ptr.Deref(obj.Spec.Replicas, 0)
+		if replicas < minReplicas || replicas > autoscaling.MaxReplicas {
+			warns = append(warns, fmt.Sprintf("`NodeSet.Spec.Replicas` (%v) is outside of the autoscaling bounds [%v, %v] and will be adjusted",
+				replicas, minReplicas, autoscaling.MaxReplicas))
+		}
+	}
+
+	if drainPolicy := obj.Spec.DrainPolicy; drainPolicy != nil {
+		switch drainPolicy.Action {
+		case "",
+			slinkyv1beta1.NodeSetDrainActionWait,
+			slinkyv1beta1.NodeSetDrainActionRequeue,
+			slinkyv1beta1.NodeSetDrainActionCancel,
+			slinkyv1beta1.NodeSetDrainActionForceDelete:
+			// valid
+		default:
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.DrainPolicy.Action` is not valid. Got: %v. Expected of: %s; %s; %s; %s",
+				drainPolicy.Action, slinkyv1beta1.NodeSetDrainActionWait, slinkyv1beta1.NodeSetDrainActionRequeue,
+				slinkyv1beta1.NodeSetDrainActionCancel, slinkyv1beta1.NodeSetDrainActionForceDelete))
+		}
+		if drainPolicy.Timeout != nil && drainPolicy.Timeout.Duration < 0 {
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.DrainPolicy.Timeout` is not valid. Got: %v. Expected a non-negative duration",
+				drainPolicy.Timeout.Duration))
+		}
+		if drainPolicy.GracePeriod != nil && drainPolicy.GracePeriod.Duration < 0 {
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.DrainPolicy.GracePeriod` is not valid. Got: %v. Expected a non-negative duration",
+				drainPolicy.GracePeriod.Duration))
+		}
+		if drainPolicy.Timeout == nil {
+			warns = append(warns, "`NodeSet.Spec.DrainPolicy.Timeout` is not set, the drain will never time out")
+		}
+		if drainPolicy.GracePeriod != nil && drainPolicy.GraceSignal == "" {
+			warns = append(warns, "`NodeSet.Spec.DrainPolicy.GracePeriod` is ignored without `NodeSet.Spec.DrainPolicy.GraceSignal`")
+		}
+	}
+
+	if remediation := obj.Spec.Remediation; remediation != nil {
+		for _, rule := range remediation.Rules {
+			switch rule.State {
+			case slinkyv1beta1.NodeSetRemediationStateDown,
+				slinkyv1beta1.NodeSetRemediationStateFail,
+				slinkyv1beta1.NodeSetRemediationStateNotResponding,
+				slinkyv1beta1.NodeSetRemediationStateInvalidReg:
+				// valid
+			default:
+				errs = append(errs, fmt.Errorf("`NodeSet.Spec.Remediation.Rules.State` is not valid. Got: %v. Expected of: %s; %s; %s; %s",
+					rule.State, slinkyv1beta1.NodeSetRemediationStateDown, slinkyv1beta1.NodeSetRemediationStateFail,
+					slinkyv1beta1.NodeSetRemediationStateNotResponding, slinkyv1beta1.NodeSetRemediationStateInvalidReg))
+			}
+			if rule.MinDuration.Duration < 0 {
+				errs = append(errs, fmt.Errorf("`NodeSet.Spec.Remediation.Rules.MinDuration` is not valid. Got: %v. Expected a non-negative duration",
+					rule.MinDuration.Duration))
+			}
+		}
+		if remediation.MaxConcurrent != nil && *remediation.MaxConcurrent < 1 {
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.Remediation.MaxConcurrent` is not valid. Got: %v. Expected a positive number",
+				*remediation.MaxConcurrent))
+		}
+	}
+
+	if nodeHealth := obj.Spec.NodeHealth; nodeHealth != nil {
+		for _, rule := range nodeHealth.Rules {
+			if (rule.Condition == nil) == (rule.Taint == nil) {
+				errs = append(errs, fmt.Errorf("`NodeSet.Spec.NodeHealth.Rules` is not valid. Expected exactly one of `Condition` or `Taint`"))
+			}
+			switch rule.Action {
+			case "",
+				slinkyv1beta1.NodeSetNodeHealthActionDrain,
+				slinkyv1beta1.NodeSetNodeHealthActionDown:
+				// valid
+			default:
+				errs = append(errs, fmt.Errorf("`NodeSet.Spec.NodeHealth.Rules.Action` is not valid. Got: %v. Expected of: %s; %s",
+					rule.Action, slinkyv1beta1.NodeSetNodeHealthActionDrain, slinkyv1beta1.NodeSetNodeHealthActionDown))
+			}
+		}
+	}
+
+	if nodeConf := obj.Spec.NodeConf; nodeConf != nil {
+		if nodeConf.MemoryReserve != nil && nodeConf.MemoryReserve.Sign() < 0 {
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.NodeConf.MemoryReserve` is not valid. Got: %v. Expected a non-negative quantity",
+				nodeConf.MemoryReserve.String()))
+		}
+		for _, label := range nodeConf.FeatureLabels {
+			if msgs := validation.IsQualifiedName(label); len(msgs) > 0 {
+				errs = append(errs, fmt.Errorf("`NodeSet.Spec.NodeConf.FeatureLabels` is not valid. Got: %v. %s",
+					label, strings.Join(msgs, "; ")))
+			}
+		}
+		resources := obj.Spec.Slurmd.Resources
+		if nodeConf.FromResources && len(resources.Limits) == 0 && len(resources.Requests) == 0 {
+			warns = append(warns, "`NodeSet.Spec.NodeConf.FromResources` has no effect without `NodeSet.Spec.Slurmd.Resources`")
+		}
+	}
+
+	if deletionCost := obj.Spec.DeletionCost; deletionCost != nil {
+		switch deletionCost.Policy {
+		case "",
+			slinkyv1beta1.NodeSetDeletionCostPolicyWorkload,
+			slinkyv1beta1.NodeSetDeletionCostPolicyIdle:
+			// valid
+		default:
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.DeletionCost.Policy` is not valid. Got: %v. Expected of: %s; %s",
+				deletionCost.Policy, slinkyv1beta1.NodeSetDeletionCostPolicyWorkload, slinkyv1beta1.NodeSetDeletionCostPolicyIdle))
+		}
+		if deletionCost.LowPriorityThreshold != nil && *deletionCost.LowPriorityThreshold < 0 {
+			errs = append(errs, fmt.Errorf("`NodeSet.Spec.DeletionCost.LowPriorityThreshold` is not valid. Got: %v. Expected a non-negative number",
+				*deletionCost.LowPriorityThreshold))
+		}
+		if deletionCost.Policy == slinkyv1beta1.NodeSetDeletionCostPolicyIdle &&
+			(len(deletionCost.PreemptibleQOS) > 0 || deletionCost.LowPriorityThreshold != nil) {
+			warns = append(warns, "`NodeSet.Spec.DeletionCost.PreemptibleQOS` and `NodeSet.Spec.DeletionCost.LowPriorityThreshold` are ignored with the Idle policy")
+		}
+	}
+
 	return warns, errs
 }
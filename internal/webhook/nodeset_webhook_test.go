@@ -4,7 +4,17 @@
 package webhook
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
 var _ = Describe("NodeSet Webhook", func() {
@@ -16,5 +26,22 @@ var _ = Describe("NodeSet Webhook", func() {
 		It("Should admit if all required fields are provided", func() {
 			// TODO(user): Add your logic here
 		})
+
+		It("Should deny a partition which collides with a Controller partition", func() {
+			utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("gpu", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+
+			webhook := &NodeSetWebhook{Client: fake.NewFakeClient()}
+			Expect(webhook.validatePartition(context.TODO(), nodeset)).To(BeEmpty())
+
+			controller.Spec.Partitions = []slinkyv1beta1.ControllerPartition{{Name: nodeset.SlurmName()}}
+			webhook = &NodeSetWebhook{Client: fake.NewClientBuilder().WithObjects(controller).Build()}
+			Expect(webhook.validatePartition(context.TODO(), nodeset)).To(HaveLen(1))
+
+			nodeset.Spec.Partition.Enabled = false
+			Expect(webhook.validatePartition(context.TODO(), nodeset)).To(BeEmpty())
+		})
 	})
 })
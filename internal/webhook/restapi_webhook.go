@@ -5,6 +5,7 @@ package webhook
 
 import (
 	"context"
+	"errors"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -72,5 +73,35 @@ func validateRestapi(obj *slinkyv1beta1.RestApi) (admission.Warnings, []error) {
 	var warns admission.Warnings
 	var errs []error
 
+	tlsWarns, tlsErrs := validateRestapiTLS(obj)
+	warns = append(warns, tlsWarns...)
+	errs = append(errs, tlsErrs...)
+
+	return warns, errs
+}
+
+// validateRestapiTLS checks that a client certificate is available when it is
+// enabled with an existing Secret.
+func validateRestapiTLS(obj *slinkyv1beta1.RestApi) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	tls := obj.Spec.TLS
+	if tls == nil {
+		return warns, errs
+	}
+
+	if tls.SecretRef != nil && tls.SecretRef.Name == "" {
+		errs = append(errs, errors.New("`RestApi.Spec.TLS.SecretRef.Name` must not be empty"))
+	}
+	if tls.ClientCertificate && tls.SecretRef != nil && tls.ClientSecretRef == nil {
+		errs = append(errs, errors.New("`RestApi.Spec.TLS.ClientSecretRef` is required for `clientCertificate` with `secretRef`"))
+	}
+	if tls.ClientSecretRef != nil && tls.SecretRef == nil {
+		warns = append(warns, "`RestApi.Spec.TLS.ClientSecretRef` is ignored without `secretRef`, the client certificate is generated")
+	} else if tls.ClientSecretRef != nil && !tls.ClientCertificate {
+		warns = append(warns, "`RestApi.Spec.TLS.ClientSecretRef` is ignored without `clientCertificate`")
+	}
+
 	return warns, errs
 }
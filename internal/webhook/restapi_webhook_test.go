@@ -5,6 +5,11 @@ package webhook
 
 import (
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
 var _ = Describe("Restapi Webhook", func() {
@@ -17,4 +22,39 @@ var _ = Describe("Restapi Webhook", func() {
 			// TODO(user): Add your logic here
 		})
 	})
+
+	Context("When creating Restapi with TLS", func() {
+		It("Should admit generated certificates", func() {
+			restapi := testutils.NewRestapi("slurm", nil)
+			restapi.Spec.TLS = &slinkyv1beta1.RestApiTLS{ClientCertificate: true}
+			warns, errs := validateRestapiTLS(restapi)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(BeEmpty())
+		})
+
+		It("Should deny a client certificate without its Secret", func() {
+			restapi := testutils.NewRestapi("slurm", nil)
+			restapi.Spec.TLS = &slinkyv1beta1.RestApiTLS{
+				SecretRef:         &corev1.LocalObjectReference{Name: "restapi-tls"},
+				ClientCertificate: true,
+			}
+			_, errs := validateRestapiTLS(restapi)
+			Expect(errs).To(HaveLen(1))
+
+			restapi.Spec.TLS.ClientSecretRef = &corev1.LocalObjectReference{Name: "operator-tls"}
+			warns, errs := validateRestapiTLS(restapi)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(BeEmpty())
+		})
+
+		It("Should warn when the client certificate is ignored", func() {
+			restapi := testutils.NewRestapi("slurm", nil)
+			restapi.Spec.TLS = &slinkyv1beta1.RestApiTLS{
+				ClientSecretRef: &corev1.LocalObjectReference{Name: "operator-tls"},
+			}
+			warns, errs := validateRestapiTLS(restapi)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(HaveLen(1))
+		})
+	})
 })
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/tresutils"
+)
+
+type SlurmAccountWebhook struct{}
+
+// log is for logging in this package.
+var slurmaccountlog = logf.Log.WithName("slurmaccount-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *SlurmAccountWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&slinkyv1beta1.SlurmAccount{}).
+		WithValidator(r).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-slurmaccount,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=slurmaccounts,verbs=create;update,versions=v1beta1,name=slurmaccount-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.CustomValidator = &SlurmAccountWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmAccountWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	account := obj.(*slinkyv1beta1.SlurmAccount)
+	slurmaccountlog.Info("validate create", "slurmaccount", klog.KObj(account))
+
+	warns, errs := validateSlurmAccount(account)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmAccountWebhook) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	newAccount := newObj.(*slinkyv1beta1.SlurmAccount)
+	oldAccount := oldObj.(*slinkyv1beta1.SlurmAccount)
+	slurmaccountlog.Info("validate update", "newSlurmAccount", klog.KObj(newAccount))
+
+	warns, errs := validateSlurmAccount(newAccount)
+
+	if newAccount.SlurmName() != oldAccount.SlurmName() {
+		errs = append(errs, errors.New("`SlurmAccount.Spec.Name` is immutable"))
+	}
+	if newAccount.Spec.ControllerRef != oldAccount.Spec.ControllerRef {
+		errs = append(errs, errors.New("`SlurmAccount.Spec.ControllerRef` is immutable"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmAccountWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	account := obj.(*slinkyv1beta1.SlurmAccount)
+	slurmaccountlog.Info("validate delete", "slurmaccount", klog.KObj(account))
+
+	return nil, nil
+}
+
+func validateSlurmAccount(obj *slinkyv1beta1.SlurmAccount) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if obj.Spec.ControllerRef.Name == "" {
+		errs = append(errs, errors.New("`SlurmAccount.Spec.ControllerRef.Name` must be set"))
+	}
+	if obj.SlurmName() == "root" {
+		errs = append(errs, errors.New("the `root` Slurm account cannot be managed"))
+	}
+	if obj.SlurmName() == obj.ParentAccount() {
+		errs = append(errs, errors.New("`SlurmAccount.Spec.ParentAccount` cannot be the account itself"))
+	}
+	errs = append(errs, validateAssociationLimits("SlurmAccount.Spec.Limits", &obj.Spec.Limits)...)
+
+	return warns, errs
+}
+
+// validateAssociationLimits checks that the TRES of the limits can be parsed.
+func validateAssociationLimits(path string, limits *slinkyv1beta1.AssociationLimits) []error {
+	var errs []error
+	errs = append(errs, validateTRES(path+".GrpTRES", limits.GrpTRES)...)
+	errs = append(errs, validateTRES(path+".MaxTRESPerJob", limits.MaxTRESPerJob)...)
+	return errs
+}
+
+// validateTRES checks that the Slurm TRES string can be parsed.
+func validateTRES(path, tres string) []error {
+	if _, err := tresutils.Parse(tres); err != nil {
+		return []error{fmt.Errorf("`%s` is not valid: %w", path, err)}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("SlurmAccount Webhook", func() {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+
+	Context("When creating SlurmAccount under Validating Webhook", func() {
+		It("Should deny if a required field is empty", func() {
+			account := testutils.NewSlurmAccount("foo", controller)
+			account.Spec.ControllerRef.Name = ""
+			_, errs := validateSlurmAccount(account)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should deny if the limits are not valid", func() {
+			account := testutils.NewSlurmAccount("foo", controller)
+			account.Spec.Limits.GrpTRES = "cpu=-1"
+			account.Spec.Limits.MaxTRESPerJob = "mem"
+			_, errs := validateSlurmAccount(account)
+			Expect(errs).To(HaveLen(2))
+		})
+
+		It("Should deny if the account is its own parent", func() {
+			account := testutils.NewSlurmAccount("foo", controller)
+			account.Spec.ParentAccount = "foo"
+			_, errs := validateSlurmAccount(account)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should admit if all required fields are provided", func() {
+			account := testutils.NewSlurmAccount("foo", controller)
+			account.Spec.Limits.GrpTRES = "cpu=64,mem=256G,gres/gpu=8"
+			_, errs := validateSlurmAccount(account)
+			Expect(errs).To(BeEmpty())
+		})
+	})
+
+	Context("When updating SlurmAccount under Validating Webhook", func() {
+		It("Should deny if the name is changed", func() {
+			oldAccount := testutils.NewSlurmAccount("foo", controller)
+			newAccount := oldAccount.DeepCopy()
+			newAccount.Spec.Name = "bar"
+			_, err := (&SlurmAccountWebhook{}).ValidateUpdate(ctx, oldAccount, newAccount)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if the limits are changed", func() {
+			oldAccount := testutils.NewSlurmAccount("foo", controller)
+			newAccount := oldAccount.DeepCopy()
+			newAccount.Spec.Limits.GrpTRES = "cpu=64"
+			newAccount.Spec.DeletionPolicy = slinkyv1beta1.DeletionPolicyRetain
+			_, err := (&SlurmAccountWebhook{}).ValidateUpdate(ctx, oldAccount, newAccount)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
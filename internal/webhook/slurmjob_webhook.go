@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+type SlurmJobWebhook struct {
+	client.Client
+}
+
+// log is for logging in this package.
+var slurmjoblog = logf.Log.WithName("slurmjob-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *SlurmJobWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&slinkyv1beta1.SlurmJob{}).
+		WithValidator(r).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-slurmjob,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=slurmjobs,verbs=create;update,versions=v1beta1,name=slurmjob-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.CustomValidator = &SlurmJobWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmJobWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	job := obj.(*slinkyv1beta1.SlurmJob)
+	slurmjoblog.Info("validate create", "slurmjob", klog.KObj(job))
+
+	warns, errs := validateSlurmJob(job)
+	errs = append(errs, r.validateSubmitAs(ctx, job)...)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmJobWebhook) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	newJob := newObj.(*slinkyv1beta1.SlurmJob)
+	oldJob := oldObj.(*slinkyv1beta1.SlurmJob)
+	slurmjoblog.Info("validate update", "newSlurmJob", klog.KObj(newJob))
+
+	warns, errs := validateSlurmJob(newJob)
+
+	// Only the TTL may change, the Slurm job is submitted once.
+	newSpec := newJob.Spec.DeepCopy()
+	newSpec.TTLSecondsAfterFinished = oldJob.Spec.TTLSecondsAfterFinished
+	if !apiequality.Semantic.DeepEqual(*newSpec, oldJob.Spec) {
+		errs = append(errs, errors.New("`SlurmJob.Spec` is immutable, except for `SlurmJob.Spec.TTLSecondsAfterFinished`"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmJobWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	job := obj.(*slinkyv1beta1.SlurmJob)
+	slurmjoblog.Info("validate delete", "slurmjob", klog.KObj(job))
+
+	return nil, nil
+}
+
+// validateSubmitAs checks that the Controller grants the SubmitAs user to the
+// namespace of the SlurmJob.
+func (r *SlurmJobWebhook) validateSubmitAs(ctx context.Context, obj *slinkyv1beta1.SlurmJob) []error {
+	var errs []error
+
+	if obj.Spec.SubmitAs == "" || obj.Spec.ControllerRef.Name == "" {
+		return errs
+	}
+	controller := &slinkyv1beta1.Controller{}
+	if err := r.Get(ctx, obj.Spec.ControllerRef.NamespacedName(), controller); err != nil {
+		if apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("`SlurmJob.Spec.ControllerRef` (%s) is not valid. Got: not found. Expected a Controller to grant `SlurmJob.Spec.SubmitAs`",
+				obj.Spec.ControllerRef.NamespacedName()))
+			return errs
+		}
+		return []error{err}
+	}
+	if !controller.IsUserGranted(obj.Namespace, obj.Spec.SubmitAs) {
+		errs = append(errs, fmt.Errorf("`SlurmJob.Spec.SubmitAs` (%s) is not valid. Expected Controller (%s) to grant it to namespace %s in `Controller.Spec.UserGrants`",
+			obj.Spec.SubmitAs, klog.KObj(controller), obj.Namespace))
+	}
+
+	return errs
+}
+
+func validateSlurmJob(obj *slinkyv1beta1.SlurmJob) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if obj.Spec.ControllerRef.Name == "" {
+		errs = append(errs, errors.New("`SlurmJob.Spec.ControllerRef.Name` must be set"))
+	}
+	if !strings.HasPrefix(obj.Spec.Script, "#!") {
+		errs = append(errs, errors.New("`SlurmJob.Spec.Script` must start with a shebang (e.g. `#!/bin/bash`)"))
+	}
+	for _, env := range obj.Spec.Env {
+		if env.ValueFrom != nil {
+			errs = append(errs, fmt.Errorf("`SlurmJob.Spec.Env` (%s) must not set `valueFrom`", env.Name))
+		}
+	}
+	for _, dep := range obj.Spec.Dependencies {
+		if dep.SlurmJob == obj.Name {
+			errs = append(errs, errors.New("`SlurmJob.Spec.Dependencies` must not depend on itself"))
+		}
+	}
+	if obj.Spec.SubmitAs == "root" {
+		warns = append(warns, "`SlurmJob.Spec.SubmitAs` will run the job as root")
+	}
+
+	return warns, errs
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("SlurmJob Webhook", func() {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	script := "#!/bin/bash\nsrun hostname\n"
+
+	Context("When creating SlurmJob under Validating Webhook", func() {
+		It("Should deny if the script has no shebang", func() {
+			job := testutils.NewSlurmJob("foo", controller, "srun hostname")
+			_, errs := validateSlurmJob(job)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should deny if an env var is not a value", func() {
+			job := testutils.NewSlurmJob("foo", controller, script)
+			job.Spec.Env = []corev1.EnvVar{
+				{Name: "FOO", ValueFrom: &corev1.EnvVarSource{}},
+			}
+			_, errs := validateSlurmJob(job)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should deny if the job depends on itself", func() {
+			job := testutils.NewSlurmJob("foo", controller, script)
+			job.Spec.Dependencies = []slinkyv1beta1.SlurmJobDependency{
+				{Type: slinkyv1beta1.SlurmJobDependencyAfterOK, SlurmJob: "foo"},
+			}
+			_, errs := validateSlurmJob(job)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should admit if all required fields are provided", func() {
+			job := testutils.NewSlurmJob("foo", controller, script)
+			job.Spec.Env = []corev1.EnvVar{
+				{Name: "FOO", Value: "bar"},
+			}
+			job.Spec.Dependencies = []slinkyv1beta1.SlurmJobDependency{
+				{Type: slinkyv1beta1.SlurmJobDependencyAfterAny, SlurmJob: "bar"},
+			}
+			_, errs := validateSlurmJob(job)
+			Expect(errs).To(BeEmpty())
+		})
+	})
+
+	Context("When creating SlurmJob with SubmitAs under Validating Webhook", func() {
+		It("Should deny a user which the Controller does not grant", func() {
+			utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+			job := testutils.NewSlurmJob("foo", controller, script)
+			job.Spec.SubmitAs = "root"
+
+			webhook := &SlurmJobWebhook{Client: fake.NewFakeClient()}
+			Expect(webhook.validateSubmitAs(context.TODO(), job)).To(HaveLen(1))
+
+			webhook = &SlurmJobWebhook{Client: fake.NewClientBuilder().WithObjects(controller).Build()}
+			Expect(webhook.validateSubmitAs(context.TODO(), job)).To(HaveLen(1))
+
+			granted := controller.DeepCopy()
+			granted.Spec.UserGrants = []slinkyv1beta1.UserGrant{
+				{Namespace: job.Namespace, Usernames: []string{"alice"}},
+			}
+			webhook = &SlurmJobWebhook{Client: fake.NewClientBuilder().WithObjects(granted).Build()}
+			Expect(webhook.validateSubmitAs(context.TODO(), job)).To(HaveLen(1))
+
+			job.Spec.SubmitAs = "alice"
+			Expect(webhook.validateSubmitAs(context.TODO(), job)).To(BeEmpty())
+		})
+	})
+
+	Context("When updating SlurmJob under Validating Webhook", func() {
+		It("Should deny if the spec is changed", func() {
+			oldJob := testutils.NewSlurmJob("foo", controller, script)
+			newJob := oldJob.DeepCopy()
+			newJob.Spec.Partition = "debug"
+			_, err := (&SlurmJobWebhook{}).ValidateUpdate(ctx, oldJob, newJob)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if the TTL is changed", func() {
+			oldJob := testutils.NewSlurmJob("foo", controller, script)
+			newJob := oldJob.DeepCopy()
+			newJob.Spec.TTLSecondsAfterFinished = ptr.To[int32](60)
+			_, err := (&SlurmJobWebhook{}).ValidateUpdate(ctx, oldJob, newJob)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+type SlurmMaintenanceWebhook struct{}
+
+// log is for logging in this package.
+var slurmmaintenancelog = logf.Log.WithName("slurmmaintenance-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *SlurmMaintenanceWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&slinkyv1beta1.SlurmMaintenance{}).
+		WithValidator(r).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-slurmmaintenance,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=slurmmaintenances,verbs=create;update,versions=v1beta1,name=slurmmaintenance-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.CustomValidator = &SlurmMaintenanceWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmMaintenanceWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	maintenance := obj.(*slinkyv1beta1.SlurmMaintenance)
+	slurmmaintenancelog.Info("validate create", "slurmmaintenance", klog.KObj(maintenance))
+
+	warns, errs := validateSlurmMaintenance(maintenance)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmMaintenanceWebhook) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	newMaintenance := newObj.(*slinkyv1beta1.SlurmMaintenance)
+	_ = oldObj.(*slinkyv1beta1.SlurmMaintenance)
+	slurmmaintenancelog.Info("validate update", "newSlurmMaintenance", klog.KObj(newMaintenance))
+
+	warns, errs := validateSlurmMaintenance(newMaintenance)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmMaintenanceWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	maintenance := obj.(*slinkyv1beta1.SlurmMaintenance)
+	slurmmaintenancelog.Info("validate delete", "slurmmaintenance", klog.KObj(maintenance))
+
+	return nil, nil
+}
+
+func validateSlurmMaintenance(obj *slinkyv1beta1.SlurmMaintenance) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if len(obj.Spec.NodeSets) == 0 && obj.Spec.PodSelector == nil {
+		errs = append(errs, errors.New("`SlurmMaintenance.Spec.NodeSets` or `SlurmMaintenance.Spec.PodSelector` must be set"))
+	}
+	if obj.Spec.PodSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(obj.Spec.PodSelector); err != nil {
+			errs = append(errs, fmt.Errorf("`SlurmMaintenance.Spec.PodSelector` is not valid: %w", err))
+		}
+	}
+	if obj.Spec.StartTime.IsZero() {
+		errs = append(errs, errors.New("`SlurmMaintenance.Spec.StartTime` must be set"))
+	}
+	if obj.Spec.Duration.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("`SlurmMaintenance.Spec.Duration` is not valid. Got: %v. Expected a positive duration",
+			obj.Spec.Duration.Duration))
+	}
+	if obj.Spec.RecreatePods && len(obj.Spec.NodeSets) == 0 {
+		warns = append(warns, "`SlurmMaintenance.Spec.RecreatePods` will delete every selected NodeSet pod once drained")
+	}
+
+	return warns, errs
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("SlurmMaintenance Webhook", func() {
+	Context("When creating SlurmMaintenance under Validating Webhook", func() {
+		It("Should deny if a required field is empty", func() {
+			maintenance := testutils.NewSlurmMaintenance("foo", nil, time.Now().Add(time.Hour), time.Hour)
+			_, errs := validateSlurmMaintenance(maintenance)
+			Expect(errs).To(HaveLen(1))
+
+			maintenance = testutils.NewSlurmMaintenance("foo", []string{"slurm"}, time.Now().Add(time.Hour), 0)
+			_, errs = validateSlurmMaintenance(maintenance)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should admit if all required fields are provided", func() {
+			maintenance := testutils.NewSlurmMaintenance("foo", []string{"slurm"}, time.Now().Add(time.Hour), time.Hour)
+			_, errs := validateSlurmMaintenance(maintenance)
+			Expect(errs).To(BeEmpty())
+
+			maintenance = testutils.NewSlurmMaintenance("foo", nil, time.Now().Add(time.Hour), time.Hour)
+			maintenance.Spec.PodSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"foo": "bar"},
+			}
+			_, errs = validateSlurmMaintenance(maintenance)
+			Expect(errs).To(BeEmpty())
+		})
+	})
+})
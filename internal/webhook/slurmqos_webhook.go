@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+type SlurmQOSWebhook struct{}
+
+// log is for logging in this package.
+var slurmqoslog = logf.Log.WithName("slurmqos-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *SlurmQOSWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&slinkyv1beta1.SlurmQOS{}).
+		WithValidator(r).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-slurmqos,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=slurmqoses,verbs=create;update,versions=v1beta1,name=slurmqos-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.CustomValidator = &SlurmQOSWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmQOSWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	qos := obj.(*slinkyv1beta1.SlurmQOS)
+	slurmqoslog.Info("validate create", "slurmqos", klog.KObj(qos))
+
+	warns, errs := validateSlurmQOS(qos)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmQOSWebhook) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	newQOS := newObj.(*slinkyv1beta1.SlurmQOS)
+	oldQOS := oldObj.(*slinkyv1beta1.SlurmQOS)
+	slurmqoslog.Info("validate update", "newSlurmQOS", klog.KObj(newQOS))
+
+	warns, errs := validateSlurmQOS(newQOS)
+
+	if newQOS.SlurmName() != oldQOS.SlurmName() {
+		errs = append(errs, errors.New("`SlurmQOS.Spec.Name` is immutable"))
+	}
+	if newQOS.Spec.ControllerRef != oldQOS.Spec.ControllerRef {
+		errs = append(errs, errors.New("`SlurmQOS.Spec.ControllerRef` is immutable"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmQOSWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	qos := obj.(*slinkyv1beta1.SlurmQOS)
+	slurmqoslog.Info("validate delete", "slurmqos", klog.KObj(qos))
+
+	return nil, nil
+}
+
+func validateSlurmQOS(obj *slinkyv1beta1.SlurmQOS) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if obj.Spec.ControllerRef.Name == "" {
+		errs = append(errs, errors.New("`SlurmQOS.Spec.ControllerRef.Name` must be set"))
+	}
+	errs = append(errs, validateTRES("SlurmQOS.Spec.GrpTRES", obj.Spec.GrpTRES)...)
+	errs = append(errs, validateTRES("SlurmQOS.Spec.MaxTRESPerJob", obj.Spec.MaxTRESPerJob)...)
+	errs = append(errs, validateTRES("SlurmQOS.Spec.MaxTRESPerNode", obj.Spec.MaxTRESPerNode)...)
+	errs = append(errs, validateTRES("SlurmQOS.Spec.MaxTRESPerUser", obj.Spec.MaxTRESPerUser)...)
+
+	return warns, errs
+}
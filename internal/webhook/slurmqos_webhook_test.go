@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("SlurmQOS Webhook", func() {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+
+	Context("When creating SlurmQOS under Validating Webhook", func() {
+		It("Should deny if a required field is empty", func() {
+			qos := testutils.NewSlurmQOS("foo", controller)
+			qos.Spec.ControllerRef.Name = ""
+			_, errs := validateSlurmQOS(qos)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should deny if the limits are not valid", func() {
+			qos := testutils.NewSlurmQOS("foo", controller)
+			qos.Spec.MaxTRESPerUser = "cpu=1,cpu=2"
+			_, errs := validateSlurmQOS(qos)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should admit if all required fields are provided", func() {
+			qos := testutils.NewSlurmQOS("foo", controller)
+			qos.Spec.MaxTRESPerNode = "gres/gpu=8"
+			_, errs := validateSlurmQOS(qos)
+			Expect(errs).To(BeEmpty())
+		})
+	})
+
+	Context("When updating SlurmQOS under Validating Webhook", func() {
+		It("Should deny if the name is changed", func() {
+			oldQOS := testutils.NewSlurmQOS("foo", controller)
+			newQOS := oldQOS.DeepCopy()
+			newQOS.Spec.Name = "bar"
+			_, err := (&SlurmQOSWebhook{}).ValidateUpdate(ctx, oldQOS, newQOS)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+type SlurmUserWebhook struct{}
+
+// log is for logging in this package.
+var slurmuserlog = logf.Log.WithName("slurmuser-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *SlurmUserWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&slinkyv1beta1.SlurmUser{}).
+		WithValidator(r).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-slurmuser,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=slurmusers,verbs=create;update,versions=v1beta1,name=slurmuser-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.CustomValidator = &SlurmUserWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmUserWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	user := obj.(*slinkyv1beta1.SlurmUser)
+	slurmuserlog.Info("validate create", "slurmuser", klog.KObj(user))
+
+	warns, errs := validateSlurmUser(user)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmUserWebhook) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	newUser := newObj.(*slinkyv1beta1.SlurmUser)
+	oldUser := oldObj.(*slinkyv1beta1.SlurmUser)
+	slurmuserlog.Info("validate update", "newSlurmUser", klog.KObj(newUser))
+
+	warns, errs := validateSlurmUser(newUser)
+
+	if newUser.SlurmName() != oldUser.SlurmName() {
+		errs = append(errs, errors.New("`SlurmUser.Spec.Name` is immutable"))
+	}
+	if newUser.Spec.ControllerRef != oldUser.Spec.ControllerRef {
+		errs = append(errs, errors.New("`SlurmUser.Spec.ControllerRef` is immutable"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmUserWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	user := obj.(*slinkyv1beta1.SlurmUser)
+	slurmuserlog.Info("validate delete", "slurmuser", klog.KObj(user))
+
+	return nil, nil
+}
+
+func validateSlurmUser(obj *slinkyv1beta1.SlurmUser) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if obj.Spec.ControllerRef.Name == "" {
+		errs = append(errs, errors.New("`SlurmUser.Spec.ControllerRef.Name` must be set"))
+	}
+	if len(obj.Spec.Associations) == 0 {
+		errs = append(errs, errors.New("`SlurmUser.Spec.Associations` must have at least one association"))
+	}
+	accounts := sets.New[string]()
+	seen := sets.New[string]()
+	for i := range obj.Spec.Associations {
+		assoc := &obj.Spec.Associations[i]
+		path := fmt.Sprintf("SlurmUser.Spec.Associations[%d]", i)
+		key := assoc.Account + "/" + assoc.Partition
+		if seen.Has(key) {
+			errs = append(errs, fmt.Errorf("`%s` is a duplicate of account %q and partition %q", path, assoc.Account, assoc.Partition))
+		}
+		seen.Insert(key)
+		accounts.Insert(assoc.Account)
+		errs = append(errs, validateAssociationLimits(path+".Limits", &assoc.Limits)...)
+	}
+	if obj.Spec.DefaultAccount != "" && !accounts.Has(obj.Spec.DefaultAccount) {
+		errs = append(errs, fmt.Errorf("`SlurmUser.Spec.DefaultAccount` (%s) must be one of the associated accounts", obj.Spec.DefaultAccount))
+	}
+	if obj.AdminLevel() != slinkyv1beta1.SlurmAdminLevelNone {
+		warns = append(warns, fmt.Sprintf("`SlurmUser.Spec.AdminLevel` grants %s privileges in Slurm", obj.AdminLevel()))
+	}
+
+	return warns, errs
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("SlurmUser Webhook", func() {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+
+	Context("When creating SlurmUser under Validating Webhook", func() {
+		It("Should deny if a required field is empty", func() {
+			user := testutils.NewSlurmUser("foo", controller)
+			_, errs := validateSlurmUser(user)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should deny if an association is duplicated", func() {
+			user := testutils.NewSlurmUser("foo", controller, "physics", "physics")
+			_, errs := validateSlurmUser(user)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should deny if the default account is not associated", func() {
+			user := testutils.NewSlurmUser("foo", controller, "physics")
+			user.Spec.DefaultAccount = "chemistry"
+			_, errs := validateSlurmUser(user)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should warn if the user is an administrator", func() {
+			user := testutils.NewSlurmUser("foo", controller, "physics")
+			user.Spec.AdminLevel = slinkyv1beta1.SlurmAdminLevelAdministrator
+			warns, errs := validateSlurmUser(user)
+			Expect(warns).To(HaveLen(1))
+			Expect(errs).To(BeEmpty())
+		})
+
+		It("Should admit if all required fields are provided", func() {
+			user := testutils.NewSlurmUser("foo", controller, "physics", "chemistry")
+			user.Spec.DefaultAccount = "chemistry"
+			user.Spec.Associations[0].Limits.MaxTRESPerJob = "gres/gpu=4"
+			_, errs := validateSlurmUser(user)
+			Expect(errs).To(BeEmpty())
+		})
+	})
+
+	Context("When updating SlurmUser under Validating Webhook", func() {
+		It("Should deny if the controller is changed", func() {
+			oldUser := testutils.NewSlurmUser("foo", controller, "physics")
+			newUser := oldUser.DeepCopy()
+			newUser.Spec.ControllerRef.Name = "other"
+			_, err := (&SlurmUserWebhook{}).ValidateUpdate(ctx, oldUser, newUser)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -5,6 +5,8 @@ package webhook
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -72,5 +74,20 @@ func validateToken(obj *slinkyv1beta1.Token) (admission.Warnings, []error) {
 	var warns admission.Warnings
 	var errs []error
 
+	hasJwtHs256KeyRef := obj.Spec.JwtHs256KeyRef.Name != ""
+	if hasJwtHs256KeyRef == obj.HasControllerRef() {
+		errs = append(errs, errors.New("exactly one of `Token.Spec.JwtHs256KeyRef` or `Token.Spec.ControllerRef` must be set"))
+	}
+	if obj.HasControllerRef() && obj.Spec.ControllerRef.Namespace == "" {
+		errs = append(errs, errors.New("`Token.Spec.ControllerRef.Namespace` must be set"))
+	}
+	if obj.Spec.SecretTemplate != nil {
+		for _, format := range obj.Spec.SecretTemplate.Formats {
+			if format.Key == obj.SecretRef().Key {
+				errs = append(errs, fmt.Errorf("`Token.Spec.SecretTemplate.Formats` key (%s) must not be the key of the JWT", format.Key))
+			}
+		}
+	}
+
 	return warns, errs
 }
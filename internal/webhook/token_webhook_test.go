@@ -5,16 +5,50 @@ package webhook
 
 import (
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
 var _ = Describe("Token Webhook", func() {
 	Context("When creating Token under Validating Webhook", func() {
 		It("Should deny if a required field is empty", func() {
-			// TODO(user): Add your logic here
+			token := testutils.NewToken("token", testutils.NewJwtHs256KeySecret(testutils.NewJwtHs256KeyRef("slurm")))
+			token.Spec.JwtHs256KeyRef = slinkyv1beta1.JwtSecretKeySelector{}
+			_, errs := validateToken(token)
+			Expect(errs).To(HaveLen(1))
+
+			token.Spec.ControllerRef = slinkyv1beta1.ObjectReference{Name: "slurm"}
+			_, errs = validateToken(token)
+			Expect(errs).To(HaveLen(1))
 		})
 
 		It("Should admit if all required fields are provided", func() {
-			// TODO(user): Add your logic here
+			token := testutils.NewToken("token", testutils.NewJwtHs256KeySecret(testutils.NewJwtHs256KeyRef("slurm")))
+			_, errs := validateToken(token)
+			Expect(errs).To(BeEmpty())
+
+			token.Spec.JwtHs256KeyRef = slinkyv1beta1.JwtSecretKeySelector{}
+			token.Spec.ControllerRef = slinkyv1beta1.ObjectReference{Namespace: corev1.NamespaceDefault, Name: "slurm"}
+			_, errs = validateToken(token)
+			Expect(errs).To(BeEmpty())
+		})
+
+		It("Should deny a format key of the JWT", func() {
+			token := testutils.NewToken("token", testutils.NewJwtHs256KeySecret(testutils.NewJwtHs256KeyRef("slurm")))
+			token.Spec.SecretTemplate = &slinkyv1beta1.TokenSecretTemplate{
+				Formats: []slinkyv1beta1.TokenSecretFormat{
+					{Key: "slurm.env", Type: slinkyv1beta1.TokenSecretFormatEnv},
+				},
+			}
+			_, errs := validateToken(token)
+			Expect(errs).To(BeEmpty())
+
+			token.Spec.SecretTemplate.Formats[0].Key = token.SecretRef().Key
+			_, errs = validateToken(token)
+			Expect(errs).To(HaveLen(1))
 		})
 	})
 })
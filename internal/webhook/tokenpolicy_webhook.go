@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+type TokenPolicyWebhook struct {
+	client.Client
+}
+
+// log is for logging in this package.
+var tokenpolicylog = logf.Log.WithName("tokenpolicy-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *TokenPolicyWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&slinkyv1beta1.TokenPolicy{}).
+		WithValidator(r).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-tokenpolicy,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=tokenpolicies,verbs=create;update,versions=v1beta1,name=tokenpolicy-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.CustomValidator = &TokenPolicyWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *TokenPolicyWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy := obj.(*slinkyv1beta1.TokenPolicy)
+	tokenpolicylog.Info("validate create", "tokenpolicy", klog.KObj(policy))
+
+	warns, errs := r.validateTokenPolicy(ctx, policy)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *TokenPolicyWebhook) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	newPolicy := newObj.(*slinkyv1beta1.TokenPolicy)
+	tokenpolicylog.Info("validate update", "newTokenPolicy", klog.KObj(newPolicy))
+
+	warns, errs := r.validateTokenPolicy(ctx, newPolicy)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *TokenPolicyWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy := obj.(*slinkyv1beta1.TokenPolicy)
+	tokenpolicylog.Info("validate delete", "tokenpolicy", klog.KObj(policy))
+
+	return nil, nil
+}
+
+func (r *TokenPolicyWebhook) validateTokenPolicy(ctx context.Context, obj *slinkyv1beta1.TokenPolicy) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if obj.Spec.ControllerRef.Name == "" {
+		errs = append(errs, errors.New("`TokenPolicy.Spec.ControllerRef.Name` must be set"))
+		return warns, errs
+	}
+
+	// The Controller of another namespace must grant the username.
+	controllerKey := obj.ControllerKey()
+	if controllerKey.Namespace == obj.Namespace {
+		return warns, errs
+	}
+	controller := &slinkyv1beta1.Controller{}
+	if err := r.Get(ctx, controllerKey, controller); err != nil {
+		if apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("`TokenPolicy.Spec.ControllerRef` (%s) is not valid. Got: not found. Expected a Controller in another namespace to exist",
+				controllerKey))
+			return warns, errs
+		}
+		return warns, []error{err}
+	}
+	if !obj.IsPermittedBy(controller) {
+		errs = append(errs, fmt.Errorf("`TokenPolicy.Spec.Username` (%s) is not valid. Expected Controller (%s) to grant it to namespace %s in `Controller.Spec.UserGrants`",
+			obj.Spec.Username, controllerKey, obj.Namespace))
+	}
+
+	return warns, errs
+}
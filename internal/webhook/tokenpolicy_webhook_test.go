@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("TokenPolicy Webhook", func() {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+
+	Context("When creating TokenPolicy under Validating Webhook", func() {
+		It("Should deny if a required field is empty", func() {
+			webhook := &TokenPolicyWebhook{Client: fake.NewFakeClient()}
+			policy := testutils.NewTokenPolicy("ci", controller, "ci-runner", "ci")
+			policy.Spec.ControllerRef = slinkyv1beta1.ObjectReference{}
+			_, errs := webhook.validateTokenPolicy(context.TODO(), policy)
+			Expect(errs).To(HaveLen(1))
+		})
+
+		It("Should admit a Controller of the same namespace", func() {
+			webhook := &TokenPolicyWebhook{Client: fake.NewFakeClient()}
+			policy := testutils.NewTokenPolicy("ci", controller, "root", "ci")
+			_, errs := webhook.validateTokenPolicy(context.TODO(), policy)
+			Expect(errs).To(BeEmpty())
+		})
+
+		It("Should deny a Controller of another namespace, unless it grants the username", func() {
+			policy := testutils.NewTokenPolicy("ci", controller, "root", "ci")
+			policy.Namespace = "other"
+
+			webhook := &TokenPolicyWebhook{Client: fake.NewFakeClient()}
+			_, errs := webhook.validateTokenPolicy(context.TODO(), policy)
+			Expect(errs).To(HaveLen(1))
+
+			webhook = &TokenPolicyWebhook{Client: fake.NewClientBuilder().WithObjects(controller).Build()}
+			_, errs = webhook.validateTokenPolicy(context.TODO(), policy)
+			Expect(errs).To(HaveLen(1))
+
+			granted := controller.DeepCopy()
+			granted.Spec.UserGrants = []slinkyv1beta1.UserGrant{
+				{Namespace: "other", Usernames: []string{"ci-runner"}},
+			}
+			webhook = &TokenPolicyWebhook{Client: fake.NewClientBuilder().WithObjects(granted).Build()}
+			_, errs = webhook.validateTokenPolicy(context.TODO(), policy)
+			Expect(errs).To(HaveLen(1))
+
+			policy.Spec.Username = "ci-runner"
+			_, errs = webhook.validateTokenPolicy(context.TODO(), policy)
+			Expect(errs).To(BeEmpty())
+		})
+	})
+})
@@ -107,7 +107,9 @@ var _ = BeforeSuite(func() {
 	err = (&LoginSetWebhook{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
-	err = (&NodeSetWebhook{}).SetupWebhookWithManager(mgr)
+	err = (&NodeSetWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
 	err = (&RestapiWebhook{}).SetupWebhookWithManager(mgr)
@@ -116,6 +118,28 @@ var _ = BeforeSuite(func() {
 	err = (&TokenWebhook{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&TokenPolicyWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&SlurmMaintenanceWebhook{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&SlurmJobWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&SlurmAccountWebhook{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&SlurmUserWebhook{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&SlurmQOSWebhook{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	//+kubebuilder:scaffold:webhook
 
 	go func() {
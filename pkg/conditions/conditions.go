@@ -31,6 +31,13 @@ const (
 	PodConditionUndrain       corev1.PodConditionType = StatePrefix + "Undrain"
 )
 
+const (
+	// PodConditionDrainTimeout indicates the Slurm node did not drain within
+	// the NodeSet DrainPolicy timeout. The reason is the action taken and the
+	// message lists the affected jobs.
+	PodConditionDrainTimeout corev1.PodConditionType = "SlurmNodeDrainTimeout"
+)
+
 func IsConditionTrue(status *corev1.PodStatus, condType corev1.PodConditionType) bool {
 	_, cond := podutil.GetPodCondition(status, condType)
 	return cond != nil && cond.Status == corev1.ConditionTrue